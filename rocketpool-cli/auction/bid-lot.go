@@ -130,6 +130,12 @@ func bidOnLot(c *cli.Context) error {
 
 	}
 
+	// Simulate the bid without the checks and prompts; the simulation reports why it would fail
+	if c.GlobalBool("dry-run") {
+		_, err := rp.BidOnLot(selectedLot.Details.Index, amountWei)
+		return err
+	}
+
 	// Check lot can be bid on
 	canBid, err := rp.CanBidOnLot(selectedLot.Details.Index, amountWei)
 	if err != nil {
@@ -0,0 +1,106 @@
+package auction
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getBidPlan(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Use the configured strategy unless it's overridden
+	minDiscount := cfg.Smartnode.AutoBidMinDiscount.Value.(float64)
+	if c.IsSet("discount") {
+		minDiscount = c.Float64("discount")
+	}
+	lotBudget := cfg.Smartnode.AutoBidLotBudget.Value.(float64)
+	if c.IsSet("lot-budget") {
+		lotBudget = c.Float64("lot-budget")
+	}
+	totalBudget := cfg.Smartnode.AutoBidTotalBudget.Value.(float64)
+	if c.IsSet("total-budget") {
+		totalBudget = c.Float64("total-budget")
+	}
+	if minDiscount <= 0 || minDiscount > 100 {
+		return fmt.Errorf("Invalid minimum discount '%f' - must be greater than 0 and at most 100. Set the Auto-Bid Minimum Discount in `rocketpool service config` or use the --discount flag.", minDiscount)
+	}
+	if lotBudget <= 0 || totalBudget <= 0 {
+		return fmt.Errorf("The lot and total budgets must both be greater than 0.")
+	}
+
+	// Get the plan
+	plan, err := rp.AuctionBidPlan(minDiscount/100, lotBudget, totalBudget)
+	if err != nil {
+		return err
+	}
+
+	// Print the strategy
+	fmt.Printf("The oracle RPL price is %.6f ETH.\n", math.RoundDown(eth.WeiToEth(plan.OraclePrice), 6))
+	fmt.Printf("Bidding on lots priced at least %.2f%% below it, up to %.6f ETH per lot and %.6f ETH in unclaimed bids.\n",
+		plan.MinDiscount*100, eth.WeiToEth(plan.LotBudget), eth.WeiToEth(plan.TotalBudget))
+	fmt.Printf("The node currently holds %.6f ETH in unclaimed bids.\n", math.RoundDown(eth.WeiToEth(plan.OpenBids), 6))
+	overridden := c.IsSet("discount") || c.IsSet("lot-budget") || c.IsSet("total-budget")
+	configuredDiscount := cfg.Smartnode.AutoBidMinDiscount.Value.(float64)
+	if !overridden && !cfg.Smartnode.AutoBidDryRun.Value.(bool) && configuredDiscount > 0 && configuredDiscount <= 100 && cfg.Smartnode.AutoBidGasThreshold.Value.(float64) > 0 {
+		fmt.Println("Automatic bidding is enabled, so the node will place these bids on its next check.")
+	}
+	fmt.Println()
+	if !plan.BiddingEnabled {
+		fmt.Println("Bidding on lots is currently disabled by the network.")
+	}
+
+	// Print the bids
+	if len(plan.Bids) == 0 {
+		fmt.Println("No lots meet the strategy, so no bids would be placed.")
+	} else {
+		fmt.Printf("%-6s%-20s%-12s%-14s%-18s%s\n", "Lot", "Price (ETH/RPL)", "Discount", "Bid (ETH)", "Expected RPL", "RPL per ETH")
+		for _, bid := range plan.Bids {
+			fmt.Printf("%-6d%-20.6f%-12s%-14.6f%-18.6f%.4f\n",
+				bid.LotIndex,
+				math.RoundDown(eth.WeiToEth(bid.CurrentPrice), 6),
+				fmt.Sprintf("%.2f%%", bid.Discount*100),
+				math.RoundDown(eth.WeiToEth(bid.BidAmount), 6),
+				math.RoundDown(eth.WeiToEth(bid.ExpectedRpl), 6),
+				bid.RplPerEth)
+		}
+		fmt.Println("Expected RPL is based on each lot's current price; lot prices only fall, so the node will receive at least this much.")
+	}
+	for _, lot := range plan.SkippedLots {
+		fmt.Printf("Lot %d is priced at %.6f ETH (%.2f%% below the oracle price) and would not be bid on.\n", lot.LotIndex, math.RoundDown(eth.WeiToEth(lot.CurrentPrice), 6), lot.Discount*100)
+	}
+
+	// Print the claims
+	if len(plan.ClaimableLots) > 0 {
+		fmt.Println()
+		fmt.Printf("RPL would be claimed from %d finished lot(s): %v\n", len(plan.ClaimableLots), plan.ClaimableLots)
+	}
+	return nil
+
+}
@@ -50,6 +50,38 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "bid-plan",
+				Aliases:   []string{"p"},
+				Usage:     "Simulate the bids and claims the node would make automatically, showing the expected RPL per ETH",
+				UsageText: "rocketpool auction bid-plan [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "discount, d",
+						Usage: "The minimum discount below the oracle RPL price, as a percentage (defaults to the Auto-Bid Minimum Discount setting)",
+					},
+					cli.Float64Flag{
+						Name:  "lot-budget, l",
+						Usage: "The most ETH to bid on a single lot (defaults to the Auto-Bid Lot Budget setting)",
+					},
+					cli.Float64Flag{
+						Name:  "total-budget, t",
+						Usage: "The most ETH to hold in unclaimed bids (defaults to the Auto-Bid Total Budget setting)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getBidPlan(c)
+
+				},
+			},
+
 			{
 				Name:      "create-lot",
 				Aliases:   []string{"t"},
@@ -0,0 +1,463 @@
+package minipool
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Config
+const BatchReportFolder = "batch-reports"
+
+// Batch entry states
+const (
+	batchState_Pending   = "pending"
+	batchState_Skipped   = "skipped"
+	batchState_Submitted = "submitted"
+	batchState_Succeeded = "succeeded"
+	batchState_Failed    = "failed"
+)
+
+// An action that can be run on a batch of minipools
+type minipoolBatchAction struct {
+	// The name of the action, e.g. "refund"
+	Name string
+
+	// Whether the action sends a transaction (exits are signed messages sent to the Beacon Chain instead)
+	SendsTransaction bool
+
+	// Check whether the action can be run on a minipool; returns the reason it can't if not
+	Check func(address common.Address) (bool, string, rocketpoolapi.GasInfo, error)
+
+	// Run the action on a minipool, returning the transaction hash if there is one
+	Run func(address common.Address) (common.Hash, error)
+
+	// An optional extra confirmation for dangerous actions, run after the standard one
+	Confirm func() bool
+}
+
+// The progress of a batch, saved so it can be resumed after a failure
+type minipoolBatchReport struct {
+	Action    string               `json:"action"`
+	StartTime time.Time            `json:"startTime"`
+	Entries   []minipoolBatchEntry `json:"entries"`
+	path      string
+}
+type minipoolBatchEntry struct {
+	Address common.Address `json:"address"`
+	State   string         `json:"state"`
+	Nonce   uint64         `json:"nonce,omitempty"`
+	TxHash  common.Hash    `json:"txHash,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// Get the flags for selecting minipools in batch mode
+func getBatchFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "Select all eligible minipools",
+		},
+		cli.StringFlag{
+			Name:  "status",
+			Usage: "Select all eligible minipools with the given status (e.g. withdrawable)",
+		},
+		cli.StringFlag{
+			Name:  "file",
+			Usage: "Select the minipools listed in a file, one address per line",
+		},
+		cli.StringFlag{
+			Name:  "resume",
+			Usage: "Resume an interrupted batch from its report file",
+		},
+	}
+}
+
+// Validate the batch mode flags
+func validateBatchFlags(c *cli.Context) error {
+	selectors := 0
+	for _, flag := range []string{"minipool", "status", "file", "resume"} {
+		if c.String(flag) != "" {
+			selectors++
+		}
+	}
+	if c.Bool("all") {
+		selectors++
+	}
+	if selectors > 1 {
+		return fmt.Errorf("Only one of --minipool, --all, --status, --file and --resume can be used at a time.")
+	}
+	if c.String("status") != "" {
+		valid := false
+		for _, status := range types.MinipoolStatuses {
+			if strings.EqualFold(status, c.String("status")) {
+				valid = true
+			}
+		}
+		if !valid {
+			return fmt.Errorf("Invalid minipool status '%s' - valid statuses are %s.", c.String("status"), strings.Join(types.MinipoolStatuses, ", "))
+		}
+	}
+	return nil
+}
+
+// Check whether batch mode was requested
+func isBatchMode(c *cli.Context) bool {
+	return c.Bool("all") || c.String("status") != "" || c.String("file") != "" || c.String("resume") != ""
+}
+
+// Select minipools from the eligible ones using the batch selector flags
+func selectBatchMinipools(c *cli.Context, eligibleMinipools []api.MinipoolDetails) ([]api.MinipoolDetails, error) {
+
+	// All eligible minipools
+	if c.Bool("all") {
+		return eligibleMinipools, nil
+	}
+
+	// Eligible minipools with a status
+	if c.String("status") != "" {
+		selectedMinipools := []api.MinipoolDetails{}
+		for _, minipool := range eligibleMinipools {
+			if strings.EqualFold(minipool.Status.Status.String(), c.String("status")) {
+				selectedMinipools = append(selectedMinipools, minipool)
+			}
+		}
+		return selectedMinipools, nil
+	}
+
+	// Get the addresses to select
+	var addresses []common.Address
+	var err error
+	if c.String("file") != "" {
+		addresses, err = readMinipoolFile(c.String("file"))
+	} else if c.String("resume") != "" {
+		var report *minipoolBatchReport
+		report, err = loadBatchReport(c.String("resume"))
+		if err == nil {
+			for _, entry := range report.Entries {
+				if entry.State != batchState_Succeeded {
+					addresses = append(addresses, entry.Address)
+				}
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Match them against the eligible minipools
+	selectedMinipools := []api.MinipoolDetails{}
+	for _, address := range addresses {
+		found := false
+		for _, minipool := range eligibleMinipools {
+			if minipool.Address == address {
+				selectedMinipools = append(selectedMinipools, minipool)
+				found = true
+				break
+			}
+		}
+		if !found {
+			fmt.Printf("Minipool %s is not eligible for this action and will be skipped.\n", address.Hex())
+		}
+	}
+	return selectedMinipools, nil
+
+}
+
+// Read a list of minipool addresses from a file, ignoring blank lines and comments
+func readMinipoolFile(path string) ([]common.Address, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening minipool list %s: %w", path, err)
+	}
+	defer file.Close()
+
+	addresses := []common.Address{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		address, err := cliutils.ValidateAddress("minipool address", line)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error reading minipool list %s: %w", path, err)
+	}
+	return addresses, nil
+}
+
+// Run an action on a batch of minipools
+func runMinipoolBatch(c *cli.Context, rp *rocketpool.Client, action minipoolBatchAction, minipools []api.MinipoolDetails) error {
+
+	// Get the batch report, resuming the previous one if requested
+	var report *minipoolBatchReport
+	var err error
+	if c.String("resume") != "" {
+		report, err = loadBatchReport(c.String("resume"))
+		if err != nil {
+			return err
+		}
+		if report.Action != action.Name {
+			return fmt.Errorf("The batch report %s is for the %s action, not %s.", c.String("resume"), report.Action, action.Name)
+		}
+		if err := waitForSubmittedEntries(rp, report); err != nil {
+			return err
+		}
+	} else {
+		report, err = newBatchReport(c, action.Name, minipools)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Run the preflight checks
+	fmt.Printf("Checking %d minipool(s)...\n\n", len(minipools))
+	readyMinipools := []common.Address{}
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Minipool\tStatus\tReady\tEst. Gas\tNote")
+	for _, minipool := range minipools {
+		if report.getEntry(minipool.Address).State == batchState_Succeeded {
+			continue
+		}
+		canRun, reason, canGasInfo, err := action.Check(minipool.Address)
+		if err != nil {
+			canRun = false
+			reason = err.Error()
+		}
+		if canRun {
+			readyMinipools = append(readyMinipools, minipool.Address)
+			gasInfo = canGasInfo
+			totalGas += canGasInfo.EstGasLimit
+			totalSafeGas += canGasInfo.SafeGasLimit
+			estGas := "-"
+			if action.SendsTransaction {
+				estGas = fmt.Sprint(canGasInfo.EstGasLimit)
+			}
+			fmt.Fprintf(table, "%s\t%s\tyes\t%s\t%s\n", minipool.Address.Hex(), minipool.Status.Status.String(), estGas, reason)
+		} else {
+			report.setEntry(minipool.Address, batchState_Skipped, reason)
+			fmt.Fprintf(table, "%s\t%s\tno\t-\t%s\n", minipool.Address.Hex(), minipool.Status.Status.String(), reason)
+		}
+	}
+	table.Flush()
+	fmt.Println("")
+	if len(readyMinipools) == 0 {
+		fmt.Printf("None of the selected minipools can %s.\n", action.Name)
+		return report.save()
+	}
+
+	// Assign max fees using the combined gas estimate
+	if action.SendsTransaction {
+		gasInfo.EstGasLimit = totalGas
+		gasInfo.SafeGasLimit = totalSafeGas
+		err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to %s %d minipool(s)?", action.Name, len(readyMinipools)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if action.Confirm != nil && !(c.Bool("yes") || action.Confirm()) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the starting nonce, using the custom nonce as the base if one was provided
+	var nonce *big.Int
+	if action.SendsTransaction {
+		if c.GlobalString("nonce") != "" {
+			cliutils.PrintMultiTransactionNonceWarning()
+			nonce, _ = new(big.Int).SetString(c.GlobalString("nonce"), 0)
+		} else {
+			nonceResponse, err := rp.NodeNonce()
+			if err != nil {
+				return err
+			}
+			nonce = new(big.Int).SetUint64(nonceResponse.Nonce)
+		}
+	}
+	if err := report.save(); err != nil {
+		return err
+	}
+	fmt.Printf("Progress is being saved to %s.\n\n", report.path)
+
+	// Submit the transactions
+	for i, address := range readyMinipools {
+		fmt.Printf("[%d/%d] Running %s on minipool %s...\n", i+1, len(readyMinipools), action.Name, address.Hex())
+		if action.SendsTransaction {
+			rp.SetCustomNonce(nonce)
+		}
+		txHash, err := action.Run(address)
+		if err != nil {
+			// Stop here so later transactions don't get stuck behind the failed nonce
+			report.setEntry(address, batchState_Failed, err.Error())
+			if saveErr := report.save(); saveErr != nil {
+				fmt.Printf("WARNING: Could not save the batch report: %s\n", saveErr.Error())
+			}
+			return fmt.Errorf("Could not %s minipool %s: %w\nThe batch has been stopped. Once the problem is fixed, you can resume it with `--resume %s`.", action.Name, address.Hex(), err, report.path)
+		}
+		if action.SendsTransaction {
+			entry := report.setEntry(address, batchState_Submitted, "")
+			entry.Nonce = nonce.Uint64()
+			entry.TxHash = txHash
+			cliutils.PrintTransactionHash(rp, txHash)
+			nonce = new(big.Int).Add(nonce, big.NewInt(1))
+		} else {
+			report.setEntry(address, batchState_Succeeded, "")
+		}
+		if err := report.save(); err != nil {
+			fmt.Printf("WARNING: Could not save the batch report: %s\n", err.Error())
+		}
+	}
+
+	// Wait for the transactions to be mined
+	if action.SendsTransaction {
+		fmt.Println("Waiting for the transactions to be mined...")
+		if err := waitForSubmittedEntries(rp, report); err != nil {
+			return err
+		}
+	}
+
+	// Print a summary
+	succeeded := 0
+	failed := 0
+	for _, entry := range report.Entries {
+		switch entry.State {
+		case batchState_Succeeded:
+			succeeded++
+		case batchState_Failed:
+			failed++
+		}
+	}
+	fmt.Printf("\nFinished: %d succeeded, %d failed.\n", succeeded, failed)
+	if failed > 0 {
+		fmt.Printf("You can retry the failed minipools with `--resume %s`.\n", report.path)
+	}
+	return nil
+
+}
+
+// Wait for the submitted transactions in a batch to be mined and record their results
+func waitForSubmittedEntries(rp *rocketpool.Client, report *minipoolBatchReport) error {
+	for i := range report.Entries {
+		entry := &report.Entries[i]
+		if entry.State != batchState_Submitted {
+			continue
+		}
+		if _, err := rp.WaitForTransaction(entry.TxHash); err != nil {
+			entry.State = batchState_Failed
+			entry.Error = err.Error()
+			fmt.Printf("Transaction %s for minipool %s failed: %s.\n", entry.TxHash.Hex(), entry.Address.Hex(), err)
+		} else {
+			entry.State = batchState_Succeeded
+			fmt.Printf("Transaction %s for minipool %s succeeded.\n", entry.TxHash.Hex(), entry.Address.Hex())
+		}
+		if err := report.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create a new batch report in the config folder
+func newBatchReport(c *cli.Context, action string, minipools []api.MinipoolDetails) (*minipoolBatchReport, error) {
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding config path: %w", err)
+	}
+	startTime := time.Now()
+	report := &minipoolBatchReport{
+		Action:    action,
+		StartTime: startTime,
+		Entries:   make([]minipoolBatchEntry, len(minipools)),
+		path:      filepath.Join(configPath, BatchReportFolder, fmt.Sprintf("%s-%s.json", action, startTime.Format("20060102-150405"))),
+	}
+	for i, minipool := range minipools {
+		report.Entries[i] = minipoolBatchEntry{
+			Address: minipool.Address,
+			State:   batchState_Pending,
+		}
+	}
+	return report, nil
+}
+
+// Load a batch report
+func loadBatchReport(path string) (*minipoolBatchReport, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading batch report %s: %w", path, err)
+	}
+	report := new(minipoolBatchReport)
+	if err := json.Unmarshal(bytes, report); err != nil {
+		return nil, fmt.Errorf("Error parsing batch report %s: %w", path, err)
+	}
+	report.path = path
+	return report, nil
+}
+
+// Save a batch report
+func (r *minipoolBatchReport) save() error {
+	bytes, err := json.MarshalIndent(r, "", "    ")
+	if err != nil {
+		return fmt.Errorf("Error serializing batch report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("Error creating batch report folder: %w", err)
+	}
+	if err := ioutil.WriteFile(r.path, bytes, 0644); err != nil {
+		return fmt.Errorf("Error writing batch report %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// Get a minipool's entry in a batch report, adding one if it doesn't exist
+func (r *minipoolBatchReport) getEntry(address common.Address) *minipoolBatchEntry {
+	for i := range r.Entries {
+		if r.Entries[i].Address == address {
+			return &r.Entries[i]
+		}
+	}
+	r.Entries = append(r.Entries, minipoolBatchEntry{
+		Address: address,
+		State:   batchState_Pending,
+	})
+	return &r.Entries[len(r.Entries)-1]
+}
+
+// Update a minipool's entry in a batch report
+func (r *minipoolBatchReport) setEntry(address common.Address, state string, errorMessage string) *minipoolBatchEntry {
+	entry := r.getEntry(address)
+	entry.State = state
+	entry.Error = errorMessage
+	return entry
+}
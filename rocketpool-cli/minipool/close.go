@@ -51,6 +51,15 @@ func closeMinipools(c *cli.Context) error {
 		return nil
 	}
 
+	// Run in batch mode if requested
+	if isBatchMode(c) {
+		selectedMinipools, err := selectBatchMinipools(c, closableMinipools)
+		if err != nil {
+			return err
+		}
+		return runMinipoolBatch(c, rp, getCloseBatchAction(rp), selectedMinipools)
+	}
+
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
 	if c.String("minipool") == "" {
@@ -158,3 +167,28 @@ func closeMinipools(c *cli.Context) error {
 	return nil
 
 }
+
+// Get the close action for batch mode
+func getCloseBatchAction(rp *rocketpool.Client) minipoolBatchAction {
+	return minipoolBatchAction{
+		Name:             "close",
+		SendsTransaction: true,
+		Check: func(address common.Address) (bool, string, rocketpoolapi.GasInfo, error) {
+			canResponse, err := rp.CanCloseMinipool(address)
+			if err != nil {
+				return false, "", rocketpoolapi.GasInfo{}, err
+			}
+			if canResponse.InvalidStatus {
+				return false, "The minipool is not in a closeable state.", canResponse.GasInfo, nil
+			}
+			if !canResponse.InConsensus {
+				return false, "The Oracle DAO is still voting on the RPL price; try again in a few minutes.", canResponse.GasInfo, nil
+			}
+			return canResponse.CanClose, "", canResponse.GasInfo, nil
+		},
+		Run: func(address common.Address) (common.Hash, error) {
+			response, err := rp.CloseMinipool(address)
+			return response.TxHash, err
+		},
+	}
+}
@@ -1,6 +1,8 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -32,6 +34,37 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "timeline",
+				Aliases:   []string{"i"},
+				Usage:     "View a minipool's past events and upcoming lifecycle deadlines",
+				UsageText: "rocketpool minipool timeline [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to view the timeline of (address)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getTimeline(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -68,12 +101,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"r"},
 				Usage:     "Refund ETH belonging to the node from minipools",
 				UsageText: "rocketpool minipool refund [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "minipool, m",
 						Usage: "The minipool/s to refund from (address or 'all')",
 					},
-				},
+				}, getBatchFlags()...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -87,6 +120,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if err := validateBatchFlags(c); err != nil {
+						return err
+					}
 
 					// Run
 					return refundMinipools(c)
@@ -100,7 +136,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			       Aliases:   []string{"d"},
 			       Usage:     "Dissolve initialized or prelaunch minipools",
 			       UsageText: "rocketpool minipool dissolve [options]",
-			       Flags: []cli.Flag{
+			       Flags: append([]cli.Flag{
 			           cli.BoolFlag{
 			               Name:  "yes, y",
 			               Usage: "Automatically confirm dissolving minipool/s",
@@ -109,7 +145,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			               Name:  "minipool, m",
 			               Usage: "The minipool/s to dissolve (address or 'all')",
 			           },
-			       },
+			       }, getBatchFlags()...),
 			       Action: func(c *cli.Context) error {
 
 			           // Validate args
@@ -120,6 +156,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			               if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil { return err }
 			           }
 
+			           if err := validateBatchFlags(c); err != nil { return err }
+
 			           // Run
 			           return dissolveMinipools(c)
 
@@ -131,7 +169,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"e"},
 				Usage:     "Exit staking minipools from the beacon chain",
 				UsageText: "rocketpool minipool exit [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Automatically confirm exiting minipool/s",
@@ -140,7 +178,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to exit (address or 'all')",
 					},
-				},
+				}, getBatchFlags()...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -154,12 +192,98 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if err := validateBatchFlags(c); err != nil {
+						return err
+					}
 
 					// Run
 					return exitMinipools(c)
 
 				},
 			},
+			{
+				Name:      "presign-exit",
+				Aliases:   []string{"p"},
+				Usage:     "Pre-sign voluntary exits for minipools without broadcasting them, and save them to disk",
+				UsageText: "rocketpool minipool presign-exit [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm pre-signing exits",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to pre-sign exits for (address or 'all')",
+					},
+					cli.Uint64Flag{
+						Name:  "epoch, e",
+						Usage: "The earliest epoch the exits can be submitted at, which must be before any scheduled fork (defaults to the current epoch)",
+					},
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The format to save the exits in: 'encrypted' (password protected) or 'json' (the standard beacon node format, unencrypted)",
+						Value: ExitFormatEncrypted,
+					},
+					cli.StringFlag{
+						Name:  "output-dir, o",
+						Usage: "The folder to save the exits to (defaults to the 'exits' folder in the Rocket Pool directory)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+					if c.String("format") != ExitFormatEncrypted && c.String("format") != ExitFormatJson {
+						return fmt.Errorf("Invalid format '%s' - must be '%s' or '%s'", c.String("format"), ExitFormatEncrypted, ExitFormatJson)
+					}
+
+					// Run
+					return presignExits(c)
+
+				},
+			},
+
+			{
+				Name:      "submit-exit",
+				Aliases:   []string{"x"},
+				Usage:     "Broadcast a pre-signed voluntary exit",
+				UsageText: "rocketpool minipool submit-exit --file path",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm submitting the exit",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The pre-signed exit file to broadcast (encrypted or standard JSON format)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("file") == "" {
+						return fmt.Errorf("An exit file must be provided with --file")
+					}
+
+					// Run
+					return submitExit(c)
+
+				},
+			},
 			/*
 			   REMOVED UNTIL BEACON WITHDRAWALS
 			   cli.Command{
@@ -167,12 +291,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			       Aliases:   []string{"c"},
 			       Usage:     "Withdraw balances from dissolved minipools and close them",
 			       UsageText: "rocketpool minipool close [options]",
-			       Flags: []cli.Flag{
+			       Flags: append([]cli.Flag{
 			           cli.StringFlag{
 			               Name:  "minipool, m",
 			               Usage: "The minipool/s to close (address or 'all')",
 			           },
-			       },
+			       }, getBatchFlags()...),
 			       Action: func(c *cli.Context) error {
 
 			           // Validate args
@@ -183,6 +307,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			               if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil { return err }
 			           }
 
+			           if err := validateBatchFlags(c); err != nil { return err }
+
 			           // Run
 			           return closeMinipools(c)
 
@@ -194,12 +320,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"u"},
 				Usage:     "Upgrade a minipool's delegate contract to the latest version",
 				UsageText: "rocketpool minipool delegate-upgrade [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "minipool, m",
 						Usage: "The minipool/s to upgrade (address or 'all')",
 					},
-				},
+				}, getBatchFlags()...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -213,6 +339,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if err := validateBatchFlags(c); err != nil {
+						return err
+					}
 
 					// Run
 					return delegateUpgradeMinipools(c)
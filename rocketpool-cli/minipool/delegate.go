@@ -27,6 +27,19 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 		return err
 	}
 
+	// Run in batch mode if requested
+	if isBatchMode(c) {
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return err
+		}
+		selectedMinipools, err := selectBatchMinipools(c, status.Minipools)
+		if err != nil {
+			return err
+		}
+		return runMinipoolBatch(c, rp, getDelegateUpgradeBatchAction(rp), selectedMinipools)
+	}
+
 	// Get selected minipools
 	var selectedMinipools []common.Address
 
@@ -121,6 +134,25 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 
 }
 
+// Get the delegate upgrade action for batch mode
+func getDelegateUpgradeBatchAction(rp *rocketpool.Client) minipoolBatchAction {
+	return minipoolBatchAction{
+		Name:             "upgrade",
+		SendsTransaction: true,
+		Check: func(address common.Address) (bool, string, rocketpoolapi.GasInfo, error) {
+			canResponse, err := rp.CanDelegateUpgradeMinipool(address)
+			if err != nil {
+				return false, "", rocketpoolapi.GasInfo{}, err
+			}
+			return true, fmt.Sprintf("Will upgrade to %s", canResponse.LatestDelegateAddress.Hex()), canResponse.GasInfo, nil
+		},
+		Run: func(address common.Address) (common.Hash, error) {
+			response, err := rp.DelegateUpgradeMinipool(address)
+			return response.TxHash, err
+		},
+	}
+}
+
 func delegateRollbackMinipools(c *cli.Context) error {
 
 	// Get RP client
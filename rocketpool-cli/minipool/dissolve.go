@@ -52,6 +52,15 @@ func dissolveMinipools(c *cli.Context) error {
 		return nil
 	}
 
+	// Run in batch mode if requested
+	if isBatchMode(c) {
+		selectedMinipools, err := selectBatchMinipools(c, initializedMinipools)
+		if err != nil {
+			return err
+		}
+		return runMinipoolBatch(c, rp, getDissolveBatchAction(rp), selectedMinipools)
+	}
+
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
 	if c.String("minipool") == "" {
@@ -157,3 +166,25 @@ func dissolveMinipools(c *cli.Context) error {
 	return nil
 
 }
+
+// Get the dissolve action for batch mode
+func getDissolveBatchAction(rp *rocketpool.Client) minipoolBatchAction {
+	return minipoolBatchAction{
+		Name:             "dissolve",
+		SendsTransaction: true,
+		Check: func(address common.Address) (bool, string, rocketpoolapi.GasInfo, error) {
+			canResponse, err := rp.CanDissolveMinipool(address)
+			if err != nil {
+				return false, "", rocketpoolapi.GasInfo{}, err
+			}
+			if canResponse.InvalidStatus {
+				return false, "The minipool can only be dissolved while initialized.", canResponse.GasInfo, nil
+			}
+			return canResponse.CanDissolve, "", canResponse.GasInfo, nil
+		},
+		Run: func(address common.Address) (common.Hash, error) {
+			response, err := rp.DissolveMinipool(address)
+			return response.TxHash, err
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
@@ -50,7 +51,15 @@ func exitMinipools(c *cli.Context) error {
 
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
+	if isBatchMode(c) {
+
+		// Select minipools in batch mode
+		selectedMinipools, err = selectBatchMinipools(c, activeMinipools)
+		if err != nil {
+			return err
+		}
+
+	} else if c.String("minipool") == "" {
 
 		// Prompt for minipool selection
 		options := make([]string, len(activeMinipools)+1)
@@ -96,6 +105,16 @@ func exitMinipools(c *cli.Context) error {
 	fmt.Printf("You will no longer receive any rewards or penalties, but your validator's balance will be LOCKED on the Beacon Chain!\n")
 	fmt.Printf("You will NOT have access to your ETH until after the ETH1-ETH2 merge, when withdrawals are implemented!\n\n%s", colorReset)
 
+	// Run in batch mode if requested
+	secondConfirmation := fmt.Sprintf("%sPlease confirm again that you understand you will no longer earn staking rewards, but your ETH balance will remain locked on the Beacon Chain until withdrawals are implemented by the Ethereum core developers.%s", colorRed, colorReset)
+	if isBatchMode(c) {
+		action := getExitBatchAction(rp)
+		action.Confirm = func() bool {
+			return cliutils.Confirm(secondConfirmation)
+		}
+		return runMinipoolBatch(c, rp, action, selectedMinipools)
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to exit %d minipool(s)? This action cannot be undone!", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
@@ -103,7 +122,7 @@ func exitMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sPlease confirm again that you understand you will no longer earn staking rewards, but your ETH balance will remain locked on the Beacon Chain until withdrawals are implemented by the Ethereum core developers.%s", colorRed, colorReset))) {
+	if !(c.Bool("yes") || cliutils.Confirm(secondConfirmation)) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -122,3 +141,25 @@ func exitMinipools(c *cli.Context) error {
 	return nil
 
 }
+
+// Get the exit action for batch mode
+func getExitBatchAction(rp *rocketpool.Client) minipoolBatchAction {
+	return minipoolBatchAction{
+		Name:             "exit",
+		SendsTransaction: false,
+		Check: func(address common.Address) (bool, string, rocketpoolapi.GasInfo, error) {
+			canResponse, err := rp.CanExitMinipool(address)
+			if err != nil {
+				return false, "", rocketpoolapi.GasInfo{}, err
+			}
+			if canResponse.InvalidStatus {
+				return false, "The minipool's validator is not active.", rocketpoolapi.GasInfo{}, nil
+			}
+			return canResponse.CanExit, "", rocketpoolapi.GasInfo{}, nil
+		},
+		Run: func(address common.Address) (common.Hash, error) {
+			_, err := rp.ExitMinipool(address)
+			return common.Hash{}, err
+		},
+	}
+}
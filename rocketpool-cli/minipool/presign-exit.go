@@ -0,0 +1,214 @@
+package minipool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Config
+const (
+	ExitFolder          = "exits"
+	ExitFormatEncrypted = "encrypted"
+	ExitFormatJson      = "json"
+)
+
+func presignExits(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get minipools with a validator on the beacon chain
+	signableMinipools := []api.MinipoolDetails{}
+	for _, minipool := range status.Minipools {
+		if minipool.Status.Status == types.Staking && minipool.Validator.Exists {
+			signableMinipools = append(signableMinipools, minipool)
+		}
+	}
+
+	// Check for signable minipools
+	if len(signableMinipools) == 0 {
+		fmt.Println("No minipools have a validator that can be exited.")
+		return nil
+	}
+
+	// Get selected minipools
+	var selectedMinipools []api.MinipoolDetails
+	if c.String("minipool") == "" {
+
+		// Prompt for minipool selection
+		options := make([]string, len(signableMinipools)+1)
+		options[0] = "All available minipools"
+		for mi, minipool := range signableMinipools {
+			options[mi+1] = fmt.Sprintf("%s (validator %d)", minipool.Address.Hex(), minipool.Validator.Index)
+		}
+		selected, _ := cliutils.Select("Please select a minipool to pre-sign an exit for:", options)
+
+		// Get minipools
+		if selected == 0 {
+			selectedMinipools = signableMinipools
+		} else {
+			selectedMinipools = []api.MinipoolDetails{signableMinipools[selected-1]}
+		}
+
+	} else {
+
+		// Get matching minipools
+		if c.String("minipool") == "all" {
+			selectedMinipools = signableMinipools
+		} else {
+			selectedAddress := common.HexToAddress(c.String("minipool"))
+			for _, minipool := range signableMinipools {
+				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+					selectedMinipools = []api.MinipoolDetails{minipool}
+					break
+				}
+			}
+			if selectedMinipools == nil {
+				return fmt.Errorf("The minipool %s does not have a validator that can be exited.", selectedAddress.Hex())
+			}
+		}
+
+	}
+
+	// Get the output folder
+	outputDir := c.String("output-dir")
+	if outputDir == "" {
+		configPath, err := homedir.Expand(c.GlobalString("config-path"))
+		if err != nil {
+			return fmt.Errorf("Error expanding config path: %w", err)
+		}
+		outputDir = filepath.Join(configPath, ExitFolder)
+	}
+	outputDir, err = homedir.Expand(outputDir)
+	if err != nil {
+		return fmt.Errorf("Error expanding output folder: %w", err)
+	}
+
+	// Show a warning message
+	colorReset := "\033[0m"
+	colorYellow := "\033[33m"
+	format := c.String("format")
+	fmt.Printf("%sAnyone who has a pre-signed exit file can use it to exit your minipool's validator once its epoch has been reached. This cannot be revoked!\n", colorYellow)
+	fmt.Printf("Exits are signed for the current fork of the beacon chain. They stop being accepted two network upgrades later, so pre-sign them again after every upgrade.\n")
+	if format == ExitFormatJson {
+		fmt.Printf("The exit files will NOT be encrypted, so store them somewhere safe.\n")
+	}
+	fmt.Printf("%s\n", colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to pre-sign exits for %d minipool(s)?", len(selectedMinipools)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Prompt for the encryption password
+	var password string
+	if format == ExitFormatEncrypted {
+		password = promptExitPassword()
+	}
+
+	// Create the output folder
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return fmt.Errorf("Error creating exit folder %s: %w", outputDir, err)
+	}
+
+	// Sign exits
+	for _, minipool := range selectedMinipools {
+		path, err := presignExit(rp, minipool.Address, c.Uint64("epoch"), format, password, outputDir)
+		if err != nil {
+			fmt.Printf("Could not pre-sign an exit for minipool %s: %s.\n", minipool.Address.Hex(), err)
+		} else {
+			fmt.Printf("Saved the pre-signed exit for minipool %s to %s.\n", minipool.Address.Hex(), path)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Pre-sign an exit for a minipool and save it to the output folder
+func presignExit(rp *rocketpool.Client, minipoolAddress common.Address, epoch uint64, format string, password string, outputDir string) (string, error) {
+
+	// Sign the exit
+	response, err := rp.SignExitMinipool(minipoolAddress, epoch)
+	if err != nil {
+		return "", err
+	}
+	exit := validator.NewSignedExit(response.ValidatorIndex, response.Epoch, response.Signature, response.ForkVersion)
+
+	// Serialize it in the requested format
+	var exitBytes []byte
+	if format == ExitFormatEncrypted {
+		encryptedExit, err := validator.EncryptExit(exit, response.Pubkey, response.Epoch, password)
+		if err != nil {
+			return "", err
+		}
+		exitBytes, err = json.MarshalIndent(encryptedExit, "", "    ")
+		if err != nil {
+			return "", fmt.Errorf("Error serializing encrypted exit: %w", err)
+		}
+	} else {
+		exitBytes, err = json.MarshalIndent(exit, "", "    ")
+		if err != nil {
+			return "", fmt.Errorf("Error serializing signed exit: %w", err)
+		}
+	}
+
+	// Save it
+	path := filepath.Join(outputDir, fmt.Sprintf("exit-%s-%d-%s.json", response.Pubkey.Hex(), response.Epoch, format))
+	if err := ioutil.WriteFile(path, exitBytes, 0600); err != nil {
+		return "", fmt.Errorf("Error writing exit file %s: %w", path, err)
+	}
+	return path, nil
+
+}
+
+// Prompt for a password to encrypt pre-signed exits with
+func promptExitPassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the pre-signed exits with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		} else {
+			fmt.Println("Password confirmation does not match.")
+			fmt.Println("")
+		}
+	}
+}
@@ -51,6 +51,15 @@ func refundMinipools(c *cli.Context) error {
 		return nil
 	}
 
+	// Run in batch mode if requested
+	if isBatchMode(c) {
+		selectedMinipools, err := selectBatchMinipools(c, refundableMinipools)
+		if err != nil {
+			return err
+		}
+		return runMinipoolBatch(c, rp, getRefundBatchAction(rp), selectedMinipools)
+	}
+
 	// Get selected minipools
 	var selectedMinipools []api.MinipoolDetails
 	if c.String("minipool") == "" {
@@ -141,3 +150,25 @@ func refundMinipools(c *cli.Context) error {
 	return nil
 
 }
+
+// Get the refund action for batch mode
+func getRefundBatchAction(rp *rocketpool.Client) minipoolBatchAction {
+	return minipoolBatchAction{
+		Name:             "refund",
+		SendsTransaction: true,
+		Check: func(address common.Address) (bool, string, rocketpoolapi.GasInfo, error) {
+			canResponse, err := rp.CanRefundMinipool(address)
+			if err != nil {
+				return false, "", rocketpoolapi.GasInfo{}, err
+			}
+			if canResponse.InsufficientRefundBalance {
+				return false, "The minipool has no refund balance to claim.", canResponse.GasInfo, nil
+			}
+			return canResponse.CanRefund, "", canResponse.GasInfo, nil
+		},
+		Run: func(address common.Address) (common.Hash, error) {
+			response, err := rp.RefundMinipool(address)
+			return response.TxHash, err
+		},
+	}
+}
@@ -2,6 +2,8 @@ package minipool
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -13,6 +15,7 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 const colorReset string = "\033[0m"
@@ -107,6 +110,11 @@ func getStatus(c *cli.Context) error {
 
 	fmt.Println("")
 
+	// Print the deposit inflow rate the queue estimates are based on
+	if status.DepositInflowRate != nil {
+		fmt.Printf("New deposits have averaged %.2f ETH per day over the last %s.\n\n", eth.WeiToEth(status.DepositInflowRate)*(24*60*60), getInflowWindowDescription())
+	}
+
 	// Print actionable minipool details
 	if len(refundableMinipools) > 0 {
 		fmt.Printf("%d minipool(s) have refunds available:\n", len(refundableMinipools))
@@ -139,6 +147,18 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 	fmt.Printf("Node fee:             %f%%\n", minipool.Node.Fee*100)
 	fmt.Printf("Node deposit:         %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
 
+	// Queue details - initialized minipools
+	if minipool.Status.Status == types.Initialized && minipool.Queue.InQueue {
+		fmt.Printf("Queue position:       %d (%d in the %s deposit queue)\n", minipool.Queue.Position+1, minipool.Queue.QueueIndex+1, strings.ToLower(minipool.Queue.DepositType.String()))
+		fmt.Printf("ETH ahead in queue:   %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Queue.EthAhead), 6))
+		fmt.Printf("Deposits needed:      %.6f ETH\n", math.RoundUp(eth.WeiToEth(minipool.Queue.EthRequired), 6))
+		if minipool.Queue.EtaKnown {
+			fmt.Printf("Estimated assignment: %s (at the average deposit rate of the last %s)\n", minipool.Queue.Eta.Round(time.Minute), getInflowWindowDescription())
+		} else {
+			fmt.Printf("Estimated assignment: unknown (too few deposits in the last %s to estimate it)\n", getInflowWindowDescription())
+		}
+	}
+
 	// RP ETH deposit details - prelaunch & staking minipools
 	if minipool.Status.Status == types.Prelaunch || minipool.Status.Status == types.Staking {
 		if minipool.User.DepositAssigned {
@@ -167,6 +187,11 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 		}
 	}
 
+	// Lifecycle details
+	if len(minipool.Lifecycle.Steps) > 0 {
+		fmt.Printf("Next action:          %s\n", getLifecycleStepDescription(minipool.Lifecycle.Status, minipool.Lifecycle.Steps[0]))
+	}
+
 	// Withdrawal details - withdrawable minipools
 	if minipool.Status.Status == types.Withdrawable {
 		fmt.Printf("Withdrawal available: yes\n")
@@ -189,3 +214,8 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 	fmt.Printf("\n")
 
 }
+
+// Get the period the deposit inflow rate is averaged over, for display
+func getInflowWindowDescription() string {
+	return fmt.Sprintf("%d days", int(rputils.DepositInflowWindow.Hours()/24))
+}
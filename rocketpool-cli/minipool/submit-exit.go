@@ -0,0 +1,101 @@
+package minipool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func submitExit(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Read the exit file
+	path, err := homedir.Expand(c.String("file"))
+	if err != nil {
+		return fmt.Errorf("Error expanding exit file path: %w", err)
+	}
+	exitBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading exit file %s: %w", path, err)
+	}
+
+	// Decrypt the exit if it's encrypted, otherwise read it in the standard format
+	var exit validator.SignedExit
+	var encryptedExit validator.EncryptedExit
+	if err := json.Unmarshal(exitBytes, &encryptedExit); err == nil && encryptedExit.Crypto != nil {
+		password := cliutils.PromptPassword("Please enter the password the exit was encrypted with:", "^.*$", "")
+		exit, err = validator.DecryptExit(encryptedExit, password)
+		if err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(exitBytes, &exit); err != nil {
+		return fmt.Errorf("Error parsing exit file %s: %w", path, err)
+	}
+	validatorIndex, epoch, signature, err := exit.Parse()
+	if err != nil {
+		return err
+	}
+
+	// Check exit can be submitted
+	canSubmit, err := rp.CanSubmitExit(epoch)
+	if err != nil {
+		return err
+	}
+	if !canSubmit.CanSubmit {
+		fmt.Println("Cannot submit exit:")
+		if canSubmit.EpochTooHigh {
+			fmt.Printf("The exit is for epoch %d, but the beacon chain is only at epoch %d.\n", epoch, canSubmit.CurrentEpoch)
+		}
+		return nil
+	}
+
+	// Check the exit was signed for the fork the beacon chain will verify it with
+	colorReset := "\033[0m"
+	colorRed := "\033[31m"
+	colorYellow := "\033[33m"
+	forkVersion := hexutil.Encode(canSubmit.ForkVersion)
+	if exit.ForkVersion == "" {
+		fmt.Printf("%sThe exit file doesn't record the fork version it was signed for, so it can't be checked before broadcasting. If it was signed before a network upgrade, the beacon chain may reject it.%s\n\n", colorYellow, colorReset)
+	} else if !strings.EqualFold(exit.ForkVersion, forkVersion) {
+		fmt.Println("Cannot submit exit:")
+		fmt.Printf("The exit was signed for fork version %s, but the beacon chain will verify it with fork version %s. Please pre-sign a new exit.\n", exit.ForkVersion, forkVersion)
+		return nil
+	}
+
+	// Show a warning message
+	fmt.Printf("%s***WARNING***\n", colorRed)
+	fmt.Printf("You are about to exit validator %d, which will tell it to stop all activities on the Beacon Chain.\n", validatorIndex)
+	fmt.Printf("You will no longer receive any rewards or penalties, and this action cannot be undone!\n\n%s", colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to exit validator %d?", validatorIndex))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit the exit
+	if _, err := rp.SubmitExit(validatorIndex, epoch, signature); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully submitted the exit for validator %d.\n", validatorIndex)
+	fmt.Println("It may take several hours for your minipool's status to be reflected.")
+	return nil
+
+}
@@ -0,0 +1,83 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getTimeline(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the selected minipool
+	var minipoolAddress common.Address
+	if c.String("minipool") == "" {
+
+		// Get minipool statuses
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return err
+		}
+		if len(status.Minipools) == 0 {
+			fmt.Println("The node does not have any minipools yet.")
+			return nil
+		}
+
+		// Prompt for minipool selection
+		options := make([]string, len(status.Minipools))
+		for mi, minipool := range status.Minipools {
+			options[mi] = fmt.Sprintf("%s (%s)", minipool.Address.Hex(), minipool.Status.Status.String())
+		}
+		selected, _ := cliutils.Select("Please select a minipool to view the timeline of:", options)
+		minipoolAddress = status.Minipools[selected].Address
+
+	} else {
+		minipoolAddress = common.HexToAddress(c.String("minipool"))
+	}
+
+	// Get the timeline
+	timeline, err := rp.MinipoolTimeline(minipoolAddress)
+	if err != nil {
+		return err
+	}
+
+	// Print past events
+	fmt.Printf("Timeline for minipool %s:\n\n", minipoolAddress.Hex())
+	if len(timeline.Events) == 0 {
+		fmt.Println("No events found.")
+	}
+	for _, event := range timeline.Events {
+		fmt.Printf("%s  %s (block %d)\n", event.Time.Format(TimeFormat), event.Description, event.BlockNumber)
+	}
+	fmt.Println("")
+
+	// Print upcoming steps
+	if len(timeline.Lifecycle.Steps) == 0 {
+		fmt.Println("The minipool has no remaining lifecycle steps.")
+		return nil
+	}
+	fmt.Println("Upcoming:")
+	for _, step := range timeline.Lifecycle.Steps {
+		fmt.Printf("- %s\n", getLifecycleStepDescription(timeline.Lifecycle.Status, step))
+	}
+
+	// Return
+	return nil
+
+}
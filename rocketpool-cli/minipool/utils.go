@@ -1,4 +1,52 @@
 package minipool
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
 // Config
 const TimeFormat = "2006-01-02, 15:04 -0700 MST"
+
+// Descriptions of minipool lifecycle actions
+var lifecycleActionDescriptions = map[api.MinipoolLifecycleAction]string{
+	api.MinipoolAction_WaitForDeposit:   "Wait for user ETH to be assigned from the deposit pool",
+	api.MinipoolAction_ScrubCheck:       "Wait for the Oracle DAO scrub check",
+	api.MinipoolAction_Stake:            "Stake the minipool",
+	api.MinipoolAction_Dissolve:         "Dissolve the minipool after the launch timeout",
+	api.MinipoolAction_Exit:             "Exit the validator (optional)",
+	api.MinipoolAction_MarkWithdrawable: "Mark the minipool as withdrawable after the validator exits",
+	api.MinipoolAction_WithdrawFinalise: "Withdraw the node's ETH and finalise the minipool",
+	api.MinipoolAction_Close:            "Close the minipool once Beacon Chain withdrawals are enabled",
+	api.MinipoolAction_Refund:           "Refund ETH belonging to the node",
+}
+
+// Names of the parties responsible for lifecycle actions
+var lifecycleActorNames = map[api.MinipoolLifecycleActor]string{
+	api.MinipoolActor_Node:      "the node",
+	api.MinipoolActor_OracleDao: "the Oracle DAO",
+	api.MinipoolActor_Protocol:  "the protocol",
+}
+
+// Get a human-readable description of a minipool lifecycle step
+func getLifecycleStepDescription(status types.MinipoolStatus, step api.MinipoolLifecycleStep) string {
+	actionDescription := lifecycleActionDescriptions[step.Action]
+	if step.Action == api.MinipoolAction_Close && status == types.Dissolved {
+		actionDescription = "Close the dissolved minipool to recover the node's deposit"
+	}
+	description := fmt.Sprintf("%s (by %s)", actionDescription, lifecycleActorNames[step.Actor])
+	if !step.AvailableTime.IsZero() && step.AvailableTime.After(time.Now()) {
+		description += fmt.Sprintf(", available from %s", step.AvailableTime.Format(TimeFormat))
+	}
+	if !step.Deadline.IsZero() {
+		description += fmt.Sprintf(", deadline %s", step.Deadline.Format(TimeFormat))
+		if remaining := time.Until(step.Deadline); remaining > 0 {
+			description += fmt.Sprintf(" (%s left)", remaining.Round(time.Second))
+		}
+	}
+	return description
+}
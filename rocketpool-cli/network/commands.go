@@ -32,6 +32,39 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "settings",
+				Aliases:   []string{"d"},
+				Usage:     "Show the current protocol and oracle DAO settings",
+				UsageText: "rocketpool network settings [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "category, c",
+						Usage: "Only show one DAO ('pdao' or 'odao') or category of settings ('auction', 'deposit', 'inflation', 'minipool', 'network', 'node', 'rewards', 'members', or 'proposals')",
+						Value: "all",
+					},
+					cli.BoolFlag{
+						Name:  "history",
+						Usage: "Also show when and to what each setting was changed by DAO proposals",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					category, err := cliutils.ValidateDAOSettingCategory("category", c.String("category"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getDAOSettings(c, category)
+
+				},
+			},
+
 			{
 				Name:      "timezone-map",
 				Aliases:   []string{"t"},
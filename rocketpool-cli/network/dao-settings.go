@@ -0,0 +1,100 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+const (
+	TimeFormat = "2006-01-02, 15:04 -0700 MST"
+
+	colorReset  = "\033[0m"
+	colorYellow = "\033[33m"
+)
+
+func getDAOSettings(c *cli.Context, category string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the settings
+	includeHistory := c.Bool("history")
+	response, err := rp.DAOSettings(category, includeHistory)
+	if err != nil {
+		return err
+	}
+
+	// Print the current values, grouped by DAO and category
+	lastGroup := ""
+	for _, current := range response.Settings {
+		group := fmt.Sprintf("%s - %s", daoTitle(current.DAO), current.Category)
+		if group != lastGroup {
+			if lastGroup != "" {
+				fmt.Println()
+			}
+			fmt.Printf("========== %s ==========\n", group)
+			lastGroup = group
+		}
+		fmt.Printf("%-52s %s\n", current.Path, formatDAOSettingValue(current.ContractName, current.Path, current.Value))
+	}
+	if !includeHistory {
+		return nil
+	}
+
+	// Print the history
+	fmt.Println()
+	fmt.Println("========== Setting Changes ==========")
+	if response.ProtocolDAOBootstrapMode {
+		fmt.Printf("%sNOTE: the protocol DAO is in bootstrap mode, so its settings are changed by the guardian directly instead of by proposal. Those changes aren't recorded on-chain as events, so they can't be shown here; the current values above are authoritative.%s\n\n", colorYellow, colorReset)
+	}
+	if response.HistoryIndexing {
+		fmt.Printf("The setting change history is still being indexed (%.2f%%); run this command again to continue. Only the changes indexed so far are shown.\n\n", response.HistoryIndexProgress*100)
+	}
+	if len(response.History) == 0 {
+		fmt.Println("No settings have been changed by a DAO proposal.")
+	}
+	for _, change := range response.History {
+		fmt.Printf("%s  %s set to %s by proposal %d (block %d, transaction %s)\n",
+			change.Time.Format(TimeFormat),
+			change.Path,
+			formatDAOSettingValue(change.ContractName, change.Path, change.Value),
+			change.ProposalID,
+			change.BlockNumber,
+			change.TxHash.Hex())
+	}
+	return nil
+
+}
+
+// Format a setting value with its units, or as a plain number if the setting isn't known
+func formatDAOSettingValue(contractName string, path string, value *big.Int) string {
+	setting, known := rputils.FindDAOSetting(contractName, path)
+	if !known {
+		return value.String()
+	}
+	return setting.FormatValue(value)
+}
+
+// Get the display name of a DAO
+func daoTitle(dao string) string {
+	if dao == rputils.DAOTrustedNode {
+		return "Oracle DAO"
+	}
+	return "Protocol DAO"
+}
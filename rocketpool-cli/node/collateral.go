@@ -0,0 +1,95 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The target collateral ratio used if none is provided
+const defaultTargetCollateralPercent float64 = 15
+
+func getCollateralPlan(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the plan
+	targetPercent := c.Float64("target")
+	plan, err := rp.NodeCollateralPlan(targetPercent / 100)
+	if err != nil {
+		return err
+	}
+
+	// Print the current collateral
+	fmt.Printf("The node has %.6f RPL staked against %d active minipool(s).\n", math.RoundDown(eth.WeiToEth(plan.RplStake), 6), plan.ActiveMinipools)
+	fmt.Printf("The current RPL price is %.6f ETH.\n", math.RoundDown(eth.WeiToEth(plan.RplPrice), 6))
+	if plan.ActiveMinipools == 0 {
+		fmt.Println("The node has no active minipools, so it doesn't have a collateral ratio yet.")
+		return nil
+	}
+	fmt.Printf("The node's collateral ratio is %.2f%% (minimum %.0f%%, maximum rewarded %.0f%%).\n\n", plan.CollateralRatio*100, plan.MinimumRatio*100, plan.MaximumRatio*100)
+
+	// Print the price thresholds
+	colorReset := "\033[0m"
+	colorRed := "\033[31m"
+	colorYellow := "\033[33m"
+	if plan.CollateralRatio < plan.MinimumRatio {
+		fmt.Printf("%sThe node is below the minimum collateral ratio, so it will not earn RPL rewards and cannot create new minipools.\n", colorRed)
+		fmt.Printf("The RPL price would need to rise to %.6f ETH to bring it back to the minimum.%s\n\n", math.RoundDown(eth.WeiToEth(plan.MinimumRatioRplPrice), 6), colorReset)
+	} else {
+		fmt.Printf("The node will fall below the minimum if the RPL price drops to %.6f ETH.\n", math.RoundDown(eth.WeiToEth(plan.MinimumRatioRplPrice), 6))
+	}
+	if plan.CollateralRatio > plan.MaximumRatio {
+		fmt.Printf("%sThe node is above the maximum collateral ratio; RPL staked beyond it does not earn rewards.\n", colorYellow)
+		fmt.Printf("The RPL price would need to drop to %.6f ETH to bring it back to the maximum.%s\n\n", math.RoundDown(eth.WeiToEth(plan.MaximumRatioRplPrice), 6), colorReset)
+	} else {
+		fmt.Printf("The node will go above the maximum rewarded ratio if the RPL price rises to %.6f ETH.\n\n", math.RoundDown(eth.WeiToEth(plan.MaximumRatioRplPrice), 6))
+	}
+
+	// Print the projections
+	fmt.Println("Projected collateral ratio:")
+	fmt.Printf("%-14s%-18s%s\n", "Price change", "RPL price (ETH)", "Collateral ratio")
+	for _, projection := range plan.Projections {
+		color := colorReset
+		if projection.CollateralRatio < plan.MinimumRatio {
+			color = colorRed
+		} else if projection.CollateralRatio > plan.MaximumRatio {
+			color = colorYellow
+		}
+		fmt.Printf("%s%-14s%-18.6f%.2f%%%s\n", color, fmt.Sprintf("%+.0f%%", projection.PriceChange*100), math.RoundDown(eth.WeiToEth(projection.RplPrice), 6), projection.CollateralRatio*100, colorReset)
+	}
+	fmt.Println()
+
+	// Print the RPL needed to reach the target
+	if plan.RplToStake.Cmp(big.NewInt(0)) == 0 {
+		fmt.Printf("The node is already at or above the target ratio of %.2f%%.\n", targetPercent)
+		return nil
+	}
+	rplToStake := math.RoundUp(eth.WeiToEth(plan.RplToStake), 6)
+	fmt.Printf("To reach a collateral ratio of %.2f%%, the node needs to stake another %.6f RPL.\n", targetPercent, rplToStake)
+	available := new(big.Int).Add(plan.RplBalance, plan.FixedSupplyRplBalance)
+	fmt.Printf("The node wallet holds %.6f RPL and %.6f legacy RPL.\n", math.RoundDown(eth.WeiToEth(plan.RplBalance), 6), math.RoundDown(eth.WeiToEth(plan.FixedSupplyRplBalance), 6))
+	if available.Cmp(plan.RplToStake) < 0 {
+		fmt.Printf("%sThe node wallet doesn't hold enough RPL; send it at least %.6f more RPL first.%s\n", colorYellow, math.RoundUp(eth.WeiToEth(new(big.Int).Sub(plan.RplToStake, available)), 6), colorReset)
+	}
+	fmt.Printf("Run `rocketpool node stake-rpl --amount %.6f` to stake it.\n", rplToStake)
+	return nil
+
+}
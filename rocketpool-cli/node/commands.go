@@ -1,6 +1,8 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -113,6 +115,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "force",
 						Usage: "Force update the withdrawal address, bypassing the 'pending' state that requires a confirmation transaction from the new address",
 					},
+					cli.StringFlag{
+						Name:  "proposal-file, p",
+						Usage: "If the withdrawal address is a Safe, write the Safe transaction proposal to this file instead of printing it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -134,13 +140,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "confirm-withdrawal-address",
 				Aliases:   []string{"f"},
-				Usage:     "Confirm the node's pending withdrawal address if it has been set back to the node's address itself",
+				Usage:     "Confirm the node's pending withdrawal address if it has been set back to the node's address itself, or create a proposal for a pending Safe to confirm itself",
 				UsageText: "rocketpool node confirm-withdrawal-address [options]",
 				Flags: []cli.Flag{
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Automatically confirm withdrawal address",
 					},
+					cli.StringFlag{
+						Name:  "proposal-file, p",
+						Usage: "If the withdrawal address is a Safe, write the Safe transaction proposal to this file instead of printing it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -256,6 +266,36 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "collateral-plan",
+				Aliases:   []string{"cp"},
+				Usage:     "Project the node's RPL collateral ratio against the RPL price and calculate the RPL needed to reach a target ratio",
+				UsageText: "rocketpool node collateral-plan [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "target, t",
+						Usage: "The target collateral ratio, as a percentage",
+						Value: defaultTargetCollateralPercent,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.Float64("target") <= 0 {
+						return fmt.Errorf("Invalid target ratio '%f' - must be greater than 0", c.Float64("target"))
+					}
+
+					// Run
+					return getCollateralPlan(c)
+
+				},
+			},
+
 			{
 				Name:      "claim-rpl",
 				Aliases:   []string{"c"},
@@ -147,6 +147,12 @@ func nodeDeposit(c *cli.Context) error {
 		salt = big.NewInt(0).SetBytes(buffer)
 	}
 
+	// Simulate the deposit without the checks and prompts; the simulation reports why it would fail
+	if c.GlobalBool("dry-run") {
+		_, err := rp.NodeDeposit(amountWei, minNodeFee, salt)
+		return err
+	}
+
 	// Check deposit can be made
 	canDeposit, err := rp.CanNodeDeposit(amountWei, minNodeFee, salt)
 	if err != nil {
@@ -66,7 +66,11 @@ func getRewards(c *cli.Context) error {
 	fmt.Println()
 	fmt.Printf("Your estimated RPL staking rewards for this cycle: %f RPL (this may change based on network activity).\n", rewards.EstimatedRewards)
 	fmt.Printf("Based on your current total stake of %f RPL, this is approximately %.2f%% APR.\n", rewards.TotalRplStake, rplApr)
-	fmt.Printf("Your node has received %f RPL staking rewards in total.\n", rewards.CumulativeRewards)
+	if rewards.CumulativeRewardsIndexing {
+		fmt.Printf("Your node's lifetime RPL staking rewards are still being indexed (%.2f%%); run this command again to continue.\n", rewards.CumulativeRewardsIndexProgress*100)
+	} else {
+		fmt.Printf("Your node has received %f RPL staking rewards in total.\n", rewards.CumulativeRewards)
+	}
 
 	if rewards.Trusted {
 		rplTrustedApr := rewards.EstimatedTrustedRewards / rewards.TrustedRplBond / rewards.RewardsInterval.Hours() * (24 * 365) * 100
@@ -74,7 +78,9 @@ func getRewards(c *cli.Context) error {
 		fmt.Println()
 		fmt.Printf("You will receive an estimated %f RPL in rewards for Oracle DAO duties (this may change based on network activity).\n", rewards.EstimatedTrustedRewards)
 		fmt.Printf("Based on your bond of %f RPL, this is approximately %.2f%% APR.\n", rewards.TrustedRplBond, rplTrustedApr)
-		fmt.Printf("Your node has received %f RPL Oracle DAO rewards in total.\n", rewards.CumulativeTrustedRewards)
+		if !rewards.CumulativeRewardsIndexing {
+			fmt.Printf("Your node has received %f RPL Oracle DAO rewards in total.\n", rewards.CumulativeTrustedRewards)
+		}
 	}
 
 	fmt.Println()
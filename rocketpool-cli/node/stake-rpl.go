@@ -40,9 +40,10 @@ func nodeStakeRpl(c *cli.Context) error {
 		cliutils.PrintMultiTransactionNonceWarning()
 	}
 
-	// Check for fixed-supply RPL balance
+	// Check for fixed-supply RPL balance; a dry run only simulates the stake, so it doesn't offer the swap
+	dryRun := c.GlobalBool("dry-run")
 	rplBalance := *(status.AccountBalances.RPL)
-	if status.AccountBalances.FixedSupplyRPL.Cmp(big.NewInt(0)) > 0 {
+	if !dryRun && status.AccountBalances.FixedSupplyRPL.Cmp(big.NewInt(0)) > 0 {
 
 		// Confirm swapping RPL
 		if c.Bool("swap") || cliutils.Confirm(fmt.Sprintf("The node has a balance of %.6f old RPL. Would you like to swap it for new RPL before staking?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))) {
@@ -229,6 +230,12 @@ func nodeStakeRpl(c *cli.Context) error {
 
 	}
 
+	// Simulate the stake without the checks and prompts; the simulation assumes the approval and reports why the stake would fail
+	if dryRun {
+		_, err := rp.NodeStakeRpl(amountWei)
+		return err
+	}
+
 	// Check allowance
 	allowance, err := rp.GetNodeStakeRplAllowance()
 	if err != nil {
@@ -9,9 +9,14 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
+// Safe proposal file permissions
+const SafeProposalFileMode = 0644
+
 // FreeGeoIP config
 const FreeGeoIPURL = "https://freegeoip.app/json/"
 
@@ -114,3 +119,53 @@ func promptMinNodeFee(networkCurrentNodeFee, networkMinNodeFee float64) float64
 	}
 
 }
+
+// Print the details of a contract withdrawal address
+func printContractWithdrawalAddress(address string, check api.CheckWithdrawalAddressResponse) {
+	if check.IsSafe {
+		fmt.Printf("%s is a Safe (version %s) with %d owners, %d of whom must sign its transactions.\n", address, check.SafeVersion, len(check.SafeOwners), check.SafeThreshold)
+	} else {
+		fmt.Printf("%s is a contract. The Smartnode can only create transaction proposals for Safes, so any transactions it needs to make must be built by hand.\n", address)
+	}
+}
+
+// Print or save a Safe transaction proposal, along with instructions for submitting it
+func printSafeProposal(rp *rocketpool.Client, response api.SafeProposalResponse, proposalFile string) error {
+
+	// Serialize the proposal
+	proposalBytes, err := json.MarshalIndent(response.Proposal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error serializing Safe transaction proposal: %w", err)
+	}
+
+	// Print or save it
+	if proposalFile != "" {
+		if err := ioutil.WriteFile(proposalFile, proposalBytes, SafeProposalFileMode); err != nil {
+			return fmt.Errorf("Error writing Safe transaction proposal to %s: %w", proposalFile, err)
+		}
+		fmt.Printf("Wrote the Safe transaction proposal to %s.\n\n", proposalFile)
+	} else {
+		fmt.Println("Safe transaction proposal:")
+		fmt.Println(string(proposalBytes))
+		fmt.Println()
+	}
+
+	// Print submission instructions
+	serviceUrl := ""
+	cfg, _, err := rp.LoadConfig()
+	if err == nil {
+		serviceUrl = cfg.Smartnode.GetSafeTxServiceUrl()
+	}
+	fmt.Printf("Safe: %s (%d signatures required)\n", response.Safe.Hex(), response.SafeThreshold)
+	fmt.Printf("Safe transaction hash: %s\n\n", response.Proposal.ContractTransactionHash.Hex())
+	fmt.Println("To submit the proposal, one of the Safe's owners must set `sender` to their address and `signature` to their signature of the Safe transaction hash.")
+	if serviceUrl != "" {
+		fmt.Printf("It can then be posted to the Safe transaction service at %s/api/v1/safes/%s/multisig-transactions/\n", serviceUrl, response.Safe.Hex())
+	} else {
+		fmt.Println("It can then be posted to the Safe transaction service for this network.")
+	}
+	fmt.Println("Once it's there, the other owners can sign and execute it from the Safe's transaction queue.")
+	fmt.Printf("The proposal uses the Safe's next nonce (%d); if another transaction with that nonce is already queued, one of them will have to be rejected.\n", response.Proposal.Nonce)
+	return nil
+
+}
@@ -118,6 +118,24 @@ func nodeWithdrawRpl(c *cli.Context) error {
 		return err
 	}
 
+	// Let the user know if the RPL will be sent to a contract
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if status.WithdrawalAddress != status.AccountAddress {
+		check, err := rp.CheckWithdrawalAddress(status.WithdrawalAddress)
+		if err != nil {
+			return err
+		}
+		if check.IsContract {
+			fmt.Printf("The RPL will be sent to your withdrawal address, %s.\n", status.WithdrawalAddress.Hex())
+			printContractWithdrawalAddress(status.WithdrawalAddress.Hex(), check)
+			fmt.Println("Rocket Pool only lets the node withdraw staked RPL, so this transaction is sent by the node; moving the RPL out of the withdrawal address afterwards is up to its owners.")
+			fmt.Println()
+		}
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to withdraw %.6f staked RPL? This may decrease your node's RPL rewards.", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
 		fmt.Println("Cancelled.")
@@ -125,6 +143,7 @@ func nodeWithdrawRpl(c *cli.Context) error {
 	}
 
 	// Withdraw RPL
+	cliutils.PrintHardwareWalletPrompt(rp)
 	response, err := rp.NodeWithdrawRpl(amountWei)
 	if err != nil {
 		return err
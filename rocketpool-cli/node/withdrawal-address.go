@@ -28,6 +28,19 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
 		return err
 	}
 
+	// Make sure the new address can receive ETH if it's a contract
+	check, err := rp.CheckWithdrawalAddress(withdrawalAddress)
+	if err != nil {
+		return err
+	}
+	if check.IsContract {
+		if !check.CanReceiveEth {
+			return fmt.Errorf("%s is a contract that cannot receive ETH (%s).\nRocket Pool sends ETH rewards and refunds to the withdrawal address, so it cannot be used.", withdrawalAddress.Hex(), check.ReceiveEthError)
+		}
+		printContractWithdrawalAddress(withdrawalAddress.Hex(), check)
+		fmt.Println()
+	}
+
 	// Print the "pending" disclaimer
 	colorReset := "\033[0m"
 	colorRed := "\033[31m"
@@ -37,15 +50,41 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
 	if !c.Bool("force") {
 		confirm = false
 		fmt.Println("By default, this will put your new withdrawal address into a \"pending\" state.")
-		fmt.Println("Rocket Pool will continue to use your old withdrawal address until you confirm that you own the new address via the Rocket Pool website.")
-		fmt.Println("You will need to use a web3-compatible wallet (such as MetaMask) with your new address to confirm it.")
-		fmt.Printf("%sIf you cannot use such a wallet, or if you want to bypass this step and force Rocket Pool to use the new address immediately, please re-run this command with the \"--force\" flag.\n\n%s", colorYellow, colorReset)
+		if check.IsSafe {
+			fmt.Println("Rocket Pool will continue to use your old withdrawal address until the Safe confirms it.")
+			fmt.Printf("Once the address is pending, run `rocketpool node confirm-withdrawal-address` to create the Safe transaction proposal that confirms it.\n\n")
+		} else {
+			fmt.Println("Rocket Pool will continue to use your old withdrawal address until you confirm that you own the new address via the Rocket Pool website.")
+			fmt.Println("You will need to use a web3-compatible wallet (such as MetaMask) with your new address to confirm it.")
+			fmt.Printf("%sIf you cannot use such a wallet, or if you want to bypass this step and force Rocket Pool to use the new address immediately, please re-run this command with the \"--force\" flag.\n\n%s", colorYellow, colorReset)
+		}
 	} else {
 		confirm = true
 		fmt.Printf("%sYou have specified the \"--force\" option, so your new address will take effect immediately.\n", colorRed)
 		fmt.Printf("Please ensure that you have the correct address - if you do not control the new address, you will not be able to change this once set!%s\n\n", colorReset)
 	}
 
+	// If the current withdrawal address isn't the node address, only it can make the change
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if status.WithdrawalAddress != status.AccountAddress {
+		current, err := rp.CheckWithdrawalAddress(status.WithdrawalAddress)
+		if err != nil {
+			return err
+		}
+		if !current.IsSafe {
+			return fmt.Errorf("Your node's current withdrawal address is %s, so only it can set a new withdrawal address.", status.WithdrawalAddress.Hex())
+		}
+		fmt.Printf("Your node's current withdrawal address is the Safe %s, so the change must be made by the Safe.\n\n", status.WithdrawalAddress.Hex())
+		proposal, err := rp.SafeSetNodeWithdrawalAddress(withdrawalAddress, confirm)
+		if err != nil {
+			return err
+		}
+		return printSafeProposal(rp, proposal, c.String("proposal-file"))
+	}
+
 	// Check if the withdrawal address can be set
 	canResponse, err := rp.CanSetNodeWithdrawalAddress(withdrawalAddress, confirm)
 	if err != nil {
@@ -105,6 +144,7 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
 	}
 
 	// Set node's withdrawal address
+	cliutils.PrintHardwareWalletPrompt(rp)
 	response, err := rp.SetNodeWithdrawalAddress(withdrawalAddress, confirm)
 	if err != nil {
 		return err
@@ -117,7 +157,10 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
 	}
 
 	// Log & return
-	if !c.Bool("force") {
+	if !c.Bool("force") && check.IsSafe {
+		fmt.Printf("The node's withdrawal address update to %s is now pending.\n"+
+			"To confirm it, run `rocketpool node confirm-withdrawal-address` to create a proposal for the Safe.\n", withdrawalAddress.Hex())
+	} else if !c.Bool("force") {
 		stakeUrl := ""
 		config, _, err := rp.LoadConfig()
 		if err == nil {
@@ -146,6 +189,26 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 	}
 	defer rp.Close()
 
+	// If the pending withdrawal address is a Safe, it has to confirm itself
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if status.PendingWithdrawalAddress != (common.Address{}) && status.PendingWithdrawalAddress != status.AccountAddress {
+		pending, err := rp.CheckWithdrawalAddress(status.PendingWithdrawalAddress)
+		if err != nil {
+			return err
+		}
+		if pending.IsSafe {
+			fmt.Printf("Your node's pending withdrawal address is the Safe %s, so it must confirm itself.\n\n", status.PendingWithdrawalAddress.Hex())
+			proposal, err := rp.SafeConfirmNodeWithdrawalAddress()
+			if err != nil {
+				return err
+			}
+			return printSafeProposal(rp, proposal, c.String("proposal-file"))
+		}
+	}
+
 	// Check if the withdrawal address can be confirmed
 	canResponse, err := rp.CanConfirmNodeWithdrawalAddress()
 	if err != nil {
@@ -165,6 +228,7 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 	}
 
 	// Confirm node's withdrawal address
+	cliutils.PrintHardwareWalletPrompt(rp)
 	response, err := rp.ConfirmNodeWithdrawalAddress()
 	if err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 // Register commands
@@ -50,6 +51,46 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "submissions",
+				Aliases:   []string{"u"},
+				Usage:     "Browse the watchtower's submissions and compare them against the values the oracle DAO reached consensus on",
+				UsageText: "rocketpool odao submissions [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "type, t",
+						Usage: "The type of submission to show ('balances', 'prices', 'withdrawable', 'scrub', 'challenge', 'vote', or 'all')",
+						Value: "all",
+					},
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The maximum number of submissions to show, newest first (0 for all)",
+						Value: 10,
+					},
+					cli.BoolFlag{
+						Name:  "details, d",
+						Usage: "Show the inputs and per-minipool breakdown of each submission",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					submissionType, err := cliutils.ValidateSubmissionType("submission type", c.String("type"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getSubmissions(c, submissionType, c.Uint64("limit"), c.Bool("details"))
+
+				},
+			},
+
 			{
 				Name:      "member-settings",
 				Aliases:   []string{"b"},
@@ -188,176 +229,30 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					},
 
 					{
-						Name:    "setting",
-						Aliases: []string{"s"},
-						Usage:   "Make an oracle DAO setting proposal",
-						Subcommands: []cli.Command{
-
-							{
-								Name:      "members-quorum",
-								Aliases:   []string{"q"},
-								Usage:     "Propose updating the members.quorum setting - takes a percent, from 0 to 100",
-								UsageText: "rocketpool odao propose setting members-quorum value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-									quorumPercent, err := cliutils.ValidatePercentage("quorum percentage", c.Args().Get(0))
-									if err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingMembersQuorum(c, quorumPercent)
-
-								},
-							},
-							{
-								Name:      "members-rplbond",
-								Aliases:   []string{"b"},
-								Usage:     "Propose updating the members.rplbond setting - takes an RPL amount (e.g. 5000)",
-								UsageText: "rocketpool odao propose setting members-rplbond value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-									bondAmountEth, err := cliutils.ValidateEthAmount("RPL bond amount", c.Args().Get(0))
-									if err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingMembersRplBond(c, bondAmountEth)
-
-								},
-							},
-							{
-								Name:      "members-minipool-unbonded-max",
-								Aliases:   []string{"u"},
-								Usage:     "Propose updating the members.minipool.unbonded.max setting - takes a number (e.g 100)",
-								UsageText: "rocketpool odao propose setting members-minipool-unbonded-max value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-									unbondedMinipoolMax, err := cliutils.ValidateUint("maximum unbonded minipool count", c.Args().Get(0))
-									if err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax)
-
-								},
-							},
-							{
-								Name:      "proposal-cooldown",
-								Aliases:   []string{"c"},
-								Usage:     "Propose updating the proposal.cooldown.time setting - format is e.g. 1h30m45s",
-								UsageText: "rocketpool odao propose setting proposal-cooldown value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingProposalCooldown(c, c.Args().Get(0))
-
-								},
-							},
-							{
-								Name:      "proposal-vote-timespan",
-								Aliases:   []string{"v"},
-								Usage:     "Propose updating the proposal.vote.time setting - format is e.g. 1h30m45s",
-								UsageText: "rocketpool odao propose setting proposal-vote-timespan value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingProposalVoteTimespan(c, c.Args().Get(0))
-
-								},
-							},
-							{
-								Name:      "proposal-vote-delay-timespan",
-								Aliases:   []string{"d"},
-								Usage:     "Propose updating the proposal.vote.delay.time setting - format is e.g. 1h30m45s",
-								UsageText: "rocketpool odao propose setting proposal-vote-delay-timespan value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingProposalVoteDelayTimespan(c, c.Args().Get(0))
-
-								},
-							},
-							{
-								Name:      "proposal-execute-timespan",
-								Aliases:   []string{"x"},
-								Usage:     "Propose updating the proposal.execute.time setting - format is e.g. 1h30m45s",
-								UsageText: "rocketpool odao propose setting proposal-execute-timespan value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingProposalExecuteTimespan(c, c.Args().Get(0))
-
-								},
-							},
-							{
-								Name:      "proposal-action-timespan",
-								Aliases:   []string{"a"},
-								Usage:     "Propose updating the proposal.action.time setting - format is e.g. 1h30m45s",
-								UsageText: "rocketpool odao propose setting proposal-action-timespan value",
-								Action: func(c *cli.Context) error {
-
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
-
-									// Run
-									return proposeSettingProposalActionTimespan(c, c.Args().Get(0))
+						Name:      "setting",
+						Aliases:   []string{"s"},
+						Usage:     "Make an oracle DAO setting proposal - run without arguments to list the available settings",
+						UsageText: "rocketpool odao propose setting key value",
+						Action: func(c *cli.Context) error {
 
-								},
-							},
-							{
-								Name:      "scrub-period",
-								Aliases:   []string{"s"},
-								Usage:     "Propose updating the minipool.scrub.period setting - format is e.g. 1h30m45s",
-								UsageText: "rocketpool odao propose setting scrub-period value",
-								Action: func(c *cli.Context) error {
+							// List the settings if none was given
+							if c.NArg() == 0 {
+								printTNDAOSettings()
+								return nil
+							}
 
-									// Validate args
-									if err := cliutils.ValidateArgCount(c, 1); err != nil {
-										return err
-									}
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+							setting, err := rputils.GetDAOSetting(rputils.DAOTrustedNode, c.Args().Get(0))
+							if err != nil {
+								return err
+							}
 
-									// Run
-									return proposeSettingScrubPeriod(c, c.Args().Get(0))
+							// Run
+							return proposeSetting(c, setting, c.Args().Get(1))
 
-								},
-							},
 						},
 					},
 				},
@@ -522,6 +417,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 						},
 					},
+
+					{
+						Name:      "policy",
+						Aliases:   []string{"p"},
+						Usage:     "Show the format of the watchtower's vote policy file and check the node's policy",
+						UsageText: "rocketpool odao proposals policy",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return checkVotePolicy(c)
+
+						},
+					},
 				},
 			},
 
@@ -96,6 +96,18 @@ func executeProposal(c *cli.Context) error {
 
 	}
 
+	// Simulate each execution without the checks and prompts; the simulations report why they would fail
+	if c.GlobalBool("dry-run") {
+		for _, proposal := range selectedProposals {
+			fmt.Printf("Proposal %d:\n", proposal.ID)
+			if _, err := rp.ExecuteTNDAOProposal(proposal.ID); err != nil {
+				cliutils.PrettyPrintError(err)
+			}
+			fmt.Println()
+		}
+		return nil
+	}
+
 	// Get the total gas limit estimate
 	var totalGas uint64 = 0
 	var totalSafeGas uint64 = 0
@@ -2,387 +2,41 @@ package odao
 
 import (
 	"fmt"
-	"time"
 
-	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
-func proposeSettingMembersQuorum(c *cli.Context, quorumPercent float64) error {
+func printTNDAOSettings() {
 
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingMembersQuorum(quorumPercent / 100)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingMembersQuorum(quorumPercent / 100)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a members.quorum setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingMembersRplBond(c *cli.Context, bondAmountEth float64) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingMembersRplBond(eth.EthToWei(bondAmountEth))
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingMembersRplBond(eth.EthToWei(bondAmountEth))
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a members.rplbond setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingMinipoolUnbondedMax(c *cli.Context, unbondedMinipoolMax uint64) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingMinipoolUnbondedMax(unbondedMinipoolMax)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingMinipoolUnbondedMax(unbondedMinipoolMax)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a members.minipool.unbonded.max setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingProposalCooldown(c *cli.Context, proposalCooldownTimespan string) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalCooldownTimespan)
-	if err != nil {
-		return fmt.Errorf("Error parsing time: %w\n", err)
-	}
-	seconds := uint64(timespan.Seconds())
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingProposalCooldown(seconds)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingProposalCooldown(seconds)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a proposal.cooldown setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingProposalVoteTimespan(c *cli.Context, proposalVoteTimespan string) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalVoteTimespan)
-	if err != nil {
-		return fmt.Errorf("Error parsing time: %w\n", err)
-	}
-	seconds := uint64(timespan.Seconds())
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingProposalVoteTimespan(seconds)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
+	fmt.Println("Oracle DAO settings that can be proposed:")
+	fmt.Println()
+	for _, setting := range rputils.GetDAOSettings(rputils.DAOTrustedNode) {
+		fmt.Printf("%s (%s)\n", setting.Path, setting.Names())
+		fmt.Printf("    %s\n", setting.Description)
+		fmt.Printf("    Unit: %s, must be %s\n", setting.Unit, setting.FormatRange())
 	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingProposalVoteTimespan(seconds)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a proposal.vote.time setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
+	fmt.Println()
+	fmt.Println("Percentages are given from 0 to 100, token amounts in whole tokens, and durations in the format 1h30m45s.")
 
 }
 
-func proposeSettingProposalVoteDelayTimespan(c *cli.Context, proposalDelayTimespan string) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalDelayTimespan)
-	if err != nil {
-		return fmt.Errorf("Error parsing time: %w\n", err)
-	}
-	seconds := uint64(timespan.Seconds())
+func proposeSetting(c *cli.Context, setting rputils.DAOSetting, value string) error {
 
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingProposalVoteDelayTimespan(seconds)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
+	// Encode the value
+	encodedValue, err := setting.ParseValue(value)
 	if err != nil {
 		return err
 	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingProposalVoteDelayTimespan(seconds)
+	decodedValue, err := setting.DecodeValue(encodedValue)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a proposal.vote.delay.time setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingProposalExecuteTimespan(c *cli.Context, proposalExecuteTimespan string) error {
-
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c)
 	if err != nil {
@@ -396,145 +50,8 @@ func proposeSettingProposalExecuteTimespan(c *cli.Context, proposalExecuteTimesp
 		return err
 	}
 
-	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalExecuteTimespan)
-	if err != nil {
-		return fmt.Errorf("Error parsing time: %w\n", err)
-	}
-	seconds := uint64(timespan.Seconds())
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingProposalExecuteTimespan(seconds)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingProposalExecuteTimespan(seconds)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a proposal.execute.time setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingProposalActionTimespan(c *cli.Context, proposalActionTimespan string) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Parse the timespan
-	timespan, err := time.ParseDuration(proposalActionTimespan)
-	if err != nil {
-		return fmt.Errorf("Error parsing time: %w\n", err)
-	}
-	seconds := uint64(timespan.Seconds())
-
-	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingProposalActionTimespan(seconds)
-	if err != nil {
-		return err
-	}
-	if !canPropose.CanPropose {
-		fmt.Println("Cannot propose setting update:")
-		if canPropose.ProposalCooldownActive {
-			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
-		}
-		return nil
-	}
-
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingProposalActionTimespan(seconds)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Submitting proposal...\n")
-	cliutils.PrintTransactionHash(rp, response.TxHash)
-	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully submitted a proposal.action.time setting update proposal with ID %d.\n", response.ProposalId)
-	return nil
-
-}
-
-func proposeSettingScrubPeriod(c *cli.Context, scrubPeriod string) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Check and assign the EC status
-	err = cliutils.CheckExecutionClientStatus(rp)
-	if err != nil {
-		return err
-	}
-
-	// Parse the timespan
-	timespan, err := time.ParseDuration(scrubPeriod)
-	if err != nil {
-		return fmt.Errorf("Error parsing time: %w\n", err)
-	}
-	seconds := uint64(timespan.Seconds())
-
 	// Check if proposal can be made
-	canPropose, err := rp.CanProposeTNDAOSettingScrubPeriod(seconds)
+	canPropose, err := rp.CanProposeTNDAOSetting(setting.Key, encodedValue)
 	if err != nil {
 		return err
 	}
@@ -553,13 +70,13 @@ func proposeSettingScrubPeriod(c *cli.Context, scrubPeriod string) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to propose setting %s to %s?", setting.Path, setting.FormatValue(decodedValue)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Submit proposal
-	response, err := rp.ProposeTNDAOSettingScrubPeriod(seconds)
+	response, err := rp.ProposeTNDAOSetting(setting.Key, encodedValue)
 	if err != nil {
 		return err
 	}
@@ -571,7 +88,7 @@ func proposeSettingScrubPeriod(c *cli.Context, scrubPeriod string) error {
 	}
 
 	// Log & return
-	fmt.Printf("Successfully submitted a minipool.scrub.period setting update proposal with ID %d.\n", response.ProposalId)
+	fmt.Printf("Successfully submitted a %s setting update proposal with ID %d.\n", setting.Path, response.ProposalId)
 	return nil
 
 }
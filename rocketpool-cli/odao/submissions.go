@@ -0,0 +1,132 @@
+package odao
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+func getSubmissions(c *cli.Context, submissionType string, limit uint64, showDetails bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckExecutionClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the submissions
+	response, err := rp.TNDAOSubmissions(submissionType, limit)
+	if err != nil {
+		return err
+	}
+	if len(response.Submissions) == 0 {
+		fmt.Println("The watchtower has not recorded any submissions yet.")
+		return nil
+	}
+
+	// Print & return
+	for _, details := range response.Submissions {
+		submission := details.Submission
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("Type:         %s\n", submission.Type)
+		fmt.Printf("Block:        %d\n", submission.Block)
+		fmt.Printf("Submitted at: %s\n", cliutils.GetDateTimeString(uint64(submission.Time.Unix())))
+		fmt.Printf("TX hash:      %s\n", submission.TxHash.Hex())
+		if submission.Error != "" {
+			fmt.Printf("%sError:        %s%s\n", colorRed, submission.Error, colorReset)
+		}
+		fmt.Printf("\n")
+
+		// Automatic votes aren't compared against consensus
+		if submission.Type == api.Submission_ProposalVote {
+			for _, key := range sortedKeys(submission.Result) {
+				fmt.Printf("%-20s %s\n", key+":", submission.Result[key])
+			}
+			fmt.Printf("\n")
+			if showDetails {
+				printSubmissionDetails(submission)
+			}
+			continue
+		}
+
+		// Print the result against consensus
+		if !details.ConsensusFound {
+			fmt.Printf("%sThe oracle DAO has not reached consensus for this submission yet.%s\n", colorYellow, colorReset)
+		}
+		for _, key := range sortedKeys(submission.Result) {
+			value := submission.Result[key]
+			if !details.ConsensusFound {
+				fmt.Printf("%-20s %s\n", key+":", value)
+				continue
+			}
+			consensus, exists := details.ConsensusResult[key]
+			if !exists {
+				fmt.Printf("%-20s %s (no consensus value)\n", key+":", value)
+			} else if consensus == value {
+				fmt.Printf("%-20s %s%s (matches consensus)%s\n", key+":", colorGreen, value, colorReset)
+			} else {
+				fmt.Printf("%-20s %s%s (consensus: %s)%s\n", key+":", colorRed, value, consensus, colorReset)
+			}
+		}
+		fmt.Printf("\n")
+
+		// Print the inputs and minipool breakdown
+		if showDetails {
+			printSubmissionDetails(submission)
+		}
+	}
+	return nil
+
+}
+
+// Print the inputs and per-minipool breakdown of a submission
+func printSubmissionDetails(submission api.WatchtowerSubmission) {
+	if len(submission.Inputs) > 0 {
+		fmt.Println("Inputs:")
+		for _, key := range sortedKeys(submission.Inputs) {
+			fmt.Printf("\t%-20s %s\n", key+":", submission.Inputs[key])
+		}
+		fmt.Printf("\n")
+	}
+	if len(submission.Minipools) > 0 {
+		fmt.Printf("Minipools (%d):\n", len(submission.Minipools))
+		for _, minipool := range submission.Minipools {
+			fmt.Printf("\t%s", minipool.Address.Hex())
+			for _, key := range sortedKeys(minipool.Values) {
+				fmt.Printf("  %s=%s", key, minipool.Values[key])
+			}
+			fmt.Printf("\n")
+		}
+		fmt.Printf("\n")
+	}
+}
+
+// Get the keys of a map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
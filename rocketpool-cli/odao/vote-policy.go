@@ -0,0 +1,58 @@
+package odao
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The name of the vote policy file in the data folder
+const votePolicyFilename string = "odao-vote-policy.yml"
+
+func checkVotePolicy(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Print the format
+	path := filepath.Join(cfg.Smartnode.DataPath.Value.(string), votePolicyFilename)
+	fmt.Printf("The watchtower votes on oracle DAO proposals automatically according to the rules in %s.\n", path)
+	fmt.Println("The file has the following format:")
+	fmt.Println()
+	fmt.Print(rputils.ExampleVotePolicy)
+	fmt.Println()
+
+	// Check the node's policy
+	policy, err := rputils.LoadVotePolicy(path)
+	if err != nil {
+		fmt.Printf("%sYour vote policy is invalid, so the watchtower won't vote automatically until it is fixed: %s%s\n", colorRed, err.Error(), colorReset)
+		return nil
+	}
+	if policy == nil {
+		fmt.Println("You don't have a vote policy, so the watchtower won't vote on any proposals automatically.")
+		return nil
+	}
+	fmt.Printf("%sYour vote policy is valid and has %d rule(s):%s\n", colorGreen, len(policy.Rules), colorReset)
+	for _, rule := range policy.Rules {
+		fmt.Printf("- %s: support %t\n", rule.String(), *rule.Support)
+	}
+	return nil
+
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Create an encrypted backup of the node and store it in the configured target
+func createBackup(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Make sure the backups can be encrypted
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smartnode before backing it up.")
+	}
+	if !cfg.Backup.CanEncrypt() {
+		return fmt.Errorf("There is no encryption passphrase, GPG public key or age recipient to encrypt the backup with. Please set one in the Backups section of `rocketpool service config`.")
+	}
+
+	// Create the backup
+	fmt.Println("Creating backup...")
+	response, err := rp.CreateBackup()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backed up %d files to %s.\n", response.Files, response.Location)
+	for _, note := range response.Notes {
+		fmt.Printf("%sNOTE: %s%s\n", colorYellow, note, colorReset)
+	}
+	return nil
+
+}
+
+// Decrypt a backup, verify its contents and stage them in a new folder so they can be moved into place
+func restoreBackup(c *cli.Context, source string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// A fresh install doesn't have a config yet, so backups can only be restored from local files then
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading user settings: %w", err)
+	}
+
+	// Get the backup
+	var encrypted []byte
+	sourcePath, err := homedir.Expand(source)
+	if err != nil {
+		return fmt.Errorf("Error expanding backup path: %w", err)
+	}
+	if _, err := os.Stat(sourcePath); err == nil {
+		encrypted, err = ioutil.ReadFile(sourcePath)
+		if err != nil {
+			return fmt.Errorf("Error reading backup %s: %w", sourcePath, err)
+		}
+	} else if !isNew && backup.IsBackupName(source) {
+		target, err := backup.NewTarget(cfg.Backup, false)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Downloading %s...\n", source)
+		encrypted, err = target.Download(source)
+		if err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("%s is not a file, or the name of a backup in your configured backup target.", source)
+	}
+
+	// Decrypt and verify it
+	privateKeyPath := c.String("private-key")
+	if privateKeyPath != "" {
+		privateKeyPath, err = homedir.Expand(privateKeyPath)
+		if err != nil {
+			return fmt.Errorf("Error expanding private key path: %w", err)
+		}
+	}
+	passphrase := c.String("passphrase")
+	if passphrase == "" && !(backup.IsAgeEncrypted(encrypted) && privateKeyPath != "") {
+		prompt := "Please enter the passphrase the backup was encrypted with:"
+		if privateKeyPath != "" {
+			prompt = "Please enter the passphrase for your GPG private key (or leave it blank if it doesn't have one):"
+		}
+		passphrase = cliutils.PromptPassword(prompt, "^.*$", "")
+	}
+	manifest, files, err := backup.Open(encrypted, passphrase, privateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	// Print a summary
+	fmt.Printf("%sThe backup was decrypted and all %d files match their checksums.%s\n\n", colorGreen, len(manifest.Files), colorReset)
+	fmt.Printf("Created:          %s\n", manifest.Created.Local().Format(time.RFC1123))
+	fmt.Printf("Smartnode:        v%s\n", manifest.Version)
+	fmt.Printf("Network:          %s\n", manifest.Network)
+	fmt.Printf("Consensus client: %s\n", manifest.ConsensusClient)
+	names := []string{}
+	for name := range manifest.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println("Files:")
+	for _, name := range names {
+		fmt.Printf("\t%s\n", name)
+	}
+	fmt.Println()
+	for _, note := range manifest.Notes {
+		fmt.Printf("%sNOTE: %s%s\n", colorYellow, note, colorReset)
+	}
+	if !isNew && cfg.Smartnode.Network.Value.(config.Network) != manifest.Network {
+		fmt.Printf("%sWARNING: This backup is from %s, but your node is currently set up for %s.%s\n", colorYellow, manifest.Network, cfg.Smartnode.Network.Value, colorReset)
+	}
+
+	// Stage the files
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return fmt.Errorf("Error expanding config path: %w", err)
+	}
+	stagePath := filepath.Join(configPath, backup.GetStageFolderName(time.Now().UTC()))
+	if err := backup.StageFiles(files, stagePath); err != nil {
+		return fmt.Errorf("Error staging the backup: %w", err)
+	}
+
+	// Explain how to put them in place
+	dataPath := os.ExpandEnv(cfg.Smartnode.DataPath.Value.(string))
+	fmt.Printf("\nThe backup has been staged in %s. Nothing in your current setup has been changed.\n\n", stagePath)
+	fmt.Println("To restore it:")
+	fmt.Println("1. Make sure these validator keys are not running on any other machine, or they will be slashed.")
+	fmt.Println("2. Stop the Smartnode with `rocketpool service stop`.")
+	fmt.Printf("3. Copy %s to %s.\n", filepath.Join(stagePath, backup.SettingsFileName), filepath.Join(configPath, backup.SettingsFileName))
+	fmt.Printf("4. Copy the contents of %s into %s (you may need to use `sudo`).\n", filepath.Join(stagePath, "data"), dataPath)
+	if _, exists := manifest.Files[backup.SlashingProtectionFileName]; exists {
+		fmt.Printf("5. Import %s into %s with its slashing protection import command. It's in the EIP-3076 interchange format.\n", filepath.Join(stagePath, backup.SlashingProtectionFileName), manifest.ConsensusClient)
+		fmt.Println("6. Start the Smartnode with `rocketpool service start`.")
+	} else {
+		fmt.Printf("5. %sThis backup has no slashing protection history.%s Wait at least 15 minutes after these keys last attested before starting the Smartnode with `rocketpool service start`.\n", colorYellow, colorReset)
+	}
+	if _, exists := manifest.Files["data/password"]; !exists {
+		fmt.Printf("\nThe backup doesn't include your node password, so you'll also need to write it to %s before starting the Smartnode.\n", filepath.Join(dataPath, "password"))
+	}
+	fmt.Printf("\n%sThe staged files are no longer protected by the backup's encryption, and include your validator keys along with their passwords. Delete %s once you've restored them.%s\n", colorYellow, stagePath, colorReset)
+	return nil
+
+}
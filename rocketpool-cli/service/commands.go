@@ -201,6 +201,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Ignore service config prompt after upgrading",
 					},
+					cli.BoolFlag{
+						Name:  "rolling, r",
+						Usage: "Update the clients with new images one at a time, waiting for each to become healthy and rolling it back to its previous image if it doesn't",
+					},
+					cli.StringFlag{
+						Name:  "health-timeout",
+						Usage: "How long to wait for each client to become healthy during a rolling update before rolling it back",
+						Value: "45m",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -335,11 +344,45 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "doctor",
+				Aliases:   []string{"dr"},
+				Usage:     "Run health checks on the Rocket Pool service and suggest fixes for any problems",
+				UsageText: "rocketpool service doctor [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "json, j",
+						Usage: "Print the results as JSON for monitoring, exiting with code 1 if a check warns or 2 if a check fails",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return runDoctor(c)
+
+				},
+			},
+
 			{
 				Name:      "prune-eth1",
 				Aliases:   []string{"n"},
-				Usage:     "Shuts down the main ETH1 client and prunes its database, freeing up disk space, then restarts it when it's done.",
-				UsageText: "rocketpool service prune-eth1",
+				Usage:     "Prunes the main ETH1 client's database to free up disk space, using a fallback client while it's offline, and reports the space reclaimed.",
+				UsageText: "rocketpool service prune-eth1 [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm pruning",
+					},
+					cli.BoolFlag{
+						Name:  "no-wait",
+						Usage: "Don't wait for pruning to finish; the space reclaimed won't be reported",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -403,6 +446,51 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "backup",
+				Usage:     "Create an encrypted backup of your Smartnode settings, node wallet and validator keys, and store it in your configured backup target",
+				UsageText: "rocketpool service backup",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return createBackup(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Decrypt a backup made with `rocketpool service backup`, verify its contents and stage them in a new folder so they can be moved into place. The backup can be a file, or the name of a backup in your configured backup target.",
+				UsageText: "rocketpool service restore [options] backup",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "passphrase, p",
+						Usage: "The passphrase the backup was encrypted with, or the passphrase for the GPG private key (will be prompted for if not provided)",
+					},
+					cli.StringFlag{
+						Name:  "private-key, k",
+						Usage: "The ASCII-armored GPG private key, or the age identity file, to decrypt the backup with if it was encrypted to a public key",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					source := c.Args().Get(0)
+
+					// Run command
+					return restoreBackup(c, source)
+
+				},
+			},
+
 			{
 				Name:      "export-eth1-data",
 				Usage:     "Exports the execution client (eth1) chain data to an external folder. Use this if you want to back up your chain data before switching execution clients.",
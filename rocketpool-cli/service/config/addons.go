@@ -119,6 +119,11 @@ func (configPage *AddonsPage) getPage() *page {
 	return configPage.page
 }
 
+// The addons page doesn't have a settings layout
+func (configPage *AddonsPage) getLayout() *standardLayout {
+	return nil
+}
+
 // Handle a bulk redraw request
 func (configPage *AddonsPage) handleLayoutChanged() {
 
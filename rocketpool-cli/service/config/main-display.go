@@ -19,6 +19,8 @@ type mainDisplay struct {
 	dockerWizard        *wizard
 	settingsHome        *settingsHome
 	settingsNativeHome  *settingsNativeHome
+	history             *settingsHistory
+	currentPage         *page
 	isNew               bool
 	isMigration         bool
 	isUpdate            bool
@@ -90,9 +92,39 @@ func NewMainDisplay(app *tview.Application, previousConfig *config.RocketPoolCon
 	md.settingsHome = newSettingsHome(md)
 	md.settingsNativeHome = newSettingsNativeHome(md)
 	md.dockerWizard = newWizard(md)
+	md.history = newSettingsHistory(md)
+
+	// Set up the shortcuts for the settings pages
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if !md.isEditingSettings() {
+			return event
+		}
+		switch event.Key() {
+		case tcell.KeyCtrlF:
+			md.showSearch()
+		case tcell.KeyCtrlG:
+			md.showDiff()
+		case tcell.KeyCtrlZ:
+			md.history.undo()
+		case tcell.KeyCtrlY:
+			md.history.redo()
+		case tcell.KeyCtrlR:
+			md.resetFocusedParameter()
+		case tcell.KeyCtrlT:
+			md.resetSection()
+		default:
+			return event
+		}
+		return nil
+	})
 
 	// Set up the resize warning
 	md.app.SetAfterDrawFunc(func(screen tcell.Screen) {
+		// Record any edits made since the last draw in the undo history
+		if md.isEditingSettings() {
+			md.history.record()
+		}
+
 		x, y := screen.Size()
 		if x == md.previousWidth && y == md.previousHeight {
 			return
@@ -128,6 +160,135 @@ func NewMainDisplay(app *tview.Application, previousConfig *config.RocketPoolCon
 
 // Sets the current page that is on display.
 func (md *mainDisplay) setPage(page *page) {
+	md.currentPage = page
 	md.navHeader.SetText(page.getHeader())
 	md.pages.SwitchToPage(page.id)
 }
+
+// Shows a status message in the navigation header, until the page changes.
+func (md *mainDisplay) setStatus(message string) {
+	if md.currentPage == nil {
+		return
+	}
+	md.navHeader.SetText(fmt.Sprintf("%s    (%s)", md.currentPage.getHeader(), message))
+}
+
+// Gets the settings home page and its subpages for the current mode.
+func (md *mainDisplay) getSettingsHome() (*page, []settingsPage) {
+	if md.isNative {
+		return md.settingsNativeHome.homePage, md.settingsNativeHome.settingsSubpages
+	}
+	return md.settingsHome.homePage, md.settingsHome.settingsSubpages
+}
+
+// Gets the settings subpage on display, or nil if a settings subpage isn't on display.
+func (md *mainDisplay) getCurrentSettingsSubpage() settingsPage {
+	_, subpages := md.getSettingsHome()
+	for _, subpage := range subpages {
+		if subpage.getPage() == md.currentPage {
+			return subpage
+		}
+	}
+	return nil
+}
+
+// Checks if one of the settings pages is on display, where the search, diff, undo and reset shortcuts apply.
+func (md *mainDisplay) isEditingSettings() bool {
+	if md.currentPage == nil {
+		return false
+	}
+	homePage, _ := md.getSettingsHome()
+	switch md.currentPage.id {
+	case homePage.id, searchPageID, diffPageID:
+		return true
+	}
+	return md.getCurrentSettingsSubpage() != nil
+}
+
+// Redraws all of the settings pages to match the config's values, keeping the focus on the same setting.
+func (md *mainDisplay) refreshSettings() {
+	subpage := md.getCurrentSettingsSubpage()
+	var focusedItem *parameterizedFormItem
+	if subpage != nil && subpage.getLayout() != nil {
+		if item, ok := md.app.GetFocus().(tview.FormItem); ok {
+			focusedItem = subpage.getLayout().parameters[item]
+		}
+	}
+
+	_, subpages := md.getSettingsHome()
+	for _, settingsSubpage := range subpages {
+		settingsSubpage.handleLayoutChanged()
+	}
+
+	if subpage != nil && subpage.getLayout() != nil {
+		md.focusParameter(subpage.getLayout(), focusedItem)
+	}
+}
+
+// Shows the page to search all of the settings.
+func (md *mainDisplay) showSearch() {
+	returnPage := md.currentPage
+	if returnPage.id == searchPageID || returnPage.id == diffPageID {
+		returnPage = returnPage.parent
+	}
+	md.pages.RemovePage(searchPageID)
+	searchPage := newSearchPage(md, returnPage)
+	md.pages.AddPage(searchPage.page.id, searchPage.page.content, true, false)
+	md.setPage(searchPage.page)
+	md.app.SetFocus(searchPage.inputField)
+}
+
+// Shows the page comparing the current settings with the saved config.
+func (md *mainDisplay) showDiff() {
+	md.history.record()
+	returnPage := md.currentPage
+	if returnPage.id == searchPageID || returnPage.id == diffPageID {
+		returnPage = returnPage.parent
+	}
+	md.pages.RemovePage(diffPageID)
+	diffPage := newDiffPage(md, returnPage)
+	md.pages.AddPage(diffPage.page.id, diffPage.page.content, true, false)
+	md.setPage(diffPage.page)
+	md.app.SetFocus(diffPage.table)
+}
+
+// Finds the settings subpage and form item for a parameter, or nil if it isn't on any of the settings pages.
+func (md *mainDisplay) findParameter(param *config.Parameter) (settingsPage, *parameterizedFormItem) {
+	_, subpages := md.getSettingsHome()
+	for _, subpage := range subpages {
+		if subpage.getLayout() == nil {
+			continue
+		}
+		for _, formItem := range subpage.getLayout().parameters {
+			if formItem.parameter == param {
+				return subpage, formItem
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Shows the settings subpage a parameter is on, with the parameter's form item focused.
+func (md *mainDisplay) jumpToParameter(subpage settingsPage, formItem *parameterizedFormItem) {
+	subpage.handleLayoutChanged()
+	md.setPage(subpage.getPage())
+	if !md.focusParameter(subpage.getLayout(), formItem) {
+		subpage.getLayout().descriptionBox.SetText(fmt.Sprintf("[orange]%s isn't shown because it doesn't apply to your current selections (for example, it belongs to a client you aren't using).[white]\n\n%s", formItem.parameter.Name, formItem.parameter.Description))
+	}
+}
+
+// Focuses a parameter's form item on a settings layout; returns false if the item isn't in the layout's form.
+func (md *mainDisplay) focusParameter(layout *standardLayout, formItem *parameterizedFormItem) bool {
+	md.app.SetFocus(layout.form)
+	if formItem == nil {
+		return false
+	}
+	for i := 0; i < layout.form.GetFormItemCount(); i++ {
+		if layout.form.GetFormItem(i) == formItem.item {
+			layout.form.SetFocus(i)
+			md.app.SetFocus(layout.form)
+			return true
+		}
+	}
+	return false
+}
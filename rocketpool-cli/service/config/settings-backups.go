@@ -0,0 +1,153 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the backup config
+// This is shared by the Docker and native settings homes, so it holds the display and home page directly.
+type BackupConfigPage struct {
+	md                 *mainDisplay
+	homePage           *page
+	page               *page
+	layout             *standardLayout
+	masterConfig       *config.RocketPoolConfig
+	enableScheduledBox *parameterizedFormItem
+	intervalBox        *parameterizedFormItem
+	keepCountBox       *parameterizedFormItem
+	passphraseBox      *parameterizedFormItem
+	publicKeyPathBox   *parameterizedFormItem
+	ageRecipientBox    *parameterizedFormItem
+	includePasswordBox *parameterizedFormItem
+	targetDropdown     *parameterizedFormItem
+	localItems         []*parameterizedFormItem
+	s3Items            []*parameterizedFormItem
+	sftpItems          []*parameterizedFormItem
+}
+
+// Creates a new page for the backup settings
+func NewBackupConfigPage(md *mainDisplay, homePage *page) *BackupConfigPage {
+
+	configPage := &BackupConfigPage{
+		md:           md,
+		homePage:     homePage,
+		masterConfig: md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		homePage,
+		"settings-backups",
+		"Backups",
+		"Select this to configure encrypted backups of your Smartnode settings, node wallet and validator keys, and where they're stored.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *BackupConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Get the layout holding the page's settings
+func (configPage *BackupConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
+// Creates the content for the backup settings page
+func (configPage *BackupConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Backup Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			// Close all dropdowns and break if one was open
+			for _, param := range configPage.layout.parameters {
+				dropDown, ok := param.item.(*DropDown)
+				if ok && dropDown.open {
+					dropDown.CloseList(configPage.md.app)
+					return nil
+				}
+			}
+
+			// Return to the home page
+			configPage.md.setPage(configPage.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	backupConfig := configPage.masterConfig.Backup
+	configPage.enableScheduledBox = createParameterizedCheckbox(&backupConfig.EnableScheduledBackups)
+	configPage.intervalBox = createParameterizedUintField(&backupConfig.IntervalHours)
+	configPage.keepCountBox = createParameterizedUintField(&backupConfig.KeepCount)
+	configPage.passphraseBox = createParameterizedStringField(&backupConfig.Passphrase)
+	configPage.publicKeyPathBox = createParameterizedStringField(&backupConfig.PublicKeyPath)
+	configPage.ageRecipientBox = createParameterizedStringField(&backupConfig.AgeRecipient)
+	configPage.includePasswordBox = createParameterizedCheckbox(&backupConfig.IncludePassword)
+	configPage.targetDropdown = createParameterizedDropDown(&backupConfig.Target, configPage.layout.descriptionBox)
+	configPage.localItems = createParameterizedFormItems(backupConfig.GetTargetParameters(config.BackupTarget_Local), configPage.layout.descriptionBox)
+	configPage.s3Items = createParameterizedFormItems(backupConfig.GetTargetParameters(config.BackupTarget_S3), configPage.layout.descriptionBox)
+	configPage.sftpItems = createParameterizedFormItems(backupConfig.GetTargetParameters(config.BackupTarget_Sftp), configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableScheduledBox, configPage.intervalBox, configPage.keepCountBox, configPage.passphraseBox, configPage.publicKeyPathBox, configPage.ageRecipientBox, configPage.includePasswordBox, configPage.targetDropdown)
+	configPage.layout.mapParameterizedFormItems(configPage.localItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.s3Items...)
+	configPage.layout.mapParameterizedFormItems(configPage.sftpItems...)
+
+	// Set up the setting callbacks
+	configPage.enableScheduledBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if backupConfig.EnableScheduledBackups.Value == checked {
+			return
+		}
+		backupConfig.EnableScheduledBackups.Value = checked
+		configPage.handleLayoutChanged()
+	})
+	configPage.targetDropdown.item.(*DropDown).SetSelectedFunc(func(text string, index int) {
+		if backupConfig.Target.Value == backupConfig.Target.Options[index].Value {
+			return
+		}
+		backupConfig.Target.Value = backupConfig.Target.Options[index].Value
+		configPage.handleLayoutChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the schedule toggle or the target has changed
+func (configPage *BackupConfigPage) handleLayoutChanged() {
+	backupConfig := configPage.masterConfig.Backup
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enableScheduledBox.item)
+	if backupConfig.EnableScheduledBackups.Value == true {
+		configPage.layout.form.AddFormItem(configPage.intervalBox.item)
+		configPage.layout.form.AddFormItem(configPage.keepCountBox.item)
+	}
+	configPage.layout.form.AddFormItem(configPage.passphraseBox.item)
+	configPage.layout.form.AddFormItem(configPage.publicKeyPathBox.item)
+	configPage.layout.form.AddFormItem(configPage.ageRecipientBox.item)
+	configPage.layout.form.AddFormItem(configPage.includePasswordBox.item)
+	configPage.layout.form.AddFormItem(configPage.targetDropdown.item)
+
+	switch backupConfig.Target.Value.(config.BackupTarget) {
+	case config.BackupTarget_Local:
+		configPage.layout.addFormItems(configPage.localItems)
+	case config.BackupTarget_S3:
+		configPage.layout.addFormItems(configPage.s3Items)
+	case config.BackupTarget_Sftp:
+		configPage.layout.addFormItems(configPage.sftpItems)
+	}
+
+	configPage.layout.refresh()
+}
@@ -50,6 +50,11 @@ func (configPage *ConsensusConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout holding the page's settings
+func (configPage *ConsensusConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Consensus client settings page
 func (configPage *ConsensusConfigPage) createContent() {
 
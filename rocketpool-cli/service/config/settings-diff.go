@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Constants
+const diffPageID string = "settings-diff"
+
+// A setting that differs from the saved config
+type diffEntry struct {
+	section    string
+	param      *config.Parameter
+	savedValue interface{}
+}
+
+// The page comparing the current settings with the saved config
+type diffPage struct {
+	md      *mainDisplay
+	page    *page
+	layout  *standardLayout
+	table   *tview.Table
+	entries []diffEntry
+}
+
+// Create a page showing the settings that have changed since the config was last saved, side by side with their saved values
+func newDiffPage(md *mainDisplay, returnPage *page) *diffPage {
+
+	diffPage := &diffPage{
+		md: md,
+	}
+
+	// Find the changed settings
+	forEachParameterPair(md.PreviousConfig, md.Config, func(section string, savedParam *config.Parameter, param *config.Parameter) {
+		if fmt.Sprint(savedParam.Value) != fmt.Sprint(param.Value) {
+			diffPage.entries = append(diffPage.entries, diffEntry{
+				section:    section,
+				param:      param,
+				savedValue: savedParam.Value,
+			})
+		}
+	})
+
+	// Create the table
+	layout := newStandardLayout()
+	diffPage.layout = layout
+	table := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.Color46).Foreground(tcell.ColorBlack))
+	table.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	diffPage.table = table
+	for column, heading := range []string{"Section", "Setting", "Saved Value", "Current Value"} {
+		table.SetCell(0, column, tview.NewTableCell(heading).
+			SetTextColor(tcell.ColorOrange).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+	for i, entry := range diffPage.entries {
+		table.SetCell(i+1, 0, tview.NewTableCell(entry.section).SetExpansion(1))
+		table.SetCell(i+1, 1, tview.NewTableCell(entry.param.Name).SetExpansion(1))
+		table.SetCell(i+1, 2, tview.NewTableCell(formatParameterValue(entry.param, entry.savedValue)).SetTextColor(tcell.ColorRed).SetExpansion(1))
+		table.SetCell(i+1, 3, tview.NewTableCell(formatParameterValue(entry.param, entry.param.Value)).SetTextColor(tcell.ColorGreen).SetExpansion(1))
+	}
+	layout.setContent(table, table.Box, fmt.Sprintf("Changes Since the Last Save (%d)", len(diffPage.entries)))
+
+	// Show the description of the highlighted setting and jump to it when selected
+	table.SetSelectionChangedFunc(func(row int, column int) {
+		diffPage.showDescription(row - 1)
+	})
+	table.SetSelectedFunc(func(row int, column int) {
+		if row < 1 || row > len(diffPage.entries) {
+			return
+		}
+		param := diffPage.entries[row-1].param
+		subpage, formItem := md.findParameter(param)
+		if subpage == nil {
+			md.setStatus(fmt.Sprintf("%s can't be edited from the settings pages", param.Name))
+			return
+		}
+		md.jumpToParameter(subpage, formItem)
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			md.setPage(returnPage)
+			return nil
+		}
+		return event
+	})
+	if len(diffPage.entries) == 0 {
+		layout.descriptionBox.SetText("You haven't changed any settings since the config was last saved.")
+	} else {
+		table.Select(1, 0)
+		diffPage.showDescription(0)
+	}
+
+	diffPage.createFooter()
+	diffPage.page = newPage(returnPage, diffPageID, "Changes", "", layout.grid)
+	return diffPage
+
+}
+
+// Create the footer with the diff page's controls
+func (diffPage *diffPage) createFooter() {
+
+	navString1 := "Arrow keys: Navigate   Enter: Go to the Setting"
+	navTextView1 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView1, navString1)
+
+	navString2 := "Esc: Go Back"
+	navTextView2 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView2, navString2)
+
+	navBar := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView1, len(navString1), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView2, len(navString2), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false)
+
+	diffPage.layout.setFooter(navBar, 2)
+
+}
+
+// Show the description of a changed setting
+func (diffPage *diffPage) showDescription(index int) {
+	if index < 0 || index >= len(diffPage.entries) {
+		return
+	}
+	param := diffPage.entries[index].param
+	diffPage.layout.descriptionBox.SetText(param.Description)
+	diffPage.layout.descriptionBox.ScrollToBeginning()
+}
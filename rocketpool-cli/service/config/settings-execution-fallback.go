@@ -48,6 +48,11 @@ func (configPage *FallbackExecutionConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout holding the page's settings
+func (configPage *FallbackExecutionConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the fallback Execution client settings page
 func (configPage *FallbackExecutionConfigPage) createContent() {
 
@@ -48,6 +48,11 @@ func (configPage *ExecutionConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout holding the page's settings
+func (configPage *ExecutionConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Execution client settings page
 func (configPage *ExecutionConfigPage) createContent() {
 
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The most edits that can be undone
+const maxHistoryLength int = 100
+
+// The undo and redo history of the edits made to the config in this session
+// Edits are recorded as snapshots of the whole config, so changes that affect several settings at once
+// (such as changing networks) are undone in a single step.
+type settingsHistory struct {
+	md        *mainDisplay
+	current   *config.RocketPoolConfig
+	undoStack []*config.RocketPoolConfig
+	redoStack []*config.RocketPoolConfig
+}
+
+// Create a new settings history, starting from the config's current values
+func newSettingsHistory(md *mainDisplay) *settingsHistory {
+	return &settingsHistory{
+		md:        md,
+		current:   md.Config.CreateCopy(),
+		undoStack: []*config.RocketPoolConfig{},
+		redoStack: []*config.RocketPoolConfig{},
+	}
+}
+
+// Record the config as a new edit if it has changed since the last one
+func (history *settingsHistory) record() {
+	if configValuesMatch(history.current, history.md.Config) {
+		return
+	}
+	history.undoStack = append(history.undoStack, history.current)
+	if len(history.undoStack) > maxHistoryLength {
+		history.undoStack = history.undoStack[1:]
+	}
+	history.redoStack = []*config.RocketPoolConfig{}
+	history.current = history.md.Config.CreateCopy()
+}
+
+// Undo the last edit
+func (history *settingsHistory) undo() {
+	history.record()
+	if len(history.undoStack) == 0 {
+		history.md.setStatus("Nothing to undo")
+		return
+	}
+	history.redoStack = append(history.redoStack, history.current)
+	history.restore(history.undoStack[len(history.undoStack)-1])
+	history.undoStack = history.undoStack[:len(history.undoStack)-1]
+	history.md.setStatus(fmt.Sprintf("Undid an edit (%d more to undo)", len(history.undoStack)))
+}
+
+// Redo the last edit that was undone
+func (history *settingsHistory) redo() {
+	history.record()
+	if len(history.redoStack) == 0 {
+		history.md.setStatus("Nothing to redo")
+		return
+	}
+	history.undoStack = append(history.undoStack, history.current)
+	history.restore(history.redoStack[len(history.redoStack)-1])
+	history.redoStack = history.redoStack[:len(history.redoStack)-1]
+	history.md.setStatus(fmt.Sprintf("Redid an edit (%d more to redo)", len(history.redoStack)))
+}
+
+// Set the config's values to a snapshot and redraw the settings pages
+// The values are copied into the existing config, since the settings pages hold pointers to its parameters.
+func (history *settingsHistory) restore(snapshot *config.RocketPoolConfig) {
+	forEachParameterPair(snapshot, history.md.Config, func(section string, snapshotParam *config.Parameter, param *config.Parameter) {
+		param.Value = snapshotParam.Value
+	})
+	history.current = snapshot
+	history.md.refreshSettings()
+}
+
+// Reset the setting that has focus to its default for the current network
+func (md *mainDisplay) resetFocusedParameter() {
+	subpage := md.getCurrentSettingsSubpage()
+	if subpage == nil || subpage.getLayout() == nil {
+		return
+	}
+	item, ok := md.app.GetFocus().(tview.FormItem)
+	if !ok {
+		return
+	}
+	formItem, exists := subpage.getLayout().parameters[item]
+	if !exists {
+		return
+	}
+	if formItem.parameter.ID == config.NetworkID {
+		md.setStatus("The network can't be reset; select it from the list instead")
+		return
+	}
+	if err := md.resetParameter(formItem.parameter); err != nil {
+		md.setStatus(err.Error())
+		return
+	}
+	md.refreshSettings()
+	md.setStatus(fmt.Sprintf("Reset %s to its default", formItem.parameter.Name))
+}
+
+// Reset all of the settings on the current page to their defaults for the current network
+func (md *mainDisplay) resetSection() {
+	subpage := md.getCurrentSettingsSubpage()
+	if subpage == nil || subpage.getLayout() == nil {
+		return
+	}
+	for _, formItem := range subpage.getLayout().parameters {
+		if formItem.parameter.ID == config.NetworkID {
+			continue
+		}
+		if err := md.resetParameter(formItem.parameter); err != nil {
+			md.setStatus(err.Error())
+			return
+		}
+	}
+	md.refreshSettings()
+	md.setStatus(fmt.Sprintf("Reset the %s settings to their defaults", subpage.getPage().title))
+}
+
+// Set a parameter to its default for the current network
+func (md *mainDisplay) resetParameter(param *config.Parameter) error {
+	defaultValue, err := param.GetDefault(md.Config.Smartnode.Network.Value.(config.Network))
+	if err != nil {
+		return err
+	}
+	param.Value = defaultValue
+	return nil
+}
+
+// Run a callback on each pair of matching parameters in two configs, along with the title of the section they're in
+func forEachParameterPair(first *config.RocketPoolConfig, second *config.RocketPoolConfig, callback func(section string, firstParam *config.Parameter, secondParam *config.Parameter)) {
+
+	// Root settings
+	secondParams := second.GetParameters()
+	for i, param := range first.GetParameters() {
+		callback(first.Title, param, secondParams[i])
+	}
+
+	// Subconfig settings, in a stable order
+	firstSubconfigs := first.GetSubconfigs()
+	secondSubconfigs := second.GetSubconfigs()
+	names := make([]string, 0, len(firstSubconfigs))
+	for name := range firstSubconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		subconfig := firstSubconfigs[name]
+		secondParams := secondSubconfigs[name].GetParameters()
+		for i, param := range subconfig.GetParameters() {
+			callback(subconfig.GetConfigTitle(), param, secondParams[i])
+		}
+	}
+
+}
+
+// Check if two configs have the same values for all of their parameters
+func configValuesMatch(first *config.RocketPoolConfig, second *config.RocketPoolConfig) bool {
+	match := true
+	forEachParameterPair(first, second, func(section string, firstParam *config.Parameter, secondParam *config.Parameter) {
+		if match && fmt.Sprint(firstParam.Value) != fmt.Sprint(secondParam.Value) {
+			match = false
+		}
+	})
+	return match
+}
+
+// Format a parameter value for display, using the option name for choice parameters
+func formatParameterValue(param *config.Parameter, value interface{}) string {
+	if param.Type == config.ParameterType_Choice {
+		for _, option := range param.Options {
+			if option.Value == value {
+				return option.Name
+			}
+		}
+	}
+	if value == nil || fmt.Sprint(value) == "" {
+		return "<blank>"
+	}
+	return fmt.Sprint(value)
+}
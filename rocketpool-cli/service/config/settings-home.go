@@ -19,6 +19,7 @@ type settingsHome struct {
 	fallbackEcPage   *FallbackExecutionConfigPage
 	ccPage           *ConsensusConfigPage
 	metricsPage      *MetricsConfigPage
+	backupPage       *BackupConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
 	settingsSubpages []settingsPage
@@ -43,6 +44,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.fallbackEcPage = NewFallbackExecutionConfigPage(home)
 	home.ccPage = NewConsensusConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
+	home.backupPage = NewBackupConfigPage(md, homePage)
 	home.addonsPage = NewAddonsPage(home.md)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -50,6 +52,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.fallbackEcPage,
 		home.ccPage,
 		home.metricsPage,
+		home.backupPage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
@@ -136,6 +139,17 @@ func (home *settingsHome) createFooter() (tview.Primitive, int) {
 		AddItem(nil, 0, 1, false)
 	fmt.Fprint(navTextView2, navString2)
 
+	navString3 := "Ctrl+F: Search   Ctrl+G: Show Changes   Ctrl+Z: Undo   Ctrl+Y: Redo"
+	navTextView3 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	navBar3 := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(navTextView3, len(navString3), 1, false).
+		AddItem(nil, 0, 1, false)
+	fmt.Fprint(navTextView3, navString3)
+
 	// Save and Quit buttons
 	saveButton := tview.NewButton("Review Changes and Save")
 	wizardButton := tview.NewButton("Open the Config Wizard")
@@ -195,7 +209,8 @@ func (home *settingsHome) createFooter() (tview.Primitive, int) {
 		AddItem(buttonBar, 1, 1, false).
 		AddItem(nil, 1, 1, false).
 		AddItem(navBar1, 1, 1, false).
-		AddItem(navBar2, 1, 1, false)
+		AddItem(navBar2, 1, 1, false).
+		AddItem(navBar3, 1, 1, false)
 
 	return footer, footer.GetItemCount()
 
@@ -223,4 +238,8 @@ func (home *settingsHome) refresh() {
 	if home.metricsPage != nil {
 		home.metricsPage.layout.refresh()
 	}
+
+	if home.backupPage != nil {
+		home.backupPage.layout.refresh()
+	}
 }
@@ -51,6 +51,11 @@ func (configPage *MetricsConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout holding the page's settings
+func (configPage *MetricsConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the monitoring / stats settings page
 func (configPage *MetricsConfigPage) createContent() {
 
@@ -17,8 +17,9 @@ type settingsNativeHome struct {
 	smartnodePage    *NativeSmartnodeConfigPage
 	nativePage       *NativePage
 	metricsPage      *NativeMetricsConfigPage
+	backupPage       *BackupConfigPage
 	categoryList     *tview.List
-	settingsSubpages []*page
+	settingsSubpages []settingsPage
 	content          tview.Primitive
 	md               *mainDisplay
 }
@@ -38,16 +39,18 @@ func newSettingsNativeHome(md *mainDisplay) *settingsNativeHome {
 	home.smartnodePage = NewNativeSmartnodeConfigPage(home)
 	home.nativePage = NewNativePage(home)
 	home.metricsPage = NewNativeMetricsConfigPage(home)
-	settingsSubpages := []*page{
-		home.smartnodePage.page,
-		home.nativePage.page,
-		home.metricsPage.page,
+	home.backupPage = NewBackupConfigPage(md, homePage)
+	settingsSubpages := []settingsPage{
+		home.smartnodePage,
+		home.nativePage,
+		home.metricsPage,
+		home.backupPage,
 	}
 	home.settingsSubpages = settingsSubpages
 
 	// Add the subpages to the main display
 	for _, subpage := range settingsSubpages {
-		md.pages.AddPage(subpage.id, subpage.content, true, false)
+		md.pages.AddPage(subpage.getPage().id, subpage.getPage().content, true, false)
 	}
 	home.createContent()
 	homePage.content = home.content
@@ -64,7 +67,7 @@ func (home *settingsNativeHome) createContent() {
 	// Create the category list
 	categoryList := tview.NewList().
 		SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-			layout.descriptionBox.SetText(home.settingsSubpages[index].description)
+			layout.descriptionBox.SetText(home.settingsSubpages[index].getPage().description)
 		})
 	categoryList.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
 	categoryList.SetBorderPadding(0, 0, 1, 1)
@@ -81,15 +84,15 @@ func (home *settingsNativeHome) createContent() {
 
 	// Add all of the subpages to the list
 	for _, subpage := range home.settingsSubpages {
-		categoryList.AddItem(subpage.title, "", 0, nil)
+		categoryList.AddItem(subpage.getPage().title, "", 0, nil)
 	}
 	categoryList.SetSelectedFunc(func(i int, s1, s2 string, r rune) {
-		home.md.setPage(home.settingsSubpages[i])
+		home.md.setPage(home.settingsSubpages[i].getPage())
 	})
 
 	// Make it the content of the layout and set the default description text
 	layout.setContent(categoryList, categoryList.Box, "Select a Category")
-	layout.descriptionBox.SetText(home.settingsSubpages[0].description)
+	layout.descriptionBox.SetText(home.settingsSubpages[0].getPage().description)
 
 	// Make the footer
 	footer, height := home.createFooter()
@@ -126,6 +129,17 @@ func (home *settingsNativeHome) createFooter() (tview.Primitive, int) {
 		AddItem(nil, 0, 1, false)
 	fmt.Fprint(navTextView2, navString2)
 
+	navString3 := "Ctrl+F: Search   Ctrl+G: Show Changes   Ctrl+Z: Undo   Ctrl+Y: Redo"
+	navTextView3 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	navBar3 := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(navTextView3, len(navString3), 1, false).
+		AddItem(nil, 0, 1, false)
+	fmt.Fprint(navTextView3, navString3)
+
 	// Save and Quit buttons
 	saveButton := tview.NewButton("Review Changes and Save")
 	wizardButton := tview.NewButton("Open the Config Wizard")
@@ -185,7 +199,8 @@ func (home *settingsNativeHome) createFooter() (tview.Primitive, int) {
 		AddItem(buttonBar, 1, 1, false).
 		AddItem(nil, 1, 1, false).
 		AddItem(navBar1, 1, 1, false).
-		AddItem(navBar2, 1, 1, false)
+		AddItem(navBar2, 1, 1, false).
+		AddItem(navBar3, 1, 1, false)
 
 	return footer, footer.GetItemCount()
 
@@ -205,4 +220,8 @@ func (home *settingsNativeHome) refresh() {
 	if home.metricsPage != nil {
 		home.metricsPage.layout.refresh()
 	}
+
+	if home.backupPage != nil {
+		home.backupPage.layout.refresh()
+	}
 }
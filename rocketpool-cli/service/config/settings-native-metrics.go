@@ -93,3 +93,18 @@ func (configPage *NativeMetricsConfigPage) handleEnableMetricsChanged() {
 
 	configPage.layout.refresh()
 }
+
+// Get the underlying page
+func (configPage *NativeMetricsConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Get the layout holding the page's settings
+func (configPage *NativeMetricsConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
+// Handle a bulk redraw request
+func (configPage *NativeMetricsConfigPage) handleLayoutChanged() {
+	configPage.handleEnableMetricsChanged()
+}
@@ -82,3 +82,18 @@ func (configPage *NativeSmartnodeConfigPage) createContent() {
 	layout.refresh()
 
 }
+
+// Get the underlying page
+func (configPage *NativeSmartnodeConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Get the layout holding the page's settings
+func (configPage *NativeSmartnodeConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
+// Handle a bulk redraw request
+func (configPage *NativeSmartnodeConfigPage) handleLayoutChanged() {
+	configPage.layout.refresh()
+}
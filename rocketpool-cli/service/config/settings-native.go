@@ -71,3 +71,18 @@ func (configPage *NativePage) createContent() {
 	// Do the initial draw
 	configPage.layout.refresh()
 }
+
+// Get the underlying page
+func (configPage *NativePage) getPage() *page {
+	return configPage.page
+}
+
+// Get the layout holding the page's settings
+func (configPage *NativePage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
+// Handle a bulk redraw request
+func (configPage *NativePage) handleLayoutChanged() {
+	configPage.layout.refresh()
+}
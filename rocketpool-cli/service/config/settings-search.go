@@ -0,0 +1,263 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Constants
+const searchPageID string = "settings-search"
+const maxSearchResults int = 50
+
+// A setting that can be found with the search page
+type searchEntry struct {
+	subpage  settingsPage
+	formItem *parameterizedFormItem
+	score    int
+}
+
+// The settings search page
+type searchPage struct {
+	md         *mainDisplay
+	page       *page
+	layout     *standardLayout
+	inputField *tview.InputField
+	resultList *tview.List
+	results    []searchEntry
+}
+
+// Create a page to search all of the settings by name, ID and description
+func newSearchPage(md *mainDisplay, returnPage *page) *searchPage {
+
+	searchPage := &searchPage{
+		md: md,
+	}
+
+	// Create the search box and the results list
+	layout := newStandardLayout()
+	searchPage.layout = layout
+	inputField := tview.NewInputField().
+		SetLabel("Search: ").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+	inputField.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	searchPage.inputField = inputField
+
+	resultList := tview.NewList().
+		ShowSecondaryText(true).
+		SetSelectedBackgroundColor(tcell.Color46).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetSecondaryTextColor(tcell.ColorDarkGray)
+	resultList.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	searchPage.resultList = resultList
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(inputField, 1, 0, true).
+		AddItem(tview.NewBox().SetBackgroundColor(tview.Styles.ContrastBackgroundColor), 1, 0, false).
+		AddItem(resultList, 0, 1, false)
+	content.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	layout.setContent(content, content.Box, "Search Settings")
+
+	// Update the results as the search text changes
+	inputField.SetChangedFunc(func(text string) {
+		searchPage.updateResults(text)
+	})
+	inputField.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			md.setPage(returnPage)
+		case tcell.KeyEnter:
+			if len(searchPage.results) > 0 {
+				searchPage.selectResult(0)
+			}
+		}
+	})
+	inputField.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyDown, tcell.KeyTab:
+			if resultList.GetItemCount() > 0 {
+				md.app.SetFocus(resultList)
+			}
+			return nil
+		}
+		return event
+	})
+
+	// Show the description of the highlighted result and jump to it when selected
+	resultList.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		searchPage.showDescription(index)
+	})
+	resultList.SetSelectedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		searchPage.selectResult(index)
+	})
+	resultList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp:
+			if resultList.GetCurrentItem() == 0 {
+				md.app.SetFocus(inputField)
+				return nil
+			}
+		case tcell.KeyTab, tcell.KeyBacktab:
+			md.app.SetFocus(inputField)
+			return nil
+		case tcell.KeyEscape:
+			md.setPage(returnPage)
+			return nil
+		}
+		return event
+	})
+
+	searchPage.createFooter()
+	searchPage.page = newPage(returnPage, searchPageID, "Search", "", layout.grid)
+	searchPage.updateResults("")
+	return searchPage
+
+}
+
+// Create the footer with the search page's controls
+func (searchPage *searchPage) createFooter() {
+
+	navString1 := "Type to Search   Arrow keys: Navigate the Results   Enter: Go to the Setting"
+	navTextView1 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView1, navString1)
+
+	navString2 := "Esc: Go Back"
+	navTextView2 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView2, navString2)
+
+	navBar := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView1, len(navString1), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView2, len(navString2), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false)
+
+	searchPage.layout.setFooter(navBar, 2)
+
+}
+
+// Find the settings that match the search text, best matches first
+func (searchPage *searchPage) updateResults(text string) {
+
+	query := strings.ToLower(strings.TrimSpace(text))
+	results := []searchEntry{}
+	_, subpages := searchPage.md.getSettingsHome()
+	for _, subpage := range subpages {
+		layout := subpage.getLayout()
+		if layout == nil {
+			continue
+		}
+		for _, formItem := range layout.parameters {
+			score := getSearchScore(query, formItem.parameter.Name, formItem.parameter.ID, formItem.parameter.Description)
+			if score > 0 {
+				results = append(results, searchEntry{
+					subpage:  subpage,
+					formItem: formItem,
+					score:    score,
+				})
+			}
+		}
+	}
+	sort.SliceStable(results, func(i int, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		if results[i].subpage.getPage().title != results[j].subpage.getPage().title {
+			return results[i].subpage.getPage().title < results[j].subpage.getPage().title
+		}
+		return results[i].formItem.parameter.Name < results[j].formItem.parameter.Name
+	})
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	searchPage.results = results
+
+	// Show the results
+	searchPage.resultList.Clear()
+	for _, result := range results {
+		searchPage.resultList.AddItem(result.formItem.parameter.Name, fmt.Sprintf("  %s > %s", result.subpage.getPage().title, result.formItem.parameter.ID), 0, nil)
+	}
+	if len(results) == 0 {
+		searchPage.layout.descriptionBox.SetText("No settings match your search.")
+	} else {
+		searchPage.showDescription(0)
+	}
+
+}
+
+// Show the description of a search result
+func (searchPage *searchPage) showDescription(index int) {
+	if index < 0 || index >= len(searchPage.results) {
+		return
+	}
+	param := searchPage.results[index].formItem.parameter
+	defaultValue, _ := param.GetDefault(searchPage.md.Config.Smartnode.Network.Value.(config.Network))
+	searchPage.layout.descriptionBox.SetText(fmt.Sprintf("Current: %s\nDefault: %s\n\n%s", formatParameterValue(param, param.Value), formatParameterValue(param, defaultValue), param.Description))
+	searchPage.layout.descriptionBox.ScrollToBeginning()
+}
+
+// Jump to the setting of a search result
+func (searchPage *searchPage) selectResult(index int) {
+	if index < 0 || index >= len(searchPage.results) {
+		return
+	}
+	result := searchPage.results[index]
+	searchPage.md.jumpToParameter(result.subpage, result.formItem)
+}
+
+// Score how well a setting matches a search query; 0 means it doesn't match
+// Matches on the name rank above matches on the ID, which rank above matches on the description.
+// Names and IDs also match if the query's characters appear in them in order, so abbreviations like "ecport" work.
+func getSearchScore(query string, name string, id string, description string) int {
+	if query == "" {
+		return 1
+	}
+	name = strings.ToLower(name)
+	id = strings.ToLower(id)
+	switch {
+	case name == query || id == query:
+		return 100
+	case strings.HasPrefix(name, query):
+		return 90
+	case strings.Contains(name, query):
+		return 80
+	case strings.Contains(id, query):
+		return 70
+	case isSubsequence(query, name):
+		return 60
+	case isSubsequence(query, id):
+		return 50
+	case strings.Contains(strings.ToLower(description), query):
+		return 40
+	}
+	return 0
+}
+
+// Check if the characters of a query appear in a string in the same order
+func isSubsequence(query string, text string) bool {
+	queryRunes := []rune(query)
+	position := 0
+	for _, char := range text {
+		if position < len(queryRunes) && char == queryRunes[position] {
+			position++
+		}
+	}
+	return position == len(queryRunes)
+}
@@ -37,6 +37,11 @@ func (configPage *SmartnodeConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout holding the page's settings
+func (configPage *SmartnodeConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Smartnode settings page
 func (configPage *SmartnodeConfigPage) createContent() {
 
@@ -143,13 +143,20 @@ func (layout *standardLayout) createSettingFooter() {
 		SetWrap(false)
 	fmt.Fprint(navTextView1, navString1)
 
-	navString2 := "Esc: Go Back to Categories"
+	navString2 := "Esc: Go Back to Categories   Ctrl+R: Reset Setting to Default   Ctrl+T: Reset Page to Defaults"
 	navTextView2 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
 		SetWrap(false)
 	fmt.Fprint(navTextView2, navString2)
 
+	navString3 := "Ctrl+F: Search   Ctrl+G: Show Changes   Ctrl+Z: Undo   Ctrl+Y: Redo"
+	navTextView3 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView3, navString3)
+
 	navBar := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(tview.NewFlex().
@@ -161,9 +168,14 @@ func (layout *standardLayout) createSettingFooter() {
 			AddItem(tview.NewBox(), 0, 1, false).
 			AddItem(navTextView2, len(navString2), 1, false).
 			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView3, len(navString3), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
 			1, 1, false)
 
-	layout.setFooter(navBar, 2)
+	layout.setFooter(navBar, 3)
 
 }
 
@@ -3,6 +3,7 @@ package config
 type settingsPage interface {
 	handleLayoutChanged()
 	getPage() *page
+	getLayout() *standardLayout
 }
 
 type wizardStep interface {
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const portCheckTimeout = 3 * time.Second
+
+// The exit codes used with --json when a check warns or fails, which follow the monitoring plugin convention
+const (
+	doctorExitWarn = 1
+	doctorExitFail = 2
+)
+
+// A health check run on the host by the CLI, rather than by the daemon
+type hostCheck func(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string) []api.HealthCheck
+
+// The checks run on the host, in order
+// Checks that need the chain belong in the daemon's doctor instead.
+var hostChecks = []hostCheck{
+	checkServiceVersion,
+	checkContainers,
+	checkChainVolumes,
+	checkP2pPorts,
+}
+
+// Run the health checks and print the results
+func runDoctor(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+
+	// Run the host checks
+	checks := []api.HealthCheck{}
+	for _, check := range hostChecks {
+		checks = append(checks, check(rp, cfg, prefix)...)
+	}
+
+	// Run the daemon's checks
+	response, err := rp.ServiceDoctor()
+	if err != nil {
+		checks = append(checks, api.HealthCheck{
+			Name:        "Smartnode daemon",
+			Status:      api.HealthCheckStatus_Fail,
+			Message:     err.Error(),
+			Remediation: "Make sure the Smartnode service is running with `rocketpool service start`.",
+		})
+	} else {
+		checks = append(checks, response.Checks...)
+	}
+
+	// Get the overall status
+	failed := 0
+	warned := 0
+	for _, check := range checks {
+		switch check.Status {
+		case api.HealthCheckStatus_Fail:
+			failed++
+		case api.HealthCheckStatus_Warn:
+			warned++
+		}
+	}
+
+	// Print the results as JSON for monitoring
+	if c.Bool("json") {
+		overall := api.HealthCheckStatus_Pass
+		if failed > 0 {
+			overall = api.HealthCheckStatus_Fail
+		} else if warned > 0 {
+			overall = api.HealthCheckStatus_Warn
+		}
+		output, err := json.MarshalIndent(struct {
+			Status api.HealthCheckStatus `json:"status"`
+			Checks []api.HealthCheck     `json:"checks"`
+		}{
+			Status: overall,
+			Checks: checks,
+		}, "", "    ")
+		if err != nil {
+			return fmt.Errorf("Error serializing health checks: %w", err)
+		}
+		fmt.Println(string(output))
+
+		// Exit with the monitoring plugin status codes, without printing anything else so the output stays valid JSON
+		exitCode := 0
+		if failed > 0 {
+			exitCode = doctorExitFail
+		} else if warned > 0 {
+			exitCode = doctorExitWarn
+		}
+		if exitCode != 0 {
+			rp.Close()
+			os.Exit(exitCode)
+		}
+		return nil
+	}
+
+	// Print the results
+	for _, check := range checks {
+		switch check.Status {
+		case api.HealthCheckStatus_Pass:
+			fmt.Printf("%s[PASS]%s %s: %s\n", colorGreen, colorReset, check.Name, check.Message)
+		case api.HealthCheckStatus_Warn:
+			fmt.Printf("%s[WARN]%s %s: %s\n", colorYellow, colorReset, check.Name, check.Message)
+		case api.HealthCheckStatus_Fail:
+			fmt.Printf("%s[FAIL]%s %s: %s\n", colorRed, colorReset, check.Name, check.Message)
+		default:
+			fmt.Printf("[SKIP] %s: %s\n", check.Name, check.Message)
+		}
+		if check.Remediation != "" {
+			fmt.Printf("       %s-> %s%s\n", colorLightBlue, check.Remediation, colorReset)
+		}
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed and %d check(s) have warnings.", failed, warned)
+	}
+	if warned > 0 {
+		fmt.Printf("All checks passed, but %d have warnings.\n", warned)
+	} else {
+		fmt.Println("All checks passed.")
+	}
+	return nil
+
+}
+
+// Check that the daemon is running the same version as the CLI
+func checkServiceVersion(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string) []api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Smartnode version"}
+	serviceVersion, err := rp.GetServiceVersion()
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = err.Error()
+		check.Remediation = "Make sure the Smartnode service is running with `rocketpool service start`."
+		return []api.HealthCheck{check}
+	}
+	if serviceVersion != shared.RocketPoolVersion {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("The Smartnode service is v%s, but the CLI is v%s.", serviceVersion, shared.RocketPoolVersion)
+		check.Remediation = "Run `rocketpool service install -d` to update the service, then `rocketpool service start`."
+		return []api.HealthCheck{check}
+	}
+	check.Status = api.HealthCheckStatus_Pass
+	check.Message = fmt.Sprintf("The Smartnode service and CLI are both v%s.", serviceVersion)
+	return []api.HealthCheck{check}
+
+}
+
+// Check that the Smartnode's containers are running the configured images
+func checkContainers(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string) []api.HealthCheck {
+
+	if cfg.IsNativeMode {
+		return []api.HealthCheck{{
+			Name:    "Containers",
+			Status:  api.HealthCheckStatus_Skip,
+			Message: "The Smartnode is configured for Native Mode.",
+		}}
+	}
+
+	// Get the expected images; externally managed clients aren't run by the Smartnode
+	expectedImages := map[string]string{
+		prefix + ApiContainerSuffix:  cfg.Smartnode.GetSmartnodeContainerTag(),
+		prefix + NodeContainerSuffix: cfg.Smartnode.GetSmartnodeContainerTag(),
+	}
+	if ccConfig, err := cfg.GetSelectedConsensusClientConfig(); err == nil {
+		expectedImages[prefix+ValidatorContainerSuffix] = ccConfig.GetValidatorImage()
+	}
+	containers := []string{prefix + ApiContainerSuffix, prefix + NodeContainerSuffix, prefix + ValidatorContainerSuffix}
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		containers = append(containers, prefix+ExecutionContainerSuffix)
+	}
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		containers = append(containers, prefix+BeaconContainerSuffix)
+	}
+
+	// Check each container
+	checks := []api.HealthCheck{}
+	for _, container := range containers {
+		check := api.HealthCheck{Name: fmt.Sprintf("Container %s", container)}
+		status, err := rp.GetDockerStatus(container)
+		if err != nil {
+			check.Status = api.HealthCheckStatus_Fail
+			check.Message = fmt.Sprintf("Could not inspect the container: %s", err.Error())
+			check.Remediation = "Run `rocketpool service start` to create the Smartnode's containers."
+			checks = append(checks, check)
+			continue
+		}
+		if status != "running" {
+			check.Status = api.HealthCheckStatus_Fail
+			check.Message = fmt.Sprintf("The container is %s.", status)
+			check.Remediation = fmt.Sprintf("Run `rocketpool service start`; if it keeps stopping, check its logs with `docker logs %s`.", container)
+			checks = append(checks, check)
+			continue
+		}
+
+		// Compare the image to the configured one
+		check.Status = api.HealthCheckStatus_Pass
+		check.Message = "The container is running."
+		expectedImage, exists := expectedImages[container]
+		if exists {
+			image, err := rp.GetDockerImage(container)
+			if err != nil {
+				check.Status = api.HealthCheckStatus_Warn
+				check.Message = fmt.Sprintf("The container is running, but its image could not be read: %s", err.Error())
+			} else if image != expectedImage {
+				check.Status = api.HealthCheckStatus_Warn
+				check.Message = fmt.Sprintf("The container is running %s, but %s is configured.", image, expectedImage)
+				check.Remediation = "Run `rocketpool service start` to recreate the container with the configured image."
+			} else {
+				check.Message = fmt.Sprintf("The container is running %s.", image)
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+
+}
+
+// Check the size of the chain data volumes and the free space left on their disks
+func checkChainVolumes(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string) []api.HealthCheck {
+
+	if cfg.IsNativeMode {
+		return []api.HealthCheck{{
+			Name:    "Chain data disk space",
+			Status:  api.HealthCheckStatus_Skip,
+			Message: "The Smartnode is configured for Native Mode.",
+		}}
+	}
+
+	// Get the locally managed chain containers
+	containers := []string{}
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		containers = append(containers, prefix+ExecutionContainerSuffix)
+	}
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		containers = append(containers, prefix+BeaconContainerSuffix)
+	}
+
+	checks := []api.HealthCheck{}
+	for _, container := range containers {
+		check := api.HealthCheck{Name: fmt.Sprintf("Disk space for %s", container)}
+
+		// Get the volume's size and the free space on its partition
+		volumePath, err := rp.GetClientVolumeSource(container, clientDataVolumeName)
+		if err != nil {
+			check.Status = api.HealthCheckStatus_Warn
+			check.Message = fmt.Sprintf("Could not get the chain data volume: %s", err.Error())
+			checks = append(checks, check)
+			continue
+		}
+		freeSpace, err := getPartitionFreeSpace(rp, volumePath)
+		if err != nil {
+			check.Status = api.HealthCheckStatus_Warn
+			check.Message = fmt.Sprintf("Could not get the free space for %s: %s", volumePath, err.Error())
+			checks = append(checks, check)
+			continue
+		}
+		volumeSize := "unknown"
+		if volumeName, err := rp.GetClientVolumeName(container, clientDataVolumeName); err == nil {
+			if size, err := rp.GetVolumeSize(volumeName); err == nil {
+				volumeSize = size
+			}
+		}
+
+		if freeSpace < PruneFreeSpaceRequired {
+			check.Status = api.HealthCheckStatus_Warn
+			check.Message = fmt.Sprintf("The chain data uses %s and only %s is free on its disk.", volumeSize, humanize.IBytes(freeSpace))
+			check.Remediation = fmt.Sprintf("Free some space or move to a larger disk. Pruning the execution client with `rocketpool service prune-eth1` needs %s free.", humanize.IBytes(PruneFreeSpaceRequired))
+		} else {
+			check.Status = api.HealthCheckStatus_Pass
+			check.Message = fmt.Sprintf("The chain data uses %s and %s is free on its disk.", volumeSize, humanize.IBytes(freeSpace))
+		}
+		checks = append(checks, check)
+	}
+	return checks
+
+}
+
+// Check that the locally managed clients are listening on their P2P ports
+// This can only see whether the ports are open on this machine; forwarding on the router has to be checked from outside.
+func checkP2pPorts(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string) []api.HealthCheck {
+
+	if cfg.IsNativeMode {
+		return []api.HealthCheck{{
+			Name:    "P2P ports",
+			Status:  api.HealthCheckStatus_Skip,
+			Message: "The Smartnode is configured for Native Mode.",
+		}}
+	}
+
+	// Get the locally managed clients' ports
+	type p2pPort struct {
+		client string
+		port   uint16
+	}
+	ports := []p2pPort{}
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		selectedEc := cfg.ExecutionClient.Value.(config.ExecutionClient)
+		if selectedEc != config.ExecutionClient_Infura && selectedEc != config.ExecutionClient_Pocket {
+			ports = append(ports, p2pPort{"execution", cfg.ExecutionCommon.P2pPort.Value.(uint16)})
+		}
+	}
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		ports = append(ports, p2pPort{"consensus", cfg.ConsensusCommon.P2pPort.Value.(uint16)})
+	}
+
+	checks := []api.HealthCheck{}
+	for _, port := range ports {
+		check := api.HealthCheck{Name: fmt.Sprintf("P2P port (%s client)", port.client)}
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", port.port), portCheckTimeout)
+		if err != nil {
+			check.Status = api.HealthCheckStatus_Fail
+			check.Message = fmt.Sprintf("Nothing is listening on TCP port %d: %s", port.port, err.Error())
+			check.Remediation = fmt.Sprintf("Make sure the %s client is running and that no firewall on this machine blocks port %d.", port.client, port.port)
+		} else {
+			conn.Close()
+			check.Status = api.HealthCheckStatus_Pass
+			check.Message = fmt.Sprintf("The %s client is listening on TCP port %d. Make sure your router forwards it (TCP and UDP) to this machine.", port.client, port.port)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	pruneFallbackFileName    string        = "prune-fallback.json"
+	pruneStatusCheckInterval time.Duration = 30 * time.Second
+)
+
+// Nethermind's full pruning statuses
+const (
+	nethermindPruningDisabled   string = "Disabled"
+	nethermindPruningDelayed    string = "Delayed"
+	nethermindPruningStarting   string = "Starting"
+	nethermindPruningInProgress string = "InProgress"
+)
+
+// The fallback settings a node had before a temporary fallback was enabled for pruning
+type pruneFallbackSettings struct {
+	UseFallbackExecutionClient  bool                   `json:"useFallbackExecutionClient"`
+	FallbackExecutionClientMode config.Mode            `json:"fallbackExecutionClientMode"`
+	FallbackExecutionClient     config.ExecutionClient `json:"fallbackExecutionClient"`
+}
+
+// Prunes the main execution client, switching to a temporary fallback while it's offline if required
+func pruneExecutionClient(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Check for a temporary fallback left over from a previous prune
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return fmt.Errorf("Error expanding config path: %w", err)
+	}
+	fallbackSettingsPath := filepath.Join(configPath, pruneFallbackFileName)
+	if _, err := os.Stat(fallbackSettingsPath); err == nil {
+		fmt.Printf("%sA temporary fallback execution client was enabled by a previous prune, and your original fallback settings haven't been restored yet.%s\n", colorYellow, colorReset)
+		fmt.Println("Only restore them once your main execution client has finished pruning and is synced again.")
+		if !(c.Bool("yes") || cliutils.Confirm("Would you like to restore your original fallback settings now?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return restorePruneFallback(c, rp, fallbackSettingsPath)
+	}
+
+	// Sanity checks
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_External {
+		fmt.Println("You are using an externally managed Execution client.\nThe Smartnode cannot prune it for you.")
+		return nil
+	}
+	if cfg.IsNativeMode {
+		fmt.Println("You are using Native Mode.\nThe Smartnode cannot prune your Execution client for you, you'll have to do it manually.")
+		return nil
+	}
+
+	// Get the container prefix
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	executionContainerName := prefix + ExecutionContainerSuffix
+
+	// Check the client
+	executionClient := cfg.ExecutionClient.Value.(config.ExecutionClient)
+	switch executionClient {
+	case config.ExecutionClient_Infura, config.ExecutionClient_Pocket:
+		fmt.Printf("You are using %s as your Execution client.\nIt doesn't store any chain data on your machine, so there is nothing to prune.\n", executionClient)
+		return nil
+	}
+
+	// Get the size of the database before pruning
+	volume, err := rp.GetClientVolumeName(executionContainerName, clientDataVolumeName)
+	if err != nil {
+		return fmt.Errorf("Error getting execution client volume name: %w", err)
+	}
+	sizeBefore, err := getVolumeSpaceUsed(rp, volume)
+	if err != nil {
+		return err
+	}
+
+	// Check for enough free space
+	volumePath, err := rp.GetClientVolumeSource(executionContainerName, clientDataVolumeName)
+	if err != nil {
+		return fmt.Errorf("Error getting execution volume source path: %w", err)
+	}
+	freeSpace, err := getPartitionFreeSpace(rp, volumePath)
+	if err != nil {
+		return err
+	}
+	freeSpaceHuman := humanize.IBytes(freeSpace)
+	if freeSpace < PruneFreeSpaceRequired {
+		return fmt.Errorf("%sYour disk must have %s free to prune, but it only has %s free. Please free some space before pruning.%s", colorRed, humanize.IBytes(PruneFreeSpaceRequired), freeSpaceHuman, colorReset)
+	}
+	fmt.Printf("Your disk has %s free, which is enough to prune.\n", freeSpaceHuman)
+	fmt.Printf("Your execution client's database is currently using %s.\n\n", humanize.IBytes(sizeBefore))
+
+	// Describe what will happen
+	useTemporaryFallback := false
+	if executionClient == config.ExecutionClient_Nethermind {
+		fmt.Println("This will ask Nethermind to prune its database, freeing up disk space.")
+		fmt.Printf("Nethermind keeps running and following the chain while it prunes, so your validators will continue attesting, though it may respond more slowly until it's done.\n\n")
+	} else {
+		if executionClient == config.ExecutionClient_Besu {
+			fmt.Println("Besu will be pruned with `besu storage trie-log prune`, which removes the trie logs its Bonsai database keeps for old blocks.")
+			fmt.Println("This needs a Bonsai database and a version of Besu that has the `storage trie-log prune` command, so update Besu first if yours is older.")
+		}
+		fmt.Println("This will shut down your main execution client and prune its database, freeing up disk space.")
+		fmt.Printf("Once pruning is complete, your execution client will restart automatically.\n\n")
+
+		if cfg.UseFallbackExecutionClient.Value == false {
+			useTemporaryFallback, err = checkTemporaryPruneFallback(cfg)
+			if err != nil {
+				return err
+			}
+		} else {
+			var fallbackClientName string
+			if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_External {
+				fallbackClientName = cfg.FallbackExternalExecution.HttpUrl.Value.(string)
+			} else {
+				fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
+			}
+			fmt.Printf("You have a fallback execution client configured (%s). Rocket Pool (and your consensus client) will use that while the main client is pruning.\n", fallbackClientName)
+
+			// Prompt for stopping the node container if using Infura to prevent people from hitting the rate limit
+			if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_Local && cfg.FallbackExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Infura {
+				fmt.Printf("\n%s=== NOTE ===\n\n", colorYellow)
+				fmt.Printf("If you are using Infura's free tier, you may hit its rate limit if pruning takes a long time.\n")
+				fmt.Printf("If this happens, you should temporarily disable the `%s` container until pruning is complete. This will:\n", prefix+NodeContainerSuffix)
+				fmt.Println("\t- Stop collecting Rocket Pool's network metrics in the Grafana dashboard")
+				fmt.Printf("\t- Stop automatic operations (claiming RPL rewards and staking new minipools)\n\n")
+				fmt.Printf("To disable the container, run: `docker stop %s`\n", prefix+NodeContainerSuffix)
+				fmt.Printf("To re-enable the container once pruning is complete, run: `docker start %s`%s\n\n", prefix+NodeContainerSuffix, colorReset)
+			}
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to prune your main execution client?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Switch to the temporary fallback
+	if useTemporaryFallback {
+		if err := enablePruneFallback(c, rp, cfg, fallbackSettingsPath); err != nil {
+			return err
+		}
+	}
+
+	// Start pruning
+	startTime := time.Now()
+	if executionClient == config.ExecutionClient_Nethermind {
+		response, err := rp.StartNethermindPruning()
+		if err != nil {
+			return fmt.Errorf("%w\nIf you've just updated the Smartnode, please restart Nethermind with `rocketpool service start` so it serves its admin endpoint, then try again.", err)
+		}
+		switch response.PruningStatus {
+		case nethermindPruningStarting, nethermindPruningInProgress:
+			fmt.Printf("Nethermind responded with pruning status: %s\n", response.PruningStatus)
+		case nethermindPruningDelayed:
+			return fmt.Errorf("Nethermind was pruned recently, and won't start another full prune until the minimum delay since the last one has passed.")
+		case nethermindPruningDisabled:
+			return fmt.Errorf("Nethermind's full pruning is disabled. If you've just updated the Smartnode, please restart Nethermind with `rocketpool service start` so it picks up the manual pruning trigger, then try again.")
+		default:
+			return fmt.Errorf("Nethermind can't prune right now (pruning status: %s).", response.PruningStatus)
+		}
+	} else if executionClient == config.ExecutionClient_Besu {
+		if err := runBesuPruning(rp, cfg, prefix, executionContainerName, volume); err != nil {
+			return err
+		}
+	} else {
+		if err := provisionGethPruning(rp, cfg, prefix, executionContainerName, volume); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%sNOTE: While pruning, you **cannot** interrupt the client (e.g. by restarting) or you risk corrupting the database!\nYou must let it run to completion!%s\n\n", colorYellow, colorReset)
+
+	// Stop here if the user doesn't want to wait for pruning to finish
+	if c.Bool("no-wait") {
+		fmt.Printf("Your main execution client is now pruning. You can follow its progress with `rocketpool service logs eth1`.\n")
+		if useTemporaryFallback {
+			fmt.Printf("%sOnce it's done and has synced again, run `rocketpool service prune-eth1` again to restore your original fallback settings.%s\n", colorYellow, colorReset)
+		}
+		return nil
+	}
+
+	// Wait for pruning to finish
+	fmt.Println("Waiting for pruning to finish. This can take several hours.")
+	fmt.Println("You can safely stop waiting with Ctrl+C; pruning will carry on, and you can follow it with `rocketpool service logs eth1`.")
+	if useTemporaryFallback {
+		fmt.Println("If you stop waiting, run `rocketpool service prune-eth1` again once it's done to restore your original fallback settings.")
+	}
+	fmt.Println()
+	if executionClient == config.ExecutionClient_Nethermind {
+		err = waitForNethermindPruning(rp, startTime)
+	} else {
+		err = waitForExecutionClientSync(rp, startTime)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Report the disk space reclaimed
+	sizeAfter, err := getVolumeSpaceUsed(rp, volume)
+	if err != nil {
+		return err
+	}
+	if sizeAfter < sizeBefore {
+		fmt.Printf("%sDone! Pruning took %s and reclaimed %s (%s before, %s after).%s\n", colorGreen, time.Since(startTime).Round(time.Second), humanize.IBytes(sizeBefore-sizeAfter), humanize.IBytes(sizeBefore), humanize.IBytes(sizeAfter), colorReset)
+	} else {
+		fmt.Printf("Done! Pruning took %s, but the database didn't shrink (%s before, %s after).\n", time.Since(startTime).Round(time.Second), humanize.IBytes(sizeBefore), humanize.IBytes(sizeAfter))
+	}
+
+	// Switch back from the temporary fallback
+	if useTemporaryFallback {
+		return restorePruneFallback(c, rp, fallbackSettingsPath)
+	}
+	return nil
+
+}
+
+// Check if a temporary Pocket fallback can be used while the main execution client is offline, and ask the user if they want it
+func checkTemporaryPruneFallback(cfg *config.RocketPoolConfig) (bool, error) {
+
+	fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while it prunes, but block proposals and most of Rocket Pool's commands will not work.%s\n", colorYellow, colorReset)
+
+	// Make sure the consensus client is compatible with Pocket
+	selectedCc, err := cfg.GetSelectedConsensusClient()
+	if err != nil {
+		return false, fmt.Errorf("Error getting the selected Consensus client: %w", err)
+	}
+	compatible := false
+	for _, cc := range cfg.FallbackPocket.CompatibleConsensusClients {
+		if cc == selectedCc {
+			compatible = true
+			break
+		}
+	}
+	if !compatible {
+		fmt.Printf("Your consensus client (%s) can't use Pocket as a fallback, so please configure a fallback client with `rocketpool service config` before running this if you want to keep proposing blocks.\n\n", selectedCc)
+		return false, nil
+	}
+
+	// Offer to use Pocket until pruning is done
+	fmt.Println("The Smartnode can temporarily use Pocket as a fallback while your main client prunes, and switch back to your current settings once it's done.")
+	return cliutils.Confirm("Would you like to use a temporary Pocket fallback while pruning?"), nil
+
+}
+
+// Enable a temporary Pocket fallback, saving the original fallback settings so they can be restored later
+func enablePruneFallback(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, fallbackSettingsPath string) error {
+
+	// Save the original settings
+	settings := pruneFallbackSettings{
+		UseFallbackExecutionClient:  cfg.UseFallbackExecutionClient.Value.(bool),
+		FallbackExecutionClientMode: cfg.FallbackExecutionClientMode.Value.(config.Mode),
+		FallbackExecutionClient:     cfg.FallbackExecutionClient.Value.(config.ExecutionClient),
+	}
+	settingsBytes, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("Error serializing the original fallback settings: %w", err)
+	}
+	if err := ioutil.WriteFile(fallbackSettingsPath, settingsBytes, 0600); err != nil {
+		return fmt.Errorf("Error saving the original fallback settings to %s: %w", fallbackSettingsPath, err)
+	}
+
+	// Switch to Pocket
+	fmt.Println("Enabling the temporary Pocket fallback...")
+	cfg.UseFallbackExecutionClient.Value = true
+	cfg.FallbackExecutionClientMode.Value = config.Mode_Local
+	cfg.FallbackExecutionClient.Value = config.ExecutionClient_Pocket
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the temporary fallback settings: %w", err)
+	}
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("Error starting the temporary fallback: %w", err)
+	}
+	fmt.Println()
+	return nil
+
+}
+
+// Restore the fallback settings that were saved before a temporary fallback was enabled
+func restorePruneFallback(c *cli.Context, rp *rocketpool.Client, fallbackSettingsPath string) error {
+
+	// Read the original settings
+	settingsBytes, err := ioutil.ReadFile(fallbackSettingsPath)
+	if err != nil {
+		return fmt.Errorf("Error reading the original fallback settings from %s: %w", fallbackSettingsPath, err)
+	}
+	var settings pruneFallbackSettings
+	if err := json.Unmarshal(settingsBytes, &settings); err != nil {
+		return fmt.Errorf("Error deserializing the original fallback settings: %w", err)
+	}
+
+	// Restore them
+	fmt.Println("Restoring your original fallback settings...")
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.UseFallbackExecutionClient.Value = settings.UseFallbackExecutionClient
+	cfg.FallbackExecutionClientMode.Value = settings.FallbackExecutionClientMode
+	cfg.FallbackExecutionClient.Value = settings.FallbackExecutionClient
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the original fallback settings: %w", err)
+	}
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("Error restarting the Smartnode with the original fallback settings: %w", err)
+	}
+	if err := os.Remove(fallbackSettingsPath); err != nil {
+		return fmt.Errorf("Error deleting %s: %w", fallbackSettingsPath, err)
+	}
+	fmt.Println("Your original fallback settings have been restored.")
+	return nil
+
+}
+
+// Stop Geth, provision its offline prune and start it again
+func provisionGethPruning(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string, executionContainerName string, volume string) error {
+
+	fmt.Printf("Stopping %s...\n", executionContainerName)
+	result, err := rp.StopContainer(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error stopping main execution container: %w", err)
+	}
+	if result != executionContainerName {
+		return fmt.Errorf("Unexpected output while stopping main execution container: %s", result)
+	}
+
+	// Run the prune provisioner
+	fmt.Printf("Provisioning pruning on volume %s...\n", volume)
+	err = rp.RunPruneProvisioner(prefix+PruneProvisionerContainerSuffix, volume, cfg.Smartnode.GetPruneProvisionerContainerTag())
+	if err != nil {
+		return fmt.Errorf("Error running prune provisioner: %w", err)
+	}
+
+	// Restart ETH1
+	fmt.Printf("Restarting %s...\n", executionContainerName)
+	result, err = rp.StartContainer(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error starting main execution client: %w", err)
+	}
+	if result != executionContainerName {
+		return fmt.Errorf("Unexpected output while starting main execution client: %s", result)
+	}
+	return nil
+
+}
+
+// Stop Besu, prune its trie logs offline and start it again
+// Unlike Geth, Besu prunes before it restarts, so it's started again even if pruning fails.
+func runBesuPruning(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string, executionContainerName string, volume string) error {
+
+	fmt.Printf("Stopping %s...\n", executionContainerName)
+	result, err := rp.StopContainer(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error stopping main execution container: %w", err)
+	}
+	if result != executionContainerName {
+		return fmt.Errorf("Unexpected output while stopping main execution container: %s", result)
+	}
+
+	// Prune the trie logs
+	fmt.Printf("Pruning Besu's trie logs on volume %s...\n", volume)
+	pruneErr := rp.RunBesuTrieLogPruner(prefix+BesuPrunerContainerSuffix, volume, cfg.Besu.ContainerTag.Value.(string))
+
+	// Restart ETH1
+	fmt.Printf("Restarting %s...\n", executionContainerName)
+	result, err = rp.StartContainer(executionContainerName)
+	if err != nil {
+		return fmt.Errorf("Error starting main execution client: %w", err)
+	}
+	if result != executionContainerName {
+		return fmt.Errorf("Unexpected output while starting main execution client: %s", result)
+	}
+	if pruneErr != nil {
+		return fmt.Errorf("Error pruning Besu's trie logs: %w\nMake sure your version of Besu has the `storage trie-log prune` command and uses a Bonsai database.", pruneErr)
+	}
+	return nil
+
+}
+
+// Wait for the main execution client to come back online and finish syncing after an offline prune
+func waitForExecutionClientSync(rp *rocketpool.Client, startTime time.Time) error {
+	for {
+		time.Sleep(pruneStatusCheckInterval)
+		response, err := rp.GetExecutionClientStatus()
+		if err != nil {
+			fmt.Printf("%sWARNING: couldn't check the execution client's status: %s%s\n", colorYellow, err.Error(), colorReset)
+			continue
+		}
+		primaryStatus := response.ManagerStatus.PrimaryEcStatus
+		if primaryStatus.IsWorking && primaryStatus.IsSynced {
+			return nil
+		}
+		if primaryStatus.IsWorking {
+			fmt.Printf("Pruning is done, waiting for the execution client to sync (%.2f%%)...\n", primaryStatus.SyncProgress*100)
+		} else {
+			fmt.Printf("Still pruning (%s elapsed)...\n", time.Since(startTime).Round(time.Second))
+		}
+	}
+}
+
+// Wait for Nethermind to report that its full prune has finished
+// Once a prune is done, Nethermind reports that the next one is delayed rather than starting it.
+func waitForNethermindPruning(rp *rocketpool.Client, startTime time.Time) error {
+	for {
+		time.Sleep(pruneStatusCheckInterval)
+		response, err := rp.GetNethermindPruningStatus()
+		if err != nil {
+			fmt.Printf("%sWARNING: couldn't check Nethermind's pruning status: %s%s\n", colorYellow, err.Error(), colorReset)
+			continue
+		}
+		switch response.PruningStatus {
+		case nethermindPruningStarting, nethermindPruningInProgress:
+			fmt.Printf("Still pruning (%s elapsed)...\n", time.Since(startTime).Round(time.Second))
+		case nethermindPruningDelayed:
+			return nil
+		default:
+			return fmt.Errorf("Nethermind reported pruning status %s while pruning. Please check its logs with `rocketpool service logs eth1` for details.", response.PruningStatus)
+		}
+	}
+}
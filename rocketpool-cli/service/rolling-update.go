@@ -0,0 +1,385 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	rollingUpdateLogFile       string = "rolling-update.log"
+	rollingUpdateCheckInterval        = 30 * time.Second
+	rollingUpdateMinPeers      uint64 = 5
+
+	// How many epochs to wait after the validator client restarts before its attestations show up in validator balances
+	rollingUpdateAttestationEpochs uint64 = 2
+)
+
+// A client that the rolling update restarts on its own
+type rollingUpdateStep struct {
+	name          string
+	serviceName   string
+	containerName string
+	imageVariable string
+	checkHealth   func(health api.ClientHealthResponse, state *rollingUpdateHealthState) (bool, string)
+}
+
+// What a health check has seen since its client was restarted
+type rollingUpdateHealthState struct {
+	firstEpoch uint64
+}
+
+// Writes each step of a rolling update to the terminal and to the rolling update log
+type rollingUpdateLog struct {
+	file *os.File
+}
+
+// Update the clients whose images have changed one at a time, waiting for each one to become healthy before moving on to the next.
+// Clients that don't become healthy before the timeout are rolled back to the image they were running before.
+func runRollingUpdate(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	// Rolling updates need Docker
+	if cfg.IsNativeMode {
+		return fmt.Errorf("Rolling updates aren't available in Native Mode; update your clients with your own service manager instead.")
+	}
+	healthTimeout, err := time.ParseDuration(c.String("health-timeout"))
+	if err != nil {
+		return fmt.Errorf("Invalid health timeout '%s': %w", c.String("health-timeout"), err)
+	}
+	composeFiles := getComposeFiles(c)
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+
+	// Open the log
+	updateLog, err := openRollingUpdateLog(c)
+	if err != nil {
+		return err
+	}
+	defer updateLog.close()
+	updateLog.printlnf("Starting a rolling update (health timeout %s).", healthTimeout)
+
+	// Find the clients that are changing to a new image
+	steps := []rollingUpdateStep{}
+	previousImages := map[string]string{}
+	for _, step := range getRollingUpdateSteps(cfg, prefix) {
+		newImage := cfg.GenerateEnvironmentVariables()[step.imageVariable]
+		previousImage, err := rp.GetDockerImage(step.containerName)
+		if err != nil || previousImage == "" {
+			updateLog.printlnf("The %s isn't running yet, so it will be started with %s after the update.", step.name, newImage)
+			continue
+		}
+		if previousImage == newImage {
+			updateLog.printlnf("The %s is already running %s.", step.name, newImage)
+			continue
+		}
+		updateLog.printlnf("The %s will be updated from %s to %s.", step.name, previousImage, newImage)
+		steps = append(steps, step)
+		previousImages[step.serviceName] = previousImage
+	}
+	if len(steps) == 0 {
+		updateLog.printlnf("None of the clients have new images, so there's nothing to update one at a time.")
+		return nil
+	}
+
+	// Pull the new images first so the clients are offline for as little time as possible
+	serviceNames := []string{}
+	for _, step := range steps {
+		serviceNames = append(serviceNames, step.serviceName)
+	}
+	updateLog.printlnf("Pulling the new images...")
+	if err := rp.PullServiceImages(composeFiles, serviceNames...); err != nil {
+		updateLog.printlnf("Pulling the new images failed: %s", err.Error())
+		return fmt.Errorf("Error pulling the new client images: %w", err)
+	}
+
+	// Update the API container first, since it runs the health checks
+	updateLog.printlnf("Updating the API container so it can check the clients' health...")
+	if err := rp.UpdateServiceContainers(composeFiles, config.ApiContainerName); err != nil {
+		updateLog.printlnf("Updating the API container failed: %s", err.Error())
+		return fmt.Errorf("Error updating the API container: %w", err)
+	}
+
+	// Update the clients one at a time
+	rolledBack := []string{}
+	for _, step := range steps {
+		previousImage := previousImages[step.serviceName]
+		newImage := cfg.GenerateEnvironmentVariables()[step.imageVariable]
+		if previousImage == newImage {
+			updateLog.printlnf("The %s no longer needs updating, since an earlier rollback switched it back to %s.", step.name, previousImage)
+			continue
+		}
+
+		updateLog.printlnf("Restarting the %s with %s...", step.name, newImage)
+		err := rp.UpdateServiceContainers(composeFiles, step.serviceName)
+		if err == nil {
+			err = waitForClientHealth(rp, step, healthTimeout, updateLog)
+		}
+		if err == nil {
+			updateLog.printlnf("The %s is healthy on %s.", step.name, newImage)
+			continue
+		}
+		updateLog.printlnf("The %s didn't become healthy on %s: %s", step.name, newImage, err.Error())
+
+		// A different client can't be rolled back to, since its data and settings won't match the old image
+		previousName, _ := getDockerImageName(previousImage)
+		newName, _ := getDockerImageName(newImage)
+		if previousName != newName {
+			updateLog.printlnf("The %s was switched from %s to %s rather than upgraded, so it can't be rolled back. Please check its logs with `rocketpool service logs %s`.", step.name, previousName, newName, step.serviceName)
+			return fmt.Errorf("The %s didn't become healthy after switching to %s.", step.name, newName)
+		}
+
+		// Roll back to the previous image
+		updateLog.printlnf("Rolling the %s back to %s...", step.name, previousImage)
+		pinned := pinClientImage(cfg, step.imageVariable, newImage, previousImage)
+		if pinned == 0 {
+			updateLog.printlnf("Couldn't find the setting that selects %s, so the %s can't be rolled back.", newImage, step.name)
+			return fmt.Errorf("The %s didn't become healthy on %s and couldn't be rolled back.", step.name, newImage)
+		}
+		if err := rp.SaveConfig(cfg); err != nil {
+			updateLog.printlnf("Saving the rolled back settings failed: %s", err.Error())
+			return fmt.Errorf("Error saving the rolled back settings: %w", err)
+		}
+		updateLog.printlnf("Set %d container tag setting(s) back to %s; the next Smartnode upgrade will try the new version again.", pinned, previousImage)
+		if err := rp.UpdateServiceContainers(composeFiles, step.serviceName); err != nil {
+			updateLog.printlnf("Rolling the %s back failed: %s", step.name, err.Error())
+			return fmt.Errorf("Error rolling the %s back to %s: %w", step.name, previousImage, err)
+		}
+		if err := waitForClientHealth(rp, step, healthTimeout, updateLog); err != nil {
+			updateLog.printlnf("The %s didn't become healthy after rolling back either: %s", step.name, err.Error())
+			return fmt.Errorf("The %s didn't become healthy on %s or after rolling back to %s. Please check its logs with `rocketpool service logs %s`.", step.name, newImage, previousImage, step.serviceName)
+		}
+		updateLog.printlnf("The %s is healthy again on %s.", step.name, previousImage)
+		rolledBack = append(rolledBack, step.name)
+	}
+
+	// Report the result
+	if len(rolledBack) > 0 {
+		updateLog.printlnf("The rolling update finished, but %d client(s) were rolled back to their previous images: %s.", len(rolledBack), strings.Join(rolledBack, ", "))
+	} else {
+		updateLog.printlnf("The rolling update finished and all of the clients are healthy.")
+	}
+	return nil
+
+}
+
+// Get the clients the Smartnode manages, in the order they should be updated
+// The fallback execution client goes first so the node can keep running on it while the primary client restarts.
+func getRollingUpdateSteps(cfg *config.RocketPoolConfig, prefix string) []rollingUpdateStep {
+
+	steps := []rollingUpdateStep{}
+	if cfg.UseFallbackExecutionClient.Value == true && cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		steps = append(steps, rollingUpdateStep{
+			name:          "fallback execution client",
+			serviceName:   config.Eth1FallbackContainerName,
+			containerName: prefix + FallbackExecutionContainerSuffix,
+			imageVariable: "FALLBACK_EC_CONTAINER_TAG",
+			checkHealth:   checkFallbackExecutionClientHealth,
+		})
+	}
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		// Light clients don't have peers of their own
+		checkHealth := checkExecutionClientHealth
+		selectedEc := cfg.ExecutionClient.Value.(config.ExecutionClient)
+		if selectedEc == config.ExecutionClient_Infura || selectedEc == config.ExecutionClient_Pocket {
+			checkHealth = checkLightExecutionClientHealth
+		}
+		steps = append(steps, rollingUpdateStep{
+			name:          "execution client",
+			serviceName:   config.Eth1ContainerName,
+			containerName: prefix + ExecutionContainerSuffix,
+			imageVariable: "EC_CONTAINER_TAG",
+			checkHealth:   checkHealth,
+		})
+	}
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		steps = append(steps, rollingUpdateStep{
+			name:          "consensus client",
+			serviceName:   config.Eth2ContainerName,
+			containerName: prefix + BeaconContainerSuffix,
+			imageVariable: "BN_CONTAINER_TAG",
+			checkHealth:   checkConsensusClientHealth,
+		})
+	}
+	steps = append(steps, rollingUpdateStep{
+		name:          "validator client",
+		serviceName:   config.ValidatorContainerName,
+		containerName: prefix + ValidatorContainerSuffix,
+		imageVariable: "VC_CONTAINER_TAG",
+		checkHealth:   checkValidatorClientHealth,
+	})
+	return steps
+
+}
+
+// Wait for a restarted client's container to stay up and its health check to pass
+func waitForClientHealth(rp *rocketpool.Client, step rollingUpdateStep, timeout time.Duration, updateLog *rollingUpdateLog) error {
+
+	state := &rollingUpdateHealthState{}
+	deadline := time.Now().Add(timeout)
+	for {
+		time.Sleep(rollingUpdateCheckInterval)
+
+		// Make sure the container isn't crashing
+		status, err := rp.GetDockerStatus(step.containerName)
+		if err != nil {
+			updateLog.printlnf("  Couldn't get the status of %s: %s", step.containerName, err.Error())
+		} else if status != "running" {
+			updateLog.printlnf("  %s is %s.", step.containerName, status)
+		} else {
+
+			// Check the client itself
+			health, err := rp.GetClientHealth()
+			if err != nil {
+				updateLog.printlnf("  Couldn't check the clients' health: %s", err.Error())
+			} else {
+				healthy, message := step.checkHealth(health, state)
+				updateLog.printlnf("  %s", message)
+				if healthy {
+					return nil
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+	}
+
+}
+
+// Check that the fallback execution client is synced
+func checkFallbackExecutionClientHealth(health api.ClientHealthResponse, state *rollingUpdateHealthState) (bool, string) {
+	return checkExecutionClientStatus(health.EcStatus.FallbackEcStatus, "fallback execution client")
+}
+
+// Check that the primary execution client is synced, for light clients that don't have peers
+func checkLightExecutionClientHealth(health api.ClientHealthResponse, state *rollingUpdateHealthState) (bool, string) {
+	return checkExecutionClientStatus(health.EcStatus.PrimaryEcStatus, "execution client")
+}
+
+// Check that the primary execution client is synced and has peers
+func checkExecutionClientHealth(health api.ClientHealthResponse, state *rollingUpdateHealthState) (bool, string) {
+	healthy, message := checkExecutionClientStatus(health.EcStatus.PrimaryEcStatus, "execution client")
+	if !healthy {
+		return false, message
+	}
+	return checkPeers(health.EcPeers, health.EcPeersError, "execution client")
+}
+
+// Check an execution client's sync status
+func checkExecutionClientStatus(status api.ExecutionClientStatus, clientType string) (bool, string) {
+	switch {
+	case !status.IsWorking:
+		return false, fmt.Sprintf("The %s isn't responding: %s", clientType, status.Error)
+	case !status.IsSynced:
+		return false, fmt.Sprintf("The %s is syncing (%.2f%%).", clientType, status.SyncProgress*100)
+	}
+	return true, fmt.Sprintf("The %s is synced.", clientType)
+}
+
+// Check that the consensus client is synced and has peers
+func checkConsensusClientHealth(health api.ClientHealthResponse, state *rollingUpdateHealthState) (bool, string) {
+	switch {
+	case health.CcError != "":
+		return false, fmt.Sprintf("The consensus client isn't responding: %s", health.CcError)
+	case !health.CcSynced:
+		return false, fmt.Sprintf("The consensus client is syncing (%.2f%%).", health.CcSyncProgress*100)
+	}
+	return checkPeers(health.CcPeers, health.CcPeersError, "consensus client")
+}
+
+// Check a client's peer count
+func checkPeers(peers uint64, peersError string, clientType string) (bool, string) {
+	if peersError != "" {
+		return false, fmt.Sprintf("Couldn't get the %s's peer count: %s", clientType, peersError)
+	}
+	if peers < rollingUpdateMinPeers {
+		return false, fmt.Sprintf("The %s is synced but only has %d peers.", clientType, peers)
+	}
+	return true, fmt.Sprintf("The %s is synced and has %d peers.", clientType, peers)
+}
+
+// Check that the node's validators are attesting
+// Balances only change at epoch transitions, so this waits a few epochs after the restart before trusting them.
+func checkValidatorClientHealth(health api.ClientHealthResponse, state *rollingUpdateHealthState) (bool, string) {
+	switch {
+	case health.ValidatorsError != "":
+		return false, fmt.Sprintf("Couldn't check the validators: %s", health.ValidatorsError)
+	case !health.ValidatorsChecked:
+		return false, "The validators can't be checked until the execution and consensus clients are synced."
+	case health.ActiveValidators == 0:
+		return true, "The validator client is running, and the node doesn't have any active validators to check."
+	}
+	if state.firstEpoch == 0 {
+		state.firstEpoch = health.Epoch
+	}
+	if health.Epoch < state.firstEpoch+rollingUpdateAttestationEpochs {
+		return false, fmt.Sprintf("Waiting for epoch %d to check the validators' attestations (currently epoch %d).", state.firstEpoch+rollingUpdateAttestationEpochs, health.Epoch)
+	}
+	if health.AttestingValidators == 0 {
+		return false, fmt.Sprintf("None of the %d active validators were rewarded for attesting at the start of epoch %d.", health.ActiveValidators, health.Epoch)
+	}
+	return true, fmt.Sprintf("%d of %d active validators were rewarded for attesting at the start of epoch %d.", health.AttestingValidators, health.ActiveValidators, health.Epoch)
+}
+
+// Set the container tag settings that select a client's new image back to its previous image, returning how many were changed
+// Some clients use one setting for several containers, so every setting that provides the image's environment variable is changed.
+func pinClientImage(cfg *config.RocketPoolConfig, imageVariable string, newImage string, previousImage string) int {
+
+	params := cfg.GetParameters()
+	for _, subconfig := range cfg.GetSubconfigs() {
+		params = append(params, subconfig.GetParameters()...)
+	}
+
+	pinned := 0
+	for _, param := range params {
+		if param.Value != newImage {
+			continue
+		}
+		for _, variable := range param.EnvironmentVariables {
+			if variable == imageVariable {
+				param.Value = previousImage
+				pinned++
+				break
+			}
+		}
+	}
+	return pinned
+
+}
+
+// Open the rolling update log in the config folder, adding to it if it already exists
+func openRollingUpdateLog(c *cli.Context) (*rollingUpdateLog, error) {
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding config path: %w", err)
+	}
+	logPath := filepath.Join(configPath, rollingUpdateLogFile)
+	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening the rolling update log at %s: %w", logPath, err)
+	}
+	fmt.Printf("The rolling update's progress will be logged to %s.\n", logPath)
+	return &rollingUpdateLog{file: file}, nil
+}
+
+// Print a step of the rolling update and write it to the log
+func (l *rollingUpdateLog) printlnf(format string, v ...interface{}) {
+	message := fmt.Sprintf(format, v...)
+	fmt.Println(message)
+	fmt.Fprintf(l.file, "%s %s\n", time.Now().Format(time.RFC3339), message)
+}
+
+// Close the log
+func (l *rollingUpdateLog) close() {
+	l.file.Close()
+}
@@ -25,16 +25,18 @@ import (
 
 // Settings
 const (
-	ExporterContainerSuffix         string = "_exporter"
-	ValidatorContainerSuffix        string = "_validator"
-	BeaconContainerSuffix           string = "_eth2"
-	ExecutionContainerSuffix        string = "_eth1"
-	NodeContainerSuffix             string = "_node"
-	ApiContainerSuffix              string = "_api"
-	PruneProvisionerContainerSuffix string = "_prune_provisioner"
-	EcMigratorContainerSuffix       string = "_ec_migrator"
-	clientDataVolumeName            string = "/ethclient"
-	dataFolderVolumeName            string = "/.rocketpool/data"
+	ExporterContainerSuffix          string = "_exporter"
+	ValidatorContainerSuffix         string = "_validator"
+	BeaconContainerSuffix            string = "_eth2"
+	ExecutionContainerSuffix         string = "_eth1"
+	FallbackExecutionContainerSuffix string = "_eth1-fallback"
+	NodeContainerSuffix              string = "_node"
+	ApiContainerSuffix               string = "_api"
+	PruneProvisionerContainerSuffix  string = "_prune_provisioner"
+	BesuPrunerContainerSuffix        string = "_besu_pruner"
+	EcMigratorContainerSuffix        string = "_ec_migrator"
+	clientDataVolumeName             string = "/ethclient"
+	dataFolderVolumeName             string = "/.rocketpool/data"
 
 	PruneFreeSpaceRequired uint64 = 50 * 1024 * 1024 * 1024
 	dockerImageRegex       string = ".*/(?P<image>.*):.*"
@@ -614,6 +616,14 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		fmt.Printf("==========\n%sWARNING: you are using a light client (Infura or Pocket) as your fallback Execution client.\nLight clients are NOT COMPATIBLE with the upcoming Ethereum Merge, and will be removed in a future version of the Smartnode.\n\nIf you wish to continue using a fallback Execution client after light clients have been removed, you will need to run one on a separate machine and use Externally Managed mode for your fallback Execution client in the `rocketpool service config` Terminal UI.%s\n==========\n\n", colorRed, colorReset)
 	}
 
+	// Update the clients one at a time if requested
+	if c.Bool("rolling") {
+		err = runRollingUpdate(c, rp, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Start service
 	err = rp.StartService(getComposeFiles(c))
 	if err != nil {
@@ -863,155 +873,6 @@ func getContainerPrefix(rp *rocketpool.Client) (string, error) {
 	return cfg.Smartnode.ProjectName.Value.(string), nil
 }
 
-// Prepares the execution client for pruning
-func pruneExecutionClient(c *cli.Context) error {
-
-	// Get RP client
-	rp, err := rocketpool.NewClientFromCtx(c)
-	if err != nil {
-		return err
-	}
-	defer rp.Close()
-
-	// Get the config
-	cfg, isNew, err := rp.LoadConfig()
-	if err != nil {
-		return err
-	}
-	if isNew {
-		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
-	}
-
-	// Sanity checks
-	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_External {
-		fmt.Println("You are using an externally managed Execution client.\nThe Smartnode cannot prune it for you.")
-		return nil
-	}
-	if cfg.IsNativeMode {
-		fmt.Println("You are using Native Mode.\nThe Smartnode cannot prune your Execution client for you, you'll have to do it manually.")
-	}
-	switch cfg.ExecutionClient.Value.(config.ExecutionClient) {
-	case config.ExecutionClient_Nethermind:
-		fmt.Println("You are using Nethermind as your Execution client.\nNethermind pruning is not supported yet.")
-		return nil
-	case config.ExecutionClient_Besu:
-		fmt.Println("You are using Besu as your Execution client.\nBesu does not need pruning.")
-		return nil
-	}
-
-	fmt.Println("This will shut down your main execution client and prune its database, freeing up disk space.")
-	fmt.Println("Once pruning is complete, your execution client will restart automatically.\n")
-
-	if cfg.UseFallbackExecutionClient.Value == false {
-		fmt.Printf("%sYou do not have a fallback execution client configured.\nYou will continue attesting while it prunes, but block proposals and most of Rocket Pool's commands will not work.\nPlease configure a fallback client with `rocketpool service config` before running this.%s\n", colorRed, colorReset)
-	} else {
-		var fallbackClientName string
-		if cfg.FallbackExecutionClientMode.Value.(config.Mode) == config.Mode_External {
-			fallbackClientName = cfg.FallbackExternalExecution.HttpUrl.Value.(string)
-		} else {
-			fallbackClientName = fmt.Sprint(cfg.FallbackExecutionClient.Value.(config.ExecutionClient))
-		}
-		fmt.Printf("You have a fallback execution client configured (%s). Rocket Pool (and your consensus client) will use that while the main client is pruning.\n", fallbackClientName)
-	}
-
-	// Get the container prefix
-	prefix, err := getContainerPrefix(rp)
-	if err != nil {
-		return fmt.Errorf("Error getting container prefix: %w", err)
-	}
-
-	// Prompt for stopping the node container if using Infura to prevent people from hitting the rate limit
-	if cfg.FallbackExecutionClient.Value.(config.ExecutionClient) == config.ExecutionClient_Infura {
-		fmt.Printf("\n%s=== NOTE ===\n\n", colorYellow)
-		fmt.Printf("If you are using Infura's free tier, you may hit its rate limit if pruning takes a long time.\n")
-		fmt.Printf("If this happens, you should temporarily disable the `%s` container until pruning is complete. This will:\n", prefix+NodeContainerSuffix)
-		fmt.Println("\t- Stop collecting Rocket Pool's network metrics in the Grafana dashboard")
-		fmt.Println("\t- Stop automatic operations (claiming RPL rewards and staking new minipools)\n")
-		fmt.Printf("To disable the container, run: `docker stop %s`\n", prefix+NodeContainerSuffix)
-		fmt.Printf("To re-enable the container one pruning is complete, run: `docker start %s`%s\n\n", prefix+NodeContainerSuffix, colorReset)
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to prune your main execution client?")) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Get the prune provisioner image
-	pruneProvisioner := cfg.Smartnode.GetPruneProvisionerContainerTag()
-
-	// Check for enough free space
-	executionContainerName := prefix + ExecutionContainerSuffix
-	volumePath, err := rp.GetClientVolumeSource(executionContainerName, clientDataVolumeName)
-	if err != nil {
-		return fmt.Errorf("Error getting execution volume source path: %w", err)
-	}
-	partitions, err := disk.Partitions(true)
-	if err != nil {
-		return fmt.Errorf("Error getting partition list: %w", err)
-	}
-
-	longestPath := 0
-	bestPartition := disk.PartitionStat{}
-	for _, partition := range partitions {
-		if strings.HasPrefix(volumePath, partition.Mountpoint) && len(partition.Mountpoint) > longestPath {
-			bestPartition = partition
-			longestPath = len(partition.Mountpoint)
-		}
-	}
-
-	diskUsage, err := disk.Usage(bestPartition.Mountpoint)
-	if err != nil {
-		return fmt.Errorf("Error getting free disk space available: %w", err)
-	}
-	freeSpaceHuman := humanize.IBytes(diskUsage.Free)
-	if diskUsage.Free < PruneFreeSpaceRequired {
-		return fmt.Errorf("%sYour disk must have 50 GiB free to prune, but it only has %s free. Please free some space before pruning.%s", colorRed, freeSpaceHuman, colorReset)
-	} else {
-		fmt.Printf("Your disk has %s free, which is enough to prune.\n", freeSpaceHuman)
-	}
-
-	fmt.Printf("Stopping %s...\n", executionContainerName)
-	result, err := rp.StopContainer(executionContainerName)
-	if err != nil {
-		return fmt.Errorf("Error stopping main execution container: %w", err)
-	}
-	if result != executionContainerName {
-		return fmt.Errorf("Unexpected output while stopping main execution container: %s", result)
-	}
-
-	// Get the ETH1 volume name
-	volume, err := rp.GetClientVolumeName(executionContainerName, clientDataVolumeName)
-	if err != nil {
-		return fmt.Errorf("Error getting execution client volume name: %w", err)
-	}
-
-	// Run the prune provisioner
-	fmt.Printf("Provisioning pruning on volume %s...\n", volume)
-	err = rp.RunPruneProvisioner(prefix+PruneProvisionerContainerSuffix, volume, pruneProvisioner)
-	if err != nil {
-		return fmt.Errorf("Error running prune provisioner: %w", err)
-	}
-
-	// Restart ETH1
-	fmt.Printf("Restarting %s...\n", executionContainerName)
-	result, err = rp.StartContainer(executionContainerName)
-	if err != nil {
-		return fmt.Errorf("Error starting main execution client: %w", err)
-	}
-	if result != executionContainerName {
-		return fmt.Errorf("Unexpected output while starting main execution client: %s", result)
-	}
-
-	fmt.Printf("\nDone! Your main execution client is now pruning. You can follow its progress with `rocketpool service logs eth1`.\n")
-	fmt.Println("Once it's done, it will restart automatically and resume normal operation.")
-
-	fmt.Printf("%sNOTE: While pruning, you **cannot** interrupt the client (e.g. by restarting) or you risk corrupting the database!\nYou must let it run to completion!%s\n", colorYellow, colorReset)
-
-	return nil
-
-}
-
 // Pause the Rocket Pool service
 func pauseService(c *cli.Context) error {
 
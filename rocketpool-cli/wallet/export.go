@@ -53,7 +53,11 @@ func exportWallet(c *cli.Context) error {
 	// Print wallet & return
 	fmt.Println("Node account private key:")
 	fmt.Println("")
-	fmt.Println(export.AccountPrivateKey)
+	if export.AccountPrivateKey == "" {
+		fmt.Println("(held on your hardware wallet)")
+	} else {
+		fmt.Println(export.AccountPrivateKey)
+	}
 	fmt.Println("")
 	fmt.Println("Wallet password:")
 	fmt.Println("")
@@ -34,6 +34,9 @@ func getStatus(c *cli.Context) error {
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		if status.HardwareWallet != "" {
+			fmt.Printf("The node account is held on your %s hardware wallet; node transactions must be confirmed on the device.\n", status.HardwareWallet)
+		}
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
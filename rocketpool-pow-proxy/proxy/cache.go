@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// Config
+const DefaultCacheSize = 10000
+
+// Methods with responses that never change once they've been returned
+var cacheableMethods = map[string]bool{
+	"eth_chainId":               true,
+	"eth_getBlockByHash":        true,
+	"eth_getTransactionReceipt": true,
+}
+
+// Cache of immutable JSON-RPC results
+type ResponseCache struct {
+	cache *lru.Cache
+}
+
+// Create a new response cache holding up to size results
+func NewResponseCache(size int) (*ResponseCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating response cache: %w", err)
+	}
+	return &ResponseCache{
+		cache: cache,
+	}, nil
+}
+
+// Get the cache key for a request, or false if the request can't be cached
+func getCacheKey(request *RpcRequest) (string, bool) {
+	if !cacheableMethods[request.Method] {
+		return "", false
+	}
+
+	// Compact the params so formatting differences don't cause cache misses
+	params := new(bytes.Buffer)
+	if len(request.Params) > 0 {
+		if err := json.Compact(params, request.Params); err != nil {
+			return "", false
+		}
+	}
+	return request.Method + ":" + params.String(), true
+}
+
+// Get the cached response for a request
+func (c *ResponseCache) Get(request *RpcRequest) ([]byte, bool) {
+
+	// Get the cached result
+	key, ok := getCacheKey(request)
+	if !ok {
+		return nil, false
+	}
+	result, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	// Build a response with the caller's ID
+	response, err := json.Marshal(RpcResponse{
+		JsonRpc: "2.0",
+		Id:      request.Id,
+		Result:  result.(json.RawMessage),
+	})
+	if err != nil {
+		return nil, false
+	}
+	return response, true
+
+}
+
+// Store the response to a request if it can be cached
+func (c *ResponseCache) Add(request *RpcRequest, responseBody []byte) {
+
+	// Check the request
+	key, ok := getCacheKey(request)
+	if !ok {
+		return
+	}
+
+	// Only cache successful, non-null results; a receipt for a pending transaction is null and will change
+	var response RpcResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return
+	}
+	if len(response.Error) > 0 || len(response.Result) == 0 || string(response.Result) == "null" {
+		return
+	}
+	c.cache.Add(key, response.Result)
+
+}
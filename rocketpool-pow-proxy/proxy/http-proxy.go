@@ -5,11 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"math"
 	"net/http"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -19,15 +17,18 @@ import (
 const InfuraURL = "https://%s.infura.io/v3/%s"
 const PocketURL = "https://%s.gateway.pokt.network/v1/%s"
 const HandleRequestRecursionLimit = 3
+const MaxProviderWaitTime = 30 * time.Second
+const MaxBatchConcurrency = 8
 
 // Proxy server
 type HttpProxyServer struct {
-	Port         string
-	ProviderUrl  string
-	Verbose      bool
-	ProviderType string
-	idLock       sync.Mutex
-	id           uint64
+	Port      string
+	Providers []*Provider
+	Policy    *MethodPolicy
+	Verbose   bool
+	cache     *ResponseCache
+	idLock    sync.Mutex
+	id        uint64
 }
 
 // Infura rate limit error
@@ -44,25 +45,57 @@ type InfuraRateLimitError struct {
 }
 
 // Create new proxy server
-func NewHttpProxyServer(port string, providerUrl string, network string, projectId string, providerType string, verbose bool) *HttpProxyServer {
+// If any provider definitions are given, they are used instead of the single provider described by the other arguments
+func NewHttpProxyServer(port string, providerUrl string, network string, projectId string, providerType string, providerDefinitions []string, cacheSize int, policy *MethodPolicy, verbose bool) (*HttpProxyServer, error) {
+
+	providers := []*Provider{}
+	if len(providerDefinitions) > 0 {
+
+		// Parse the provider list
+		for _, definition := range providerDefinitions {
+			provider, err := ParseProvider(definition)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, provider)
+		}
+
+	} else {
+
+		// Default provider to Infura
+		if providerType == "infura" {
+			providerUrl = fmt.Sprintf(InfuraURL, network, projectId)
+		} else if providerType == "pocket" {
+			providerUrl = fmt.Sprintf(PocketURL, network, projectId)
+		} else if providerUrl == "" {
+			return nil, fmt.Errorf("Unknown provider [%s] and no providerUrl was provided", providerType)
+		}
+		provider, err := NewProvider(providerUrl, providerType, DefaultProviderWeight, 0)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
 
-	// Default provider to Infura
-	if providerType == "infura" {
-		providerUrl = fmt.Sprintf(InfuraURL, network, projectId)
-	} else if providerType == "pocket" {
-		providerUrl = fmt.Sprintf(PocketURL, network, projectId)
-	} else if providerUrl == "" {
-		fmt.Printf("Unknown provider [%s] and no providerUrl was provided, exiting.\n", providerType)
-		os.Exit(1)
+	}
+
+	// Create the response cache
+	var cache *ResponseCache
+	if cacheSize > 0 {
+		var err error
+		cache, err = NewResponseCache(cacheSize)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create and return proxy server
 	return &HttpProxyServer{
-		Port:         port,
-		ProviderUrl:  providerUrl,
-		Verbose:      verbose,
-		ProviderType: providerType,
-	}
+		Port:      port,
+		Providers: providers,
+		Policy:    policy,
+		Verbose:   verbose,
+		cache:     cache,
+	}, nil
 
 }
 
@@ -71,6 +104,9 @@ func (p *HttpProxyServer) Start() error {
 
 	// Log
 	log.Printf("Proxy server listening on port %s\n", p.Port)
+	for _, provider := range p.Providers {
+		log.Printf("Using provider %s (weight %d, rate limit %.2f requests/s)\n", provider.Name, provider.Weight, provider.RateLimit)
+	}
 
 	// Listen on RPC port
 	return http.ListenAndServe(":"+p.Port, p)
@@ -112,7 +148,7 @@ func (p *HttpProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Handle the request
-	responseReader, err := p.handleRequest(contentTypes[0], requestBody, messageId, 0)
+	responseBody, err := p.handleRequest(contentTypes[0], requestBody, messageId)
 	if err != nil {
 		log.Println(err.Error())
 		_, _ = fmt.Fprintln(w, err.Error())
@@ -122,11 +158,10 @@ func (p *HttpProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Set response writer header
 	w.Header().Set("Content-Type", "application/json")
 
-	// Copy provider response body to response writer
-	_, err = io.Copy(w, responseReader)
+	// Write the response body to the response writer
+	_, err = w.Write(responseBody)
 	if err != nil {
-		log.Println(fmt.Errorf("Error reading response from remote server: %w", err))
-		_, _ = fmt.Fprintln(w, fmt.Errorf("Error reading response from remote server: %w", err))
+		log.Println(fmt.Errorf("Error writing response: %w", err))
 		return
 	}
 
@@ -134,59 +169,199 @@ func (p *HttpProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Response sent to %s successfully\n", r.RemoteAddr)
 }
 
-// Handle request / serve response
-func (p *HttpProxyServer) handleRequest(contentType, requestBody string, messageId uint64, recursionCount int) (io.Reader, error) {
+// Handle request, splitting batches into individual requests
+func (p *HttpProxyServer) handleRequest(contentType, requestBody string, messageId uint64) ([]byte, error) {
+
+	// Parse the request
+	requests, isBatch, err := parseRequests([]byte(requestBody))
+	if err != nil {
+		return newErrorResponse(nil, ParseErrorCode, fmt.Sprintf("Parse error: %s", err.Error())), nil
+	}
+	if !isBatch {
+		response, _ := p.handleSingleRequest(contentType, requests[0], messageId)
+		return response, nil
+	}
+
+	// Handle the requests in the batch concurrently, with a limited number in flight so a large batch can't flood the providers
+	responses := make([][]byte, len(requests))
+	isNotification := make([]bool, len(requests))
+	semaphore := make(chan struct{}, MaxBatchConcurrency)
+	wg := new(sync.WaitGroup)
+	wg.Add(len(requests))
+	for i, request := range requests {
+		semaphore <- struct{}{}
+		go func(i int, request json.RawMessage) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			responses[i], isNotification[i] = p.handleSingleRequest(contentType, request, messageId)
+		}(i, request)
+	}
+	wg.Wait()
+
+	// Reassemble the responses in order; notifications don't get a response
+	// A response that isn't valid JSON would make the whole batch fail to marshal, so it's replaced with an error for its request
+	batchResponse := []json.RawMessage{}
+	for i, response := range responses {
+		if isNotification[i] {
+			continue
+		}
+		if !json.Valid(response) {
+			var request RpcRequest
+			_ = json.Unmarshal(requests[i], &request)
+			log.Printf("Invalid response for batched request %d: %s\n", i, string(response))
+			response = newErrorResponse(request.Id, InternalErrorCode, "Invalid response from the provider")
+		}
+		batchResponse = append(batchResponse, response)
+	}
+	if len(batchResponse) == 0 {
+		return []byte{}, nil
+	}
+	return json.Marshal(batchResponse)
+
+}
+
+// Handle a single request, applying the method policy and serving it from the cache if possible
+// Returns the response and whether the request was a notification
+func (p *HttpProxyServer) handleSingleRequest(contentType string, requestBody json.RawMessage, messageId uint64) ([]byte, bool) {
 
-	// Error out if we've tried too many times
-	if recursionCount >= HandleRequestRecursionLimit {
-		return nil, fmt.Errorf("Request hit the rate limit too many times.")
+	// Parse the request
+	var request RpcRequest
+	if err := json.Unmarshal(requestBody, &request); err != nil || request.Method == "" {
+		return newErrorResponse(nil, InvalidRequestCode, "Invalid request"), false
 	}
+	isNotification := len(request.Id) == 0
+
+	// Check the method policy
+	if !p.Policy.IsAllowed(request.Method) {
+		log.Printf("Blocked request for method %s\n", request.Method)
+		return newErrorResponse(request.Id, MethodNotAllowedCode, fmt.Sprintf("Method %s is not allowed by this proxy", request.Method)), isNotification
+	}
+
+	// Check the cache
+	cacheable := false
+	if p.cache != nil {
+		if _, ok := getCacheKey(&request); ok {
+			cacheable = true
+			if response, ok := p.cache.Get(&request); ok {
+				cacheHits.Inc()
+				if p.Verbose {
+					fmt.Printf("(> %d) [cached] %s\n", messageId, string(response))
+				}
+				return response, isNotification
+			}
+			cacheMisses.Inc()
+		}
+	}
+
+	// Forward the request
+	responseBody, err := p.forwardRequest(contentType, string(requestBody), messageId)
+	if err != nil {
+		log.Println(err.Error())
+		return newErrorResponse(request.Id, InternalErrorCode, err.Error()), isNotification
+	}
+
+	// Cache the response
+	if cacheable {
+		p.cache.Add(&request, responseBody)
+	}
+	return responseBody, isNotification
+
+}
+
+// Forward a request to the upstream providers, failing over between them on errors and rate limits
+func (p *HttpProxyServer) forwardRequest(contentType, requestBody string, messageId uint64) ([]byte, error) {
+
+	for attempt := 0; attempt < HandleRequestRecursionLimit; attempt++ {
+
+		// Try each available provider in weighted random order
+		for _, provider := range orderProviders(p.Providers) {
+			if !provider.TryAcquire() {
+				continue
+			}
+			responseBody, err := p.sendToProvider(provider, contentType, requestBody, messageId)
+			if err == nil {
+				return responseBody, nil
+			}
+			log.Printf("%s (Attempt %d of %d)\n", err.Error(), attempt+1, HandleRequestRecursionLimit)
+		}
+
+		// Wait for the next provider to become available
+		if attempt < HandleRequestRecursionLimit-1 {
+			nextAvailable := time.Now().Add(MaxProviderWaitTime)
+			for _, provider := range p.Providers {
+				providerAvailable := provider.NextAvailable()
+				if providerAvailable.Before(nextAvailable) {
+					nextAvailable = providerAvailable
+				}
+			}
+			time.Sleep(time.Until(nextAvailable))
+		}
+
+	}
+
+	return nil, fmt.Errorf("Request failed on all providers too many times.")
+
+}
+
+// Send a request to a single provider
+func (p *HttpProxyServer) sendToProvider(provider *Provider, contentType, requestBody string, messageId uint64) ([]byte, error) {
 
 	// Forward request to provider
-	var reader io.Reader
-	reader = strings.NewReader(requestBody)
-	response, err := http.Post(p.ProviderUrl, contentType, reader)
+	start := time.Now()
+	response, err := http.Post(provider.Url, contentType, strings.NewReader(requestBody))
 	if err != nil {
-		return nil, fmt.Errorf("Error forwarding request to remote server: %w", err)
+		upstreamErrors.WithLabelValues(provider.Name, "connection").Inc()
+		provider.Backoff(ProviderBackoffTime)
+		return nil, fmt.Errorf("Error forwarding request to %s: %w", provider.Name, err)
 	}
 	defer func() {
 		_ = response.Body.Close()
 	}()
 
-	// Get the response body as a string
+	// Get the response body
 	responseBuffer := new(bytes.Buffer)
 	_, err = responseBuffer.ReadFrom(response.Body)
+	upstreamLatency.WithLabelValues(provider.Name).Observe(time.Since(start).Seconds())
 	if err != nil {
-		return nil, fmt.Errorf("Error getting response body string: %w", err)
+		upstreamErrors.WithLabelValues(provider.Name, "read").Inc()
+		provider.Backoff(ProviderBackoffTime)
+		return nil, fmt.Errorf("Error getting response body from %s: %w", provider.Name, err)
 	}
-	responseBody := responseBuffer.String()
 
 	// Log response if in verbose mode
 	if p.Verbose {
-		fmt.Printf("(> %d) %s\n", messageId, responseBody)
+		fmt.Printf("(> %d) [%s] %s\n", messageId, provider.Name, responseBuffer.String())
 	}
 
-	// If using Infura, check for a rate limit error
-	if p.ProviderType == "infura" && response.StatusCode == 429 {
-
-		// Unmarshal it into an object
-		var infuraError InfuraRateLimitError
-		err = json.Unmarshal(responseBuffer.Bytes(), &infuraError)
-		if err != nil {
-			return nil, fmt.Errorf("Received a 429 from Infura but failed deserializing: %w", err)
+	// Check for rate limiting
+	if response.StatusCode == http.StatusTooManyRequests {
+		upstreamErrors.WithLabelValues(provider.Name, "rate_limit").Inc()
+
+		// Infura reports how long to back off for
+		backoffTime := ProviderBackoffTime
+		if provider.Type == "infura" {
+			var infuraError InfuraRateLimitError
+			err = json.Unmarshal(responseBuffer.Bytes(), &infuraError)
+			if err != nil {
+				log.Printf("Received a 429 from Infura but failed deserializing: %s\n", err.Error())
+			} else if infuraError.Error.Data.Rate.BackoffSeconds > 0 {
+				backoffTime = time.Duration(math.Ceil(infuraError.Error.Data.Rate.BackoffSeconds)) * time.Second
+			}
 		}
+		provider.Backoff(backoffTime)
+		return nil, fmt.Errorf("%s rate limit hit, backing off for %s", provider.Name, backoffTime)
+	}
 
-		// Wait for the requested number of seconds, then try again
-		secondsToWait := int(math.Ceil(infuraError.Error.Data.Rate.BackoffSeconds))
-		log.Printf("Infura rate limit hit, waiting %d seconds... (Attempt %d of %d)\n", secondsToWait, recursionCount+1, HandleRequestRecursionLimit)
-		time.Sleep(time.Duration(secondsToWait) * time.Second)
-		return p.handleRequest(contentType, requestBody, messageId, recursionCount+1)
-	} else if p.ProviderType == "pocket" && response.StatusCode == 502 {
-		log.Printf("Pocket returned a 502 gateway error, trying again... (Attempt %d of %d)\n", recursionCount+1, HandleRequestRecursionLimit)
-		return p.handleRequest(contentType, requestBody, messageId, recursionCount+1)
+	// Check for server errors (e.g. Pocket's 502 gateway errors)
+	if response.StatusCode >= 500 {
+		upstreamErrors.WithLabelValues(provider.Name, "server_error").Inc()
+		provider.Backoff(ProviderBackoffTime)
+		return nil, fmt.Errorf("%s returned a %d error", provider.Name, response.StatusCode)
 	}
 
 	// Success, return the body
-	return strings.NewReader(responseBody), nil
+	return responseBuffer.Bytes(), nil
 
 }
@@ -0,0 +1,67 @@
+package proxy
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "rocketpool"
+const subsystem = "pow_proxy"
+
+// Proxy metrics
+var (
+	upstreamLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "The time taken by upstream providers to respond to requests",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider"})
+
+	upstreamErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "upstream_errors_total",
+		Help:      "The number of failed requests to upstream providers",
+	}, []string{"provider", "reason"})
+
+	cacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "cache_hits_total",
+		Help:      "The number of requests served from the response cache",
+	})
+
+	cacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "cache_misses_total",
+		Help:      "The number of cacheable requests that had to be sent upstream",
+	})
+)
+
+// Start the metrics server
+func StartMetricsServer(port string) error {
+
+	// Set up Prometheus
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(upstreamLatency)
+	registry.MustRegister(upstreamErrors)
+	registry.MustRegister(cacheHits)
+	registry.MustRegister(cacheMisses)
+
+	// Start the HTTP server
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	log.Printf("Metrics server listening on port %s\n", port)
+	err := http.ListenAndServe(":"+port, mux)
+	if err != nil {
+		return fmt.Errorf("Error running metrics server: %w", err)
+	}
+	return nil
+
+}
@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Policy for which RPC methods can be proxied
+// Entries can either be full method names (e.g. eth_sendRawTransaction) or namespaces (e.g. debug_*)
+type MethodPolicy struct {
+	allowed []string
+	denied  []string
+}
+
+// Create a new method policy
+// If allowed is empty, every method that isn't denied is allowed
+func NewMethodPolicy(allowed []string, denied []string) *MethodPolicy {
+	return &MethodPolicy{
+		allowed: normalizeMethods(allowed),
+		denied:  normalizeMethods(denied),
+	}
+}
+
+// Check whether a method can be proxied
+func (p *MethodPolicy) IsAllowed(method string) bool {
+	if p == nil {
+		return true
+	}
+	if matchesMethod(p.denied, method) {
+		return false
+	}
+	if len(p.allowed) > 0 && !matchesMethod(p.allowed, method) {
+		return false
+	}
+	return true
+}
+
+// Policy for which origins can open websocket connections
+type OriginPolicy struct {
+	allowed []string
+}
+
+// Create a new origin policy
+// If allowed is empty, every origin is allowed
+func NewOriginPolicy(allowed []string) *OriginPolicy {
+	origins := []string{}
+	for _, origin := range allowed {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			origins = append(origins, strings.ToLower(origin))
+		}
+	}
+	return &OriginPolicy{
+		allowed: origins,
+	}
+}
+
+// Check whether a websocket request's origin is allowed
+// Requests without an Origin header don't come from browsers, so they are always allowed
+func (p *OriginPolicy) CheckOrigin(r *http.Request) bool {
+	if p == nil || len(p.allowed) == 0 {
+		return true
+	}
+	origin := strings.ToLower(strings.TrimRight(r.Header.Get("Origin"), "/"))
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range p.allowed {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// Trim and drop empty method entries
+func normalizeMethods(methods []string) []string {
+	normalized := []string{}
+	for _, method := range methods {
+		method = strings.TrimSpace(method)
+		if method != "" {
+			normalized = append(normalized, method)
+		}
+	}
+	return normalized
+}
+
+// Check whether a method matches any entry in a list
+func matchesMethod(entries []string, method string) bool {
+	for _, entry := range entries {
+		if strings.HasSuffix(entry, "*") {
+			if strings.HasPrefix(method, strings.TrimSuffix(entry, "*")) {
+				return true
+			}
+		} else if entry == method {
+			return true
+		}
+	}
+	return false
+}
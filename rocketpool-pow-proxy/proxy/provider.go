@@ -0,0 +1,207 @@
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config
+const DefaultProviderWeight = 1
+const ProviderBackoffTime = 5 * time.Second
+
+// An upstream Eth 1.0 provider
+type Provider struct {
+	Url       string
+	Name      string
+	Type      string
+	Weight    int
+	RateLimit float64
+
+	lock         sync.Mutex
+	tokens       float64
+	lastRefill   time.Time
+	backoffUntil time.Time
+}
+
+// Create a new provider
+// rateLimit is the maximum number of requests per second to send to it; 0 means unlimited
+func NewProvider(providerUrl string, providerType string, weight int, rateLimit float64) (*Provider, error) {
+
+	// Validate the URL
+	parsedUrl, err := url.Parse(providerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid provider URL [%s]: %w", providerUrl, err)
+	}
+	if parsedUrl.Host == "" {
+		return nil, fmt.Errorf("Invalid provider URL [%s]: no host specified", providerUrl)
+	}
+
+	// Validate the weight and rate limit
+	if weight < 1 {
+		return nil, fmt.Errorf("Invalid weight %d for provider [%s]: must be at least 1", weight, parsedUrl.Host)
+	}
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("Invalid rate limit %f for provider [%s]: must not be negative", rateLimit, parsedUrl.Host)
+	}
+
+	// Create and return provider
+	// The name only includes the host so project IDs embedded in the path don't leak into logs and metrics
+	return &Provider{
+		Url:        providerUrl,
+		Name:       parsedUrl.Host,
+		Type:       providerType,
+		Weight:     weight,
+		RateLimit:  rateLimit,
+		tokens:     math.Max(1, rateLimit),
+		lastRefill: time.Now(),
+	}, nil
+
+}
+
+// Parse a provider from a command line definition in the form URL[;weight[;requestsPerSecond]]
+func ParseProvider(definition string) (*Provider, error) {
+
+	// Split the definition
+	parts := strings.Split(definition, ";")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("Invalid provider definition [%s]: expected URL[;weight[;requestsPerSecond]]", definition)
+	}
+	providerUrl := strings.TrimSpace(parts[0])
+
+	// Get the weight
+	weight := DefaultProviderWeight
+	if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+		parsedWeight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid weight in provider definition [%s]: %w", definition, err)
+		}
+		weight = parsedWeight
+	}
+
+	// Get the rate limit
+	rateLimit := float64(0)
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		parsedRateLimit, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rate limit in provider definition [%s]: %w", definition, err)
+		}
+		rateLimit = parsedRateLimit
+	}
+
+	// Detect the provider type so the provider-specific error handling still applies
+	providerType := ""
+	if strings.Contains(providerUrl, ".infura.io") {
+		providerType = "infura"
+	} else if strings.Contains(providerUrl, ".pokt.network") {
+		providerType = "pocket"
+	}
+
+	return NewProvider(providerUrl, providerType, weight, rateLimit)
+
+}
+
+// Check whether the provider is available, consuming a request from its rate limit if it is
+func (p *Provider) TryAcquire() bool {
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Check if the provider is backing off
+	now := time.Now()
+	if now.Before(p.backoffUntil) {
+		return false
+	}
+
+	// Unlimited providers are always available
+	if p.RateLimit == 0 {
+		return true
+	}
+
+	// Refill the token bucket
+	p.tokens = p.getRefilledTokens(now)
+	p.lastRefill = now
+
+	// Consume a token
+	if p.tokens < 1 {
+		return false
+	}
+	p.tokens--
+	return true
+
+}
+
+// Get the time at which the provider will next be available
+func (p *Provider) NextAvailable() time.Time {
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Wait for the backoff to end
+	now := time.Now()
+	if now.Before(p.backoffUntil) {
+		return p.backoffUntil
+	}
+
+	// Wait for the next token to be refilled
+	if p.RateLimit == 0 {
+		return now
+	}
+	tokens := p.getRefilledTokens(now)
+	if tokens >= 1 {
+		return now
+	}
+	return now.Add(time.Duration((1 - tokens) / p.RateLimit * float64(time.Second)))
+
+}
+
+// Get the number of tokens in the bucket after refilling it up to now
+// The burst size is one second's worth of requests, but at least one request so providers limited to less than one request per second can still be used.
+func (p *Provider) getRefilledTokens(now time.Time) float64 {
+	tokens := p.tokens + now.Sub(p.lastRefill).Seconds()*p.RateLimit
+	return math.Min(tokens, math.Max(1, p.RateLimit))
+}
+
+// Stop sending requests to the provider for the given duration
+func (p *Provider) Backoff(duration time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	backoffUntil := time.Now().Add(duration)
+	if backoffUntil.After(p.backoffUntil) {
+		p.backoffUntil = backoffUntil
+	}
+}
+
+// Get the providers in the order they should be tried, using a weighted random shuffle
+func orderProviders(providers []*Provider) []*Provider {
+
+	// Copy the providers and get the total weight
+	remaining := make([]*Provider, len(providers))
+	copy(remaining, providers)
+	totalWeight := 0
+	for _, provider := range remaining {
+		totalWeight += provider.Weight
+	}
+
+	// Pick providers one at a time, weighted by their share of the remaining weight
+	ordered := make([]*Provider, 0, len(providers))
+	for len(remaining) > 0 {
+		target := rand.Intn(totalWeight)
+		for i, provider := range remaining {
+			if target < provider.Weight {
+				ordered = append(ordered, provider)
+				totalWeight -= provider.Weight
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+			target -= provider.Weight
+		}
+	}
+	return ordered
+
+}
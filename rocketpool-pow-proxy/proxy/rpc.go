@@ -0,0 +1,77 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// JSON-RPC error codes
+const ParseErrorCode = -32700
+const InvalidRequestCode = -32600
+const MethodNotAllowedCode = -32601
+const InternalErrorCode = -32603
+
+// A JSON-RPC request
+type RpcRequest struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// A JSON-RPC response
+type RpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+// A JSON-RPC error
+type RpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Create a serialized JSON-RPC error response
+func newErrorResponse(id json.RawMessage, code int, message string) []byte {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	rpcError, _ := json.Marshal(RpcError{
+		Code:    code,
+		Message: message,
+	})
+	response, _ := json.Marshal(RpcResponse{
+		JsonRpc: "2.0",
+		Id:      id,
+		Error:   rpcError,
+	})
+	return response
+}
+
+// Parse a request body into its individual requests
+// Returns whether the body was a batch
+func parseRequests(requestBody []byte) ([]json.RawMessage, bool, error) {
+
+	// Check for a batch
+	trimmed := bytes.TrimSpace(requestBody)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(trimmed, &batch); err != nil {
+			return nil, true, err
+		}
+		if len(batch) == 0 {
+			return nil, true, errors.New("empty batch")
+		}
+		return batch, true, nil
+	}
+
+	// Single request
+	if !json.Valid(trimmed) {
+		return nil, false, errors.New("invalid JSON")
+	}
+	return []json.RawMessage{trimmed}, false, nil
+
+}
@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,11 +17,13 @@ const InfuraWsURL = "wss://%s.infura.io/ws/v3/%s"
 type WsProxyServer struct {
 	Port        string
 	ProviderUrl string
+	Policy      *MethodPolicy
+	Origins     *OriginPolicy
 	Verbose     bool
 }
 
 // Create new proxy server
-func NewWsProxyServer(port string, providerUrl string, network string, projectId string, verbose bool) *WsProxyServer {
+func NewWsProxyServer(port string, providerUrl string, network string, projectId string, policy *MethodPolicy, origins *OriginPolicy, verbose bool) *WsProxyServer {
 
 	// Default provider to Infura
 	if providerUrl == "" {
@@ -31,6 +34,8 @@ func NewWsProxyServer(port string, providerUrl string, network string, projectId
 	return &WsProxyServer{
 		Port:        port,
 		ProviderUrl: providerUrl,
+		Policy:      policy,
+		Origins:     origins,
 		Verbose:     verbose,
 	}
 
@@ -50,7 +55,7 @@ func (p *WsProxyServer) Start() error {
 func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var upgrader = websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool { return true },
+		CheckOrigin: p.Origins.CheckOrigin,
 	}
 
 	// Establish a websocket with the requester
@@ -74,6 +79,9 @@ func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		_ = infuraConnection.Close()
 	}()
 
+	// Both loops can write to eth2, so writes need to be synchronized
+	eth2WriteLock := new(sync.Mutex)
+
 	// Wait groups for the proxy loops
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
@@ -94,6 +102,21 @@ func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Printf("< %d %s\n", mt, message)
 			}
 
+			// Reject any requests for methods that aren't allowed
+			message, rejection := p.filterMessage(mt, message)
+			if rejection != nil {
+				eth2WriteLock.Lock()
+				err = eth2Connection.WriteMessage(mt, rejection)
+				eth2WriteLock.Unlock()
+				if err != nil {
+					log.Println(fmt.Errorf("Error writing to eth2: %w", err))
+					break
+				}
+			}
+			if message == nil {
+				continue
+			}
+
 			// Send it to the remote server
 			if err = infuraConnection.WriteMessage(mt, message); err != nil {
 				log.Println(fmt.Errorf("Error writing to remote websocket: %w", err))
@@ -122,7 +145,10 @@ func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Send it to eth2
-			if err = eth2Connection.WriteMessage(mt, message); err != nil {
+			eth2WriteLock.Lock()
+			err = eth2Connection.WriteMessage(mt, message)
+			eth2WriteLock.Unlock()
+			if err != nil {
 				log.Println(fmt.Errorf("Error writing to eth2: %w", err))
 				_, _ = fmt.Fprintln(w, fmt.Errorf("Error writing to eth2: %w", err))
 				break
@@ -136,3 +162,58 @@ func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	wg.Wait()
 	return
 }
+
+// Apply the method policy to a message from eth2
+// Returns the message to forward (nil if there is nothing left to forward) and the error response for any rejected requests (nil if nothing was rejected)
+func (p *WsProxyServer) filterMessage(messageType int, message []byte) ([]byte, []byte) {
+
+	// Only text messages carry JSON-RPC requests
+	if messageType != websocket.TextMessage || p.Policy == nil {
+		return message, nil
+	}
+
+	// Parse the message; anything malformed is left for the remote server to reject
+	requests, isBatch, err := parseRequests(message)
+	if err != nil {
+		return message, nil
+	}
+
+	// Split out the requests that aren't allowed
+	allowed := []json.RawMessage{}
+	rejections := []json.RawMessage{}
+	for _, requestBody := range requests {
+		var request RpcRequest
+		if err := json.Unmarshal(requestBody, &request); err != nil || p.Policy.IsAllowed(request.Method) {
+			allowed = append(allowed, requestBody)
+			continue
+		}
+		log.Printf("Blocked request for method %s\n", request.Method)
+		if len(request.Id) > 0 {
+			rejections = append(rejections, newErrorResponse(request.Id, MethodNotAllowedCode, fmt.Sprintf("Method %s is not allowed by this proxy", request.Method)))
+		}
+	}
+
+	// Build the rejection response
+	var rejection []byte
+	if len(rejections) > 0 {
+		if isBatch {
+			rejection, _ = json.Marshal(rejections)
+		} else {
+			rejection = rejections[0]
+		}
+	}
+
+	// Build the message to forward
+	if len(allowed) == 0 {
+		return nil, rejection
+	}
+	if len(allowed) == len(requests) {
+		return message, nil
+	}
+	forward, err := json.Marshal(allowed)
+	if err != nil {
+		return nil, rejection
+	}
+	return forward, rejection
+
+}
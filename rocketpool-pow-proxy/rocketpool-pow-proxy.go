@@ -2,8 +2,10 @@ package main
 
 import (
 	"log"
+	"math/rand"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/urfave/cli"
 
@@ -78,6 +80,32 @@ func main() {
 			Usage: "Eth 1.0 provider type if not using `URL`: Infura or Pocket",
 			Value: "infura",
 		},
+		cli.StringSliceFlag{
+			Name:  "provider, P",
+			Usage: "Upstream Eth 1.0 provider in the form `URL[;weight[;requestsPerSecond]]`; can be specified multiple times to load balance and fail over between providers (overrides 'httpProviderUrl' and 'providerType')",
+		},
+		cli.IntFlag{
+			Name:  "cacheSize, c",
+			Usage: "Maximum number of immutable responses to cache; 0 disables caching",
+			Value: proxy.DefaultCacheSize,
+		},
+		cli.StringFlag{
+			Name:  "metricsPort, m",
+			Usage: "Local port to serve Prometheus metrics on; metrics are disabled if not specified",
+			Value: "",
+		},
+		cli.StringSliceFlag{
+			Name:  "allowMethod, a",
+			Usage: "RPC `method` to allow (e.g. eth_call) or namespace to allow (e.g. eth_*); can be specified multiple times, all methods are allowed if not specified",
+		},
+		cli.StringSliceFlag{
+			Name:  "denyMethod, d",
+			Usage: "RPC `method` to block (e.g. eth_sendRawTransaction) or namespace to block (e.g. debug_*); can be specified multiple times",
+		},
+		cli.StringSliceFlag{
+			Name:  "allowOrigin, o",
+			Usage: "Websocket `origin` to allow (e.g. http://localhost:3000); can be specified multiple times, all origins are allowed if not specified",
+		},
 		cli.BoolFlag{
 			Name:  "verbose, V",
 			Usage: "Enables logging of all incoming and outgoing proxied data",
@@ -87,13 +115,35 @@ func main() {
 	// Set application action
 	app.Action = func(c *cli.Context) error {
 
+		// Seed the provider selection
+		rand.Seed(time.Now().UnixNano())
+
+		// Create the request policies
+		policy := proxy.NewMethodPolicy(c.GlobalStringSlice("allowMethod"), c.GlobalStringSlice("denyMethod"))
+		origins := proxy.NewOriginPolicy(c.GlobalStringSlice("allowOrigin"))
+
+		// Create the HTTP server
+		proxyServer, err := proxy.NewHttpProxyServer(c.GlobalString("httpPort"), c.GlobalString("httpProviderUrl"), c.GlobalString("network"), c.GlobalString("projectId"), c.GlobalString("providerType"), c.GlobalStringSlice("provider"), c.GlobalInt("cacheSize"), policy, c.GlobalBool("verbose"))
+		if err != nil {
+			return err
+		}
+
+		// Metrics server
+		if c.GlobalString("metricsPort") != "" {
+			go func() {
+				err := proxy.StartMetricsServer(c.GlobalString("metricsPort"))
+				if err != nil {
+					log.Fatalf("Could not start metrics server %v", err)
+				}
+			}()
+		}
+
 		// We need a wait group since we have 2 HTTP listeners
 		wg := new(sync.WaitGroup)
 		wg.Add(2)
 
 		// HTTP server
 		go func() {
-			proxyServer := proxy.NewHttpProxyServer(c.GlobalString("httpPort"), c.GlobalString("httpProviderUrl"), c.GlobalString("network"), c.GlobalString("projectId"), c.GlobalString("providerType"), c.GlobalBool("verbose"))
 			err := proxyServer.Start()
 			if err != nil {
 				log.Fatalf("Could not start HTTP proxy server %v", err)
@@ -105,7 +155,7 @@ func main() {
 		// Websocket server
 		go func() {
 			if c.GlobalString("providerType") == "infura" || c.GlobalString("wsProviderUrl") != "" {
-				proxyServer := proxy.NewWsProxyServer(c.GlobalString("wsPort"), c.GlobalString("wsProviderUrl"), c.GlobalString("network"), c.GlobalString("projectId"), c.GlobalBool("verbose"))
+				proxyServer := proxy.NewWsProxyServer(c.GlobalString("wsPort"), c.GlobalString("wsProviderUrl"), c.GlobalString("network"), c.GlobalString("projectId"), policy, origins, c.GlobalBool("verbose"))
 				err := proxyServer.Start()
 				if err != nil {
 					log.Fatalf("Could not start websocket proxy server %v", err)
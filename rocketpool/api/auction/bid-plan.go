@@ -0,0 +1,62 @@
+package auction
+
+import (
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getBidPlan(c *cli.Context, minDiscount float64, lotBudget float64, totalBudget float64) (*api.AuctionBidPlanResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.AuctionBidPlanResponse{
+		MinDiscount: minDiscount,
+		LotBudget:   eth.EthToWei(lotBudget),
+		TotalBudget: eth.EthToWei(totalBudget),
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan the bids
+	plan, err := rputils.PlanAuctionBids(rp, nodeAccount.Address, rputils.AuctionBidStrategy{
+		MinDiscount: minDiscount,
+		LotBudget:   response.LotBudget,
+		TotalBudget: response.TotalBudget,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.BiddingEnabled = plan.BiddingEnabled
+	response.OraclePrice = plan.OraclePrice
+	response.OpenBids = plan.OpenBids
+	response.Bids = plan.Bids
+	response.SkippedLots = plan.SkippedLots
+	response.ClaimableLots = plan.ClaimableLots
+
+	// Return response
+	return &response, nil
+
+}
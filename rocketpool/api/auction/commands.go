@@ -53,6 +53,36 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "bid-plan",
+				Usage:     "Simulate the bids and claims the node would make automatically with a bidding strategy",
+				UsageText: "rocketpool api auction bid-plan min-discount lot-budget total-budget",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					minDiscount, err := cliutils.ValidateFraction("minimum discount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					lotBudget, err := cliutils.ValidatePositiveEthAmount("lot budget", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					totalBudget, err := cliutils.ValidatePositiveEthAmount("total budget", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBidPlan(c, minDiscount, lotBudget, totalBudget))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-create-lot",
 				Usage:     "Check whether the node can create a new lot",
@@ -28,7 +28,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					api.PrintResponse(GetStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "timeline",
+				Usage:     "Get a minipool's past events and upcoming lifecycle deadlines",
+				UsageText: "rocketpool api minipool timeline minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTimeline(c, minipoolAddress))
 					return nil
 
 				},
@@ -210,6 +231,83 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "sign-exit",
+				Usage:     "Sign a voluntary exit for a minipool's validator without broadcasting it",
+				UsageText: "rocketpool api minipool sign-exit minipool-address epoch",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signExitMinipool(c, minipoolAddress, epoch))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-submit-exit",
+				Usage:     "Check whether a signed voluntary exit can be broadcast",
+				UsageText: "rocketpool api minipool can-submit-exit epoch",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSubmitExit(c, epoch))
+					return nil
+
+				},
+			},
+			{
+				Name:      "submit-exit",
+				Usage:     "Broadcast a signed voluntary exit",
+				UsageText: "rocketpool api minipool submit-exit validator-index epoch signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					validatorIndex, err := cliutils.ValidateUint("validator index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateValidatorSignature("signature", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(submitExit(c, validatorIndex, epoch, signature))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-close",
 				Usage:     "Check whether the minipool can be closed",
@@ -3,11 +3,14 @@ package minipool
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -83,44 +86,151 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	// Response
 	response := api.ExitMinipoolResponse{}
 
-	// Get minipool validator pubkey
-	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	// Get beacon head
+	head, err := bc.GetBeaconHead()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get validator private key
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	// Get signed voluntary exit message
+	validatorIndex, signature, _, err := getSignedExit(rp, w, bc, minipoolAddress, head.Epoch)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get beacon head
-	head, err := bc.GetBeaconHead()
+	// Broadcast voluntary exit message
+	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func signExitMinipool(c *cli.Context, minipoolAddress common.Address, epoch uint64) (*api.SignExitMinipoolResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get voluntary exit signature domain
-	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch)
+	// Response
+	response := api.SignExitMinipoolResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get validator index
-	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Default to the current epoch
+	if epoch == 0 {
+		head, err := bc.GetBeaconHead()
+		if err != nil {
+			return nil, err
+		}
+		epoch = head.Epoch
+	}
+
+	// Get minipool validator pubkey
+	response.Pubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	response.ValidatorIndex, response.Signature, response.ForkVersion, err = getSignedExit(rp, w, bc, minipoolAddress, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	response.Epoch = epoch
+	return &response, nil
+
+}
+
+func canSubmitExit(c *cli.Context, epoch uint64) (*api.CanSubmitExitResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
 
+	// Response
+	response := api.CanSubmitExitResponse{}
+
+	// Exits can't be included before their epoch
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentEpoch = head.Epoch
+	response.EpochTooHigh = (epoch > head.Epoch)
+
+	// Get the fork version the exit will be verified with
+	if !response.EpochTooHigh {
+		forks, err := bc.GetForkSchedule()
+		if err != nil {
+			return nil, err
+		}
+		response.ForkVersion, err = validator.GetExitForkVersion(forks, head.Epoch, epoch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Update & return response
+	response.CanSubmit = !response.EpochTooHigh
+	return &response, nil
+
+}
+
+func submitExit(c *cli.Context, validatorIndex uint64, epoch uint64, signature types.ValidatorSignature) (*api.SubmitExitResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SubmitExitResponse{}
+
 	// Broadcast voluntary exit message
-	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+	if err := bc.ExitValidator(validatorIndex, epoch, signature); err != nil {
 		return nil, err
 	}
 
@@ -128,3 +238,55 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	return &response, nil
 
 }
+
+// Sign a voluntary exit message for a minipool's validator at the given epoch, and get the fork version it was signed for
+func getSignedExit(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, epoch uint64) (uint64, types.ValidatorSignature, []byte, error) {
+
+	// Get minipool validator pubkey
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+
+	// Get validator private key
+	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+
+	// Get the fork version for the epoch
+	// The head fork can't be used for epochs past a scheduled fork, since the chain will verify those with the new fork's version
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+	forks, err := bc.GetForkSchedule()
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+	forkVersion, err := validator.GetExitForkVersion(forks, head.Epoch, epoch)
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+
+	// Get voluntary exit signature domain
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+	signatureDomain := eth2types.Domain(eth2types.DomainVoluntaryExit, forkVersion, eth2Config.GenesisValidatorsRoot)
+
+	// Get validator index
+	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+
+	// Sign the voluntary exit message
+	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, epoch, signatureDomain)
+	if err != nil {
+		return 0, types.ValidatorSignature{}, nil, err
+	}
+	return validatorIndex, signature, forkVersion, nil
+
+}
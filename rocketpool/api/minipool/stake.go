@@ -7,13 +7,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
-	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/urfave/cli"
 
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
@@ -61,12 +61,11 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 
 	if status.Status == rptypes.Prelaunch {
 
-		// Get the scrub period
-		scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+		// Get the lifecycle settings
+		lifecycleSettings, err := rputils.GetMinipoolLifecycleSettings(rp, nil)
 		if err != nil {
 			return nil, err
 		}
-		scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
 
 		// Get the time of the latest block
 		latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
@@ -75,11 +74,7 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 		}
 		latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
-		creationTime := status.StatusTime
-		remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
-		if remainingTime < 0 {
-			response.CanStake = true
-		}
+		response.CanStake = rputils.IsMinipoolStakeable(status, lifecycleSettings, latestBlockTime)
 	}
 
 	if response.CanStake {
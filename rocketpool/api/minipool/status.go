@@ -3,13 +3,19 @@ package minipool
 import (
 	"fmt"
 
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
-func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
+// Get the status of the node's minipools
+func GetStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 
 	// Get services
 	if err := services.RequireNodeRegistered(c); err != nil {
@@ -52,7 +58,63 @@ func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 
 	response.LatestDelegate = *delegate.Address
 
+	// Get the queue positions of minipools waiting for user ETH
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := getQueuePositions(rp, cfg, &response); err != nil {
+		return nil, err
+	}
+
 	// Return response
 	return &response, nil
 
 }
+
+// Get the queue position and ETA of each minipool waiting in the queue
+func getQueuePositions(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, response *api.MinipoolStatusResponse) error {
+
+	// Only scan the deposit history if there are queued minipools
+	queued := false
+	for _, mp := range response.Minipools {
+		if mp.Status.Status == types.Initialized {
+			queued = true
+			break
+		}
+	}
+	if !queued {
+		return nil
+	}
+
+	// Get the queue state and the deposit inflow rate
+	queueState, err := rputils.GetMinipoolQueueState(rp, nil)
+	if err != nil {
+		return err
+	}
+	eventLogInterval, err := apiutils.GetEventLogInterval(cfg)
+	if err != nil {
+		return err
+	}
+	response.DepositInflowRate, err = rputils.GetDepositInflowRate(rp, eventLogInterval, nil)
+	if err != nil {
+		return err
+	}
+
+	// Get the positions
+	for i, mp := range response.Minipools {
+		if mp.Status.Status != types.Initialized {
+			continue
+		}
+		position, err := rputils.GetMinipoolQueuePosition(rp, queueState, mp.Address, mp.DepositType, nil)
+		if err != nil {
+			return err
+		}
+		if position.InQueue {
+			position.Eta, position.EtaKnown = rputils.GetMinipoolQueueEta(position, response.DepositInflowRate)
+		}
+		response.Minipools[i].Queue = position
+	}
+	return nil
+
+}
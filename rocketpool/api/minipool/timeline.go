@@ -0,0 +1,168 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Matches the boundaries between words in event names
+var eventNameBoundary = regexp.MustCompile("([a-z])([A-Z])")
+
+func getTimeline(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolTimelineResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolTimelineResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Get the minipool's current state
+	status, err := mp.GetStatusDetails(nil)
+	if err != nil {
+		return nil, err
+	}
+	finalised, err := mp.GetFinalised(nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeDetails, err := mp.GetNodeDetails(nil)
+	if err != nil {
+		return nil, err
+	}
+	lifecycleSettings, err := rputils.GetMinipoolLifecycleSettings(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+	}
+	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
+	refundAvailable := (nodeDetails.RefundBalance.Cmp(big.NewInt(0)) > 0)
+	response.Lifecycle = rputils.GetMinipoolLifecycle(status, finalised, refundAvailable, lifecycleSettings, latestBlockTime)
+
+	// Get the minipool's past events
+	eventLogInterval, err := apiutils.GetEventLogInterval(cfg)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := eth.GetLogs(rp, []common.Address{minipoolAddress}, nil, eventLogInterval, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting event logs for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+
+	// Decode the events
+	blockTimes := map[uint64]time.Time{}
+	events := []api.MinipoolTimelineEvent{}
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		abiEvent, err := mp.Contract.ABI.EventByID(log.Topics[0])
+		if err != nil {
+			// Not an event on the minipool contract
+			continue
+		}
+		values := map[string]interface{}{}
+		if err := abiEvent.Inputs.NonIndexed().UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Error decoding %s event for minipool %s: %w", abiEvent.Name, minipoolAddress.Hex(), err)
+		}
+
+		// Get the event time, falling back to the block time if the event doesn't include one
+		var eventTime time.Time
+		if timestamp, ok := values["time"].(*big.Int); ok {
+			eventTime = time.Unix(timestamp.Int64(), 0)
+		} else if blockTime, ok := blockTimes[log.BlockNumber]; ok {
+			eventTime = blockTime
+		} else {
+			header, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(log.BlockNumber))
+			if err != nil {
+				return nil, fmt.Errorf("Error getting time of block %d: %w", log.BlockNumber, err)
+			}
+			eventTime = time.Unix(int64(header.Time), 0)
+			blockTimes[log.BlockNumber] = eventTime
+		}
+
+		events = append(events, api.MinipoolTimelineEvent{
+			Name:        abiEvent.Name,
+			Description: getEventDescription(abiEvent.Name, log.Topics, values),
+			Time:        eventTime,
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].BlockNumber < events[j].BlockNumber
+	})
+	response.Events = events
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a human-readable description of a minipool event
+func getEventDescription(name string, topics []common.Hash, values map[string]interface{}) string {
+
+	// Status updates carry the new status as an indexed topic
+	if name == "StatusUpdated" && len(topics) > 1 {
+		status := rptypes.MinipoolStatus(new(big.Int).SetBytes(topics[1].Bytes()).Uint64())
+		return fmt.Sprintf("Status changed to %s", status.String())
+	}
+
+	// Split the event name into words
+	description := strings.ToLower(eventNameBoundary.ReplaceAllString(name, "$1 $2"))
+	description = strings.ToUpper(description[:1]) + description[1:]
+
+	// Include the amount of ETH if there is one
+	if amount, ok := values["amount"].(*big.Int); ok {
+		return fmt.Sprintf("%s (%.6f ETH)", description, eth.WeiToEth(amount))
+	}
+	return description
+
+}
@@ -10,8 +10,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"github.com/rocket-pool/rocketpool-go/settings/protocol"
-	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -122,15 +120,8 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 
 	}
 
-	// Get the scrub period
-	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
-	if err != nil {
-		return nil, err
-	}
-	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
-
-	// Get the dissolve timeout
-	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	// Get the lifecycle settings
+	lifecycleSettings, err := rputils.GetMinipoolLifecycleSettings(rp, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -142,16 +133,12 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 	}
 	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
-	// Check the stake status of each minipool
+	// Get the lifecycle and stake status of each minipool
 	for i, mpDetails := range details {
-		if mpDetails.Status.Status == types.Prelaunch {
-			creationTime := mpDetails.Status.StatusTime
-			dissolveTime := creationTime.Add(timeout)
-			remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
-			if remainingTime < 0 {
-				details[i].CanStake = true
-				details[i].TimeUntilDissolve = time.Until(dissolveTime)
-			}
+		details[i].Lifecycle = rputils.GetMinipoolLifecycle(mpDetails.Status, mpDetails.Finalised, mpDetails.RefundAvailable, lifecycleSettings, latestBlockTime)
+		if rputils.IsMinipoolStakeable(mpDetails.Status, lifecycleSettings, latestBlockTime) {
+			details[i].CanStake = true
+			details[i].TimeUntilDissolve = time.Until(rputils.GetDissolveTime(mpDetails.Status, lifecycleSettings))
 		}
 	}
 
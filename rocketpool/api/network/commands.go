@@ -53,6 +53,32 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "dao-settings",
+				Usage:     "Get the current value of each protocol and oracle DAO setting, and optionally the changes made to them by proposals",
+				UsageText: "rocketpool api network dao-settings category include-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					category, err := cliutils.ValidateDAOSettingCategory("category", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					includeHistory, err := cliutils.ValidateBool("include history", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDAOSettings(c, category, includeHistory))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "stats",
 				Aliases:   []string{"s"},
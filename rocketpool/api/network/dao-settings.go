@@ -0,0 +1,118 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/indexer"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The maximum number of blocks to add to the event index per call, so the history is built up over several calls instead of blocking for a full chain scan
+const settingsIndexBlocksPerCall uint64 = 200000
+
+func getDAOSettings(c *cli.Context, category string, includeHistory bool) (*api.NetworkDAOSettingsResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkDAOSettingsResponse{
+		Settings: []api.NetworkDAOSetting{},
+		History:  []api.NetworkDAOSettingChange{},
+	}
+
+	// Get the current value of each setting in the category
+	for _, setting := range rputils.GetDAOSettings("") {
+		if !isInDAOSettingCategory(setting, category) {
+			continue
+		}
+		value, err := rputils.GetDAOSettingValue(rp, setting, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.Settings = append(response.Settings, api.NetworkDAOSetting{
+			DAO:          setting.DAO,
+			Category:     setting.Category,
+			ContractName: setting.ContractName,
+			Path:         setting.Path,
+			Value:        value,
+		})
+	}
+	if !includeHistory {
+		return &response, nil
+	}
+
+	// Check whether the guardian can still change protocol DAO settings without a proposal
+	response.ProtocolDAOBootstrapMode, err = rputils.GetProtocolDAOBootstrapMode(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bring the event index towards the chain head, so the executed proposals don't need to be searched for from the deployment block on every call
+	idx, err := indexer.OpenIndexer(rp, cfg, "api", 0)
+	if errors.Is(err, indexer.ErrIndexBusy) {
+		response.HistoryIndexing = true
+		return &response, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer idx.Close()
+	progress, err := idx.SyncLimited(nil, settingsIndexBlocksPerCall)
+	if err != nil {
+		return nil, fmt.Errorf("Error updating event index: %w", err)
+	}
+	response.HistoryIndexing = progress < 1
+	response.HistoryIndexProgress = progress
+
+	// Get the setting changes made by the executed proposals indexed so far
+	executions, err := idx.GetProposalExecutions(0)
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range executions {
+		change, isSettingChange, err := rputils.GetProposalSettingChange(rp, execution.ProposalID)
+		if err != nil {
+			return nil, err
+		}
+		if !isSettingChange {
+			continue
+		}
+		setting, known := rputils.FindDAOSetting(change.ContractName, change.Path)
+		if known && !isInDAOSettingCategory(setting, category) {
+			continue
+		}
+		response.History = append(response.History, api.NetworkDAOSettingChange{
+			ProposalID:   change.ProposalID,
+			ContractName: change.ContractName,
+			Path:         change.Path,
+			Value:        change.Value,
+			Time:         execution.Time,
+			BlockNumber:  execution.BlockNumber,
+			TxHash:       execution.TxHash,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check whether a setting belongs to the requested category, which can also be a DAO
+func isInDAOSettingCategory(setting rputils.DAOSetting, category string) bool {
+	return category == "all" || category == setting.DAO || category == setting.Category
+}
@@ -0,0 +1,86 @@
+package node
+
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The RPL price changes the collateral ratio is projected against
+var collateralProjectionPriceChanges = []float64{-0.5, -0.25, -0.1, 0, 0.1, 0.25, 0.5}
+
+func getCollateralPlan(c *cli.Context, targetRatio float64) (*api.NodeCollateralPlanResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rocketPool, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeCollateralPlanResponse{
+		TargetRatio: targetRatio,
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's collateral
+	collateral, err := rp.GetNodeCollateral(rocketPool, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.RplStake = collateral.RplStake
+	response.RplPrice = collateral.RplPrice
+	response.ActiveMinipools = collateral.ActiveMinipools
+	response.MinimumRatio = collateral.MinimumRatio
+	response.MaximumRatio = collateral.MaximumRatio
+	response.CollateralRatio = rp.GetCollateralRatio(collateral.RplStake, collateral.RplPrice, collateral.ActiveMinipools)
+
+	// Get the node's RPL balances that could be staked
+	response.RplBalance, err = tokens.GetRPLBalance(rocketPool, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.FixedSupplyRplBalance, err = tokens.GetFixedSupplyRPLBalance(rocketPool, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the prices at which the node crosses the collateral bounds
+	response.MinimumRatioRplPrice = rp.GetRplPriceForCollateralRatio(collateral.MinimumRatio, collateral.RplStake, collateral.ActiveMinipools)
+	response.MaximumRatioRplPrice = rp.GetRplPriceForCollateralRatio(collateral.MaximumRatio, collateral.RplStake, collateral.ActiveMinipools)
+
+	// Get the RPL needed to reach the target
+	response.RplToStake = rp.GetRplToStakeForCollateralRatio(collateral, targetRatio)
+
+	// Project the ratio against changes in the RPL price
+	for _, change := range collateralProjectionPriceChanges {
+		price := new(big.Float).Mul(new(big.Float).SetInt(collateral.RplPrice), big.NewFloat(1+change))
+		priceWei, _ := price.Int(nil)
+		response.Projections = append(response.Projections, api.CollateralProjection{
+			PriceChange:     change,
+			RplPrice:        priceWei,
+			CollateralRatio: rp.GetCollateralRatio(collateral.RplStake, priceWei, collateral.ActiveMinipools),
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -28,7 +28,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					api.PrintResponse(GetStatus(c))
 					return nil
 
 				},
@@ -47,7 +47,25 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSyncProgress(c))
+					api.PrintResponse(GetSyncProgress(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-nonce",
+				Usage:     "Get the next available nonce for the node account, including pending transactions",
+				UsageText: "rocketpool api node get-nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNonce(c))
 					return nil
 
 				},
@@ -185,6 +203,70 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "check-withdrawal-address",
+				Usage:     "Checks whether an address is a contract or Safe, and whether it can receive ETH",
+				UsageText: "rocketpool api node check-withdrawal-address address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("withdrawal address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkWithdrawalAddress(c, address))
+					return nil
+
+				},
+			},
+			{
+				Name:      "safe-set-withdrawal-address",
+				Usage:     "Create a Safe transaction proposal for the node's current withdrawal address to set a new one",
+				UsageText: "rocketpool api node safe-set-withdrawal-address address confirm",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					withdrawalAddress, err := cliutils.ValidateAddress("withdrawal address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					confirm, err := cliutils.ValidateBool("confirm", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSafeSetWithdrawalAddressProposal(c, withdrawalAddress, confirm))
+					return nil
+
+				},
+			},
+			{
+				Name:      "safe-confirm-withdrawal-address",
+				Usage:     "Create a Safe transaction proposal for the node's pending withdrawal address to confirm itself",
+				UsageText: "rocketpool api node safe-confirm-withdrawal-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSafeConfirmWithdrawalAddressProposal(c))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-set-timezone",
@@ -360,6 +442,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "collateral-plan",
+				Usage:     "Project the node's RPL collateral ratio against the RPL price, and get the RPL needed to reach a target ratio",
+				UsageText: "rocketpool api node collateral-plan target-ratio",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					targetRatio, err := cliutils.ValidateCollateralRatio("target ratio", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getCollateralPlan(c, targetRatio))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-stake-rpl",
 				Usage:     "Check whether the node can stake RPL",
@@ -749,7 +853,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRewards(c))
+					api.PrintResponse(GetRewards(c))
 					return nil
 
 				},
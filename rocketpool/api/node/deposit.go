@@ -337,8 +337,13 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		return nil, err
 	}
 
-	// Create and save a new validator key
-	validatorKey, err := w.CreateValidatorKey()
+	// Create and save a new validator key; a dry run only derives it so nothing is written to the keystores
+	var validatorKey *eth2types.BLSPrivateKey
+	if c.GlobalBool("dry-run") {
+		validatorKey, err = w.GetNextValidatorKey()
+	} else {
+		validatorKey, err = w.CreateValidatorKey()
+	}
 	if err != nil {
 		return nil, err
 	}
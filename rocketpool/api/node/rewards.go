@@ -1,6 +1,7 @@
 package node
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -17,12 +18,16 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/indexer"
 	"github.com/rocket-pool/smartnode/shared/types/api"
-	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 )
 
-func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
+// The most blocks a single rewards call will add to the event index; the rest are indexed by later calls
+const rewardsIndexBlocksPerCall uint64 = 200000
+
+// Get the node's rewards
+func GetRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get services
 	if err := services.RequireNodeWallet(c); err != nil {
@@ -60,10 +65,21 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 		return nil, err
 	}
 
-	// Get the event log interval
-	eventLogInterval, err := apiutils.GetEventLogInterval(cfg)
-	if err != nil {
+	// Bring the event index towards the chain head for the lifetime rewards
+	// The index is only advanced by a limited amount per call, and is skipped if another call is using it, so this never blocks for long.
+	idx, err := indexer.OpenIndexer(rp, cfg, "api", 0)
+	if errors.Is(err, indexer.ErrIndexBusy) {
+		response.CumulativeRewardsIndexing = true
+	} else if err != nil {
 		return nil, err
+	} else {
+		defer idx.Close()
+		progress, err := idx.SyncLimited(nil, rewardsIndexBlocksPerCall)
+		if err != nil {
+			return nil, fmt.Errorf("Error updating event index: %w", err)
+		}
+		response.CumulativeRewardsIndexing = progress < 1
+		response.CumulativeRewardsIndexProgress = progress
 	}
 
 	var totalEffectiveStake *big.Int
@@ -108,13 +124,15 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 	})
 
 	// Get cumulative rewards
-	wg.Go(func() error {
-		rewards, err := rewards.CalculateLifetimeNodeRewards(rp, nodeAccount.Address, eventLogInterval, nil)
-		if err == nil {
-			response.CumulativeRewards = eth.WeiToEth(rewards)
-		}
-		return err
-	})
+	if !response.CumulativeRewardsIndexing {
+		wg.Go(func() error {
+			rewards, err := idx.GetNodeRplClaimed(nodeAccount.Address)
+			if err == nil {
+				response.CumulativeRewards = eth.WeiToEth(rewards)
+			}
+			return err
+		})
+	}
 
 	// Get the start of the rewards checkpoint
 	wg.Go(func() error {
@@ -259,13 +277,15 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 		})
 
 		// Get cumulative ODAO rewards
-		wg2.Go(func() error {
-			rewards, err := rewards.CalculateLifetimeTrustedNodeRewards(rp, nodeAccount.Address, eventLogInterval, nil)
-			if err == nil {
-				response.CumulativeTrustedRewards = eth.WeiToEth(rewards)
-			}
-			return err
-		})
+		if !response.CumulativeRewardsIndexing {
+			wg2.Go(func() error {
+				rewards, err := idx.GetTrustedNodeRplClaimed(nodeAccount.Address)
+				if err == nil {
+					response.CumulativeTrustedRewards = eth.WeiToEth(rewards)
+				}
+				return err
+			})
+		}
 
 		// Get the ODAO member count
 		wg2.Go(func() error {
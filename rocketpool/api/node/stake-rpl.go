@@ -7,11 +7,15 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -232,9 +236,21 @@ func stakeRpl(c *cli.Context, amountWei *big.Int) (*api.NodeStakeRplStakeRespons
 		return nil, err
 	}
 
+	simulator, err := services.GetTransactionSimulator(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Response
 	response := api.NodeStakeRplStakeResponse{}
 
+	// A dry run doesn't send the approval, so simulate the stake as if it had been
+	if simulator != nil {
+		if err := assumeStakeAllowance(rp, w, simulator, amountWei); err != nil {
+			return nil, err
+		}
+	}
+
 	// Stake RPL
 	opts, err := w.GetNodeAccountTransactor()
 	if err != nil {
@@ -254,3 +270,37 @@ func stakeRpl(c *cli.Context, amountWei *big.Int) (*api.NodeStakeRplStakeRespons
 	return &response, nil
 
 }
+
+// Assume the staking contract is approved to spend the amount of RPL being staked, if it isn't already
+func assumeStakeAllowance(rp *rocketpool.RocketPool, w *wallet.Wallet, simulator *simulation.Simulator, amountWei *big.Int) error {
+
+	// Get the contract addresses
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	rocketNodeStakingAddress, err := rp.GetAddress("rocketNodeStaking")
+	if err != nil {
+		return err
+	}
+	rplAddress, err := rp.GetAddress("rocketTokenRPL")
+	if err != nil {
+		return err
+	}
+
+	// Check the current allowance
+	allowance, err := tokens.GetRPLAllowance(rp, account.Address, *rocketNodeStakingAddress, nil)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amountWei) >= 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("rocketNodeStaking is approved to spend %.6f RPL (the current allowance is %.6f RPL)", eth.WeiToEth(amountWei), eth.WeiToEth(allowance))
+	if err := simulator.AssumeTokenAllowance(*rplAddress, account.Address, *rocketNodeStakingAddress, amountWei, description); err != nil {
+		return fmt.Errorf("Error assuming the RPL approval for the dry run: %w", err)
+	}
+	return nil
+
+}
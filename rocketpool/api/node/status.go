@@ -16,7 +16,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
-func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
+// Get the node's status
+func GetStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
 	// Get services
 	if err := services.RequireNodeWallet(c); err != nil {
@@ -7,7 +7,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
-func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
+// Get the sync progress of the node's clients
+func GetSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
 
 	// Response
 	response := api.NodeSyncProgressResponse{}
@@ -8,6 +8,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/safe"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -289,3 +290,107 @@ func getNodePendingWithdrawalAddress(c *cli.Context) (*api.GetNodePendingWithdra
 	return &response, nil
 
 }
+
+func checkWithdrawalAddress(c *cli.Context, address common.Address) (*api.CheckWithdrawalAddressResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the address
+	return safe.CheckAddress(rp, nodeAccount.Address, address)
+
+}
+
+func getSafeSetWithdrawalAddressProposal(c *cli.Context, withdrawalAddress common.Address, confirm bool) (*api.SafeProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the current withdrawal address can change it
+	currentAddress, err := storage.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if currentAddress == nodeAccount.Address {
+		return nil, fmt.Errorf("This node's withdrawal address is the node address, so it can be changed from the node without a Safe.")
+	}
+
+	// Create the proposal
+	data, err := rp.RocketStorageContract.ABI.Pack("setWithdrawalAddress", nodeAccount.Address, withdrawalAddress, confirm)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding set withdrawal address call: %w", err)
+	}
+	return safe.CreateProposal(rp, currentAddress, *rp.RocketStorageContract.Address, data)
+
+}
+
+func getSafeConfirmWithdrawalAddressProposal(c *cli.Context) (*api.SafeProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// The pending withdrawal address confirms itself
+	pendingAddress, err := storage.GetNodePendingWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pendingAddress == (common.Address{}) {
+		return nil, fmt.Errorf("This node does not have a pending withdrawal address.")
+	}
+
+	// Create the proposal
+	data, err := rp.RocketStorageContract.ABI.Pack("confirmWithdrawalAddress", nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding confirm withdrawal address call: %w", err)
+	}
+	return safe.CreateProposal(rp, pendingAddress, *rp.RocketStorageContract.Address, data)
+
+}
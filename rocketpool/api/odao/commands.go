@@ -5,6 +5,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 // Register subcommands
@@ -471,387 +472,77 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			},
 
 			{
-				Name:      "can-propose-members-quorum",
-				Usage:     "Check whether the node can propose the members.quorum setting",
-				UsageText: "rocketpool api odao can-propose-members-quorum value",
+				Name:      "can-propose-setting",
+				Usage:     "Check whether the node can propose an oracle DAO setting update",
+				UsageText: "rocketpool api odao can-propose-setting key value",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					quorum, err := cliutils.ValidateFraction("quorum", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(canProposeSettingMembersQuorum(c, quorum))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-members-quorum",
-				Usage:     "Propose updating the members.quorum setting",
-				UsageText: "rocketpool api odao propose-members-quorum value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					quorum, err := cliutils.ValidateFraction("quorum", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(proposeSettingMembersQuorum(c, quorum))
-					return nil
-
-				},
-			},
-
-			{
-				Name:      "can-propose-members-rplbond",
-				Usage:     "Check whether the node can propose the members.rplbond setting",
-				UsageText: "rocketpool api odao can-propose-members-rplbond value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					bondAmountWei, err := cliutils.ValidateWeiAmount("RPL bond amount", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(canProposeSettingMembersRplBond(c, bondAmountWei))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-members-rplbond",
-				Usage:     "Propose updating the members.rplbond setting",
-				UsageText: "rocketpool api odao propose-members-rplbond value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					bondAmountWei, err := cliutils.ValidateWeiAmount("RPL bond amount", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(proposeSettingMembersRplBond(c, bondAmountWei))
-					return nil
-
-				},
-			},
-
-			{
-				Name:      "can-propose-members-minipool-unbonded-max",
-				Usage:     "Check whether the node can propose the members.minipool.unbonded.max setting",
-				UsageText: "rocketpool api odao can-propose-members-minipool-unbonded-max value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					unbondedMinipoolMax, err := cliutils.ValidateUint("maximum unbonded minipool count", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(canProposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-members-minipool-unbonded-max",
-				Usage:     "Propose updating the members.minipool.unbonded.max setting",
-				UsageText: "rocketpool api odao propose-members-minipool-unbonded-max value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					unbondedMinipoolMax, err := cliutils.ValidateUint("maximum unbonded minipool count", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(proposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
-					return nil
-
-				},
-			},
-
-			{
-				Name:      "can-propose-proposal-cooldown",
-				Usage:     "Check whether the node can propose the proposal.cooldown setting",
-				UsageText: "rocketpool api odao can-propose-proposal-cooldown value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalCooldownBlocks, err := cliutils.ValidateUint("proposal cooldown period", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(canProposeSettingProposalCooldown(c, proposalCooldownBlocks))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-proposal-cooldown",
-				Usage:     "Propose updating the proposal.cooldown setting",
-				UsageText: "rocketpool api odao propose-proposal-cooldown value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalCooldownBlocks, err := cliutils.ValidateUint("proposal cooldown period", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(proposeSettingProposalCooldown(c, proposalCooldownBlocks))
-					return nil
-
-				},
-			},
-
-			{
-				Name:      "can-propose-proposal-vote-timespan",
-				Usage:     "Check whether the node can propose the proposal.vote.time setting",
-				UsageText: "rocketpool api odao can-propose-proposal-vote-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalVoteTimespan, err := cliutils.ValidateUint("proposal voting period", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(canProposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-proposal-vote-timespan",
-				Usage:     "Propose updating the proposal.vote.time setting",
-				UsageText: "rocketpool api odao propose-proposal-vote-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalVoteTimespan, err := cliutils.ValidateUint("proposal voting period", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(proposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
-					return nil
-
-				},
-			},
-
-			{
-				Name:      "can-propose-proposal-vote-delay-timespan",
-				Usage:     "Check whether the node can propose the proposal.vote.delay.time setting",
-				UsageText: "rocketpool api odao can-propose-proposal-vote-delay-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalDelayTimespan, err := cliutils.ValidateUint("proposal delay period", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(canProposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-proposal-vote-delay-timespan",
-				Usage:     "Propose updating the proposal.vote.delay.time setting",
-				UsageText: "rocketpool api odao propose-proposal-vote-delay-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalDelayTimespan, err := cliutils.ValidateUint("proposal delay period", c.Args().Get(0))
-					if err != nil {
-						return err
-					}
-
-					// Run
-					api.PrintResponse(proposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
-					return nil
-
-				},
-			},
-
-			{
-				Name:      "can-propose-proposal-execute-timespan",
-				Usage:     "Check whether the node can propose the proposal.execute.time setting",
-				UsageText: "rocketpool api odao can-propose-proposal-execute-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
 						return err
 					}
-					proposalExecuteTimespan, err := cliutils.ValidateUint("proposal execution period", c.Args().Get(0))
+					setting, err := rputils.GetDAOSetting(rputils.DAOTrustedNode, c.Args().Get(0))
 					if err != nil {
 						return err
 					}
-
-					// Run
-					api.PrintResponse(canProposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-proposal-execute-timespan",
-				Usage:     "Propose updating the proposal.execute.time setting",
-				UsageText: "rocketpool api odao propose-proposal-execute-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalExecuteTimespan, err := cliutils.ValidateUint("proposal execution period", c.Args().Get(0))
+					value, err := setting.DecodeValue(c.Args().Get(1))
 					if err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
+					api.PrintResponse(canProposeSetting(c, setting, value))
 					return nil
 
 				},
 			},
-
 			{
-				Name:      "can-propose-proposal-action-timespan",
-				Usage:     "Check whether the node can propose the proposal.action.time setting",
-				UsageText: "rocketpool api odao can-propose-proposal-action-timespan value",
+				Name:      "propose-setting",
+				Usage:     "Propose an oracle DAO setting update",
+				UsageText: "rocketpool api odao propose-setting key value",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
 						return err
 					}
-					proposalActionTimespan, err := cliutils.ValidateUint("proposal action period", c.Args().Get(0))
+					setting, err := rputils.GetDAOSetting(rputils.DAOTrustedNode, c.Args().Get(0))
 					if err != nil {
 						return err
 					}
-
-					// Run
-					api.PrintResponse(canProposeSettingProposalActionTimespan(c, proposalActionTimespan))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-proposal-action-timespan",
-				Usage:     "Propose updating the proposal.action.time setting",
-				UsageText: "rocketpool api odao propose-proposal-action-timespan value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					proposalActionTimespan, err := cliutils.ValidateUint("proposal action period", c.Args().Get(0))
+					value, err := setting.DecodeValue(c.Args().Get(1))
 					if err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalActionTimespan(c, proposalActionTimespan))
+					api.PrintResponse(proposeSetting(c, setting, value))
 					return nil
 
 				},
 			},
 
 			{
-				Name:      "can-propose-scrub-period",
-				Usage:     "Check whether the node can propose the minipool.scrub.period setting",
-				UsageText: "rocketpool api odao can-propose-scrub-period value",
+				Name:      "submissions",
+				Usage:     "Get the watchtower's recorded submissions and the values the oracle DAO reached consensus on",
+				UsageText: "rocketpool api odao submissions type limit",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
 						return err
 					}
-					scrubPeriod, err := cliutils.ValidateUint("scrub period", c.Args().Get(0))
+					submissionType, err := cliutils.ValidateSubmissionType("submission type", c.Args().Get(0))
 					if err != nil {
 						return err
 					}
-
-					// Run
-					api.PrintResponse(canProposeSettingScrubPeriod(c, scrubPeriod))
-					return nil
-
-				},
-			},
-			{
-				Name:      "propose-scrub-period",
-				Usage:     "Propose updating the minipool.scrub.period setting",
-				UsageText: "rocketpool api odao propose-scrub-period value",
-				Action: func(c *cli.Context) error {
-
-					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					scrubPeriod, err := cliutils.ValidateUint("scrub period", c.Args().Get(0))
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(1))
 					if err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingScrubPeriod(c, scrubPeriod))
+					api.PrintResponse(getSubmissions(c, submissionType, limit))
 					return nil
 
 				},
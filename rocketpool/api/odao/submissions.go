@@ -0,0 +1,153 @@
+package odao
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The consensus values for a network update, keyed by the block they were for
+type consensusValues map[uint64]map[string]string
+
+func getSubmissions(c *cli.Context, submissionType string, limit uint64) (*api.TNDAOSubmissionsResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOSubmissionsResponse{}
+
+	// Load the submission records
+	if submissionType == "all" {
+		submissionType = ""
+	}
+	submissions, err := audit.NewLog(cfg).Load(api.WatchtowerSubmissionType(submissionType), int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the earliest block of the network updates, so consensus is only searched for from there
+	var balancesStart *big.Int
+	var pricesStart *big.Int
+	for _, submission := range submissions {
+		block := big.NewInt(0).SetUint64(submission.Block)
+		switch submission.Type {
+		case api.Submission_NetworkBalances:
+			if balancesStart == nil || block.Cmp(balancesStart) < 0 {
+				balancesStart = block
+			}
+		case api.Submission_RplPrice:
+			if pricesStart == nil || block.Cmp(pricesStart) < 0 {
+				pricesStart = block
+			}
+		}
+	}
+
+	// Get the network updates the oracle DAO reached consensus on
+	eventLogInterval, err := apiutils.GetEventLogInterval(cfg)
+	if err != nil {
+		return nil, err
+	}
+	balancesConsensus := consensusValues{}
+	if balancesStart != nil {
+		balancesConsensus, err = getConsensusValues(rp, "rocketNetworkBalances", rputils.BalancesUpdatedEvent, balancesStart, eventLogInterval)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pricesConsensus := consensusValues{}
+	if pricesStart != nil {
+		pricesConsensus, err = getConsensusValues(rp, "rocketNetworkPrices", rputils.PricesUpdatedEvent, pricesStart, eventLogInterval)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compare each submission against the chain
+	response.Submissions = make([]api.TNDAOSubmissionAudit, len(submissions))
+	for i, submission := range submissions {
+		details := api.TNDAOSubmissionAudit{
+			Submission: submission,
+		}
+		switch submission.Type {
+		case api.Submission_NetworkBalances:
+			details.ConsensusResult, details.ConsensusFound = balancesConsensus[submission.Block]
+		case api.Submission_RplPrice:
+			details.ConsensusResult, details.ConsensusFound = pricesConsensus[submission.Block]
+		case api.Submission_WithdrawableMinipool, api.Submission_ScrubMinipool:
+			if len(submission.Minipools) == 0 {
+				break
+			}
+			status, err := getMinipoolStatus(rp, submission.Minipools[0].Address)
+			if err != nil {
+				return nil, err
+			}
+			details.ConsensusFound = true
+			if submission.Type == api.Submission_WithdrawableMinipool {
+				details.ConsensusResult = map[string]string{"withdrawable": strconv.FormatBool(status == rptypes.Withdrawable)}
+			} else {
+				details.ConsensusResult = map[string]string{"scrub": strconv.FormatBool(status == rptypes.Dissolved)}
+			}
+		case api.Submission_ChallengeResponse:
+			isChallenged, err := trustednode.GetMemberIsChallenged(rp, common.HexToAddress(submission.Inputs["member"]), nil)
+			if err != nil {
+				return nil, err
+			}
+			details.ConsensusFound = true
+			details.ConsensusResult = map[string]string{"challengeDecided": strconv.FormatBool(!isChallenged)}
+		}
+		response.Submissions[i] = details
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the values of a network contract's consensus updates, keyed by the block they were for
+func getConsensusValues(rp *rocketpool.RocketPool, contractName string, eventName string, startBlock *big.Int, eventLogInterval *big.Int) (consensusValues, error) {
+	updates, err := rputils.GetNetworkUpdates(rp, contractName, eventName, nil, startBlock, nil, eventLogInterval)
+	if err != nil {
+		return nil, err
+	}
+	values := consensusValues{}
+	for _, update := range updates {
+		result := map[string]string{}
+		for name, value := range update.Values {
+			result[name] = value.String()
+		}
+		values[update.Block] = result
+	}
+	return values, nil
+}
+
+// Get the current status of a minipool
+func getMinipoolStatus(rp *rocketpool.RocketPool, address common.Address) (rptypes.MinipoolStatus, error) {
+	mp, err := minipool.NewMinipool(rp, address)
+	if err != nil {
+		return 0, err
+	}
+	return mp.GetStatus(nil)
+}
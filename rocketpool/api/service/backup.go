@@ -0,0 +1,44 @@
+package service
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Create an encrypted backup of the node's settings, wallet and validator keys, and store it in the configured target
+// This runs in the API container because the data folder is owned by root.
+func createBackup(c *cli.Context) (*api.CreateBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateBackupResponse{}
+
+	// Create the backup
+	name, location, manifest, err := backup.Create(cfg, os.ExpandEnv(c.GlobalString("settings")), d)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = name
+	response.Location = location
+	response.Files = len(manifest.Files)
+	response.Notes = manifest.Notes
+
+	// Return response
+	return &response, nil
+
+}
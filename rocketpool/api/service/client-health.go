@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the health of each client, so the CLI can tell if a client it restarted has recovered
+// Problems with individual clients are reported in the response instead of as errors, since they're what's being checked for.
+func getClientHealth(c *cli.Context) (*api.ClientHealthResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ClientHealthResponse{}
+
+	// Get the EC status and peer count
+	response.EcStatus = *ec.CheckStatus()
+	response.EcPeers, err = ec.PeerCount(context.Background())
+	if err != nil {
+		response.EcPeersError = err.Error()
+	}
+
+	// Get the CC status and peer count
+	syncStatus, err := bc.GetSyncStatus()
+	if err != nil {
+		response.CcError = err.Error()
+		return &response, nil
+	}
+	response.CcSynced = !syncStatus.Syncing
+	response.CcSyncProgress = syncStatus.Progress
+	response.CcPeers, err = bc.GetPeerCount()
+	if err != nil {
+		response.CcPeersError = err.Error()
+	}
+
+	// Check the node's validators once the clients are ready
+	ecStatus := response.EcStatus
+	ecReady := (ecStatus.PrimaryEcStatus.IsWorking && ecStatus.PrimaryEcStatus.IsSynced) || (ecStatus.FallbackEnabled && ecStatus.FallbackEcStatus.IsWorking && ecStatus.FallbackEcStatus.IsSynced)
+	if !ecReady || !response.CcSynced {
+		return &response, nil
+	}
+	response.ValidatorsChecked = true
+	if !w.IsInitialized() {
+		return &response, nil
+	}
+	if err := getAttestingValidators(w, rp, bc, &response); err != nil {
+		response.ValidatorsError = err.Error()
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Count the node's active validators, and how many of them were rewarded for attesting in the latest epoch transition
+// A validator's balance only goes up at an epoch transition if it attested, so this shows the validator client is working.
+func getAttestingValidators(w *wallet.Wallet, rp *rocketpool.RocketPool, bc beacon.Client, response *api.ClientHealthResponse) error {
+
+	// Get the node's minipool pubkeys
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return fmt.Errorf("Error getting node account: %w", err)
+	}
+	registered, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error checking if the node is registered: %w", err)
+	}
+	if !registered {
+		return nil
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the node's minipool pubkeys: %w", err)
+	}
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	// Get the validator balances at the start of the current and previous epochs
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("Error getting the beacon head: %w", err)
+	}
+	response.Epoch = head.Epoch
+	if head.Epoch == 0 {
+		return nil
+	}
+	currentStatuses, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{Epoch: head.Epoch})
+	if err != nil {
+		return fmt.Errorf("Error getting validator statuses for epoch %d: %w", head.Epoch, err)
+	}
+	previousStatuses, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{Epoch: head.Epoch - 1})
+	if err != nil {
+		return fmt.Errorf("Error getting validator statuses for epoch %d: %w", head.Epoch-1, err)
+	}
+
+	// Compare the balances of the validators that were active for both epochs
+	for _, pubkey := range pubkeys {
+		current := currentStatuses[pubkey]
+		previous := previousStatuses[pubkey]
+		if !current.Exists || !previous.Exists || current.Slashed || current.ActivationEpoch >= head.Epoch-1 || current.ExitEpoch <= head.Epoch {
+			continue
+		}
+		response.ActiveValidators++
+		if current.Balance > previous.Balance {
+			response.AttestingValidators++
+		}
+	}
+	return nil
+
+}
@@ -52,6 +52,97 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "client-health",
+				Usage:     "Gets the sync status and peer counts of the clients, and how many of the node's validators are attesting",
+				UsageText: "rocketpool api service client-health",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getClientHealth(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "start-nethermind-pruning",
+				Usage:     "Ask the primary Nethermind client to start a full prune of its state database",
+				UsageText: "rocketpool api service start-nethermind-pruning",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(startNethermindPruning(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "nethermind-pruning-status",
+				Usage:     "Get the status of the primary Nethermind client's full prune",
+				UsageText: "rocketpool api service nethermind-pruning-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNethermindPruningStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Usage:     "Create an encrypted backup of the node's settings, wallet and validator keys, and store it in the configured target",
+				UsageText: "rocketpool api service backup",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createBackup(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "doctor",
+				Aliases:   []string{"d"},
+				Usage:     "Run health checks against the clients, wallet and node",
+				UsageText: "rocketpool api service doctor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(runDoctor(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
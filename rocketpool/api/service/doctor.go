@@ -0,0 +1,523 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	MinHealthyPeerCount    uint64  = 5
+	LowGasBalanceThreshold float64 = 0.05
+	// Execution clients accept blocks with timestamps up to 15 seconds ahead of their own clock
+	MaxClockDrift     = 15 * time.Second
+	MaxLatestBlockAge = 2 * time.Minute
+)
+
+// A health check run by the doctor
+type doctorCheck func(d *doctor) api.HealthCheck
+
+// The checks run by the doctor, in order
+// New checks only need to be added here.
+var doctorChecks = []doctorCheck{
+	checkExecutionClientSync,
+	checkExecutionClientPeers,
+	checkConsensusClientSync,
+	checkConsensusClientPeers,
+	checkClockDrift,
+	checkLatestBlockAge,
+	checkWallet,
+	checkMinipoolKeys,
+	checkGasBalance,
+	checkRplCollateral,
+}
+
+// The services shared by the checks
+type doctor struct {
+	cfg *config.RocketPoolConfig
+	pm  *passwords.PasswordManager
+	w   *wallet.Wallet
+	ec  *services.ExecutionClientManager
+	bc  beacon.Client
+	rp  *rocketpool.RocketPool
+
+	// Filled in as the checks run, so later checks can be skipped if an earlier one failed
+	ecReady        bool
+	ccHeadSlot     uint64
+	ccReady        bool
+	nodeAccount    common.Address
+	walletReady    bool
+	nodeRegistered bool
+}
+
+// Run the health checks
+func runDoctor(c *cli.Context) (*api.ServiceDoctorResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ServiceDoctorResponse{}
+	d := &doctor{
+		cfg: cfg,
+		pm:  pm,
+		w:   w,
+		ec:  ec,
+		bc:  bc,
+		rp:  rp,
+	}
+
+	// Run the checks
+	for _, check := range doctorChecks {
+		response.Checks = append(response.Checks, check(d))
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check that an execution client is synced and usable
+func checkExecutionClientSync(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Execution client sync"}
+	logsRemediation := "check the execution client's logs with `rocketpool service logs eth1`."
+	if d.cfg.IsNativeMode {
+		logsRemediation = "check your execution client's logs."
+	}
+
+	// Get the EC status
+	status := d.ec.CheckStatus()
+	primary := status.PrimaryEcStatus
+	fallback := status.FallbackEcStatus
+	d.ecReady = (primary.IsWorking && primary.IsSynced) || (status.FallbackEnabled && fallback.IsWorking && fallback.IsSynced)
+
+	switch {
+	case primary.IsWorking && primary.IsSynced:
+		check.Status = api.HealthCheckStatus_Pass
+		check.Message = "The primary execution client is synced."
+
+	case d.ecReady:
+		check.Status = api.HealthCheckStatus_Warn
+		if primary.Error != "" {
+			check.Message = fmt.Sprintf("The primary execution client is unavailable (%s); the fallback client is being used.", primary.Error)
+		} else {
+			check.Message = fmt.Sprintf("The primary execution client is still syncing (%.2f%%); the fallback client is being used.", primary.SyncProgress*100)
+		}
+		check.Remediation = "To find out why the primary client isn't ready, " + logsRemediation
+
+	case primary.IsWorking && primary.Error == "":
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("The primary execution client is still syncing (%.2f%%) and no fallback client is ready.", primary.SyncProgress*100)
+		check.Remediation = "Wait for the execution client to finish syncing. If its progress stalls, " + logsRemediation
+
+	default:
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("No execution client is ready (primary: %s).", primary.Error)
+		if status.FallbackEnabled {
+			check.Message = fmt.Sprintf("No execution client is ready (primary: %s, fallback: %s).", primary.Error, fallback.Error)
+		}
+		check.Remediation = "Make sure the execution client is running, and " + logsRemediation
+
+	}
+	return check
+
+}
+
+// Check that the primary execution client has enough peers
+func checkExecutionClientPeers(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Execution client peers"}
+
+	// Light clients managed by the Smartnode don't have peers of their own
+	if !d.cfg.IsNativeMode && d.cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		selectedEc := d.cfg.ExecutionClient.Value.(config.ExecutionClient)
+		if selectedEc == config.ExecutionClient_Infura || selectedEc == config.ExecutionClient_Pocket {
+			check.Status = api.HealthCheckStatus_Skip
+			check.Message = fmt.Sprintf("%s doesn't use peer-to-peer connections.", selectedEc)
+			return check
+		}
+	}
+
+	// Get the peer count
+	peers, err := d.ec.PeerCount(context.Background())
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the execution client's peer count: %s", err.Error())
+		check.Remediation = "Make sure the execution client is running and exposes the `net` API namespace."
+		return check
+	}
+	return checkPeerCount(check, peers, "execution", d.cfg.ExecutionCommon.P2pPort.Value.(uint16))
+
+}
+
+// Check that the beacon node is synced
+func checkConsensusClientSync(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Consensus client sync"}
+
+	// Get the sync status
+	syncStatus, err := d.bc.GetSyncStatus()
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("Could not get the consensus client's sync status: %s", err.Error())
+		check.Remediation = "Make sure the consensus client is running."
+		if !d.cfg.IsNativeMode {
+			check.Remediation += " Check its logs with `rocketpool service logs eth2`."
+		}
+		return check
+	}
+
+	if syncStatus.Syncing {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("The consensus client is still syncing (%.2f%%).", syncStatus.Progress*100)
+		check.Remediation = "Wait for the consensus client to finish syncing. Your validators can't attest until it does."
+		return check
+	}
+	check.Status = api.HealthCheckStatus_Pass
+	check.Message = fmt.Sprintf("The consensus client is synced (head slot %d).", syncStatus.HeadSlot)
+	d.ccReady = true
+	d.ccHeadSlot = syncStatus.HeadSlot
+	return check
+
+}
+
+// Check that the beacon node has enough peers
+func checkConsensusClientPeers(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Consensus client peers"}
+
+	// Get the peer count
+	peers, err := d.bc.GetPeerCount()
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the consensus client's peer count: %s", err.Error())
+		check.Remediation = "Make sure the consensus client is running."
+		return check
+	}
+	return checkPeerCount(check, peers, "consensus", d.cfg.ConsensusCommon.P2pPort.Value.(uint16))
+
+}
+
+// Grade a client's peer count
+func checkPeerCount(check api.HealthCheck, peers uint64, clientType string, p2pPort uint16) api.HealthCheck {
+	remediation := fmt.Sprintf("Make sure port %d (TCP and UDP) is open in your firewall and forwarded by your router so the %s client can find peers.", p2pPort, clientType)
+	switch {
+	case peers == 0:
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("The %s client has no peers.", clientType)
+		check.Remediation = remediation
+	case peers < MinHealthyPeerCount:
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("The %s client only has %d peers.", clientType, peers)
+		check.Remediation = remediation
+	default:
+		check.Status = api.HealthCheckStatus_Pass
+		check.Message = fmt.Sprintf("The %s client has %d peers.", clientType, peers)
+	}
+	return check
+}
+
+// Check the system clock against the time of the consensus client's head slot, derived from the beacon chain's genesis time
+// The head slot should be the one in progress, so a clock that's before it is behind and a clock that's past its end is fast.
+func checkClockDrift(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "System clock"}
+	if !d.ccReady {
+		check.Status = api.HealthCheckStatus_Skip
+		check.Message = "The clock can't be checked until the consensus client is synced."
+		return check
+	}
+
+	// Get the head slot's time
+	eth2Config, err := d.bc.GetEth2Config()
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the beacon chain's genesis time: %s", err.Error())
+		return check
+	}
+	slotTime := time.Unix(int64(eth2Config.GenesisTime+d.ccHeadSlot*eth2Config.SecondsPerSlot), 0)
+	drift := getClockDrift(time.Now(), slotTime, time.Duration(eth2Config.SecondsPerSlot)*time.Second).Round(time.Second)
+
+	// Compare it to the clock
+	remediation := "Make sure your system clock is synchronized with NTP (for example, `timedatectl status` should show \"System clock synchronized: yes\"). Validators with a drifting clock miss attestations and proposals."
+	switch {
+	case drift < -MaxClockDrift:
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("The system clock is %s behind: the consensus client's head slot (%d) starts at %s.", -drift, d.ccHeadSlot, slotTime.UTC().Format(time.RFC3339))
+		check.Remediation = remediation
+	case drift > MaxClockDrift:
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("The system clock is %s ahead: the consensus client's head slot (%d) started at %s.", drift, d.ccHeadSlot, slotTime.UTC().Format(time.RFC3339))
+		check.Remediation = remediation
+	default:
+		check.Status = api.HealthCheckStatus_Pass
+		check.Message = fmt.Sprintf("The system clock agrees with the consensus client's head slot (%d).", d.ccHeadSlot)
+	}
+	return check
+
+}
+
+// Get how far a clock is outside of a slot, which is negative if the slot hasn't started yet and zero if it's in progress
+func getClockDrift(now time.Time, slotTime time.Time, slotDuration time.Duration) time.Duration {
+	if now.Before(slotTime) {
+		return now.Sub(slotTime)
+	}
+	if slotEnd := slotTime.Add(slotDuration); now.After(slotEnd) {
+		return now.Sub(slotEnd)
+	}
+	return 0
+}
+
+// Check that the execution client is still following the chain, using the age of its latest block
+func checkLatestBlockAge(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Execution client head"}
+	if !d.ecReady {
+		check.Status = api.HealthCheckStatus_Skip
+		check.Message = "The latest block can't be checked until an execution client is synced."
+		return check
+	}
+
+	// Get the latest block's timestamp
+	header, err := d.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the latest execution block: %s", err.Error())
+		return check
+	}
+	age := time.Since(time.Unix(int64(header.Time), 0)).Round(time.Second)
+
+	// Check its age
+	if age > MaxLatestBlockAge {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("The latest block (%d) is %s old, so the execution client may have stopped following the chain.", header.Number.Uint64(), age)
+		check.Remediation = "Check that the execution client has peers and isn't reporting errors."
+		if !d.cfg.IsNativeMode {
+			check.Remediation += " Check its logs with `rocketpool service logs eth1`."
+		}
+		return check
+	}
+	check.Status = api.HealthCheckStatus_Pass
+	check.Message = fmt.Sprintf("The latest block (%d) is %s old.", header.Number.Uint64(), age)
+	return check
+
+}
+
+// Check that the wallet can be unlocked and the node account is available
+func checkWallet(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Node wallet"}
+
+	// Check the password & wallet
+	if !d.pm.IsPasswordSet() {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = "The node password has not been set."
+		check.Remediation = "Run `rocketpool wallet init` or `rocketpool wallet recover`."
+		return check
+	}
+	if !d.w.IsInitialized() {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = "The node wallet could not be loaded with the node password."
+		check.Remediation = "Run `rocketpool wallet status`; if the wallet is missing, restore it with `rocketpool wallet recover`."
+		return check
+	}
+
+	// Get the node account
+	nodeAccount, err := d.w.GetNodeAccount()
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("Could not get the node account: %s", err.Error())
+		if d.w.GetHardwareWalletName() != "" {
+			check.Remediation = "Make sure your hardware wallet is plugged in and unlocked."
+		}
+		return check
+	}
+	d.nodeAccount = nodeAccount.Address
+	d.walletReady = true
+
+	// Check if the node is registered
+	check.Status = api.HealthCheckStatus_Pass
+	check.Message = fmt.Sprintf("The node wallet is unlocked (node account %s).", nodeAccount.Address.Hex())
+	if !d.ecReady {
+		return check
+	}
+	registered, err := node.GetNodeExists(d.rp, nodeAccount.Address, nil)
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message += fmt.Sprintf(" Could not check if the node is registered: %s", err.Error())
+		return check
+	}
+	d.nodeRegistered = registered
+	if !registered {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message += " The node is not registered with Rocket Pool."
+		check.Remediation = "Run `rocketpool node register` once your execution client is synced."
+	}
+	return check
+
+}
+
+// Check that the selected consensus client has a key for every validating minipool
+func checkMinipoolKeys(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Validator keys"}
+	if !d.nodeRegistered {
+		check.Status = api.HealthCheckStatus_Skip
+		check.Message = "The node is not registered, so there are no minipools to check."
+		return check
+	}
+
+	// Get the minipool pubkeys
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(d.rp, d.nodeAccount, nil)
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the node's minipool pubkeys: %s", err.Error())
+		return check
+	}
+	if len(pubkeys) == 0 {
+		check.Status = api.HealthCheckStatus_Pass
+		check.Message = "The node doesn't have any validating minipools."
+		return check
+	}
+
+	// Check the keystore for the selected client
+	cc, err := d.cfg.GetSelectedConsensusClient()
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the selected consensus client: %s", err.Error())
+		return check
+	}
+	missing := []string{}
+	for _, pubkey := range pubkeys {
+		hasKey, err := d.w.HasValidatorKey(string(cc), pubkey)
+		if err != nil {
+			check.Status = api.HealthCheckStatus_Warn
+			check.Message = fmt.Sprintf("Could not check the key for validator %s: %s", pubkey.Hex(), err.Error())
+			return check
+		}
+		if !hasKey {
+			missing = append(missing, pubkey.Hex())
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("%d of %d minipool validator keys are missing from the %s keystore: %s", len(missing), len(pubkeys), cc, strings.Join(missing, ", "))
+		check.Remediation = "Run `rocketpool wallet rebuild` to regenerate the keys, then restart the validator client."
+		return check
+	}
+	check.Status = api.HealthCheckStatus_Pass
+	check.Message = fmt.Sprintf("All %d minipool validator keys are in the %s keystore.", len(pubkeys), cc)
+	return check
+
+}
+
+// Check that the node account has enough ETH to pay for gas
+func checkGasBalance(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "Gas balance"}
+	if !d.walletReady || !d.ecReady {
+		check.Status = api.HealthCheckStatus_Skip
+		check.Message = "The balance can't be checked without a node wallet and a synced execution client."
+		return check
+	}
+
+	// Get the balance
+	balance, err := d.ec.BalanceAt(context.Background(), d.nodeAccount, nil)
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the node account's balance: %s", err.Error())
+		return check
+	}
+	remediation := fmt.Sprintf("Send some ETH to the node account (%s) so it can pay for transactions such as claiming rewards and staking minipools.", d.nodeAccount.Hex())
+	switch {
+	case balance.Cmp(big.NewInt(0)) == 0:
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = "The node account has no ETH to pay for gas."
+		check.Remediation = remediation
+	case balance.Cmp(eth.EthToWei(LowGasBalanceThreshold)) < 0:
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("The node account only has %.6f ETH to pay for gas.", eth.WeiToEth(balance))
+		check.Remediation = remediation
+	default:
+		check.Status = api.HealthCheckStatus_Pass
+		check.Message = fmt.Sprintf("The node account has %.6f ETH to pay for gas.", eth.WeiToEth(balance))
+	}
+	return check
+
+}
+
+// Check the node's RPL stake against its minimum
+func checkRplCollateral(d *doctor) api.HealthCheck {
+
+	check := api.HealthCheck{Name: "RPL collateral"}
+	if !d.nodeRegistered {
+		check.Status = api.HealthCheckStatus_Skip
+		check.Message = "The node is not registered."
+		return check
+	}
+
+	// Get the stake & minimum
+	stake, err := node.GetNodeRPLStake(d.rp, d.nodeAccount, nil)
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the node's RPL stake: %s", err.Error())
+		return check
+	}
+	minimum, err := node.GetNodeMinimumRPLStake(d.rp, d.nodeAccount, nil)
+	if err != nil {
+		check.Status = api.HealthCheckStatus_Warn
+		check.Message = fmt.Sprintf("Could not get the node's minimum RPL stake: %s", err.Error())
+		return check
+	}
+	if stake.Cmp(minimum) < 0 {
+		check.Status = api.HealthCheckStatus_Fail
+		check.Message = fmt.Sprintf("The node has %.6f RPL staked, below its minimum of %.6f RPL. It won't earn RPL rewards until it's topped up.", eth.WeiToEth(stake), eth.WeiToEth(minimum))
+		check.Remediation = "Stake more RPL with `rocketpool node stake-rpl`."
+		return check
+	}
+	check.Status = api.HealthCheckStatus_Pass
+	check.Message = fmt.Sprintf("The node has %.6f RPL staked (minimum %.6f RPL).", eth.WeiToEth(stake), eth.WeiToEth(minimum))
+	return check
+
+}
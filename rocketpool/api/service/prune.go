@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Ask Nethermind to start a full prune of its state database through its admin RPC module
+// Nethermind prunes while it keeps running, so this returns as soon as the prune has been requested.
+func startNethermindPruning(c *cli.Context) (*api.NethermindPruningResponse, error) {
+	return callNethermindPrune(c)
+}
+
+// Get the status of Nethermind's full prune
+// Nethermind only has the one admin method for this: while a prune is running it reports that it's in progress, and once it's done
+// the Smartnode's minimum delay between full prunes makes it report that the next one is delayed, so this never starts a new prune.
+func getNethermindPruningStatus(c *cli.Context) (*api.NethermindPruningResponse, error) {
+	return callNethermindPrune(c)
+}
+
+// Call admin_prune on the primary Nethermind client's admin endpoint
+// The admin module is only served on a separate port on the Docker network, never on the regular RPC endpoint.
+func callNethermindPrune(c *cli.Context) (*api.NethermindPruningResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NethermindPruningResponse{}
+
+	// Make sure the primary EC is a local Nethermind client
+	if cfg.ExecutionClientMode.Value.(config.Mode) != config.Mode_Local || cfg.ExecutionClient.Value.(config.ExecutionClient) != config.ExecutionClient_Nethermind {
+		return nil, fmt.Errorf("The primary Execution client is not a locally managed Nethermind client.")
+	}
+
+	// Connect to the admin endpoint
+	adminEndpoint := fmt.Sprintf("http://%s:%d", config.Eth1ContainerName, cfg.Nethermind.AdminPort.Value.(uint16))
+	client, err := rpc.DialContext(context.Background(), adminEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to Nethermind's admin endpoint at %s: %w", adminEndpoint, err)
+	}
+	defer client.Close()
+
+	// Request the prune
+	if err := client.CallContext(context.Background(), &response.PruningStatus, "admin_prune"); err != nil {
+		return nil, fmt.Errorf("Error calling admin_prune on Nethermind's admin endpoint at %s: %w", adminEndpoint, err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
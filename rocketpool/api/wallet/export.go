@@ -41,7 +41,10 @@ func exportWallet(c *cli.Context) (*api.ExportWalletResponse, error) {
 	}
 	response.Wallet = wallet
 
-	// Get account private key
+	// Get account private key, unless it's held on a hardware wallet
+	if w.GetHardwareWalletName() != "" {
+		return &response, nil
+	}
 	privateKey, err := w.GetNodePrivateKeyBytes()
 	if err != nil {
 		return nil, err
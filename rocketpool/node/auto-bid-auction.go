@@ -0,0 +1,279 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/auction"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Auto bid on auction lots task
+type autoBidAuction struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	strategy       rputils.AuctionBidStrategy
+	enabled        bool
+	dryRun         bool
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create auto bid on auction lots task
+func newAutoBidAuction(c *cli.Context, logger log.ColorLogger) (*autoBidAuction, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-bidding is disabled
+	minDiscount := cfg.Smartnode.AutoBidMinDiscount.Value.(float64)
+	lotBudget := cfg.Smartnode.AutoBidLotBudget.Value.(float64)
+	totalBudget := cfg.Smartnode.AutoBidTotalBudget.Value.(float64)
+	gasThreshold := cfg.Smartnode.AutoBidGasThreshold.Value.(float64)
+	enabled := true
+	if minDiscount == 0 {
+		logger.Println("Auto-bid minimum discount is set to 0, automatic auction bids will be disabled.")
+		enabled = false
+	} else if minDiscount < 0 || minDiscount > 100 {
+		logger.Printlnf("WARNING: the auto-bid minimum discount is set to %.2f%%, but it must be between 0 and 100. Automatic auction bids will be disabled.", minDiscount)
+		enabled = false
+	} else if lotBudget <= 0 || totalBudget <= 0 {
+		logger.Println("WARNING: the auto-bid lot and total budgets must both be above 0. Automatic auction bids will be disabled.")
+		enabled = false
+	} else if gasThreshold == 0 {
+		logger.Println("Auto-bid gas threshold is set to 0, automatic auction bids will be disabled.")
+		enabled = false
+	}
+	dryRun := cfg.Smartnode.AutoBidDryRun.Value.(bool)
+	if enabled && dryRun {
+		logger.Println("Auto-bid dry run is enabled, so automatic auction bids and claims will only be logged.")
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &autoBidAuction{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+		strategy: rputils.AuctionBidStrategy{
+			MinDiscount: minDiscount / 100,
+			LotBudget:   eth.EthToWei(lotBudget),
+			TotalBudget: eth.EthToWei(totalBudget),
+		},
+		enabled:        enabled,
+		dryRun:         dryRun,
+		gasThreshold:   gasThreshold,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Claim finished lots, then bid on open lots that are priced far enough below the oracle RPL price
+func (t *autoBidAuction) run() error {
+
+	// Check to see if auto-bidding is disabled
+	if !t.enabled {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking RPL auction lots...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Plan the bids and claims
+	plan, err := rputils.PlanAuctionBids(t.rp, nodeAccount.Address, t.strategy, nil)
+	if err != nil {
+		return fmt.Errorf("Error planning auction bids: %w", err)
+	}
+
+	// Claim finished lots
+	for _, lotIndex := range plan.ClaimableLots {
+		if t.dryRun {
+			t.log.Printlnf("Dry run: would claim RPL from lot %d.", lotIndex)
+			continue
+		}
+		lotIndex := lotIndex
+		t.log.Printlnf("Claiming RPL from lot %d...", lotIndex)
+		submitted, err := t.submit(nil,
+			func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return auction.EstimateClaimBidGas(t.rp, lotIndex, opts)
+			},
+			func(opts *bind.TransactOpts) (common.Hash, error) {
+				return auction.ClaimBid(t.rp, lotIndex, opts)
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if submitted {
+			t.log.Printlnf("Successfully claimed RPL from lot %d.", lotIndex)
+		}
+	}
+
+	// Bid on open lots
+	if !plan.BiddingEnabled {
+		return nil
+	}
+	for _, bid := range plan.Bids {
+		t.log.Printlnf("Lot %d is priced at %.6f ETH per RPL, %.2f%% below the oracle price of %.6f ETH.",
+			bid.LotIndex, eth.WeiToEth(bid.CurrentPrice), bid.Discount*100, eth.WeiToEth(plan.OraclePrice))
+		if t.dryRun {
+			t.log.Printlnf("Dry run: would bid %.6f ETH on lot %d for at least %.6f RPL (%.4f RPL per ETH).",
+				math.RoundDown(eth.WeiToEth(bid.BidAmount), 6), bid.LotIndex, math.RoundDown(eth.WeiToEth(bid.ExpectedRpl), 6), bid.RplPerEth)
+			continue
+		}
+
+		// Check the node has enough ETH
+		balance, err := t.rp.Client.BalanceAt(context.Background(), nodeAccount.Address, nil)
+		if err != nil {
+			return err
+		}
+		if balance.Cmp(bid.BidAmount) < 0 {
+			t.log.Printlnf("WARNING: the node wallet only holds %.6f ETH, which isn't enough to bid %.6f ETH on lot %d.",
+				math.RoundDown(eth.WeiToEth(balance), 6), math.RoundUp(eth.WeiToEth(bid.BidAmount), 6), bid.LotIndex)
+			return nil
+		}
+
+		// Bid
+		lotIndex := bid.LotIndex
+		t.log.Printlnf("Bidding %.6f ETH on lot %d for at least %.6f RPL (%.4f RPL per ETH)...",
+			math.RoundDown(eth.WeiToEth(bid.BidAmount), 6), lotIndex, math.RoundDown(eth.WeiToEth(bid.ExpectedRpl), 6), bid.RplPerEth)
+		submitted, err := t.submit(bid.BidAmount,
+			func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return auction.EstimatePlaceBidGas(t.rp, lotIndex, opts)
+			},
+			func(opts *bind.TransactOpts) (common.Hash, error) {
+				return auction.PlaceBid(t.rp, lotIndex, opts)
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if !submitted {
+			return nil
+		}
+		t.log.Printlnf("Successfully bid on lot %d.", lotIndex)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Submit a transaction once gas is below the threshold, and wait for it to be mined
+// Returns false if the transaction was held back because of the gas price.
+func (t *autoBidAuction) submit(value *big.Int, estimate func(*bind.TransactOpts) (rocketpool.GasInfo, error), send func(*bind.TransactOpts) (common.Hash, error)) (bool, error) {
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+	opts.Value = value
+
+	// Get the gas limit
+	gasInfo, err := estimate(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Check the threshold
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Send the transaction
+	hash, err := send(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be mined
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+
+}
@@ -0,0 +1,327 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Auto stake RPL task
+type autoStakeRpl struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	floor          float64
+	target         float64
+	swapLegacyRpl  bool
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create auto stake RPL task
+func newAutoStakeRpl(c *cli.Context, logger log.ColorLogger) (*autoStakeRpl, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-staking is disabled
+	floor := cfg.Smartnode.AutoStakeRplFloor.Value.(float64)
+	target := cfg.Smartnode.AutoStakeRplTarget.Value.(float64)
+	if floor == 0 {
+		logger.Println("Auto-stake RPL floor is set to 0, automatic RPL stakes will be disabled.")
+	} else if target <= floor {
+		logger.Printlnf("WARNING: the auto-stake RPL target (%.2f%%) is not above the floor (%.2f%%), so automatic RPL stakes would never be made. Automatic RPL stakes will be disabled.", target, floor)
+		floor = 0
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &autoStakeRpl{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		floor:          floor / 100,
+		target:         target / 100,
+		swapLegacyRpl:  cfg.Smartnode.AutoStakeSwapLegacyRpl.Value.(bool),
+		gasThreshold:   cfg.Smartnode.RplClaimGasThreshold.Value.(float64),
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Stake RPL if the node's collateral ratio has dropped below the floor
+func (t *autoStakeRpl) run() error {
+
+	// Check to see if auto-staking is disabled
+	if t.floor == 0 {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking RPL collateral...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check the collateral ratio
+	collateral, err := rputils.GetNodeCollateral(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if collateral.ActiveMinipools == 0 {
+		return nil
+	}
+	ratio := rputils.GetCollateralRatio(collateral.RplStake, collateral.RplPrice, collateral.ActiveMinipools)
+	if ratio >= t.floor {
+		return nil
+	}
+	amount := rputils.GetRplToStakeForCollateralRatio(collateral, t.target)
+	t.log.Printlnf("The node's collateral ratio (%.2f%%) is below the auto-stake floor (%.2f%%); %.6f RPL is needed to reach the target (%.2f%%).",
+		ratio*100, t.floor*100, math.RoundUp(eth.WeiToEth(amount), 6), t.target*100)
+
+	// Get the RPL available to stake
+	rplBalance, err := tokens.GetRPLBalance(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+
+	// Swap legacy RPL to make up any shortfall
+	if rplBalance.Cmp(amount) < 0 && t.swapLegacyRpl {
+		legacyBalance, err := tokens.GetFixedSupplyRPLBalance(t.rp, nodeAccount.Address, nil)
+		if err != nil {
+			return err
+		}
+		swapAmount := new(big.Int).Sub(amount, rplBalance)
+		if legacyBalance.Cmp(swapAmount) < 0 {
+			swapAmount = legacyBalance
+		}
+		if swapAmount.Sign() > 0 {
+			swapped, err := t.swapLegacyRplForRpl(nodeAccount.Address, swapAmount)
+			if err != nil {
+				return err
+			}
+			if !swapped {
+				return nil
+			}
+			rplBalance.Add(rplBalance, swapAmount)
+		}
+	}
+
+	// Stake as much of the required amount as the wallet holds
+	if rplBalance.Sign() == 0 {
+		t.log.Println("WARNING: the node wallet doesn't hold any RPL to stake. Send RPL to the node account to top up its collateral.")
+		return nil
+	}
+	if rplBalance.Cmp(amount) < 0 {
+		t.log.Printlnf("WARNING: the node wallet only holds %.6f RPL, so the node will not reach the target collateral ratio.", math.RoundDown(eth.WeiToEth(rplBalance), 6))
+		amount = rplBalance
+	}
+	return t.stakeRpl(nodeAccount.Address, amount)
+
+}
+
+// Swap legacy RPL for new RPL, approving the swap first if required
+func (t *autoStakeRpl) swapLegacyRplForRpl(nodeAddress common.Address, amount *big.Int) (bool, error) {
+
+	// Check the allowance
+	rocketTokenRPLAddress, err := t.rp.GetAddress("rocketTokenRPL")
+	if err != nil {
+		return false, err
+	}
+	allowance, err := tokens.GetFixedSupplyRPLAllowance(t.rp, nodeAddress, *rocketTokenRPLAddress, nil)
+	if err != nil {
+		return false, err
+	}
+	if allowance.Cmp(amount) < 0 {
+		t.log.Printlnf("Approving %.6f legacy RPL for swapping...", math.RoundUp(eth.WeiToEth(amount), 6))
+		submitted, err := t.submit(
+			func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return tokens.EstimateApproveFixedSupplyRPLGas(t.rp, *rocketTokenRPLAddress, amount, opts)
+			},
+			func(opts *bind.TransactOpts) (common.Hash, error) {
+				return tokens.ApproveFixedSupplyRPL(t.rp, *rocketTokenRPLAddress, amount, opts)
+			},
+		)
+		if err != nil || !submitted {
+			return false, err
+		}
+	}
+
+	// Swap
+	t.log.Printlnf("Swapping %.6f legacy RPL for new RPL...", math.RoundUp(eth.WeiToEth(amount), 6))
+	submitted, err := t.submit(
+		func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return tokens.EstimateSwapFixedSupplyRPLForRPLGas(t.rp, amount, opts)
+		},
+		func(opts *bind.TransactOpts) (common.Hash, error) {
+			return tokens.SwapFixedSupplyRPLForRPL(t.rp, amount, opts)
+		},
+	)
+	if err != nil || !submitted {
+		return false, err
+	}
+	t.log.Println("Successfully swapped legacy RPL.")
+	return true, nil
+
+}
+
+// Stake RPL, approving it for staking first if required
+func (t *autoStakeRpl) stakeRpl(nodeAddress common.Address, amount *big.Int) error {
+
+	// Check the allowance
+	rocketNodeStakingAddress, err := t.rp.GetAddress("rocketNodeStaking")
+	if err != nil {
+		return err
+	}
+	allowance, err := tokens.GetRPLAllowance(t.rp, nodeAddress, *rocketNodeStakingAddress, nil)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) < 0 {
+		t.log.Printlnf("Approving %.6f RPL for staking...", math.RoundUp(eth.WeiToEth(amount), 6))
+		submitted, err := t.submit(
+			func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return tokens.EstimateApproveRPLGas(t.rp, *rocketNodeStakingAddress, amount, opts)
+			},
+			func(opts *bind.TransactOpts) (common.Hash, error) {
+				return tokens.ApproveRPL(t.rp, *rocketNodeStakingAddress, amount, opts)
+			},
+		)
+		if err != nil || !submitted {
+			return err
+		}
+	}
+
+	// Stake
+	t.log.Printlnf("Staking %.6f RPL...", math.RoundUp(eth.WeiToEth(amount), 6))
+	submitted, err := t.submit(
+		func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+			return node.EstimateStakeGas(t.rp, amount, opts)
+		},
+		func(opts *bind.TransactOpts) (common.Hash, error) {
+			return node.StakeRPL(t.rp, amount, opts)
+		},
+	)
+	if err != nil || !submitted {
+		return err
+	}
+	t.log.Printlnf("Successfully staked %.6f RPL.", math.RoundUp(eth.WeiToEth(amount), 6))
+	return nil
+
+}
+
+// Submit a transaction once gas is below the threshold, and wait for it to be mined
+// Returns false if the transaction was held back because of the gas price.
+func (t *autoStakeRpl) submit(estimate func(*bind.TransactOpts) (rocketpool.GasInfo, error), send func(*bind.TransactOpts) (common.Hash, error)) (bool, error) {
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := estimate(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Check the threshold
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Send the transaction
+	hash, err := send(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be mined
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+
+}
@@ -4,12 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
-	"github.com/rocket-pool/smartnode/shared/utils/rp"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -24,21 +21,15 @@ type BeaconCollector struct {
 	// The number of upcoming proposals for this node's validators
 	upcomingProposals *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
-
 	// The beacon client
 	bc beacon.Client
 
-	// The eth1 client
-	ec rocketpool.ExecutionClient
-
-	// The node's address
-	nodeAddress common.Address
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new PerformanceCollector instance
-func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpool.ExecutionClient, nodeAddress common.Address) *BeaconCollector {
+func NewBeaconCollector(bc beacon.Client, sm *SnapshotManager) *BeaconCollector {
 	subsystem := "beacon"
 	return &BeaconCollector{
 		activeSyncCommittee: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_sync_committee"),
@@ -53,10 +44,8 @@ func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpo
 			"The number of proposals assigned to validators in this epoch and the next",
 			nil, nil,
 		),
-		rp:          rp,
-		bc:          bc,
-		ec:          ec,
-		nodeAddress: nodeAddress,
+		bc: bc,
+		sm: sm,
 	}
 }
 
@@ -71,40 +60,20 @@ func (collector *BeaconCollector) Describe(channel chan<- *prometheus.Desc) {
 func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 
 	// Sync
-	var wg errgroup.Group
 	var wg2 errgroup.Group
 
 	activeSyncCommittee := float64(0)
 	upcomingSyncCommittee := float64(0)
 	upcomingProposals := float64(0)
 
-	var validatorIndices []uint64
-	var head beacon.BeaconHead
-
-	// Get sync committee duties
-	wg.Go(func() error {
-		var err error
-		validatorIndices, err = rp.GetNodeValidatorIndices(collector.rp, collector.ec, collector.bc, collector.nodeAddress)
-		if err != nil {
-			return fmt.Errorf("Error getting validator indices: %w", err)
-		}
-		return nil
-	})
-
-	wg.Go(func() error {
-		var err error
-		head, err = collector.bc.GetBeaconHead()
-		if err != nil {
-			return fmt.Errorf("Error getting beaconchain head: %w", err)
-		}
-		return nil
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the node's validators from the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	validatorIndices := snapshot.Node.ValidatorIndices
+	head := snapshot.Node.BeaconHead
 
 	wg2.Go(func() error {
 		// Get current duties
@@ -1,15 +1,10 @@
 package collectors
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/deposit"
-	"github.com/rocket-pool/rocketpool-go/minipool"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"golang.org/x/sync/errgroup"
 )
 
 const namespace = "rocketpool"
@@ -28,12 +23,12 @@ type DemandCollector struct {
 	// The effective ETH capacity of the Minipool queue
 	effectiveMinipoolCapacity *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new DemandCollector instance
-func NewDemandCollector(rp *rocketpool.RocketPool) *DemandCollector {
+func NewDemandCollector(sm *SnapshotManager) *DemandCollector {
 	subsystem := "demand"
 	return &DemandCollector{
 		depositPoolBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposit_pool_balance"),
@@ -52,7 +47,7 @@ func NewDemandCollector(rp *rocketpool.RocketPool) *DemandCollector {
 			"The effective ETH capacity of the Minipool queue",
 			nil, nil,
 		),
-		rp: rp,
+		sm: sm,
 	}
 }
 
@@ -67,52 +62,18 @@ func (collector *DemandCollector) Describe(channel chan<- *prometheus.Desc) {
 // Collect the latest metric values and pass them to Prometheus
 func (collector *DemandCollector) Collect(channel chan<- prometheus.Metric) {
 
-	// Sync
-	var wg errgroup.Group
-	balanceFloat := float64(-1)
-	excessFloat := float64(-1)
-	totalFloat := float64(-1)
-	effectiveFloat := float64(-1)
-
-	// Get the Deposit Pool balance
-	wg.Go(func() error {
-		depositPoolBalance, err := deposit.GetBalance(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting deposit pool balance: %w", err)
-		} else {
-			balanceFloat = eth.WeiToEth(depositPoolBalance)
-		}
-		return nil
-	})
-
-	// Get the deposit pool excess
-	wg.Go(func() error {
-		depositPoolExcess, err := deposit.GetExcessBalance(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting deposit pool excess: %w", err)
-		} else {
-			excessFloat = eth.WeiToEth(depositPoolExcess)
-		}
-		return nil
-	})
-
-	// Get the total and effective minipool capacities
-	wg.Go(func() error {
-		minipoolQueueCapacity, err := minipool.GetQueueCapacity(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting minipool queue capacity: %w", err)
-		} else {
-			totalFloat = eth.WeiToEth(minipoolQueueCapacity.Total)
-			effectiveFloat = eth.WeiToEth(minipoolQueueCapacity.Effective)
-		}
-		return nil
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	state := snapshot.Network
+
+	balanceFloat := eth.WeiToEth(state.DepositPoolBalance)
+	excessFloat := eth.WeiToEth(state.DepositPoolExcess)
+	totalFloat := eth.WeiToEth(state.MinipoolQueueCapacity.Total)
+	effectiveFloat := eth.WeiToEth(state.MinipoolQueueCapacity.Effective)
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.depositPoolBalance, prometheus.GaugeValue, balanceFloat)
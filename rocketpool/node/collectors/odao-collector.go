@@ -1,14 +1,9 @@
 package collectors
 
 import (
-	"context"
-	"fmt"
 	"log"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/network"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"golang.org/x/sync/errgroup"
 )
 
 // Represents the collector for the ODAO metrics
@@ -25,12 +20,12 @@ type OdaoCollector struct {
 	// The latest ETH1 block where network prices were reportable by the ODAO
 	latestReportableBlock *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new DemandCollector instance
-func NewOdaoCollector(rp *rocketpool.RocketPool) *OdaoCollector {
+func NewOdaoCollector(sm *SnapshotManager) *OdaoCollector {
 	subsystem := "odao"
 	return &OdaoCollector{
 		currentEth1Block: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "current_eth1_block"),
@@ -49,7 +44,7 @@ func NewOdaoCollector(rp *rocketpool.RocketPool) *OdaoCollector {
 			"The latest ETH1 block where network prices were reportable by the ODAO",
 			nil, nil,
 		),
-		rp: rp,
+		sm: sm,
 	}
 }
 
@@ -64,52 +59,18 @@ func (collector *OdaoCollector) Describe(channel chan<- *prometheus.Desc) {
 // Collect the latest metric values and pass them to Prometheus
 func (collector *OdaoCollector) Collect(channel chan<- prometheus.Metric) {
 
-	// Sync
-	var wg errgroup.Group
-	blockNumberFloat := float64(-1)
-	pricesBlockFloat := float64(-1)
-	effectiveRplStakeBlockFloat := float64(-1)
-	latestReportableBlockFloat := float64(-1)
-
-	// Get the latest block reported by the ETH1 client
-	wg.Go(func() error {
-		blockNumber, err := collector.rp.Client.BlockNumber(context.Background())
-		if err != nil {
-			return fmt.Errorf("Error getting latest ETH1 block: %w", err)
-		} else {
-			blockNumberFloat = float64(blockNumber)
-		}
-		return nil
-	})
-
-	// Get ETH1 block that was used when reporting the latest prices
-	wg.Go(func() error {
-		pricesBlock, err := network.GetPricesBlock(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting ETH1 prices block: %w", err)
-		} else {
-			pricesBlockFloat = float64(pricesBlock)
-			effectiveRplStakeBlockFloat = float64(pricesBlock)
-		}
-		return nil
-	})
-
-	// Get the latest ETH1 block where network prices were reportable by the ODAO
-	wg.Go(func() error {
-		latestReportableBlock, err := network.GetLatestReportablePricesBlock(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting ETH1 latest reportable block: %w", err)
-		} else {
-			latestReportableBlockFloat = float64(latestReportableBlock.Uint64())
-		}
-		return nil
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	state := snapshot.Network
+
+	blockNumberFloat := float64(snapshot.BlockNumber)
+	pricesBlockFloat := float64(state.PricesBlock)
+	effectiveRplStakeBlockFloat := float64(state.PricesBlock)
+	latestReportableBlockFloat := float64(state.LatestReportablePricesBlock.Uint64())
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.currentEth1Block, prometheus.GaugeValue, blockNumberFloat)
@@ -1,16 +1,10 @@
 package collectors
 
 import (
-	"context"
-	"fmt"
 	"log"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/network"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"golang.org/x/sync/errgroup"
 )
 
 // Represents the collector for the Performance metrics
@@ -33,12 +27,12 @@ type PerformanceCollector struct {
 	// The ETH balance of the rETH contract address
 	rethContractBalance *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new PerformanceCollector instance
-func NewPerformanceCollector(rp *rocketpool.RocketPool) *PerformanceCollector {
+func NewPerformanceCollector(sm *SnapshotManager) *PerformanceCollector {
 	subsystem := "performance"
 	return &PerformanceCollector{
 		ethUtilizationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eth_utilization_rate"),
@@ -65,7 +59,7 @@ func NewPerformanceCollector(rp *rocketpool.RocketPool) *PerformanceCollector {
 			"The total rETH supply",
 			nil, nil,
 		),
-		rp: rp,
+		sm: sm,
 	}
 }
 
@@ -82,91 +76,20 @@ func (collector *PerformanceCollector) Describe(channel chan<- *prometheus.Desc)
 // Collect the latest metric values and pass them to Prometheus
 func (collector *PerformanceCollector) Collect(channel chan<- prometheus.Metric) {
 
-	// Sync
-	var wg errgroup.Group
-	ethUtilizationRate := float64(-1)
-	balanceFloat := float64(-1)
-	exchangeRate := float64(-1)
-	tvlFloat := float64(-1)
-	rETHBalance := float64(-1)
-	rethFloat := float64(-1)
-
-	// Get the ETH utilization rate
-	wg.Go(func() error {
-		_ethUtilizationRate, err := network.GetETHUtilizationRate(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting ETH utilization rate: %w", err)
-		} else {
-			ethUtilizationRate = _ethUtilizationRate
-		}
-		return nil
-	})
-
-	// Get the total ETH staking balance
-	wg.Go(func() error {
-		totalStakingBalance, err := network.GetStakingETHBalance(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total ETH staking balance: %w", err)
-		} else {
-			balanceFloat = eth.WeiToEth(totalStakingBalance)
-		}
-		return nil
-	})
-
-	// Get the ETH-rETH exchange rate
-	wg.Go(func() error {
-		_exchangeRate, err := tokens.GetRETHExchangeRate(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting ETH-rETH exchange rate: %w", err)
-		} else {
-			exchangeRate = _exchangeRate
-		}
-		return nil
-	})
-
-	// Get the total ETH balance (TVL)
-	wg.Go(func() error {
-		tvl, err := network.GetTotalETHBalance(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total ETH balance (TVL): %w", err)
-		} else {
-			tvlFloat = eth.WeiToEth(tvl)
-		}
-		return nil
-	})
-
-	// Get the ETH balance of the rETH contract
-	wg.Go(func() error {
-		rETHContract, err := collector.rp.GetContract("rocketTokenRETH")
-		if err != nil {
-			return fmt.Errorf("Error getting ETH balance of rETH staking contract: %w", err)
-		} else {
-			balance, err := collector.rp.Client.BalanceAt(context.Background(), *rETHContract.Address, nil)
-			if err != nil {
-				return fmt.Errorf("Error getting ETH balance of rETH staking contract: %w", err)
-			} else {
-				rETHBalance = eth.WeiToEth(balance)
-			}
-		}
-		return nil
-	})
-
-	// Get the total rETH supply
-	wg.Go(func() error {
-		totalRethSupply, err := tokens.GetRETHTotalSupply(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total rETH supply: %w", err)
-		} else {
-			rethFloat = eth.WeiToEth(totalRethSupply)
-		}
-		return nil
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	state := snapshot.Network
+
+	ethUtilizationRate := state.EthUtilizationRate
+	balanceFloat := eth.WeiToEth(state.StakingEthBalance)
+	exchangeRate := state.RethExchangeRate
+	tvlFloat := eth.WeiToEth(state.TotalEthBalance)
+	rETHBalance := eth.WeiToEth(state.RethContractBalance)
+	rethFloat := eth.WeiToEth(state.RethTotalSupply)
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.ethUtilizationRate, prometheus.GaugeValue, ethUtilizationRate)
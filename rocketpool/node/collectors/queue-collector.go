@@ -0,0 +1,90 @@
+package collectors
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Represents the collector for the minipool queue metrics
+type QueueCollector struct {
+	// The average amount of ETH deposited into the Deposit Pool per day
+	depositInflowRate *prometheus.Desc
+
+	// The position of each of the node's queued minipools across all of the queues
+	minipoolPosition *prometheus.Desc
+
+	// The amount of user ETH that will be assigned to minipools ahead of each of the node's queued minipools
+	minipoolEthAhead *prometheus.Desc
+
+	// The estimated number of seconds until each of the node's queued minipools is assigned user ETH
+	minipoolEta *prometheus.Desc
+
+	// The state snapshot manager
+	sm *SnapshotManager
+}
+
+// Create a new QueueCollector instance
+func NewQueueCollector(sm *SnapshotManager) *QueueCollector {
+	subsystem := "queue"
+	return &QueueCollector{
+		depositInflowRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposit_inflow_rate"),
+			"The average amount of ETH deposited into the Deposit Pool per day",
+			nil, nil,
+		),
+		minipoolPosition: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_position"),
+			"The position of each of the node's queued minipools across all of the queues",
+			[]string{"minipool", "depositType"}, nil,
+		),
+		minipoolEthAhead: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_eth_ahead"),
+			"The amount of user ETH that will be assigned to minipools ahead of each of the node's queued minipools",
+			[]string{"minipool", "depositType"}, nil,
+		),
+		minipoolEta: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_eta_seconds"),
+			"The estimated number of seconds until each of the node's queued minipools is assigned user ETH",
+			[]string{"minipool", "depositType"}, nil,
+		),
+		sm: sm,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *QueueCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.depositInflowRate
+	channel <- collector.minipoolPosition
+	channel <- collector.minipoolEthAhead
+	channel <- collector.minipoolEta
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *QueueCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
+		log.Printf("%s\n", err.Error())
+		return
+	}
+
+	if snapshot.Network.DepositInflowRate != nil {
+		inflowPerDay := eth.WeiToEth(snapshot.Network.DepositInflowRate) * (24 * 60 * 60)
+		channel <- prometheus.MustNewConstMetric(
+			collector.depositInflowRate, prometheus.GaugeValue, inflowPerDay)
+	}
+
+	// The ETA is left out for minipools it can't be estimated for
+	for address, position := range snapshot.Node.QueuePositions {
+		minipool := address.Hex()
+		depositType := position.DepositType.String()
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolPosition, prometheus.GaugeValue, float64(position.Position), minipool, depositType)
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolEthAhead, prometheus.GaugeValue, eth.WeiToEth(position.EthAhead), minipool, depositType)
+		if position.EtaKnown {
+			channel <- prometheus.MustNewConstMetric(
+				collector.minipoolEta, prometheus.GaugeValue, position.Eta.Seconds(), minipool, depositType)
+		}
+	}
+
+}
@@ -1,17 +1,10 @@
 package collectors
 
 import (
-	"fmt"
 	"log"
-	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/network"
-	"github.com/rocket-pool/rocketpool-go/node"
-	"github.com/rocket-pool/rocketpool-go/rewards"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"golang.org/x/sync/errgroup"
 )
 
 // Represents the collector for the RPL metrics
@@ -28,12 +21,12 @@ type RplCollector struct {
 	// The date and time of the next RPL rewards checkpoint
 	checkpointTime *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new DemandCollector instance
-func NewRplCollector(rp *rocketpool.RocketPool) *RplCollector {
+func NewRplCollector(sm *SnapshotManager) *RplCollector {
 	subsystem := "rpl"
 	return &RplCollector{
 		rplPrice: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_price"),
@@ -52,7 +45,7 @@ func NewRplCollector(rp *rocketpool.RocketPool) *RplCollector {
 			"The date and time of the next RPL rewards checkpoint",
 			nil, nil,
 		),
-		rp: rp,
+		sm: sm,
 	}
 }
 
@@ -67,74 +60,19 @@ func (collector *RplCollector) Describe(channel chan<- *prometheus.Desc) {
 // Collect the latest metric values and pass them to Prometheus
 func (collector *RplCollector) Collect(channel chan<- prometheus.Metric) {
 
-	// Sync
-	var wg errgroup.Group
-	rplPriceFloat := float64(-1)
-	totalValueStakedFloat := float64(-1)
-	totalEffectiveStakedFloat := float64(-1)
-	var lastCheckpoint time.Time
-	var rewardsInterval time.Duration
-
-	// Get the RPL price (in terms of ETH)
-	wg.Go(func() error {
-		rplPrice, err := network.GetRPLPrice(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting RPL price: %w", err)
-		} else {
-			rplPriceFloat = eth.WeiToEth(rplPrice)
-		}
-		return nil
-	})
-
-	// Get the total amount of RPL staked on the network
-	wg.Go(func() error {
-		totalValueStaked, err := node.GetTotalRPLStake(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total amount of RPL staked on the network: %w", err)
-		} else {
-			totalValueStakedFloat = eth.WeiToEth(totalValueStaked)
-		}
-		return nil
-	})
-
-	// Get the total effective amount of RPL staked on the network
-	wg.Go(func() error {
-		totalEffectiveStaked, err := node.GetTotalEffectiveRPLStake(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total effective amount of RPL staked on the network: %w", err)
-		} else {
-			totalEffectiveStakedFloat = eth.WeiToEth(totalEffectiveStaked)
-		}
-		return nil
-	})
-
-	// Get the start of the rewards checkpoint
-	wg.Go(func() error {
-		_lastCheckpoint, err := rewards.GetClaimIntervalTimeStart(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting the previous rewards claim time: %w", err)
-		} else {
-			lastCheckpoint = _lastCheckpoint
-		}
-		return err
-	})
-
-	// Get the rewards checkpoint interval
-	wg.Go(func() error {
-		_rewardsInterval, err := rewards.GetClaimIntervalTime(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting the rewards interval: %w", err)
-		} else {
-			rewardsInterval = _rewardsInterval
-		}
-		return err
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	state := snapshot.Network
+
+	rplPriceFloat := eth.WeiToEth(state.RplPrice)
+	totalValueStakedFloat := eth.WeiToEth(state.TotalRplStake)
+	totalEffectiveStakedFloat := eth.WeiToEth(state.TotalEffectiveRplStake)
+	lastCheckpoint := state.ClaimIntervalTimeStart
+	rewardsInterval := state.ClaimIntervalTime
 
 	nextRewardsTime := float64(lastCheckpoint.Add(rewardsInterval).Unix()) * 1000
 
@@ -0,0 +1,81 @@
+package collectors
+
+import (
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the state snapshot metrics
+type SnapshotCollector struct {
+	// The number of seconds since the state snapshot was loaded
+	age *prometheus.Desc
+
+	// The number of seconds it took to load the state snapshot
+	refreshDuration *prometheus.Desc
+
+	// The block the state snapshot was loaded at
+	block *prometheus.Desc
+
+	// Whether each field of the state snapshot is carried over from an earlier block
+	staleField *prometheus.Desc
+
+	// The state snapshot manager
+	sm *SnapshotManager
+}
+
+// Create a new SnapshotCollector instance
+func NewSnapshotCollector(sm *SnapshotManager) *SnapshotCollector {
+	subsystem := "snapshot"
+	return &SnapshotCollector{
+		age: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "age_seconds"),
+			"The number of seconds since the state snapshot was loaded",
+			nil, nil,
+		),
+		refreshDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "refresh_duration_seconds"),
+			"The number of seconds it took to load the state snapshot",
+			nil, nil,
+		),
+		block: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "block"),
+			"The block the state snapshot was loaded at",
+			nil, nil,
+		),
+		staleField: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "stale_field"),
+			"A field of the state snapshot that couldn't be loaded at its block, and has its value from an earlier one",
+			[]string{"field"}, nil,
+		),
+		sm: sm,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SnapshotCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.age
+	channel <- collector.refreshDuration
+	channel <- collector.block
+	channel <- collector.staleField
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SnapshotCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
+		log.Printf("%s\n", err.Error())
+		return
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.age, prometheus.GaugeValue, time.Since(snapshot.RefreshTime).Seconds())
+	channel <- prometheus.MustNewConstMetric(
+		collector.refreshDuration, prometheus.GaugeValue, snapshot.RefreshDuration.Seconds())
+	channel <- prometheus.MustNewConstMetric(
+		collector.block, prometheus.GaugeValue, float64(snapshot.BlockNumber))
+	for _, field := range snapshot.StaleFields {
+		channel <- prometheus.MustNewConstMetric(
+			collector.staleField, prometheus.GaugeValue, 1, field)
+	}
+
+}
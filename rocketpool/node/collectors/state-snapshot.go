@@ -0,0 +1,730 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/indexer"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/multicall"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	// How often to check the execution client for a new block
+	SnapshotPollInterval = 5 * time.Second
+
+	// How long a snapshot can be served for before it's refreshed, even if there hasn't been a new block
+	SnapshotMaxAge = time.Minute
+
+	// How often to refresh the oDAO participation data, which is slow to load and changes infrequently
+	ParticipationRefreshInterval = time.Minute
+
+	// How often to refresh the deposit inflow rate, which scans a week of deposit events
+	DepositInflowRefreshInterval = 10 * time.Minute
+)
+
+// Network-wide Rocket Pool state
+type NetworkState struct {
+	// Deposit pool and minipool queue
+	DepositPoolBalance    *big.Int
+	DepositPoolExcess     *big.Int
+	MinipoolQueueCapacity minipool.QueueCapacity
+	DepositInflowRate     *big.Int
+
+	// Staking performance
+	EthUtilizationRate  float64
+	StakingEthBalance   *big.Int
+	TotalEthBalance     *big.Int
+	RethExchangeRate    float64
+	RethContractBalance *big.Int
+	RethTotalSupply     *big.Int
+
+	// Nodes and minipools
+	NodeCount              uint64
+	NodeFee                float64
+	MinipoolCounts         minipool.MinipoolCountsPerStatus
+	FinalisedMinipoolCount uint64
+
+	// RPL and rewards
+	RplPrice                   *big.Int
+	TotalRplStake              *big.Int
+	TotalEffectiveRplStake     *big.Int
+	RplTotalSupply             *big.Int
+	RplInflationIntervalRate   *big.Int
+	ClaimIntervalTimeStart     time.Time
+	ClaimIntervalTime          time.Duration
+	NodeOperatorRewardsPercent float64
+
+	// Network price reporting
+	PricesBlock                 uint64
+	LatestReportablePricesBlock *big.Int
+}
+
+// The state of the local node
+type NodeState struct {
+	RplStake             *big.Int
+	EffectiveRplStake    *big.Int
+	Balances             tokens.Balances
+	ActiveMinipoolCount  uint64
+	MinipoolAddresses    []common.Address
+	UnclaimedRewards     *big.Int
+	CumulativeRplRewards *big.Int
+
+	// Queue positions of the node's minipools that are waiting for user ETH
+	QueuePositions map[common.Address]apitypes.MinipoolQueuePosition
+
+	// Beacon chain state of the node's minipools
+	BeaconHead       beacon.BeaconHead
+	ValidatorIndices []uint64
+	DepositBalance   *big.Int
+	NodeShare        *big.Int
+	BeaconBalance    *big.Int
+}
+
+// The state of the oracle DAO
+type TrustedNodeState struct {
+	Proposals             []dao.ProposalDetails
+	Members               []trustednode.MemberDetails
+	MemberBalances        map[common.Address]*big.Int
+	BalancesParticipation map[common.Address]bool
+	PricesParticipation   map[common.Address]bool
+}
+
+// A snapshot of all of the state used by the metrics collectors, loaded at a single block
+// Fields that can't be loaded at the block keep their values from the previous snapshot, and are listed in StaleFields.
+type StateSnapshot struct {
+	BlockNumber     uint64
+	BlockTime       time.Time
+	RefreshTime     time.Time
+	RefreshDuration time.Duration
+	StaleFields     []string
+	Network         NetworkState
+	Node            NodeState
+	TrustedNode     TrustedNodeState
+}
+
+// Loads state snapshots in the background and serves them to the collectors
+type SnapshotManager struct {
+	rp               *rocketpool.RocketPool
+	bc               beacon.Client
+	cfg              *config.RocketPoolConfig
+	mc               *multicall.MultiCaller
+	nodeAddress      common.Address
+	eventLogInterval *big.Int
+	log              log.ColorLogger
+
+	// The latest snapshot
+	snapshot *StateSnapshot
+	lock     sync.RWMutex
+
+	// The event index used for the lifetime RPL rewards, created on the first refresh
+	idx *indexer.Indexer
+
+	// The oDAO participation data is refreshed less often than the rest of the snapshot
+	balancesParticipation map[common.Address]bool
+	pricesParticipation   map[common.Address]bool
+	participationTime     time.Time
+
+	// The deposit inflow rate is refreshed less often than the rest of the snapshot
+	depositInflowRate *big.Int
+	depositInflowTime time.Time
+}
+
+// A snapshot field read through the multicall batch; every one of them is a uint256
+type batchedField struct {
+	name     string
+	contract string
+	method   string
+	args     []interface{}
+	set      func(value *big.Int)
+}
+
+// The fields of a snapshot that couldn't be loaded, and why
+type snapshotFailures struct {
+	errors map[string]error
+	lock   sync.Mutex
+}
+
+// Record a field that couldn't be loaded
+func (f *snapshotFailures) add(name string, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.errors[name] = err
+}
+
+// Check if any of the given fields couldn't be loaded
+func (f *snapshotFailures) any(names ...string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, name := range names {
+		if _, exists := f.errors[name]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// Create a new SnapshotManager instance
+func NewSnapshotManager(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, logger log.ColorLogger) (*SnapshotManager, error) {
+
+	// Get the event log interval
+	eventLogInterval, err := api.GetEventLogInterval(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting event log interval: %w", err)
+	}
+
+	// Create the multicaller
+	mc, err := multicall.NewMultiCaller(rp.Client, common.HexToAddress(cfg.Smartnode.GetMulticallAddress()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotManager{
+		rp:               rp,
+		bc:               bc,
+		cfg:              cfg,
+		mc:               mc,
+		nodeAddress:      nodeAddress,
+		eventLogInterval: eventLogInterval,
+		log:              logger,
+	}, nil
+
+}
+
+// Refresh the snapshot whenever there's a new block or the current one gets too old
+func (m *SnapshotManager) Run() {
+	for {
+		if err := m.refreshIfRequired(); err != nil {
+			m.log.Printlnf("Error refreshing state snapshot: %s", err.Error())
+		}
+		time.Sleep(SnapshotPollInterval)
+	}
+}
+
+// Get the latest snapshot
+func (m *SnapshotManager) GetSnapshot() (*StateSnapshot, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if m.snapshot == nil {
+		return nil, fmt.Errorf("The state snapshot has not been loaded yet")
+	}
+	return m.snapshot, nil
+}
+
+// Refresh the snapshot if there's a new block or the current one is too old
+func (m *SnapshotManager) refreshIfRequired() error {
+
+	// Get the latest block
+	header, err := m.rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("Error getting latest block header: %w", err)
+	}
+
+	// Check if the current snapshot is still up to date
+	m.lock.RLock()
+	current := m.snapshot
+	m.lock.RUnlock()
+	if current != nil && current.BlockNumber == header.Number.Uint64() && time.Since(current.RefreshTime) < SnapshotMaxAge {
+		return nil
+	}
+
+	// Load a new snapshot
+	snapshot, err := m.loadSnapshot(current, header.Number, time.Unix(int64(header.Time), 0))
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	m.snapshot = snapshot
+	m.lock.Unlock()
+	return nil
+
+}
+
+// Load a snapshot of the network and node state at the given block
+// The snapshot starts as a copy of the previous one, and each field is only replaced once it's been loaded, so a field that can't be
+// loaded keeps its previous value instead of dropping the whole snapshot. The first snapshot has nothing to fall back on, so it fails instead.
+func (m *SnapshotManager) loadSnapshot(previous *StateSnapshot, blockNumber *big.Int, blockTime time.Time) (*StateSnapshot, error) {
+
+	start := time.Now()
+	opts := &bind.CallOpts{BlockNumber: blockNumber}
+	snapshot := &StateSnapshot{}
+	if previous != nil {
+		*snapshot = *previous
+	}
+	snapshot.BlockNumber = blockNumber.Uint64()
+	snapshot.BlockTime = blockTime
+	failures := &snapshotFailures{errors: map[string]error{}}
+
+	// Load everything that only depends on the block at once
+	var wg sync.WaitGroup
+	m.loadNetworkState(&wg, failures, &snapshot.Network, opts)
+	m.loadNodeState(&wg, failures, &snapshot.Node, opts)
+	m.loadTrustedNodeState(&wg, failures, &snapshot.TrustedNode, opts)
+
+	// Read the simple contract values in a single batch
+	m.loadBatchedFields(failures, snapshot, opts)
+
+	// Index the RPL rewards claimed since the last snapshot
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rplRewards, err := m.getCumulativeRplRewards(blockNumber)
+		if err != nil {
+			failures.add("cumulative RPL rewards", err)
+			return
+		}
+		snapshot.Node.CumulativeRplRewards = rplRewards
+	}()
+
+	// Wait for data
+	wg.Wait()
+
+	// Load the state that depends on the results above
+	if !failures.any("node minipool addresses", "beacon chain head") {
+		m.loadBeaconState(failures, &snapshot.Node, opts)
+	} else {
+		failures.add("beacon balances", fmt.Errorf("The node's minipools or the beacon chain head couldn't be loaded"))
+		failures.add("validator indices", fmt.Errorf("The node's minipools or the beacon chain head couldn't be loaded"))
+	}
+	if !failures.any("oDAO members") {
+		m.loadMemberBalances(failures, &snapshot.TrustedNode, opts)
+	} else {
+		failures.add("oDAO member balances", fmt.Errorf("The members couldn't be loaded"))
+	}
+
+	// Only refresh the oDAO participation periodically
+	if time.Since(m.participationTime) > ParticipationRefreshInterval {
+		if err := m.loadParticipation(opts); err != nil {
+			failures.add("oDAO participation", err)
+		} else {
+			m.participationTime = time.Now()
+		}
+	}
+	snapshot.TrustedNode.BalancesParticipation = m.balancesParticipation
+	snapshot.TrustedNode.PricesParticipation = m.pricesParticipation
+
+	// Only refresh the deposit inflow rate periodically
+	if time.Since(m.depositInflowTime) > DepositInflowRefreshInterval {
+		rate, err := rputils.GetDepositInflowRate(m.rp, m.eventLogInterval, opts)
+		if err != nil {
+			failures.add("deposit inflow rate", fmt.Errorf("Error getting deposit inflow rate: %w", err))
+		} else {
+			m.depositInflowRate = rate
+			m.depositInflowTime = time.Now()
+		}
+	}
+	snapshot.Network.DepositInflowRate = m.depositInflowRate
+	if !failures.any("node minipool addresses", "deposit inflow rate") {
+		if err := m.loadQueuePositions(&snapshot.Node, snapshot.Network.DepositInflowRate, opts); err != nil {
+			failures.add("queue positions", err)
+		}
+	} else {
+		failures.add("queue positions", fmt.Errorf("The node's minipools or the deposit inflow rate couldn't be loaded"))
+	}
+
+	// Handle the fields that couldn't be loaded
+	snapshot.StaleFields = make([]string, 0, len(failures.errors))
+	for name := range failures.errors {
+		snapshot.StaleFields = append(snapshot.StaleFields, name)
+	}
+	sort.Strings(snapshot.StaleFields)
+	if previous == nil && len(snapshot.StaleFields) > 0 {
+		return nil, failures.errors[snapshot.StaleFields[0]]
+	}
+	for _, name := range snapshot.StaleFields {
+		m.log.Printlnf("WARNING: couldn't load %s for block %d, keeping its previous value: %s", name, snapshot.BlockNumber, failures.errors[name].Error())
+	}
+
+	// Return
+	snapshot.RefreshTime = time.Now()
+	snapshot.RefreshDuration = time.Since(start)
+	return snapshot, nil
+
+}
+
+// Read the snapshot fields that are simple contract values through a single multicall batch
+func (m *SnapshotManager) loadBatchedFields(failures *snapshotFailures, snapshot *StateSnapshot, opts *bind.CallOpts) {
+
+	network := &snapshot.Network
+	node := &snapshot.Node
+	fields := []batchedField{
+		// Deposit pool and minipool queue
+		{"deposit pool balance", "rocketDepositPool", "getBalance", nil, func(value *big.Int) { network.DepositPoolBalance = value }},
+		{"deposit pool excess", "rocketDepositPool", "getExcessBalance", nil, func(value *big.Int) { network.DepositPoolExcess = value }},
+		{"minipool queue total capacity", "rocketMinipoolQueue", "getTotalCapacity", nil, func(value *big.Int) { network.MinipoolQueueCapacity.Total = value }},
+		{"minipool queue effective capacity", "rocketMinipoolQueue", "getEffectiveCapacity", nil, func(value *big.Int) { network.MinipoolQueueCapacity.Effective = value }},
+		{"minipool queue next capacity", "rocketMinipoolQueue", "getNextCapacity", nil, func(value *big.Int) { network.MinipoolQueueCapacity.NextMinipool = value }},
+
+		// Staking performance
+		{"ETH utilization rate", "rocketNetworkBalances", "getETHUtilizationRate", nil, func(value *big.Int) { network.EthUtilizationRate = eth.WeiToEth(value) }},
+		{"total ETH staking balance", "rocketNetworkBalances", "getStakingETHBalance", nil, func(value *big.Int) { network.StakingEthBalance = value }},
+		{"total ETH balance", "rocketNetworkBalances", "getTotalETHBalance", nil, func(value *big.Int) { network.TotalEthBalance = value }},
+		{"ETH-rETH exchange rate", "rocketTokenRETH", "getExchangeRate", nil, func(value *big.Int) { network.RethExchangeRate = eth.WeiToEth(value) }},
+		{"rETH supply", "rocketTokenRETH", "totalSupply", nil, func(value *big.Int) { network.RethTotalSupply = value }},
+
+		// Nodes and minipools
+		{"node count", "rocketNodeManager", "getNodeCount", nil, func(value *big.Int) { network.NodeCount = value.Uint64() }},
+		{"node fee", "rocketNetworkFees", "getNodeFee", nil, func(value *big.Int) { network.NodeFee = eth.WeiToEth(value) }},
+		{"finalised minipool count", "rocketMinipoolManager", "getFinalisedMinipoolCount", nil, func(value *big.Int) { network.FinalisedMinipoolCount = value.Uint64() }},
+
+		// RPL and rewards
+		{"RPL price", "rocketNetworkPrices", "getRPLPrice", nil, func(value *big.Int) { network.RplPrice = value }},
+		{"total RPL stake", "rocketNodeStaking", "getTotalRPLStake", nil, func(value *big.Int) { network.TotalRplStake = value }},
+		{"total effective RPL stake", "rocketNodeStaking", "getTotalEffectiveRPLStake", nil, func(value *big.Int) { network.TotalEffectiveRplStake = value }},
+		{"RPL supply", "rocketTokenRPL", "totalSupply", nil, func(value *big.Int) { network.RplTotalSupply = value }},
+		{"RPL inflation interval rate", "rocketTokenRPL", "getInflationIntervalRate", nil, func(value *big.Int) { network.RplInflationIntervalRate = value }},
+		{"rewards claim interval start", "rocketRewardsPool", "getClaimIntervalTimeStart", nil, func(value *big.Int) { network.ClaimIntervalTimeStart = time.Unix(int64(value.Uint64()), 0) }},
+		{"rewards claim interval", "rocketRewardsPool", "getClaimIntervalTime", nil, func(value *big.Int) { network.ClaimIntervalTime = time.Duration(value.Int64()) * time.Second }},
+		{"node operator rewards percent", "rocketRewardsPool", "getClaimingContractPerc", []interface{}{"rocketClaimNode"}, func(value *big.Int) { network.NodeOperatorRewardsPercent = eth.WeiToEth(value) }},
+
+		// Network price reporting
+		{"prices block", "rocketNetworkPrices", "getPricesBlock", nil, func(value *big.Int) { network.PricesBlock = value.Uint64() }},
+		{"latest reportable prices block", "rocketNetworkPrices", "getLatestReportableBlock", nil, func(value *big.Int) { network.LatestReportablePricesBlock = value }},
+
+		// The local node
+		{"node RPL stake", "rocketNodeStaking", "getNodeRPLStake", []interface{}{m.nodeAddress}, func(value *big.Int) { node.RplStake = value }},
+		{"node effective RPL stake", "rocketNodeStaking", "getNodeEffectiveRPLStake", []interface{}{m.nodeAddress}, func(value *big.Int) { node.EffectiveRplStake = value }},
+		{"node rETH balance", "rocketTokenRETH", "balanceOf", []interface{}{m.nodeAddress}, func(value *big.Int) { node.Balances.RETH = value }},
+		{"node RPL balance", "rocketTokenRPL", "balanceOf", []interface{}{m.nodeAddress}, func(value *big.Int) { node.Balances.RPL = value }},
+		{"node fixed-supply RPL balance", "rocketTokenRPLFixedSupply", "balanceOf", []interface{}{m.nodeAddress}, func(value *big.Int) { node.Balances.FixedSupplyRPL = value }},
+		{"node active minipool count", "rocketMinipoolManager", "getNodeActiveMinipoolCount", []interface{}{m.nodeAddress}, func(value *big.Int) { node.ActiveMinipoolCount = value.Uint64() }},
+		{"node unclaimed RPL rewards", "rocketClaimNode", "getClaimRewardsAmount", []interface{}{m.nodeAddress}, func(value *big.Int) { node.UnclaimedRewards = value }},
+	}
+
+	// Queue the calls
+	values := make([]*big.Int, len(fields))
+	queued := []int{}
+	for i, field := range fields {
+		contract, err := m.rp.GetContract(field.contract)
+		if err != nil {
+			failures.add(field.name, fmt.Errorf("Error getting %s contract: %w", field.contract, err))
+			continue
+		}
+		if err := m.mc.AddCall(contract, &values[i], field.name, field.method, field.args...); err != nil {
+			failures.add(field.name, err)
+			continue
+		}
+		queued = append(queued, i)
+	}
+
+	// Queue the ETH balances
+	var rethContractBalance *big.Int
+	var nodeEthBalance *big.Int
+	rethContract, err := m.rp.GetContract("rocketTokenRETH")
+	if err != nil {
+		failures.add("rETH contract balance", fmt.Errorf("Error getting rETH contract: %w", err))
+	} else if err := m.mc.AddEthBalance(*rethContract.Address, &rethContractBalance, "rETH contract balance"); err != nil {
+		failures.add("rETH contract balance", err)
+	}
+	if err := m.mc.AddEthBalance(m.nodeAddress, &nodeEthBalance, "node ETH balance"); err != nil {
+		failures.add("node ETH balance", err)
+	}
+
+	// Run the batch
+	errs, err := m.mc.Execute(opts)
+	if err != nil {
+		for _, i := range queued {
+			failures.add(fields[i].name, err)
+		}
+		failures.add("rETH contract balance", err)
+		failures.add("node ETH balance", err)
+		return
+	}
+	for _, i := range queued {
+		if err := errs[fields[i].name]; err != nil {
+			failures.add(fields[i].name, err)
+			continue
+		}
+		fields[i].set(values[i])
+	}
+	if err := errs["rETH contract balance"]; err != nil {
+		failures.add("rETH contract balance", err)
+	} else if rethContractBalance != nil {
+		snapshot.Network.RethContractBalance = rethContractBalance
+	}
+	if err := errs["node ETH balance"]; err != nil {
+		failures.add("node ETH balance", err)
+	} else {
+		snapshot.Node.Balances.ETH = nodeEthBalance
+	}
+
+}
+
+// Load the network-wide state that can't be read through the multicall batch
+func (m *SnapshotManager) loadNetworkState(wg *sync.WaitGroup, failures *snapshotFailures, state *NetworkState, opts *bind.CallOpts) {
+
+	// The per-status counts are read in pages of minipools
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		minipoolCounts, err := minipool.GetMinipoolCountPerStatus(m.rp, opts)
+		if err != nil {
+			failures.add("minipool counts", fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err))
+			return
+		}
+		state.MinipoolCounts = minipoolCounts
+	}()
+
+}
+
+// Load the local node's state that can't be read through the multicall batch
+func (m *SnapshotManager) loadNodeState(wg *sync.WaitGroup, failures *snapshotFailures, state *NodeState, opts *bind.CallOpts) {
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(m.rp, m.nodeAddress, opts)
+		if err != nil {
+			failures.add("node minipool addresses", fmt.Errorf("Error getting node minipool addresses: %w", err))
+			return
+		}
+		state.MinipoolAddresses = minipoolAddresses
+	}()
+	go func() {
+		defer wg.Done()
+		beaconHead, err := m.bc.GetBeaconHead()
+		if err != nil {
+			failures.add("beacon chain head", fmt.Errorf("Error getting beacon chain head: %w", err))
+			return
+		}
+		state.BeaconHead = beaconHead
+	}()
+
+}
+
+// Load the beacon chain state of the node's minipools
+func (m *SnapshotManager) loadBeaconState(failures *snapshotFailures, state *NodeState, opts *bind.CallOpts) {
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// Get the total deposits and corresponding beacon chain balance share
+	go func() {
+		defer wg.Done()
+		minipoolDetails, err := eth2.GetBeaconBalances(m.rp, m.bc, state.MinipoolAddresses, state.BeaconHead, opts)
+		if err != nil {
+			failures.add("beacon balances", err)
+			return
+		}
+		depositBalance := big.NewInt(0)
+		nodeShare := big.NewInt(0)
+		beaconBalance := big.NewInt(0)
+		for _, minipool := range minipoolDetails {
+			depositBalance.Add(depositBalance, minipool.NodeDeposit)
+			nodeShare.Add(nodeShare, minipool.NodeBalance)
+			beaconBalance.Add(beaconBalance, minipool.TotalBalance)
+		}
+		state.DepositBalance = depositBalance
+		state.NodeShare = nodeShare
+		state.BeaconBalance = beaconBalance
+	}()
+
+	// Get the indices of the node's validating minipools
+	go func() {
+		defer wg.Done()
+		pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(m.rp, m.nodeAddress, opts)
+		if err != nil {
+			failures.add("validator indices", fmt.Errorf("Error getting validator pubkeys: %w", err))
+			return
+		}
+		statuses, err := m.bc.GetValidatorStatuses(pubkeys, nil)
+		if err != nil {
+			failures.add("validator indices", fmt.Errorf("Error getting validator statuses: %w", err))
+			return
+		}
+		validatorIndices := []uint64{}
+		for _, status := range statuses {
+			if status.Exists {
+				validatorIndices = append(validatorIndices, status.Index)
+			}
+		}
+		state.ValidatorIndices = validatorIndices
+	}()
+
+	wg.Wait()
+
+}
+
+// Load the queue positions of the node's minipools that are waiting for user ETH
+func (m *SnapshotManager) loadQueuePositions(state *NodeState, depositInflowRate *big.Int, opts *bind.CallOpts) error {
+
+	// Find the minipools in the queue
+	var wg errgroup.Group
+	var lock sync.Mutex
+	depositTypes := map[common.Address]rptypes.MinipoolDeposit{}
+	for _, address := range state.MinipoolAddresses {
+		address := address
+		wg.Go(func() error {
+			mp, err := minipool.NewMinipool(m.rp, address)
+			if err != nil {
+				return err
+			}
+			status, err := mp.GetStatus(opts)
+			if err != nil {
+				return fmt.Errorf("Error getting status of minipool %s: %w", address.Hex(), err)
+			}
+			if status != rptypes.Initialized {
+				return nil
+			}
+			depositType, err := mp.GetDepositType(opts)
+			if err != nil {
+				return fmt.Errorf("Error getting deposit type of minipool %s: %w", address.Hex(), err)
+			}
+			lock.Lock()
+			depositTypes[address] = depositType
+			lock.Unlock()
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+	queuePositions := map[common.Address]apitypes.MinipoolQueuePosition{}
+	if len(depositTypes) == 0 {
+		state.QueuePositions = queuePositions
+		return nil
+	}
+
+	// Get their positions
+	queueState, err := rputils.GetMinipoolQueueState(m.rp, opts)
+	if err != nil {
+		return fmt.Errorf("Error getting minipool queue state: %w", err)
+	}
+	for address, depositType := range depositTypes {
+		position, err := rputils.GetMinipoolQueuePosition(m.rp, queueState, address, depositType, opts)
+		if err != nil {
+			return err
+		}
+		if !position.InQueue {
+			continue
+		}
+		position.Eta, position.EtaKnown = rputils.GetMinipoolQueueEta(position, depositInflowRate)
+		queuePositions[address] = position
+	}
+	state.QueuePositions = queuePositions
+	return nil
+
+}
+
+// Load the oracle DAO's state
+func (m *SnapshotManager) loadTrustedNodeState(wg *sync.WaitGroup, failures *snapshotFailures, state *TrustedNodeState, opts *bind.CallOpts) {
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		proposals, err := dao.GetProposalsWithMember(m.rp, m.nodeAddress, opts)
+		if err != nil {
+			failures.add("oDAO proposals", fmt.Errorf("Error getting proposals: %w", err))
+			return
+		}
+		state.Proposals = proposals
+	}()
+	go func() {
+		defer wg.Done()
+		members, err := trustednode.GetMembers(m.rp, opts)
+		if err != nil {
+			failures.add("oDAO members", fmt.Errorf("Error getting members: %w", err))
+			return
+		}
+		state.Members = members
+	}()
+
+}
+
+// Load the ETH balances of the oracle DAO members through a single multicall batch
+func (m *SnapshotManager) loadMemberBalances(failures *snapshotFailures, state *TrustedNodeState, opts *bind.CallOpts) {
+
+	balances := make([]*big.Int, len(state.Members))
+	for i, member := range state.Members {
+		if err := m.mc.AddEthBalance(member.Address, &balances[i], member.Address.Hex()); err != nil {
+			failures.add("oDAO member balances", err)
+			return
+		}
+	}
+	errs, err := m.mc.Execute(opts)
+	if err != nil {
+		failures.add("oDAO member balances", err)
+		return
+	}
+	memberBalances := make(map[common.Address]*big.Int, len(state.Members))
+	for i, member := range state.Members {
+		if err := errs[member.Address.Hex()]; err != nil {
+			failures.add("oDAO member balances", fmt.Errorf("Error getting ETH balance of oDAO member %s: %w", member.Address.Hex(), err))
+			return
+		}
+		memberBalances[member.Address] = balances[i]
+	}
+	state.MemberBalances = memberBalances
+
+}
+
+// Load the oracle DAO members' participation in the latest balance and price updates
+func (m *SnapshotManager) loadParticipation(opts *bind.CallOpts) error {
+
+	var wg errgroup.Group
+	var balancesParticipation map[common.Address]bool
+	var pricesParticipation map[common.Address]bool
+
+	wg.Go(func() error {
+		var err error
+		balancesParticipation, err = node.GetTrustedNodeLatestBalancesParticipation(m.rp, m.eventLogInterval, opts)
+		if err != nil {
+			return fmt.Errorf("Error getting trusted node balances participation data: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		pricesParticipation, err = node.GetTrustedNodeLatestPricesParticipation(m.rp, m.eventLogInterval, opts)
+		if err != nil {
+			return fmt.Errorf("Error getting trusted node prices participation data: %w", err)
+		}
+		return nil
+	})
+
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+	m.balancesParticipation = balancesParticipation
+	m.pricesParticipation = pricesParticipation
+	return nil
+
+}
+
+// Get the total amount of RPL rewards claimed by the node up to the given block
+func (m *SnapshotManager) getCumulativeRplRewards(blockNumber *big.Int) (*big.Int, error) {
+	if m.idx == nil {
+		idx, err := indexer.NewIndexer(m.rp, m.cfg, "node")
+		if err != nil {
+			return nil, err
+		}
+		m.idx = idx
+	}
+	if err := m.idx.Sync(blockNumber); err != nil {
+		return nil, err
+	}
+	return m.idx.GetNodeRplClaimed(m.nodeAddress)
+}
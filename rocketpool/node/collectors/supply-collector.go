@@ -1,15 +1,9 @@
 package collectors
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/minipool"
-	"github.com/rocket-pool/rocketpool-go/network"
-	"github.com/rocket-pool/rocketpool-go/node"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"golang.org/x/sync/errgroup"
 )
 
 // Represents the collector for the Supply metrics
@@ -29,12 +23,12 @@ type SupplyCollector struct {
 	// The number of active (non-finalized) Rocket Pool minipools
 	activeMinipools *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new PerformanceCollector instance
-func NewSupplyCollector(rp *rocketpool.RocketPool) *SupplyCollector {
+func NewSupplyCollector(sm *SnapshotManager) *SupplyCollector {
 	subsystem := "supply"
 	return &SupplyCollector{
 		nodeCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_count"),
@@ -57,7 +51,7 @@ func NewSupplyCollector(rp *rocketpool.RocketPool) *SupplyCollector {
 			"The number of active (non-finalized) Rocket Pool minipools",
 			nil, nil,
 		),
-		rp: rp,
+		sm: sm,
 	}
 }
 
@@ -73,66 +67,22 @@ func (collector *SupplyCollector) Describe(channel chan<- *prometheus.Desc) {
 // Collect the latest metric values and pass them to Prometheus
 func (collector *SupplyCollector) Collect(channel chan<- prometheus.Metric) {
 
-	// Sync
-	var wg errgroup.Group
-	nodeCount := float64(-1)
-	nodeFee := float64(-1)
-	initializedCount := float64(-1)
-	prelaunchCount := float64(-1)
-	stakingCount := float64(-1)
-	withdrawableCount := float64(-1)
-	dissolvedCount := float64(-1)
-	finalizedCount := float64(-1)
-
-	// Get total number of Rocket Pool nodes
-	wg.Go(func() error {
-		nodeCountUint, err := node.GetNodeCount(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total number of Rocket Pool nodes: %w", err)
-		} else {
-			nodeCount = float64(nodeCountUint)
-		}
-		return nil
-	})
-
-	// Get the current node fee for new minipools
-	wg.Go(func() error {
-		_nodeFee, err := network.GetNodeFee(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting current node fee for new minipools: %w", err)
-		} else {
-			nodeFee = _nodeFee
-		}
-		return nil
-	})
-
-	// Get the total number of Rocket Pool minipools
-	wg.Go(func() error {
-		minipoolCounts, err := minipool.GetMinipoolCountPerStatus(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err)
-		} else {
-			initializedCount = float64(minipoolCounts.Initialized.Uint64())
-			prelaunchCount = float64(minipoolCounts.Prelaunch.Uint64())
-			stakingCount = float64(minipoolCounts.Staking.Uint64())
-			withdrawableCount = float64(minipoolCounts.Withdrawable.Uint64())
-			dissolvedCount = float64(minipoolCounts.Dissolved.Uint64())
-		}
-		finalizedCountUint, err := minipool.GetFinalisedMinipoolCount(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting total number of Rocket Pool minipools: %w", err)
-		} else {
-			finalizedCount = float64(finalizedCountUint)
-			withdrawableCount -= finalizedCount
-		}
-		return nil
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	state := snapshot.Network
+
+	nodeCount := float64(state.NodeCount)
+	nodeFee := state.NodeFee
+	initializedCount := float64(state.MinipoolCounts.Initialized.Uint64())
+	prelaunchCount := float64(state.MinipoolCounts.Prelaunch.Uint64())
+	stakingCount := float64(state.MinipoolCounts.Staking.Uint64())
+	dissolvedCount := float64(state.MinipoolCounts.Dissolved.Uint64())
+	finalizedCount := float64(state.FinalisedMinipoolCount)
+	withdrawableCount := float64(state.MinipoolCounts.Withdrawable.Uint64()) - finalizedCount
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.nodeCount, prometheus.GaugeValue, nodeCount)
@@ -1,26 +1,13 @@
 package collectors
 
 import (
-	"fmt"
 	"log"
-	"math/big"
 	"strconv"
-	"sync"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/rocket-pool/rocketpool-go/dao"
-	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
-	"github.com/rocket-pool/rocketpool-go/node"
-	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"github.com/rocket-pool/smartnode/shared/services/beacon"
-	"github.com/rocket-pool/smartnode/shared/services/config"
-	"github.com/rocket-pool/smartnode/shared/utils/api"
-	"golang.org/x/sync/errgroup"
 )
 
 // Represents the collector for the user's trusted node
@@ -43,33 +30,12 @@ type TrustedNodeCollector struct {
 	// The prices submission participation of the ODAO members
 	pricesParticipation *prometheus.Desc
 
-	// The Rocket Pool contract manager
-	rp *rocketpool.RocketPool
-
-	// The beacon client
-	bc beacon.Client
-
-	// The node's address
-	nodeAddress common.Address
-
-	// Cached data
-	cacheTime     time.Time
-	cachedMetrics []prometheus.Metric
-
-	// The event log interval for the current eth1 client
-	eventLogInterval *big.Int
+	// The state snapshot manager
+	sm *SnapshotManager
 }
 
 // Create a new NodeCollector instance
-func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig) *TrustedNodeCollector {
-
-	// Get the event log interval
-	eventLogInterval, err := api.GetEventLogInterval(cfg)
-	if err != nil {
-		log.Printf("Error getting event log interval: %s\n", err.Error())
-		return nil
-	}
-
+func NewTrustedNodeCollector(sm *SnapshotManager) *TrustedNodeCollector {
 	subsystem := "trusted_node"
 	return &TrustedNodeCollector{
 		proposalCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposal_count"),
@@ -96,10 +62,7 @@ func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAd
 			"Whether each member has participated in the current prices update interval",
 			[]string{"member"}, nil,
 		),
-		rp:               rp,
-		bc:               bc,
-		nodeAddress:      nodeAddress,
-		eventLogInterval: eventLogInterval,
+		sm: sm,
 	}
 }
 
@@ -113,73 +76,22 @@ func (collector *TrustedNodeCollector) Describe(channel chan<- *prometheus.Desc)
 	channel <- collector.pricesParticipation
 }
 
-// Caches slow to process metrics so it doesn't have to be processed every second
-func (collector *TrustedNodeCollector) collectSlowMetrics(memberIds map[common.Address]string) {
-
-	// Create a new cached metrics array to populate
-	collector.cachedMetrics = make([]prometheus.Metric, 0)
-
-	// Sync
-	var wg errgroup.Group
-
-	var balancesParticipation map[common.Address]bool
-	var pricesParticipation map[common.Address]bool
-
-	// Get the balances participation data
-	wg.Go(func() error {
-		var err error
-		balancesParticipation, err = node.GetTrustedNodeLatestBalancesParticipation(collector.rp, nil, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting trusted node balances participation data: %w", err)
-		}
-		return nil
-	})
-
-	// Get the prices participation data
-	wg.Go(func() error {
-		var err error
-		pricesParticipation, err = node.GetTrustedNodeLatestPricesParticipation(collector.rp, nil, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting trusted node prices participation data: %w", err)
-		}
-		return nil
-	})
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric) {
 
-	// Wait for data
-	if err := wg.Wait(); err != nil {
+	// Get the latest state snapshot
+	snapshot, err := collector.sm.GetSnapshot()
+	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
 	}
+	state := snapshot.TrustedNode
 
-	// Balances participation
-	for member, status := range balancesParticipation {
-		value := float64(0)
-		if status {
-			value = 1
-		}
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(collector.balancesParticipation, prometheus.GaugeValue, value, memberIds[member]))
-	}
-
-	// Prices participation
-	for member, status := range pricesParticipation {
-		value := float64(0)
-		if status {
-			value = 1
-		}
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(collector.pricesParticipation, prometheus.GaugeValue, value, memberIds[member]))
-	}
-}
-
-// Collect the latest metric values and pass them to Prometheus
-func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric) {
-
-	// Sync
-	var wg errgroup.Group
-	var err error
-
-	var proposals []dao.ProposalDetails
+	proposals := state.Proposals
 	memberIds := make(map[common.Address]string)
-	ethBalances := make(map[string]float64)
+	for _, member := range state.Members {
+		memberIds[member.Address] = member.ID
+	}
 
 	unvotedCount := float64(0)
 	pendingCount := float64(0)
@@ -190,65 +102,6 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 	defeatedCount := float64(0)
 	expiredCount := float64(0)
 
-	// Get the total staked RPL
-	wg.Go(func() error {
-		proposals, err = dao.GetProposalsWithMember(collector.rp, collector.nodeAddress, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting proposals: %w", err)
-		}
-		return nil
-	})
-
-	// Get member IDs
-	wg.Go(func() error {
-		members, err := trustednode.GetMembers(collector.rp, nil)
-		if err != nil {
-			return fmt.Errorf("Error getting members: %w", err)
-		}
-		for _, member := range members {
-			memberIds[member.Address] = member.ID
-		}
-		return nil
-	})
-
-	// Only collect fresh participation metrics from chain every 60 seconds as it updates infrequently and takes longer to collect
-	now := time.Now()
-	if now.Unix() > collector.cacheTime.Add(time.Second*60).Unix() {
-		collector.collectSlowMetrics(memberIds)
-		collector.cacheTime = now
-	}
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		log.Printf("%s\n", err.Error())
-		return
-	}
-
-	lock := sync.Mutex{}
-
-	// Get member ETH balances
-	for address, memberId := range memberIds {
-		wg.Go(func(address common.Address, id string) func() error {
-			return func() error {
-				// Get balances
-				balances, err := tokens.GetBalances(collector.rp, address, nil)
-				if err != nil {
-					return fmt.Errorf("Error getting node balances: %w", err)
-				}
-				lock.Lock()
-				ethBalances[id] = eth.WeiToEth(balances.ETH)
-				lock.Unlock()
-				return nil
-			}
-		}(address, memberId))
-	}
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		log.Printf("%s\n", err.Error())
-		return
-	}
-
 	// Calculate metrics
 	for _, proposal := range proposals {
 		switch proposal.State {
@@ -291,9 +144,9 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 		collector.proposalCount, prometheus.GaugeValue, expiredCount, "expired")
 
 	// Update balance metrics
-	for memberId, balance := range ethBalances {
+	for address, balance := range state.MemberBalances {
 		channel <- prometheus.MustNewConstMetric(
-			collector.ethBalance, prometheus.GaugeValue, balance, memberId)
+			collector.ethBalance, prometheus.GaugeValue, eth.WeiToEth(balance), memberIds[address])
 	}
 
 	// Update proposal metrics
@@ -309,8 +162,21 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 			collector.proposalTable, prometheus.GaugeValue, proposal.VotesRequired, strconv.FormatUint(proposal.ID, 10), "required")
 	}
 
-	// Include cached metrics
-	for _, metric := range collector.cachedMetrics {
-		channel <- metric
+	// Update participation metrics
+	for member, status := range state.BalancesParticipation {
+		value := float64(0)
+		if status {
+			value = 1
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.balancesParticipation, prometheus.GaugeValue, value, memberIds[member])
+	}
+	for member, status := range state.PricesParticipation {
+		value := float64(0)
+		if status {
+			value = 1
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.pricesParticipation, prometheus.GaugeValue, value, memberIds[member])
 	}
 }
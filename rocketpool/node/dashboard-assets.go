@@ -0,0 +1,350 @@
+package node
+
+// The web dashboard's static assets, which are compiled into the daemon so it doesn't need any files to serve them
+// The page loads each panel from the dashboard's API, and hides the operator-only panels from read-only logins.
+
+const dashboardHtml = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Rocket Pool Node Dashboard</title>
+<link rel="stylesheet" href="/dashboard.css">
+</head>
+<body>
+<header>
+<h1>Rocket Pool Node Dashboard</h1>
+<span id="session"></span>
+<span id="updated"></span>
+</header>
+<main>
+<section id="status-panel"><h2>Node</h2><div class="content">Loading...</div></section>
+<section id="sync-panel"><h2>Client Sync</h2><div class="content">Loading...</div></section>
+<section id="rewards-panel"><h2>RPL Rewards</h2><div class="content">Loading...</div></section>
+<section id="minipools-panel" class="wide"><h2>Minipools</h2><div class="content">Loading...</div></section>
+<section id="transactions-panel" class="wide operator" hidden><h2>Pending Transactions</h2><div class="content">Loading...</div></section>
+<section id="logs-panel" class="wide operator" hidden><h2>Daemon Logs</h2><div class="content">Loading...</div></section>
+</main>
+<script src="/dashboard.js"></script>
+</body>
+</html>
+`
+
+const dashboardCss = `body {
+	margin: 0;
+	font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif;
+	background: #f4f4f6;
+	color: #222;
+}
+header {
+	display: flex;
+	align-items: baseline;
+	gap: 1.5em;
+	padding: 0.75em 1.5em;
+	background: #ff7a1a;
+	color: #fff;
+}
+header h1 {
+	margin: 0;
+	font-size: 1.4em;
+}
+main {
+	display: grid;
+	grid-template-columns: repeat(auto-fit, minmax(22em, 1fr));
+	gap: 1em;
+	padding: 1em 1.5em;
+}
+section {
+	background: #fff;
+	border-radius: 6px;
+	padding: 0.5em 1em 1em;
+	box-shadow: 0 1px 3px rgba(0, 0, 0, 0.1);
+	overflow-x: auto;
+}
+section.wide {
+	grid-column: 1 / -1;
+}
+section h2 {
+	font-size: 1.1em;
+	border-bottom: 1px solid #eee;
+	padding-bottom: 0.4em;
+}
+table {
+	border-collapse: collapse;
+	width: 100%;
+	font-size: 0.9em;
+}
+th, td {
+	text-align: left;
+	padding: 0.25em 0.75em 0.25em 0;
+	white-space: nowrap;
+}
+th {
+	color: #666;
+	font-weight: normal;
+}
+.error {
+	color: #c62828;
+}
+.good {
+	color: #2e7d32;
+}
+.mono, pre {
+	font-family: Menlo, Consolas, monospace;
+	font-size: 0.85em;
+}
+pre {
+	max-height: 30em;
+	overflow: auto;
+	margin: 0;
+	white-space: pre-wrap;
+}
+`
+
+const dashboardJs = `"use strict";
+
+var refreshInterval = 60000;
+var session = null;
+
+function el(tag, text, className) {
+	var node = document.createElement(tag);
+	if (text !== undefined && text !== null) {
+		node.textContent = text;
+	}
+	if (className) {
+		node.className = className;
+	}
+	return node;
+}
+
+function eth(wei, decimals) {
+	if (wei === undefined || wei === null) {
+		return "-";
+	}
+	return (Number(wei) / 1e18).toFixed(decimals === undefined ? 6 : decimals);
+}
+
+function percent(value) {
+	return (value * 100).toFixed(2) + "%";
+}
+
+function duration(nanoseconds) {
+	var seconds = Math.round(nanoseconds / 1e9);
+	var days = Math.floor(seconds / 86400);
+	var hours = Math.floor((seconds % 86400) / 3600);
+	var minutes = Math.floor((seconds % 3600) / 60);
+	if (days > 0) {
+		return days + "d " + hours + "h";
+	}
+	if (hours > 0) {
+		return hours + "h " + minutes + "m";
+	}
+	return minutes + "m";
+}
+
+function date(value) {
+	var d = new Date(value);
+	if (isNaN(d.getTime()) || d.getFullYear() < 2000) {
+		return "-";
+	}
+	return d.toLocaleString();
+}
+
+function table(rows, headings) {
+	var t = el("table");
+	if (headings) {
+		var head = el("tr");
+		headings.forEach(function (heading) {
+			head.appendChild(el("th", heading));
+		});
+		t.appendChild(head);
+	}
+	rows.forEach(function (row) {
+		var tr = el("tr");
+		row.forEach(function (cell, i) {
+			var tag = (!headings && i === 0) ? "th" : "td";
+			if (cell instanceof Node) {
+				var td = el(tag);
+				td.appendChild(cell);
+				tr.appendChild(td);
+			} else {
+				tr.appendChild(el(tag, cell));
+			}
+		});
+		t.appendChild(tr);
+	});
+	return t;
+}
+
+function txLink(hash) {
+	if (!session || !session.txWatchUrl) {
+		return el("span", hash, "mono");
+	}
+	var link = el("a", hash, "mono");
+	link.href = session.txWatchUrl + "/" + hash;
+	link.target = "_blank";
+	link.rel = "noopener";
+	return link;
+}
+
+function setContent(panel, content) {
+	var container = document.querySelector("#" + panel + "-panel .content");
+	container.textContent = "";
+	container.appendChild(content);
+}
+
+function load(endpoint, render) {
+	return fetch("/api/" + endpoint, { credentials: "same-origin", cache: "no-store" })
+		.then(function (response) {
+			return response.json();
+		})
+		.then(function (data) {
+			if (data.status !== "success") {
+				throw new Error(data.error || "Unknown error");
+			}
+			setContent(endpoint, render(data));
+		})
+		.catch(function (err) {
+			setContent(endpoint, el("p", "Error: " + err.message, "error"));
+		});
+}
+
+function renderStatus(data) {
+	var rows = [
+		["Node account", el("span", data.accountAddress, "mono")],
+		["Withdrawal address", el("span", data.withdrawalAddress, "mono")],
+		["Registered", data.registered ? "Yes" : "No"],
+		["Oracle DAO member", data.trusted ? "Yes" : "No"],
+		["Timezone", data.timezoneLocation],
+		["ETH balance", eth(data.accountBalances.eth) + " ETH"],
+		["RPL balance", eth(data.accountBalances.rpl) + " RPL"],
+		["RPL stake", eth(data.rplStake) + " RPL"],
+		["Effective RPL stake", eth(data.effectiveRplStake) + " RPL"],
+		["Collateral ratio", percent(data.collateralRatio)],
+		["Minipools", data.minipoolCounts.total + " (limit " + data.minipoolLimit + ")"]
+	];
+	return table(rows);
+}
+
+function renderClient(name, status) {
+	if (!status.isWorking) {
+		return [name, el("span", "Unavailable" + (status.error ? ": " + status.error : ""), "error")];
+	}
+	if (status.isSynced) {
+		return [name, el("span", "Synced", "good")];
+	}
+	return [name, "Syncing (" + percent(status.syncProgress) + ")"];
+}
+
+function renderSync(data) {
+	var rows = [renderClient("Execution client", data.ecStatus.primaryEcStatus)];
+	if (data.ecStatus.fallbackEnabled) {
+		rows.push(renderClient("Fallback execution client", data.ecStatus.fallbackEcStatus));
+	}
+	rows.push(data.eth2Synced
+		? ["Consensus client", el("span", "Synced", "good")]
+		: ["Consensus client", "Syncing (" + percent(data.eth2Progress) + ")"]);
+	return table(rows);
+}
+
+function renderRewards(data) {
+	if (!data.registered) {
+		return el("p", "The node is not registered.");
+	}
+	var rows = [
+		["Last checkpoint", date(data.lastCheckpoint)],
+		["Rewards interval", duration(data.rewardsInterval)],
+		["Next checkpoint", date(new Date(new Date(data.lastCheckpoint).getTime() + data.rewardsInterval / 1e6))],
+		["Estimated rewards", data.estimatedRewards.toFixed(6) + " RPL"],
+		["Unclaimed rewards", data.unclaimedRewards.toFixed(6) + " RPL"],
+		["Claimed rewards", data.cumulativeRewards.toFixed(6) + " RPL"],
+		["Beacon chain rewards", data.beaconRewards.toFixed(6) + " ETH"]
+	];
+	if (data.trusted) {
+		rows.push(["Estimated oracle DAO rewards", data.estimatedTrustedRewards.toFixed(6) + " RPL"]);
+		rows.push(["Unclaimed oracle DAO rewards", data.unclaimedTrustedRewards.toFixed(6) + " RPL"]);
+	}
+	return table(rows);
+}
+
+function renderMinipools(data) {
+	if (!data.minipools || data.minipools.length === 0) {
+		return el("p", "The node doesn't have any minipools.");
+	}
+	var rows = data.minipools.map(function (mp) {
+		var status = mp.status.status;
+		if (mp.queue && mp.queue.inQueue) {
+			status += " (queue position " + (mp.queue.position + 1) + (mp.queue.etaKnown ? ", ~" + duration(mp.queue.eta) : "") + ")";
+		}
+		return [
+			el("span", mp.address, "mono"),
+			status,
+			mp.depositType,
+			mp.validator.exists ? String(mp.validator.index) : "-",
+			mp.validator.exists ? eth(mp.validator.balance, 4) + " ETH" : "-",
+			eth(mp.node.depositBalance, 2) + " ETH",
+			(mp.node.fee * 100).toFixed(2) + "%"
+		];
+	});
+	return table(rows, ["Address", "Status", "Type", "Validator", "Balance", "Node deposit", "Commission"]);
+}
+
+function renderTransactions(data) {
+	var container = el("div");
+	container.appendChild(table([
+		["Node account", el("span", data.accountAddress, "mono")],
+		["Waiting to be mined", String(data.pending)]
+	]));
+	if (data.transactions.length > 0) {
+		container.appendChild(el("h3", "Submitted by the node daemon"));
+		container.appendChild(table(data.transactions.map(function (tx) {
+			var status = !tx.mined ? el("span", "Pending") : tx.succeeded
+				? el("span", "Mined in block " + tx.blockNumber, "good")
+				: el("span", "Failed in block " + tx.blockNumber, "error");
+			return [txLink(tx.hash), date(tx.submittedAt), status];
+		}), ["Hash", "Submitted", "Status"]));
+	}
+	return container;
+}
+
+function renderLogs(data) {
+	var text = data.lines.map(function (line) {
+		return line.text;
+	}).join("\n");
+	var pre = el("pre", text || "No log output yet.");
+	setTimeout(function () {
+		pre.scrollTop = pre.scrollHeight;
+	}, 0);
+	return pre;
+}
+
+function refresh() {
+	var loads = [
+		load("status", renderStatus),
+		load("sync", renderSync),
+		load("rewards", renderRewards),
+		load("minipools", renderMinipools)
+	];
+	if (session && !session.readOnly) {
+		loads.push(load("transactions", renderTransactions));
+		loads.push(load("logs", renderLogs));
+	}
+	Promise.all(loads).then(function () {
+		document.getElementById("updated").textContent = "Updated " + new Date().toLocaleTimeString();
+	});
+}
+
+fetch("/api/session", { credentials: "same-origin", cache: "no-store" })
+	.then(function (response) {
+		return response.json();
+	})
+	.then(function (data) {
+		session = data;
+		document.getElementById("session").textContent = data.network + " - " + (data.readOnly ? "read-only view" : "operator view");
+		document.querySelectorAll(".operator").forEach(function (panel) {
+			panel.hidden = data.readOnly;
+		});
+		refresh();
+		setInterval(refresh, refreshInterval);
+	});
+`
@@ -0,0 +1,94 @@
+package node
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of log lines and submitted transactions the dashboard keeps
+const (
+	dashboardLogLines     int = 500
+	dashboardTransactions int = 50
+)
+
+// Matches ANSI color codes, which the dashboard doesn't render
+var ansiColorPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Matches the line api.PrintAndWaitForTransaction logs when a task submits a transaction
+var submittedTransactionPattern = regexp.MustCompile(`Transaction has been submitted with hash (0x[0-9a-fA-F]{64})`)
+
+// Keeps the daemon's most recent log lines, and the transactions its tasks have submitted, for the dashboard
+// It's written to alongside the standard log output, so it sees every task's log lines.
+type dashboardLogBuffer struct {
+	lock         sync.Mutex
+	partial      string
+	lines        []api.DashboardLogLine
+	transactions []api.DashboardTransaction
+}
+
+// Create a dashboard log buffer
+func newDashboardLogBuffer() *dashboardLogBuffer {
+	return &dashboardLogBuffer{
+		lines:        []api.DashboardLogLine{},
+		transactions: []api.DashboardTransaction{},
+	}
+}
+
+// Add log output to the buffer
+func (b *dashboardLogBuffer) Write(p []byte) (int, error) {
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// Only complete lines are added; the rest is held until the next write
+	text := b.partial + ansiColorPattern.ReplaceAllString(string(p), "")
+	lines := strings.Split(text, "\n")
+	b.partial = lines[len(lines)-1]
+
+	now := time.Now()
+	for _, line := range lines[:len(lines)-1] {
+		if line == "" {
+			continue
+		}
+		b.lines = append(b.lines, api.DashboardLogLine{
+			Time: now,
+			Text: line,
+		})
+		if match := submittedTransactionPattern.FindStringSubmatch(line); match != nil {
+			b.transactions = append(b.transactions, api.DashboardTransaction{
+				Hash:        common.HexToHash(match[1]),
+				SubmittedAt: now,
+			})
+		}
+	}
+
+	// Drop the oldest entries once the buffer is full
+	if len(b.lines) > dashboardLogLines {
+		b.lines = append([]api.DashboardLogLine{}, b.lines[len(b.lines)-dashboardLogLines:]...)
+	}
+	if len(b.transactions) > dashboardTransactions {
+		b.transactions = append([]api.DashboardTransaction{}, b.transactions[len(b.transactions)-dashboardTransactions:]...)
+	}
+	return len(p), nil
+
+}
+
+// Get a copy of the buffered log lines, oldest first
+func (b *dashboardLogBuffer) getLines() []api.DashboardLogLine {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return append([]api.DashboardLogLine{}, b.lines...)
+}
+
+// Get a copy of the transactions the daemon has submitted, oldest first
+func (b *dashboardLogBuffer) getTransactions() []api.DashboardTransaction {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return append([]api.DashboardTransaction{}, b.transactions...)
+}
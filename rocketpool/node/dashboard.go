@@ -0,0 +1,325 @@
+package node
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/urfave/cli"
+
+	apiminipool "github.com/rocket-pool/smartnode/rocketpool/api/minipool"
+	apinode "github.com/rocket-pool/smartnode/rocketpool/api/node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long the dashboard reuses an API response for
+const dashboardCacheTime = 30 * time.Second
+
+// The API handlers behind each dashboard panel, keyed by their dashboard endpoint
+// These are the same handlers, and responses, the CLI uses for the matching information; they're called in-process with the daemon's services.
+var dashboardQueries = map[string]func(c *cli.Context) (interface{}, error){
+	"status":    func(c *cli.Context) (interface{}, error) { return apinode.GetStatus(c) },
+	"minipools": func(c *cli.Context) (interface{}, error) { return apiminipool.GetStatus(c) },
+	"rewards":   func(c *cli.Context) (interface{}, error) { return apinode.GetRewards(c) },
+	"sync":      func(c *cli.Context) (interface{}, error) { return apinode.GetSyncProgress(c) },
+}
+
+// A cached API response
+type dashboardCacheEntry struct {
+	lock    sync.Mutex
+	updated time.Time
+	body    []byte
+}
+
+// The web dashboard server
+type dashboardServer struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	ec             *services.ExecutionClientManager
+	logBuffer      *dashboardLogBuffer
+	operatorSecret string
+	viewerSecret   string
+	cache          map[string]*dashboardCacheEntry
+}
+
+// Run the web dashboard server
+func runDashboardServer(c *cli.Context, logger log.ColorLogger, logBuffer *dashboardLogBuffer) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+
+	// Return if the dashboard is disabled
+	if cfg.Smartnode.EnableDashboard.Value == false {
+		return nil
+	}
+	operatorSecret := cfg.Smartnode.DashboardPassword.Value.(string)
+	if operatorSecret == "" {
+		logger.Println("WARNING: the web dashboard is enabled but doesn't have a password set, so it won't be started.")
+		return nil
+	}
+
+	// Create the server
+	server := &dashboardServer{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		ec:             ec,
+		logBuffer:      logBuffer,
+		operatorSecret: operatorSecret,
+		viewerSecret:   cfg.Smartnode.DashboardViewerPassword.Value.(string),
+		cache:          map[string]*dashboardCacheEntry{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.handle(api.DashboardRole_Viewer, server.serveIndex))
+	mux.HandleFunc("/api/session", server.handle(api.DashboardRole_Viewer, server.serveSession))
+	for name := range dashboardQueries {
+		mux.HandleFunc("/api/"+name, server.handle(api.DashboardRole_Viewer, server.serveQuery(name)))
+		server.cache[name] = &dashboardCacheEntry{}
+	}
+	mux.HandleFunc("/api/logs", server.handle(api.DashboardRole_Operator, server.serveLogs))
+	mux.HandleFunc("/api/transactions", server.handle(api.DashboardRole_Operator, server.serveTransactions))
+
+	// Make sure the passwords won't be sent over the network in plain text
+	dashboardAddress := c.GlobalString("dashboardAddress")
+	dashboardPort := cfg.Smartnode.DashboardPort.Value.(uint16)
+	certPath, keyPath := cfg.Smartnode.GetDashboardTlsPaths(true)
+	if certPath == "" {
+		if cfg.Smartnode.DashboardOpenPort.Value == true {
+			logger.Println("WARNING: the web dashboard's port is exposed to your network, but it doesn't have a TLS certificate and key set, so it won't be started. Set them in the Dashboard TLS Certificate Path and Dashboard TLS Key Path settings, or stop exposing the port.")
+			return nil
+		}
+		if cfg.IsNativeMode && !isLoopbackAddress(dashboardAddress) {
+			logger.Printlnf("WARNING: the web dashboard is being served on %s without TLS, so its passwords will be sent over the network in plain text. Set a TLS certificate and key, or serve it on 127.0.0.1.", dashboardAddress)
+		}
+	}
+
+	// Start the HTTP server
+	logger.Printlnf("Starting web dashboard on %s:%d.", dashboardAddress, dashboardPort)
+	if server.viewerSecret == "" {
+		logger.Println("The read-only dashboard view is disabled because it doesn't have a password set.")
+	}
+	listenAddress := fmt.Sprintf("%s:%d", dashboardAddress, dashboardPort)
+	if certPath != "" {
+		err = http.ListenAndServeTLS(listenAddress, certPath, keyPath, mux)
+	} else {
+		err = http.ListenAndServe(listenAddress, mux)
+	}
+	if err != nil {
+		return fmt.Errorf("Error running dashboard HTTP server: %w", err)
+	}
+
+	return nil
+
+}
+
+// Wrap a handler so it's only served to logins with at least the required role
+func (s *dashboardServer) handle(required api.DashboardRole, handler func(http.ResponseWriter, *http.Request, api.DashboardRole)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		role, ok := s.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Rocket Pool Dashboard", charset="UTF-8"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if required == api.DashboardRole_Operator && role != api.DashboardRole_Operator {
+			s.writeJSON(w, http.StatusForbidden, &api.APIResponse{Status: "error", Error: "This is only available to the node operator."})
+			return
+		}
+		handler(w, r, role)
+	}
+}
+
+// Get the role a request's login is for
+func (s *dashboardServer) authenticate(r *http.Request) (api.DashboardRole, bool) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return "", false
+	}
+	switch api.DashboardRole(username) {
+	case api.DashboardRole_Operator:
+		return api.DashboardRole_Operator, secretsMatch(password, s.operatorSecret)
+	case api.DashboardRole_Viewer:
+		return api.DashboardRole_Viewer, s.viewerSecret != "" && secretsMatch(password, s.viewerSecret)
+	}
+	return "", false
+}
+
+// Serve the dashboard page and its assets
+func (s *dashboardServer) serveIndex(w http.ResponseWriter, r *http.Request, role api.DashboardRole) {
+	var body, contentType string
+	switch r.URL.Path {
+	case "/":
+		body, contentType = dashboardHtml, "text/html; charset=utf-8"
+	case "/dashboard.css":
+		body, contentType = dashboardCss, "text/css; charset=utf-8"
+	case "/dashboard.js":
+		body, contentType = dashboardJs, "application/javascript; charset=utf-8"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(body))
+}
+
+// Serve the details of the current login
+func (s *dashboardServer) serveSession(w http.ResponseWriter, r *http.Request, role api.DashboardRole) {
+	s.writeJSON(w, http.StatusOK, &api.DashboardSessionResponse{
+		Status:     "success",
+		Role:       role,
+		ReadOnly:   role != api.DashboardRole_Operator,
+		Network:    fmt.Sprint(s.cfg.Smartnode.Network.Value),
+		TxWatchUrl: s.cfg.Smartnode.GetTxWatchUrl(),
+	})
+}
+
+// Serve the response of one of the dashboard's API commands
+func (s *dashboardServer) serveQuery(name string) func(http.ResponseWriter, *http.Request, api.DashboardRole) {
+	return func(w http.ResponseWriter, r *http.Request, role api.DashboardRole) {
+		body, err := s.runQuery(name)
+		if err != nil {
+			s.writeJSON(w, http.StatusInternalServerError, &api.APIResponse{Status: "error", Error: err.Error()})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+// Get the response of one of the dashboard's API handlers, running it if the cached response is out of date
+func (s *dashboardServer) runQuery(name string) ([]byte, error) {
+
+	entry := s.cache[name]
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+	if entry.body != nil && time.Since(entry.updated) < dashboardCacheTime {
+		return entry.body, nil
+	}
+
+	// Run the handler
+	response, err := dashboardQueries[name](s.c)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the %s response: %w", name, err)
+	}
+	entry.body = apiutils.EncodeResponse(response, nil)
+	entry.updated = time.Now()
+	return entry.body, nil
+
+}
+
+// Serve the daemon's recent log lines
+func (s *dashboardServer) serveLogs(w http.ResponseWriter, r *http.Request, role api.DashboardRole) {
+	s.writeJSON(w, http.StatusOK, &api.DashboardLogsResponse{
+		Status: "success",
+		Lines:  s.logBuffer.getLines(),
+	})
+}
+
+// Serve the node account's pending transactions
+func (s *dashboardServer) serveTransactions(w http.ResponseWriter, r *http.Request, role api.DashboardRole) {
+	response, err := s.getTransactions()
+	if err != nil {
+		s.writeJSON(w, http.StatusInternalServerError, &api.APIResponse{Status: "error", Error: err.Error()})
+		return
+	}
+	s.writeJSON(w, http.StatusOK, response)
+}
+
+// Get the node account's pending transaction count, and the status of the transactions the daemon has submitted
+func (s *dashboardServer) getTransactions() (*api.DashboardTransactionsResponse, error) {
+
+	response := &api.DashboardTransactionsResponse{
+		Status: "success",
+	}
+
+	// Get the node account's nonces
+	nodeAccount, err := s.w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+	response.MinedNonce, err = s.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the node account's nonce: %w", err)
+	}
+	response.PendingNonce, err = s.ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the node account's pending nonce: %w", err)
+	}
+	if response.PendingNonce > response.MinedNonce {
+		response.Pending = response.PendingNonce - response.MinedNonce
+	}
+
+	// Check the transactions the daemon has submitted, newest first
+	transactions := s.logBuffer.getTransactions()
+	response.Transactions = make([]api.DashboardTransaction, 0, len(transactions))
+	for i := len(transactions) - 1; i >= 0; i-- {
+		tx := transactions[i]
+		receipt, err := s.ec.TransactionReceipt(context.Background(), tx.Hash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("Error getting the receipt of transaction %s: %w", tx.Hash.Hex(), err)
+		}
+		if receipt != nil {
+			tx.Mined = true
+			tx.Succeeded = receipt.Status == 1
+			tx.BlockNumber = receipt.BlockNumber.Uint64()
+		}
+		response.Transactions = append(response.Transactions, tx)
+	}
+	return response, nil
+
+}
+
+// Write a JSON response
+func (s *dashboardServer) writeJSON(w http.ResponseWriter, status int, response interface{}) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		s.log.Printlnf("WARNING: Error encoding dashboard response: %s", err.Error())
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Check whether an address only accepts connections from this machine
+func isLoopbackAddress(address string) bool {
+	if address == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(address)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Compare a password with a secret in constant time
+func secretsMatch(password string, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(password), []byte(secret)) == 1
+}
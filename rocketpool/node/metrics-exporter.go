@@ -31,10 +31,6 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	if err != nil {
 		return err
 	}
-	ec, err := services.GetEthClient(c)
-	if err != nil {
-		return err
-	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -46,19 +42,29 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 		return fmt.Errorf("Error getting node account: %w", err)
 	}
 
+	// Start loading the state snapshots the collectors are served from
+	snapshotManager, err := collectors.NewSnapshotManager(rp, bc, nodeAccount.Address, cfg, logger)
+	if err != nil {
+		return err
+	}
+	go snapshotManager.Run()
+
 	// Create the collectors
-	demandCollector := collectors.NewDemandCollector(rp)
-	performanceCollector := collectors.NewPerformanceCollector(rp)
-	supplyCollector := collectors.NewSupplyCollector(rp)
-	rplCollector := collectors.NewRplCollector(rp)
-	odaoCollector := collectors.NewOdaoCollector(rp)
-	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg)
-	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg)
-	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address)
+	demandCollector := collectors.NewDemandCollector(snapshotManager)
+	queueCollector := collectors.NewQueueCollector(snapshotManager)
+	performanceCollector := collectors.NewPerformanceCollector(snapshotManager)
+	supplyCollector := collectors.NewSupplyCollector(snapshotManager)
+	rplCollector := collectors.NewRplCollector(snapshotManager)
+	odaoCollector := collectors.NewOdaoCollector(snapshotManager)
+	nodeCollector := collectors.NewNodeCollector(snapshotManager)
+	trustedNodeCollector := collectors.NewTrustedNodeCollector(snapshotManager)
+	beaconCollector := collectors.NewBeaconCollector(bc, snapshotManager)
+	snapshotCollector := collectors.NewSnapshotCollector(snapshotManager)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(demandCollector)
+	registry.MustRegister(queueCollector)
 	registry.MustRegister(performanceCollector)
 	registry.MustRegister(supplyCollector)
 	registry.MustRegister(rplCollector)
@@ -66,6 +72,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	registry.MustRegister(nodeCollector)
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
+	registry.MustRegister(snapshotCollector)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -1,7 +1,10 @@
 package node
 
 import (
+	"io"
+	stdlog "log"
 	"net/http"
+	"os"
 	"sync"
 	"time"
 
@@ -21,7 +24,11 @@ const (
 
 	ClaimRplRewardsColor         = color.FgGreen
 	StakePrelaunchMinipoolsColor = color.FgBlue
+	AutoStakeRplColor            = color.FgHiGreen
+	AutoBidAuctionColor          = color.FgCyan
 	MetricsColor                 = color.FgHiYellow
+	DashboardColor               = color.FgHiMagenta
+	ScheduledBackupColor         = color.FgHiBlue
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 )
@@ -44,6 +51,16 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Keep a copy of the log output for the web dashboard if it's enabled
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	logBuffer := newDashboardLogBuffer()
+	if cfg.Smartnode.EnableDashboard.Value == true {
+		stdlog.SetOutput(io.MultiWriter(os.Stderr, logBuffer))
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
@@ -58,13 +75,37 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	autoStakeRpl, err := newAutoStakeRpl(c, log.NewColorLogger(AutoStakeRplColor))
+	if err != nil {
+		return err
+	}
+	autoBidAuction, err := newAutoBidAuction(c, log.NewColorLogger(AutoBidAuctionColor))
+	if err != nil {
+		return err
+	}
+	scheduledBackup, err := newScheduledBackup(c, log.NewColorLogger(ScheduledBackupColor))
+	if err != nil {
+		return err
+	}
 
 	// Initialize loggers
 	errorLog := log.NewColorLogger(ErrorColor)
 
+	// Refuse automatic transactions if the node account is held on a hardware wallet, since nobody is around to confirm them on the device
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	w.SetUnattended()
+	if hardwareWallet := w.GetHardwareWalletName(); hardwareWallet != "" {
+		warningLog := log.NewColorLogger(WarningColor)
+		warningLog.Printlnf("WARNING: The node account is held on your %s hardware wallet, so the node daemon won't claim RPL rewards, stake minipools, top up RPL collateral or bid on RPL auctions. "+
+			"Use the CLI for these instead, and stake prelaunch minipools with `rocketpool minipool stake` before they time out and are dissolved.", hardwareWallet)
+	}
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(3)
 
 	// Run task loop
 	go func() {
@@ -84,6 +125,23 @@ func run(c *cli.Context) error {
 				if err := stakePrelaunchMinipools.run(); err != nil {
 					errorLog.Println(err)
 				}
+				time.Sleep(taskCooldown)
+
+				// Run the RPL collateral check
+				if err := autoStakeRpl.run(); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the RPL auction check
+				if err := autoBidAuction.run(); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Run the backup check, which doesn't need the EC
+			if err := scheduledBackup.run(); err != nil {
+				errorLog.Println(err)
 			}
 			time.Sleep(tasksInterval)
 		}
@@ -99,7 +157,16 @@ func run(c *cli.Context) error {
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Run dashboard loop
+	go func() {
+		err := runDashboardServer(c, log.NewColorLogger(DashboardColor), logBuffer)
+		if err != nil {
+			errorLog.Println(err)
+		}
+		wg.Done()
+	}()
+
+	// Wait for all threads to stop
 	wg.Wait()
 	return nil
 
@@ -0,0 +1,127 @@
+package node
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Scheduled backup task
+type scheduledBackup struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	d            *client.Client
+	settingsPath string
+	interval     time.Duration
+	keepCount    int
+	enabled      bool
+}
+
+// Create scheduled backup task
+func newScheduledBackup(c *cli.Context, logger log.ColorLogger) (*scheduledBackup, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if scheduled backups are enabled
+	enabled := cfg.Backup.EnableScheduledBackups.Value == true
+	intervalHours := cfg.Backup.IntervalHours.Value.(uint64)
+	if enabled && intervalHours == 0 {
+		logger.Println("WARNING: the backup interval is set to 0 hours. Scheduled backups will be disabled.")
+		enabled = false
+	}
+	if enabled && !cfg.Backup.CanEncrypt() {
+		logger.Println("WARNING: there is no encryption passphrase, GPG public key or age recipient to encrypt backups with. Scheduled backups will be disabled.")
+		enabled = false
+	}
+
+	// Return task
+	return &scheduledBackup{
+		c:            c,
+		log:          logger,
+		cfg:          cfg,
+		d:            d,
+		settingsPath: os.ExpandEnv(c.GlobalString("settings")),
+		interval:     time.Duration(intervalHours) * time.Hour,
+		keepCount:    int(cfg.Backup.KeepCount.Value.(uint64)),
+		enabled:      enabled,
+	}, nil
+
+}
+
+// Make a backup if the interval has passed since the last one
+func (t *scheduledBackup) run() error {
+
+	// Check if scheduled backups are enabled
+	if !t.enabled {
+		return nil
+	}
+
+	// Check when the last backup was made
+	lastBackupPath := t.cfg.Backup.GetLastBackupPath()
+	lastBackupBytes, err := ioutil.ReadFile(lastBackupPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Error reading the time of the last backup from %s: %w", lastBackupPath, err)
+	}
+	if err == nil {
+		lastBackup, err := time.Parse(time.RFC3339, strings.TrimSpace(string(lastBackupBytes)))
+		if err == nil && time.Since(lastBackup) < t.interval {
+			return nil
+		}
+	}
+
+	// Log
+	t.log.Println("Creating scheduled backup...")
+
+	// Create the backup
+	_, location, manifest, err := backup.Create(t.cfg, t.settingsPath, t.d)
+	if err != nil {
+		return fmt.Errorf("Error creating scheduled backup: %w", err)
+	}
+	t.log.Printlnf("Backed up %d files to %s.", len(manifest.Files), location)
+	for _, note := range manifest.Notes {
+		t.log.Printlnf("NOTE: %s", note)
+	}
+
+	// Record the time of the backup
+	if err := ioutil.WriteFile(lastBackupPath, []byte(manifest.Created.Format(time.RFC3339)), backup.FileMode); err != nil {
+		return fmt.Errorf("Error recording the time of the backup in %s: %w", lastBackupPath, err)
+	}
+
+	// Delete the oldest backups
+	if t.keepCount > 0 {
+		target, err := backup.NewTarget(t.cfg.Backup, true)
+		if err != nil {
+			return err
+		}
+		deleted, err := backup.Prune(target, manifest.Network, t.keepCount)
+		for _, name := range deleted {
+			t.log.Printlnf("Deleted old backup %s.", name)
+		}
+		if err != nil {
+			return fmt.Errorf("Error deleting old backups: %w", err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
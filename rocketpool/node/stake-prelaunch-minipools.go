@@ -14,7 +14,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -27,6 +26,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
@@ -220,12 +220,11 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 		return []*minipool.Minipool{}, err
 	}
 
-	// Get the scrub period
-	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(t.rp, nil)
+	// Get the lifecycle settings
+	lifecycleSettings, err := rputils.GetMinipoolLifecycleSettings(t.rp, nil)
 	if err != nil {
 		return []*minipool.Minipool{}, err
 	}
-	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
 
 	// Get the time of the latest block
 	latestEth1Block, err := t.rp.Client.HeaderByNumber(context.Background(), nil)
@@ -238,11 +237,10 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 	prelaunchMinipools := []*minipool.Minipool{}
 	for mi, mp := range minipools {
 		if statuses[mi].Status == rptypes.Prelaunch {
-			creationTime := statuses[mi].StatusTime
-			remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
-			if remainingTime < 0 {
+			if rputils.IsMinipoolStakeable(statuses[mi], lifecycleSettings, latestBlockTime) {
 				prelaunchMinipools = append(prelaunchMinipools, mp)
 			} else {
+				remainingTime := rputils.GetScrubCheckEnd(statuses[mi], lifecycleSettings).Sub(latestBlockTime)
 				t.log.Printlnf("Minipool %s has %s left until it can be staked.", mp.Address.Hex(), remainingTime)
 			}
 		}
@@ -102,6 +102,10 @@ func main() {
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Simulate transactions against the pending block instead of signing and sending them",
+		},
 		cli.StringFlag{
 			Name:  "metricsAddress, m",
 			Usage: "Address to serve metrics on if enabled",
@@ -112,6 +116,11 @@ func main() {
 			Usage: "Port to serve metrics on if enabled",
 			Value: 9102,
 		},
+		cli.StringFlag{
+			Name:  "dashboardAddress",
+			Usage: "Address to serve the web dashboard on if enabled",
+			Value: "127.0.0.1",
+		},
 		cli.BoolFlag{
 			Name:  "ignore-sync-check",
 			Usage: "Set this to true if you already checked the sync status of the execution client(s) and don't need to re-check it for this command",
@@ -10,8 +10,6 @@ import (
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
-	"github.com/rocket-pool/rocketpool-go/settings/protocol"
-	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
@@ -21,6 +19,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 // Settings
@@ -125,7 +124,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
 	// Data
 	var wg1 errgroup.Group
 	var addresses []common.Address
-	var launchTimeout time.Duration
+	var lifecycleSettings rputils.MinipoolLifecycleSettings
 	var latestEth1Block *types.Header
 
 	// Get minipool addresses
@@ -135,10 +134,10 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
 		return err
 	})
 
-	// Get launch timeout
+	// Get lifecycle settings
 	wg1.Go(func() error {
 		var err error
-		launchTimeout, err = protocol.GetMinipoolLaunchTimeout(t.rp, nil)
+		lifecycleSettings, err = rputils.GetMinipoolLifecycleSettings(t.rp, nil)
 		return err
 	})
 
@@ -198,7 +197,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
 	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 	timedOutMinipools := []*minipool.Minipool{}
 	for mi, mp := range minipools {
-		if statuses[mi].Status == rptypes.Prelaunch && latestBlockTime.Sub(statuses[mi].StatusTime) >= launchTimeout {
+		if rputils.IsMinipoolTimedOut(statuses[mi], lifecycleSettings, latestBlockTime) {
 			timedOutMinipools = append(timedOutMinipools, mp)
 		}
 	}
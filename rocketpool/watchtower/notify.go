@@ -0,0 +1,68 @@
+package watchtower
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait for the notification webhook to respond
+const notificationTimeout = 10 * time.Second
+
+// Sends notifications to the watchtower log, and to a webhook if one is configured
+type notifier struct {
+	log        log.ColorLogger
+	webhookUrl string
+	client     *http.Client
+}
+
+// Create a notifier
+func newNotifier(cfg *config.RocketPoolConfig, logger log.ColorLogger) *notifier {
+	return &notifier{
+		log:        logger,
+		webhookUrl: cfg.Smartnode.ProposalWebhookUrl.Value.(string),
+		client:     &http.Client{Timeout: notificationTimeout},
+	}
+}
+
+// Send a notification
+// Delivery failures are logged rather than returned, so they don't interrupt the task that raised the notification.
+func (n *notifier) notify(message string) {
+
+	n.log.Println(message)
+	if n.webhookUrl == "" {
+		return
+	}
+
+	// Discord reads the content field and Slack reads the text field, so set both
+	body, err := json.Marshal(map[string]string{
+		"content": message,
+		"text":    message,
+	})
+	if err != nil {
+		n.log.Printlnf("WARNING: Error serializing notification: %s", err.Error())
+		return
+	}
+	if err := n.post(body); err != nil {
+		n.log.Printlnf("WARNING: Error sending notification to the webhook: %s", err.Error())
+	}
+
+}
+
+// Post a notification body to the webhook
+func (n *notifier) post(body []byte) error {
+	response, err := n.client.Post(n.webhookUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
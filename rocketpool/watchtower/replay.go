@@ -0,0 +1,260 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The tasks that can be replayed
+const (
+	ReplayTaskBalances = "balances"
+	ReplayTaskPrices   = "prices"
+)
+
+// Replay a watchtower calculation for a historic block without submitting anything
+func replay(c *cli.Context) error {
+
+	// Get & validate the flags
+	task := c.String("task")
+	if task != ReplayTaskBalances && task != ReplayTaskPrices {
+		return fmt.Errorf("Invalid task '%s' - valid tasks are '%s' and '%s'", task, ReplayTaskBalances, ReplayTaskPrices)
+	}
+	blockNumber := c.Uint64("block")
+	if blockNumber == 0 {
+		return fmt.Errorf("Please specify the block to replay with --block")
+	}
+
+	// Configure
+	configureHTTP()
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the block has been mined
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("Error getting latest block number: %w", err)
+	}
+	if blockNumber > latestBlock {
+		return fmt.Errorf("Block %d has not been mined yet (latest block is %d)", blockNumber, latestBlock)
+	}
+
+	// Replay the task
+	switch task {
+	case ReplayTaskBalances:
+		return replayNetworkBalances(c, rp, blockNumber, latestBlock)
+	default:
+		return replayRplPrice(c, rp, blockNumber, latestBlock)
+	}
+
+}
+
+// Replay the network balances calculation for a block
+func replayNetworkBalances(c *cli.Context, rp *rocketpool.RocketPool, blockNumber uint64, latestBlock uint64) error {
+
+	// Balances are calculated from minipool validator balances, so the beacon client is needed too
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return err
+	}
+	task, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor))
+	if err != nil {
+		return err
+	}
+
+	// Calculate the balances
+	fmt.Printf("Calculating network balances for block %d...\n\n", blockNumber)
+	balances, err := task.getNetworkBalances(blockNumber)
+	if err != nil {
+		return fmt.Errorf("Error calculating network balances at block %d: %w", blockNumber, err)
+	}
+	totalEth := big.NewInt(0)
+	totalEth.Add(totalEth, balances.DepositPool)
+	totalEth.Add(totalEth, balances.MinipoolsTotal)
+	totalEth.Add(totalEth, balances.RETHContract)
+
+	// Print the breakdown
+	stakingCount := 0
+	for _, mp := range balances.Minipools {
+		if mp.IsStaking {
+			stakingCount++
+		}
+	}
+	fmt.Printf("Deposit pool balance:          %.6f ETH\n", mathutils.RoundDown(eth.WeiToEth(balances.DepositPool), 6))
+	fmt.Printf("Total minipool user balance:   %.6f ETH (%d minipools)\n", mathutils.RoundDown(eth.WeiToEth(balances.MinipoolsTotal), 6), len(balances.Minipools))
+	fmt.Printf("Staking minipool user balance: %.6f ETH (%d minipools)\n", mathutils.RoundDown(eth.WeiToEth(balances.MinipoolsStaking), 6), stakingCount)
+	fmt.Printf("rETH contract balance:         %.6f ETH\n", mathutils.RoundDown(eth.WeiToEth(balances.RETHContract), 6))
+	fmt.Printf("rETH token supply:             %.6f rETH\n", mathutils.RoundDown(eth.WeiToEth(balances.RETHSupply), 6))
+	fmt.Printf("Total ETH:                     %.6f ETH\n\n", mathutils.RoundDown(eth.WeiToEth(totalEth), 6))
+	if c.Bool("minipools") {
+		fmt.Printf("Minipools (%d):\n", len(balances.Minipools))
+		for _, mp := range balances.Minipools {
+			fmt.Printf("\t%s  staking=%t  userBalance=%s\n", mp.Address.Hex(), mp.IsStaking, mp.UserBalance.String())
+		}
+		fmt.Println()
+	}
+
+	// Compare against what the oracle DAO submitted
+	frequency, err := protocol.GetSubmitBalancesFrequency(rp, nil)
+	if err != nil {
+		return err
+	}
+	return compareReplay(c, rp, "rocketNetworkBalances", rputils.BalancesSubmittedEvent, rputils.BalancesUpdatedEvent, blockNumber, latestBlock, frequency, map[string]*big.Int{
+		"totalEth":   totalEth,
+		"stakingEth": balances.MinipoolsStaking,
+		"rethSupply": balances.RETHSupply,
+	})
+
+}
+
+// Replay the RPL price calculation for a block
+func replayRplPrice(c *cli.Context, rp *rocketpool.RocketPool, blockNumber uint64, latestBlock uint64) error {
+
+	task, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor))
+	if err != nil {
+		return err
+	}
+
+	// Calculate the price and the effective stake
+	fmt.Printf("Getting RPL price for block %d...\n\n", blockNumber)
+	check, err := task.getRplPrice(blockNumber)
+	if check == nil {
+		return err
+	}
+	for _, source := range check.Prices {
+		if source.Error != nil {
+			fmt.Printf("%-30s unavailable (%s)\n", source.Source+" price:", source.Error.Error())
+		} else {
+			fmt.Printf("%-30s %.6f ETH\n", source.Source+" price:", mathutils.RoundDown(eth.WeiToEth(source.Price), 6))
+		}
+	}
+	fmt.Printf("Largest deviation from median: %.2f%% (tolerance %.2f%%)\n", check.MaxDeviation, check.Tolerance)
+	if err != nil {
+		fmt.Printf("The watchtower would have refused to submit this price: %s\n", err.Error())
+	}
+	if check.Median == nil {
+		return nil
+	}
+	rplPrice := check.Median
+	zero := big.NewInt(0)
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(blockNumber),
+	}
+	effectiveRplStake, err := node.CalculateTotalEffectiveRPLStake(rp, zero, zero, rplPrice, opts)
+	if err != nil {
+		return fmt.Errorf("Error getting total effective RPL stake at block %d: %w", blockNumber, err)
+	}
+
+	// Print the breakdown
+	fmt.Printf("RPL price (median):            %.6f ETH\n", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
+	fmt.Printf("Total effective RPL stake:     %.6f RPL\n\n", mathutils.RoundDown(eth.WeiToEth(effectiveRplStake), 6))
+	fmt.Printf("NOTE: the watchtower calculates the effective RPL stake at the time it submits, so it may differ slightly from the value at the reported block.\n\n")
+
+	// Compare against what the oracle DAO submitted
+	frequency, err := protocol.GetSubmitPricesFrequency(rp, nil)
+	if err != nil {
+		return err
+	}
+	return compareReplay(c, rp, "rocketNetworkPrices", rputils.PricesSubmittedEvent, rputils.PricesUpdatedEvent, blockNumber, latestBlock, frequency, map[string]*big.Int{
+		"rplPrice":          rplPrice,
+		"effectiveRplStake": effectiveRplStake,
+	})
+
+}
+
+// Compare replayed values against the consensus update and each member's submission for the block
+func compareReplay(c *cli.Context, rp *rocketpool.RocketPool, contractName string, submittedEvent string, updatedEvent string, blockNumber uint64, latestBlock uint64, frequency uint64, values map[string]*big.Int) error {
+
+	// Submissions for a block are made after it, so search up to two submission intervals ahead
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	eventLogInterval, err := api.GetEventLogInterval(cfg)
+	if err != nil {
+		return err
+	}
+	fromBlock := big.NewInt(0).SetUint64(blockNumber)
+	toBlock := blockNumber + (frequency * 2)
+	if toBlock > latestBlock {
+		toBlock = latestBlock
+	}
+
+	// Get the updates for the block
+	consensus, err := rputils.GetNetworkUpdates(rp, contractName, updatedEvent, fromBlock, fromBlock, big.NewInt(0).SetUint64(toBlock), eventLogInterval)
+	if err != nil {
+		return err
+	}
+	submissions, err := rputils.GetNetworkUpdates(rp, contractName, submittedEvent, fromBlock, fromBlock, big.NewInt(0).SetUint64(toBlock), eventLogInterval)
+	if err != nil {
+		return err
+	}
+
+	// Print the consensus comparison
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(consensus) == 0 {
+		fmt.Printf("The oracle DAO did not reach consensus for block %d between blocks %d and %d.\n\n", blockNumber, blockNumber, toBlock)
+	} else {
+		fmt.Printf("Consensus update (block %d, TX %s):\n", consensus[0].BlockNumber, consensus[0].TxHash.Hex())
+		printReplayComparison(keys, values, consensus[0].Values)
+		fmt.Println()
+	}
+
+	// Print each member's submission
+	if len(submissions) == 0 {
+		fmt.Printf("No oracle DAO member submitted values for block %d between blocks %d and %d.\n", blockNumber, blockNumber, toBlock)
+		return nil
+	}
+	fmt.Printf("Member submissions (%d):\n", len(submissions))
+	for _, submission := range submissions {
+		memberId, err := trustednode.GetMemberID(rp, submission.Submitter, nil)
+		if err != nil || memberId == "" {
+			memberId = "unknown"
+		}
+		fmt.Printf("%s (%s), block %d, TX %s:\n", memberId, submission.Submitter.Hex(), submission.BlockNumber, submission.TxHash.Hex())
+		printReplayComparison(keys, values, submission.Values)
+	}
+	return nil
+
+}
+
+// Print replayed values next to the values submitted on chain
+func printReplayComparison(keys []string, replayed map[string]*big.Int, submitted map[string]*big.Int) {
+	for _, key := range keys {
+		value := replayed[key]
+		onChain, exists := submitted[key]
+		if !exists {
+			fmt.Printf("\t%-20s %s (not submitted)\n", key+":", value.String())
+		} else if onChain.Cmp(value) == 0 {
+			fmt.Printf("\t%-20s %s (matches)\n", key+":", value.String())
+		} else {
+			difference := big.NewInt(0).Sub(value, onChain)
+			fmt.Printf("\t%-20s %s (submitted %s, difference %s)\n", key+":", value.String(), onChain.String(), difference.String())
+		}
+	}
+}
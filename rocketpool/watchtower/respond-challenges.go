@@ -1,7 +1,11 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -9,8 +13,10 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
@@ -22,6 +28,7 @@ type respondChallenges struct {
 	cfg *config.RocketPoolConfig
 	w   *wallet.Wallet
 	rp  *rocketpool.RocketPool
+	al  *audit.Log
 }
 
 // Create respond to challenges task
@@ -48,6 +55,7 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger) (*respondChall
 		cfg: cfg,
 		w:   w,
 		rp:  rp,
+		al:  audit.NewLog(cfg),
 	}, nil
 
 }
@@ -79,7 +87,11 @@ func (t *respondChallenges) run() error {
 	t.log.Println("Checking for challenges to respond to...")
 
 	// Check for active challenges
-	isChallenged, err := trustednode.GetMemberIsChallenged(t.rp, nodeAccount.Address, nil)
+	challengeBlock, err := t.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return err
+	}
+	isChallenged, err := trustednode.GetMemberIsChallenged(t.rp, nodeAccount.Address, &bind.CallOpts{BlockNumber: big.NewInt(0).SetUint64(challengeBlock)})
 	if err != nil {
 		return err
 	}
@@ -115,12 +127,20 @@ func (t *respondChallenges) run() error {
 
 	// Respond to challenge
 	hash, err := trustednode.DecideChallenge(t.rp, nodeAccount.Address, opts)
-	if err != nil {
-		return err
+	if err == nil {
+		// Print TX info and wait for it to be mined
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	}
 
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Record the submission
+	submission := audit.NewSubmission(apitypes.Submission_ChallengeResponse, challengeBlock)
+	submission.Inputs["member"] = nodeAccount.Address.Hex()
+	if err == nil {
+		submission.Result["challengeDecided"] = "true"
+	}
+	if auditErr := t.al.Save(submission, hash, err); auditErr != nil {
+		t.log.Printlnf("WARNING: %s", auditErr.Error())
+	}
 	if err != nil {
 		return err
 	}
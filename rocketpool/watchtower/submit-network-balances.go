@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,9 +22,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -45,6 +48,7 @@ type submitNetworkBalances struct {
 	ec  rocketpool.ExecutionClient
 	rp  *rocketpool.RocketPool
 	bc  beacon.Client
+	al  *audit.Log
 }
 
 // Network balance info
@@ -55,8 +59,10 @@ type networkBalances struct {
 	MinipoolsStaking *big.Int
 	RETHContract     *big.Int
 	RETHSupply       *big.Int
+	Minipools        []minipoolBalanceDetails
 }
 type minipoolBalanceDetails struct {
+	Address     common.Address
 	IsStaking   bool
 	UserBalance *big.Int
 }
@@ -95,6 +101,7 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
 		ec:  ec,
 		rp:  rp,
 		bc:  bc,
+		al:  audit.NewLog(cfg),
 	}, nil
 
 }
@@ -337,6 +344,7 @@ func (t *submitNetworkBalances) getNetworkBalances(blockNumber uint64) (networkB
 		MinipoolsStaking: big.NewInt(0),
 		RETHContract:     rethContractBalance,
 		RETHSupply:       rethTotalSupply,
+		Minipools:        minipoolBalanceDetails,
 	}
 
 	// Add minipool balances
@@ -490,6 +498,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
 	// No balance if no user deposit assigned
 	if userDepositBalance.Cmp(big.NewInt(0)) == 0 {
 		return minipoolBalanceDetails{
+			Address:     minipoolAddress,
 			UserBalance: big.NewInt(0),
 		}, nil
 	}
@@ -497,6 +506,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
 	// Use user deposit balance if initialized or prelaunch
 	if status == types.Initialized || status == types.Prelaunch {
 		return minipoolBalanceDetails{
+			Address:     minipoolAddress,
 			UserBalance: userDepositBalance,
 		}, nil
 	}
@@ -504,6 +514,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
 	// Use user deposit balance if validator not yet active on beacon chain at block
 	if !validator.Exists || validator.ActivationEpoch >= blockEpoch {
 		return minipoolBalanceDetails{
+			Address:     minipoolAddress,
 			UserBalance: userDepositBalance,
 		}, nil
 	}
@@ -547,6 +558,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
 
 	// Return
 	return minipoolBalanceDetails{
+		Address:     minipoolAddress,
 		IsStaking:   (validator.ExitEpoch > blockEpoch),
 		UserBalance: userBalance,
 	}, nil
@@ -590,12 +602,15 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 
 	// Submit balances
 	hash, err := network.SubmitBalances(t.rp, balances.Block, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts)
-	if err != nil {
-		return err
+	if err == nil {
+		// Print TX info and wait for it to be mined
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	}
 
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Record the submission
+	if auditErr := t.al.Save(t.getSubmissionRecord(balances, totalEth, err == nil), hash, err); auditErr != nil {
+		t.log.Printlnf("WARNING: %s", auditErr.Error())
+	}
 	if err != nil {
 		return err
 	}
@@ -607,3 +622,24 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	return nil
 
 }
+
+// Get the audit record of a network balances submission, with its result if it succeeded
+func (t *submitNetworkBalances) getSubmissionRecord(balances networkBalances, totalEth *big.Int, succeeded bool) *apitypes.WatchtowerSubmission {
+	submission := audit.NewSubmission(apitypes.Submission_NetworkBalances, balances.Block)
+	submission.Inputs["depositPool"] = audit.Amount(balances.DepositPool)
+	submission.Inputs["minipoolsTotal"] = audit.Amount(balances.MinipoolsTotal)
+	submission.Inputs["minipoolsStaking"] = audit.Amount(balances.MinipoolsStaking)
+	submission.Inputs["rethContract"] = audit.Amount(balances.RETHContract)
+	if succeeded {
+		submission.Result["totalEth"] = audit.Amount(totalEth)
+		submission.Result["stakingEth"] = audit.Amount(balances.MinipoolsStaking)
+		submission.Result["rethSupply"] = audit.Amount(balances.RETHSupply)
+	}
+	for _, mp := range balances.Minipools {
+		audit.AddMinipool(submission, mp.Address, map[string]string{
+			"isStaking":   strconv.FormatBool(mp.IsStaking),
+			"userBalance": audit.Amount(mp.UserBalance),
+		})
+	}
+	return submission
+}
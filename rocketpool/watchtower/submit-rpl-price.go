@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strconv"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
@@ -18,9 +18,12 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
@@ -39,6 +42,8 @@ type submitRplPrice struct {
 	w   *wallet.Wallet
 	rp  *rocketpool.RocketPool
 	oio *contracts.OneInchOracle
+	pc  *prices.PriceChecker
+	al  *audit.Log
 }
 
 // Create submit RPL price task
@@ -66,6 +71,11 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 		return nil, err
 	}
 
+	pc, err := prices.NewPriceChecker(cfg, rp, oio)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return task
 	return &submitRplPrice{
 		c:   c,
@@ -75,6 +85,8 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 		w:   w,
 		rp:  rp,
 		oio: oio,
+		pc:  pc,
+		al:  audit.NewLog(cfg),
 	}, nil
 
 }
@@ -162,10 +174,17 @@ func (t *submitRplPrice) run() error {
 	t.log.Printlnf("Getting RPL price for block %d...", blockNumber)
 
 	// Get RPL price at block
-	rplPrice, err := t.getRplPrice(blockNumber)
+	check, err := t.getRplPrice(blockNumber)
+	if check != nil {
+		t.logPriceSources(check)
+	}
 	if err != nil {
+		if check != nil {
+			t.log.Printlnf("ALERT: Refusing to submit the RPL price for block %d: %s", blockNumber, err.Error())
+		}
 		return err
 	}
+	rplPrice := check.Median
 
 	// Calculate the total effective RPL stake on the network
 	zero := new(big.Int).SetUint64(0)
@@ -175,7 +194,7 @@ func (t *submitRplPrice) run() error {
 	}
 
 	// Log
-	t.log.Printlnf("RPL price: %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
+	t.log.Printlnf("RPL price (median): %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
 
 	// Check if we have reported these specific values before
 	hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(nodeAccount.Address, blockNumber, rplPrice, effectiveRplStake)
@@ -199,7 +218,7 @@ func (t *submitRplPrice) run() error {
 	t.log.Println("Submitting RPL price...")
 
 	// Submit RPL price
-	if err := t.submitRplPrice(blockNumber, rplPrice, effectiveRplStake); err != nil {
+	if err := t.submitRplPrice(check, effectiveRplStake); err != nil {
 		return fmt.Errorf("Could not submit RPL price: %w", err)
 	}
 
@@ -249,35 +268,39 @@ func (t *submitRplPrice) hasSubmittedSpecificBlockPrices(nodeAddress common.Addr
 
 }
 
-// Get RPL price at block
-func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*big.Int, error) {
+// Get RPL price at block from every price source
+func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*prices.PriceCheck, error) {
 
 	// Require 1inch oracle contract
 	if err := services.RequireOneInchOracle(t.c); err != nil {
 		return nil, err
 	}
 
-	// Get RPL token address
-	rplAddress := common.HexToAddress(t.cfg.Smartnode.GetRplTokenAddress())
-
-	// Initialize call options
-	opts := &bind.CallOpts{
-		BlockNumber: big.NewInt(int64(blockNumber)),
-	}
-
-	// Get RPL price
-	rplPrice, err := t.oio.GetRateToEth(opts, rplAddress, true)
-	if err != nil {
+	// Get the price from each source and check they agree
+	check, err := t.pc.GetRplPrice(blockNumber)
+	if check == nil {
 		return nil, fmt.Errorf("Could not get RPL price at block %d: %w", blockNumber, err)
 	}
+	return check, err
 
-	// Return
-	return rplPrice, nil
+}
 
+// Log the price reported by each source
+func (t *submitRplPrice) logPriceSources(check *prices.PriceCheck) {
+	for _, source := range check.Prices {
+		if source.Error != nil {
+			t.log.Printlnf("%s price: unavailable (%s)", source.Source, source.Error.Error())
+		} else {
+			t.log.Printlnf("%s price: %.6f ETH", source.Source, mathutils.RoundDown(eth.WeiToEth(source.Price), 6))
+		}
+	}
 }
 
 // Submit RPL price and total effective RPL stake
-func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveRplStake *big.Int) error {
+func (t *submitRplPrice) submitRplPrice(check *prices.PriceCheck, effectiveRplStake *big.Int) error {
+
+	blockNumber := check.Block
+	rplPrice := check.Median
 
 	// Log
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
@@ -307,12 +330,26 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 
 	// Submit RPL price
 	hash, err := network.SubmitPrices(t.rp, blockNumber, rplPrice, effectiveRplStake, opts)
-	if err != nil {
-		return err
+	if err == nil {
+		// Print TX info and wait for it to be mined
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	}
 
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Record the submission
+	submission := audit.NewSubmission(apitypes.Submission_RplPrice, blockNumber)
+	for _, source := range check.Prices {
+		if source.Error == nil {
+			submission.Inputs[source.Source] = audit.Amount(source.Price)
+		}
+	}
+	submission.Inputs["maxDeviation"] = strconv.FormatFloat(check.MaxDeviation, 'f', 4, 64)
+	if err == nil {
+		submission.Result["rplPrice"] = audit.Amount(rplPrice)
+		submission.Result["effectiveRplStake"] = audit.Amount(effectiveRplStake)
+	}
+	if auditErr := t.al.Save(submission, hash, err); auditErr != nil {
+		t.log.Printlnf("WARNING: %s", auditErr.Error())
+	}
 	if err != nil {
 		return err
 	}
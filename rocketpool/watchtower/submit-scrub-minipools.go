@@ -15,16 +15,18 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/types"
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	ethpb "github.com/prysmaticlabs/prysm/v2/proto/prysm/v1alpha1"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/indexer"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -46,10 +48,12 @@ type submitScrubMinipools struct {
 	rp        *rocketpool.RocketPool
 	ec        rocketpool.ExecutionClient
 	bc        beacon.Client
+	idx       *indexer.Indexer
 	it        *iterationData
 	coll      *collectors.ScrubCollector
 	lock      *sync.Mutex
 	isRunning bool
+	al        *audit.Log
 }
 
 type iterationData struct {
@@ -64,14 +68,19 @@ type iterationData struct {
 	unknownMinipools      int
 	safetyScrubs          int
 
+	// The latest block when the check started
+	checkBlock uint64
+
 	// Minipool info
 	minipools map[*minipool.Minipool]*minipoolDetails
 
+	// The check that failed for each minipool being scrubbed, and the values it was based on
+	scrubInputs map[*minipool.Minipool]map[string]string
+
 	// ETH1 search artifacts
-	startBlock       *big.Int
-	eventLogInterval *big.Int
-	depositDomain    []byte
-	latestBlockTime  time.Time
+	startBlock      *big.Int
+	depositDomain   []byte
+	latestBlockTime time.Time
 }
 
 type minipoolDetails struct {
@@ -118,6 +127,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		coll:      coll,
 		lock:      lock,
 		isRunning: false,
+		al:        audit.NewLog(cfg),
 	}, nil
 
 }
@@ -169,6 +179,15 @@ func (t *submitScrubMinipools) run() error {
 		t.log.Printlnf("%s Starting scrub check in a separate thread.", checkPrefix)
 
 		t.it = new(iterationData)
+		t.it.scrubInputs = map[*minipool.Minipool]map[string]string{}
+
+		// Get the block the check is starting at
+		checkBlock, err := t.ec.BlockNumber(context.Background())
+		if err != nil {
+			t.handleError(fmt.Errorf("%s %w", checkPrefix, err))
+			return
+		}
+		t.it.checkBlock = checkBlock
 
 		// Get minipools in prelaunch status
 		minipoolAddresses, err := minipool.GetPrelaunchMinipoolAddresses(t.rp, nil)
@@ -335,6 +354,12 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types
 				t.log.Printlnf("\tActual creds: %s", beaconCreds.Hex())
 				t.log.Println("======================================")
 				minipoolsToScrub = append(minipoolsToScrub, minipool)
+				t.it.scrubInputs[minipool] = map[string]string{
+					"check":               "beaconChain",
+					"pubkey":              pubkey.Hex(),
+					"expectedCredentials": expectedCreds.Hex(),
+					"actualCredentials":   beaconCreds.Hex(),
+				}
 				t.it.badOnBeaconCount++
 			} else {
 				// This minipool's credentials match, it's clean.
@@ -386,12 +411,17 @@ func (t *submitScrubMinipools) getEth1SearchArtifacts() error {
 	}
 	t.it.startBlock = targetBlock.Number
 
-	// Check the prestake event from the minipool and validate its signature
-	eventLogInterval, err := api.GetEventLogInterval(t.cfg)
-	if err != nil {
-		return err
+	// Bring the event index up to date so the prestake and deposit events can be looked up
+	if t.idx == nil {
+		idx, err := indexer.NewIndexer(t.rp, t.cfg, "watchtower")
+		if err != nil {
+			return err
+		}
+		t.idx = idx
+	}
+	if err := t.idx.Sync(latestEth1Block.Number); err != nil {
+		return fmt.Errorf("Error updating event index: %w", err)
 	}
-	t.it.eventLogInterval = eventLogInterval
 
 	// Put together the signature validation data
 	eth2Config, err := t.bc.GetEth2Config()
@@ -416,7 +446,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 	weiPerGwei := big.NewInt(int64(eth.WeiPerGwei))
 	for minipool := range t.it.minipools {
 		// Get the MinipoolPrestaked event
-		prestakeData, err := minipool.GetPrestakeEvent(t.it.eventLogInterval, nil)
+		prestakeData, err := t.idx.GetPrestakeEvent(minipool.Address)
 		if err != nil {
 			t.log.Printlnf("Error getting prestake event for minipool %s: %s", minipool.Address.Hex(), err.Error())
 			continue
@@ -443,6 +473,11 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
 			// Remove this minipool from the list of things to process in the next step
 			minipoolsToScrub = append(minipoolsToScrub, minipool)
+			t.it.scrubInputs[minipool] = map[string]string{
+				"check":  "prestake",
+				"pubkey": prestakeData.Pubkey.Hex(),
+				"error":  err.Error(),
+			}
 			t.it.badPrestakeCount++
 			delete(t.it.minipools, minipool)
 		} else {
@@ -475,7 +510,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 	}
 
 	// Get the deposits from the deposit contract
-	depositMap, err := utils.GetDeposits(t.rp, pubkeys, t.it.startBlock, t.it.eventLogInterval, nil)
+	depositMap, err := t.idx.GetDeposits(pubkeys, t.it.startBlock.Uint64())
 	if err != nil {
 		return err
 	}
@@ -519,6 +554,13 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 					t.log.Printlnf("\tActual creds: %s", actualCreds.Hex())
 					t.log.Println("==========================================")
 					minipoolsToScrub = append(minipoolsToScrub, minipool)
+					t.it.scrubInputs[minipool] = map[string]string{
+						"check":               "depositContract",
+						"pubkey":              details.pubkey.Hex(),
+						"depositTxHash":       deposit.TxHash.Hex(),
+						"expectedCredentials": expectedCreds.Hex(),
+						"actualCredentials":   actualCreds.Hex(),
+					}
 					t.it.badOnDepositContract++
 				} else {
 					t.it.goodOnDepositContract++
@@ -592,6 +634,12 @@ func (t *submitScrubMinipools) checkSafetyScrub() error {
 			t.log.Printlnf("\tSafety scrub period: %s", safetyPeriod)
 			t.log.Println("=============================")
 			minipoolsToScrub = append(minipoolsToScrub, minipool)
+			t.it.scrubInputs[minipool] = map[string]string{
+				"check":           "safety",
+				"prelaunchTime":   statusDetails.StatusTime.UTC().String(),
+				"safetyPeriod":    safetyPeriod.String(),
+				"latestBlockTime": t.it.latestBlockTime.UTC().String(),
+			}
 			t.it.safetyScrubs++
 			// Remove this minipool from the list of things to process in the next step
 			delete(t.it.minipools, minipool)
@@ -641,12 +689,21 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) er
 
 	// Dissolve
 	hash, err := mp.VoteScrub(opts)
-	if err != nil {
-		return err
+	if err == nil {
+		// Print TX info and wait for it to be mined
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	}
 
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Record the submission
+	submission := audit.NewSubmission(apitypes.Submission_ScrubMinipool, t.it.checkBlock)
+	submission.Inputs = t.it.scrubInputs[mp]
+	if err == nil {
+		submission.Result["scrub"] = "true"
+	}
+	audit.AddMinipool(submission, mp.Address, map[string]string{})
+	if auditErr := t.al.Save(submission, hash, err); auditErr != nil {
+		t.log.Printlnf("WARNING: %s", auditErr.Error())
+	}
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
@@ -17,9 +18,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -37,14 +40,18 @@ type submitWithdrawableMinipools struct {
 	w   *wallet.Wallet
 	rp  *rocketpool.RocketPool
 	bc  beacon.Client
+	al  *audit.Log
 }
 
 // Withdrawable minipool info
 type minipoolWithdrawableDetails struct {
-	Address      common.Address
-	StartBalance *big.Int
-	EndBalance   *big.Int
-	Withdrawable bool
+	Address       common.Address
+	Block         uint64
+	StartBalance  *big.Int
+	EndBalance    *big.Int
+	EthBalance    *big.Int
+	RefundBalance *big.Int
+	Withdrawable  bool
 }
 
 // Create submit withdrawable minipools task
@@ -76,6 +83,7 @@ func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*su
 		w:   w,
 		rp:  rp,
 		bc:  bc,
+		al:  audit.NewLog(cfg),
 	}, nil
 
 }
@@ -320,14 +328,18 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 		return minipoolWithdrawableDetails{}, nil
 	}
 
-	// Get the current ETH balance
-	ethBalance, err := t.rp.Client.BalanceAt(context.Background(), minipoolAddress, nil)
+	// Get the current block and ETH balance
+	blockNumber, err := t.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return minipoolWithdrawableDetails{}, err
+	}
+	ethBalance, err := t.rp.Client.BalanceAt(context.Background(), minipoolAddress, big.NewInt(0).SetUint64(blockNumber))
 	if err != nil {
 		return minipoolWithdrawableDetails{}, err
 	}
 
 	// Get the refund balance
-	refundBalance, err := mp.GetNodeRefundBalance(nil)
+	refundBalance, err := mp.GetNodeRefundBalance(&bind.CallOpts{BlockNumber: big.NewInt(0).SetUint64(blockNumber)})
 	if err != nil {
 		return minipoolWithdrawableDetails{}, err
 	}
@@ -341,10 +353,13 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 
 	// Return
 	return minipoolWithdrawableDetails{
-		Address:      minipoolAddress,
-		StartBalance: startBalance,
-		EndBalance:   endBalance,
-		Withdrawable: true,
+		Address:       minipoolAddress,
+		Block:         blockNumber,
+		StartBalance:  startBalance,
+		EndBalance:    endBalance,
+		EthBalance:    ethBalance,
+		RefundBalance: refundBalance,
+		Withdrawable:  true,
 	}, nil
 
 }
@@ -380,12 +395,24 @@ func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoo
 
 	// Dissolve
 	hash, err := minipool.SubmitMinipoolWithdrawable(t.rp, details.Address, opts)
-	if err != nil {
-		return err
+	if err == nil {
+		// Print TX info and wait for it to be mined
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	}
 
-	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Record the submission
+	submission := audit.NewSubmission(apitypes.Submission_WithdrawableMinipool, details.Block)
+	submission.Inputs["startBalance"] = audit.Amount(details.StartBalance)
+	submission.Inputs["endBalance"] = audit.Amount(details.EndBalance)
+	submission.Inputs["ethBalance"] = audit.Amount(details.EthBalance)
+	submission.Inputs["refundBalance"] = audit.Amount(details.RefundBalance)
+	if err == nil {
+		submission.Result["withdrawable"] = "true"
+	}
+	audit.AddMinipool(submission, details.Address, map[string]string{})
+	if auditErr := t.al.Save(submission, hash, err); auditErr != nil {
+		t.log.Printlnf("WARNING: %s", auditErr.Error())
+	}
 	if err != nil {
 		return err
 	}
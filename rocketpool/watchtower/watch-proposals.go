@@ -0,0 +1,312 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The name of the oracle DAO proposals contract
+const trustedNodeProposalsDAO = "rocketDAONodeTrustedProposals"
+
+// The proposals the watcher has already handled, persisted across restarts so notifications aren't repeated
+type proposalWatcherState struct {
+	Notified map[uint64]bool `json:"notified"`
+	Reminded map[uint64]bool `json:"reminded"`
+	Closed   map[uint64]bool `json:"closed"`
+}
+
+// Watch proposals task
+type watchProposals struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	al       *audit.Log
+	notifier *notifier
+}
+
+// Create watch proposals task
+func newWatchProposals(c *cli.Context, logger log.ColorLogger) (*watchProposals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &watchProposals{
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		w:        w,
+		rp:       rp,
+		al:       audit.NewLog(cfg),
+		notifier: newNotifier(cfg, logger),
+	}, nil
+
+}
+
+// Watch oracle DAO proposals
+func (t *watchProposals) run() error {
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check node trusted status
+	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if !nodeTrusted {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for oracle DAO proposals...")
+
+	// Load the watcher state; on the first run, proposals that have already closed are skipped silently
+	state, firstRun, err := t.loadState()
+	if err != nil {
+		return err
+	}
+
+	// Load the vote policy; if it's invalid, keep notifying but don't vote on anything
+	policy, err := rputils.LoadVotePolicy(t.cfg.Smartnode.GetProposalPolicyPath())
+	if err != nil {
+		t.log.Printlnf("WARNING: %s - proposals won't be voted on automatically until it is fixed.", err.Error())
+		policy = nil
+	}
+
+	// Get the proposals
+	proposalIds, err := dao.GetDAOProposalIDs(t.rp, trustedNodeProposalsDAO, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting oracle DAO proposal IDs: %w", err)
+	}
+	for _, proposalId := range proposalIds {
+		if state.Closed[proposalId] {
+			continue
+		}
+		proposal, err := dao.GetProposalDetailsWithMember(t.rp, proposalId, nodeAccount.Address, nil)
+		if err != nil {
+			return fmt.Errorf("Error getting details of proposal %d: %w", proposalId, err)
+		}
+		if proposal.State != rptypes.Pending && proposal.State != rptypes.Active {
+			state.Closed[proposalId] = true
+			continue
+		}
+		if err := t.handleProposal(proposal, nodeAccount.Address, policy, state); err != nil {
+			t.log.Printlnf("WARNING: Error handling proposal %d: %s", proposalId, err.Error())
+		}
+	}
+	if firstRun {
+		t.log.Printlnf("Started watching oracle DAO proposals; %d are open.", len(state.Notified))
+	}
+
+	// Save the watcher state
+	return t.saveState(state)
+
+}
+
+// Notify, vote on or remind the node about an open proposal
+func (t *watchProposals) handleProposal(proposal dao.ProposalDetails, nodeAddress common.Address, policy *rputils.VotePolicy, state *proposalWatcherState) error {
+
+	// Describe the proposal
+	payload, err := rputils.DecodeProposalPayload(t.rp, trustedNodeProposalsDAO, proposal.Payload)
+	if err != nil {
+		return err
+	}
+	description := payload.Describe(t.getCurrentSettingValue(payload))
+
+	// Notify new proposals
+	if !state.Notified[proposal.ID] {
+		t.notifier.notify(fmt.Sprintf("New oracle DAO proposal %d by %s: '%s' - this will %s. Voting closes %s.",
+			proposal.ID, proposal.ProposerAddress.Hex(), proposal.Message, description, formatProposalTime(proposal.EndTime)))
+		state.Notified[proposal.ID] = true
+	}
+
+	// Voting is only possible while the proposal is active
+	if proposal.State != rptypes.Active || proposal.MemberVoted {
+		return nil
+	}
+
+	// Vote if the policy covers the proposal
+	if rule := policy.Match(proposal.ProposerAddress, nodeAddress, payload); rule != nil {
+		return t.vote(proposal, payload, rule)
+	}
+
+	// Remind the node to vote if voting closes soon and quorum hasn't been reached
+	reminderWindow := time.Duration(t.cfg.Smartnode.ProposalReminderWindow.Value.(uint64)) * time.Hour
+	if reminderWindow == 0 || state.Reminded[proposal.ID] || proposal.VotesFor >= proposal.VotesRequired {
+		return nil
+	}
+	if time.Until(time.Unix(int64(proposal.EndTime), 0)) > reminderWindow {
+		return nil
+	}
+	t.notifier.notify(fmt.Sprintf("Reminder: voting on oracle DAO proposal %d (%s) closes %s and you haven't voted yet. It has %.2f of the %.2f votes required to pass.",
+		proposal.ID, description, formatProposalTime(proposal.EndTime), proposal.VotesFor, proposal.VotesRequired))
+	state.Reminded[proposal.ID] = true
+	return nil
+
+}
+
+// Vote on a proposal according to a policy rule
+func (t *watchProposals) vote(proposal dao.ProposalDetails, payload rputils.ProposalPayload, rule *rputils.VoteRule) error {
+
+	support := *rule.Support
+	t.log.Printlnf("Proposal %d matches vote policy rule %s, voting (support: %t)...", proposal.ID, rule.String(), support)
+
+	// Get the current block for the audit record
+	block, err := t.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := trustednode.EstimateVoteOnProposalGas(t.rp, proposal.ID, support, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to vote on proposal %d: %w", proposal.ID, err)
+	}
+
+	// Print the gas info
+	maxFee := eth.GweiToWei(WatchtowerMaxFee)
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
+		return nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(WatchtowerMaxPriorityFee)
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Vote
+	hash, err := trustednode.VoteOnProposal(t.rp, proposal.ID, support, opts)
+	if err == nil {
+		// Print TX info and wait for it to be mined
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	}
+
+	// Record the submission
+	submission := audit.NewSubmission(apitypes.Submission_ProposalVote, block)
+	submission.Inputs["proposalId"] = strconv.FormatUint(proposal.ID, 10)
+	submission.Inputs["proposer"] = proposal.ProposerAddress.Hex()
+	submission.Inputs["payload"] = payload.Describe(nil)
+	submission.Inputs["rule"] = rule.String()
+	if err == nil {
+		submission.Result["support"] = strconv.FormatBool(support)
+	}
+	if auditErr := t.al.Save(submission, hash, err); auditErr != nil {
+		t.log.Printlnf("WARNING: %s", auditErr.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	// Notify
+	t.notifier.notify(fmt.Sprintf("Voted on oracle DAO proposal %d (support: %t) under vote policy rule %s.", proposal.ID, support, rule.String()))
+	return nil
+
+}
+
+// Get the current value of the setting a proposal changes, or nil if it isn't a setting proposal
+func (t *watchProposals) getCurrentSettingValue(payload rputils.ProposalPayload) *big.Int {
+	contractName, path, _, err := payload.GetSettingChange()
+	if err != nil {
+		return nil
+	}
+	setting, known := rputils.FindDAOSetting(contractName, path)
+	if !known {
+		return nil
+	}
+	value, err := rputils.GetDAOSettingValue(t.rp, setting, nil)
+	if err != nil {
+		t.log.Printlnf("WARNING: Error getting the current value of %s: %s", path, err.Error())
+		return nil
+	}
+	return value
+}
+
+// Load the watcher state, and report whether this is the first time the watcher has run
+func (t *watchProposals) loadState() (*proposalWatcherState, bool, error) {
+	state := &proposalWatcherState{
+		Notified: map[uint64]bool{},
+		Reminded: map[uint64]bool{},
+		Closed:   map[uint64]bool{},
+	}
+	path := t.cfg.Smartnode.GetProposalWatcherPath()
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, true, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("Error reading proposal watcher state %s: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, state); err != nil {
+		return nil, false, fmt.Errorf("Error parsing proposal watcher state %s: %w", path, err)
+	}
+	return state, false, nil
+}
+
+// Save the watcher state
+func (t *watchProposals) saveState(state *proposalWatcherState) error {
+	path := t.cfg.Smartnode.GetProposalWatcherPath()
+	bytes, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("Error serializing proposal watcher state: %w", err)
+	}
+	if err := ioutil.WriteFile(path, bytes, 0664); err != nil {
+		return fmt.Errorf("Error saving proposal watcher state %s: %w", path, err)
+	}
+	return nil
+}
+
+// Format a proposal timestamp
+func formatProposalTime(timestamp uint64) string {
+	return time.Unix(int64(timestamp), 0).UTC().Format("2006-01-02 15:04 MST")
+}
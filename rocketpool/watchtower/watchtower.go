@@ -11,6 +11,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -30,6 +31,7 @@ const (
 	DissolveTimedOutMinipoolsColor   = color.FgMagenta
 	ProcessWithdrawalsColor          = color.FgCyan
 	SubmitScrubMinipoolsColor        = color.FgHiGreen
+	WatchProposalsColor              = color.FgHiCyan
 	ErrorColor                       = color.FgRed
 	MetricsColor                     = color.FgHiYellow
 	WarningColor                     = color.FgYellow
@@ -44,6 +46,41 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
+		Subcommands: []cli.Command{
+			{
+				Name:    "replay",
+				Aliases: []string{"r"},
+				Usage:   "Replay a watchtower calculation for a historic block in read-only mode, and compare it with what the oracle DAO submitted",
+				Description: "Nothing is submitted to the network. Replaying blocks older than your clients keep state for requires an archive execution client " +
+					"(and, for balances, a beacon node that can serve historic validator balances).",
+				UsageText: "rocketpool watchtower replay --task balances|prices --block number [--minipools]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "task, t",
+						Usage: "The task to replay ('balances' or 'prices')",
+					},
+					cli.Uint64Flag{
+						Name:  "block, b",
+						Usage: "The block to replay the calculation for",
+					},
+					cli.BoolFlag{
+						Name:  "minipools, m",
+						Usage: "Print the balance of each minipool when replaying network balances",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return replay(c)
+
+				},
+			},
+		},
 	})
 }
 
@@ -64,6 +101,17 @@ func run(c *cli.Context) error {
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
 
+	// Refuse automatic transactions if the node account is held on a hardware wallet, since nobody is around to confirm them on the device
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	w.SetUnattended()
+	if hardwareWallet := w.GetHardwareWalletName(); hardwareWallet != "" {
+		warningLog := log.NewColorLogger(WarningColor)
+		warningLog.Printlnf("WARNING: The node account is held on your %s hardware wallet, so the watchtower won't make oracle DAO submissions or vote on proposals.", hardwareWallet)
+	}
+
 	// Initialize tasks
 	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
 	if err != nil {
@@ -97,6 +145,10 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	watchProposals, err := newWatchProposals(c, log.NewColorLogger(WatchProposalsColor))
+	if err != nil {
+		return err
+	}
 
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
@@ -163,6 +215,12 @@ func run(c *cli.Context) error {
 				if err := submitScrubMinipools.run(); err != nil {
 					errorLog.Println(err)
 				}
+				time.Sleep(taskCooldown)
+
+				// Run the proposal watcher
+				if err := watchProposals.run(); err != nil {
+					errorLog.Println(err)
+				}
 			}
 			time.Sleep(interval)
 		}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	FileMode   = 0600
+	FolderMode = 0700
+)
+
+// Stores a record of each watchtower submission as a JSON file in the audit folder
+type Log struct {
+	path string
+}
+
+// Create a new audit log
+func NewLog(cfg *config.RocketPoolConfig) *Log {
+	return &Log{
+		path: cfg.Smartnode.GetAuditPath(),
+	}
+}
+
+// Create a new submission record for a block, timestamped now
+func NewSubmission(submissionType api.WatchtowerSubmissionType, block uint64) *api.WatchtowerSubmission {
+	return &api.WatchtowerSubmission{
+		Type:   submissionType,
+		Time:   time.Now(),
+		Block:  block,
+		Inputs: map[string]string{},
+		Result: map[string]string{},
+	}
+}
+
+// Add a per-minipool breakdown entry to a submission record
+func AddMinipool(submission *api.WatchtowerSubmission, address common.Address, values map[string]string) {
+	submission.Minipools = append(submission.Minipools, api.WatchtowerSubmissionDetail{
+		Address: address,
+		Values:  values,
+	})
+}
+
+// Format an amount for a submission record
+func Amount(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
+
+// Save a submission record, along with the transaction hash and error from submitting it
+func (l *Log) Save(submission *api.WatchtowerSubmission, txHash common.Hash, submitErr error) error {
+
+	submission.TxHash = txHash
+	if submitErr != nil {
+		submission.Error = submitErr.Error()
+	}
+
+	// Serialize the record
+	bytes, err := json.MarshalIndent(submission, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error serializing %s submission record: %w", submission.Type, err)
+	}
+
+	// Write it to the audit folder
+	if err := os.MkdirAll(l.path, FolderMode); err != nil {
+		return fmt.Errorf("Error creating audit folder: %w", err)
+	}
+	filename := fmt.Sprintf("%d-%s-%d.json", submission.Time.UnixNano(), submission.Type, submission.Block)
+	if err := ioutil.WriteFile(filepath.Join(l.path, filename), bytes, FileMode); err != nil {
+		return fmt.Errorf("Error saving %s submission record: %w", submission.Type, err)
+	}
+	return nil
+
+}
+
+// Load the most recent submission records, newest first
+// If submissionType is empty, records of every type are loaded; if limit is 0, all matching records are loaded
+func (l *Log) Load(submissionType api.WatchtowerSubmissionType, limit int) ([]api.WatchtowerSubmission, error) {
+
+	// Get the record files
+	files, err := ioutil.ReadDir(l.path)
+	if os.IsNotExist(err) {
+		return []api.WatchtowerSubmission{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error reading audit folder: %w", err)
+	}
+	filenames := []string{}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		if submissionType != "" && !strings.Contains(name, fmt.Sprintf("-%s-", submissionType)) {
+			continue
+		}
+		filenames = append(filenames, name)
+	}
+
+	// Filenames start with the submission time, so sort them newest first
+	sort.Slice(filenames, func(i, j int) bool {
+		return filenames[i] > filenames[j]
+	})
+	if limit > 0 && len(filenames) > limit {
+		filenames = filenames[:limit]
+	}
+
+	// Load the records
+	submissions := make([]api.WatchtowerSubmission, 0, len(filenames))
+	for _, name := range filenames {
+		bytes, err := ioutil.ReadFile(filepath.Join(l.path, name))
+		if err != nil {
+			return nil, fmt.Errorf("Error reading submission record %s: %w", name, err)
+		}
+		var submission api.WatchtowerSubmission
+		if err := json.Unmarshal(bytes, &submission); err != nil {
+			return nil, fmt.Errorf("Error decoding submission record %s: %w", name, err)
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, nil
+
+}
@@ -0,0 +1,290 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+)
+
+// Settings
+const (
+	ManifestFileName = "manifest.json"
+	SettingsFileName = "user-settings.yml"
+	FileMode         = 0600
+	FolderMode       = 0700
+)
+
+// A description of what's in a backup archive
+type Manifest struct {
+	Version         string                 `json:"version"`
+	Network         config.Network         `json:"network"`
+	ConsensusClient config.ConsensusClient `json:"consensusClient"`
+	Created         time.Time              `json:"created"`
+	Notes           []string               `json:"notes,omitempty"`
+	// The SHA256 checksum of each file in the archive, by its path in the archive
+	Files map[string]string `json:"files"`
+}
+
+// Get the keystore folder for a Consensus client, and a filter for the key and password files in it
+// Anything else in the folder, such as the validator client's live slashing protection database, is left out of the archive.
+func getKeystoreFiles(client config.ConsensusClient) (string, func(relativePath string) bool, error) {
+	switch client {
+	case config.ConsensusClient_Lighthouse:
+		return lighthouse.KeystoreDir, func(relativePath string) bool {
+			return strings.HasPrefix(relativePath, lighthouse.SecretsDir+"/") ||
+				(strings.HasPrefix(relativePath, lighthouse.ValidatorsDir+"/") && path.Base(relativePath) == lighthouse.KeyFileName)
+		}, nil
+	case config.ConsensusClient_Nimbus:
+		return nimbus.KeystoreDir, func(relativePath string) bool {
+			return strings.HasPrefix(relativePath, nimbus.SecretsDir+"/") ||
+				(strings.HasPrefix(relativePath, nimbus.ValidatorsDir+"/") && path.Base(relativePath) == nimbus.KeyFileName)
+		}, nil
+	case config.ConsensusClient_Prysm:
+		return prysm.KeystoreDir, func(relativePath string) bool {
+			return strings.HasPrefix(relativePath, path.Join(prysm.WalletDir, prysm.AccountsDir)+"/") ||
+				relativePath == path.Join(prysm.WalletDir, prysm.ConfigFileName)
+		}, nil
+	case config.ConsensusClient_Teku:
+		return teku.KeystoreDir, func(relativePath string) bool {
+			return strings.HasPrefix(relativePath, teku.SecretsDir+"/") || strings.HasPrefix(relativePath, teku.ValidatorsDir+"/")
+		}, nil
+	default:
+		return "", nil, fmt.Errorf("unknown consensus client [%v]", client)
+	}
+}
+
+// Create a compressed archive of the node's settings, wallet, and the keystores and slashing protection history of the active validator client
+// The slashing protection history is exported with the given exporter; if it's nil, the history is left out with a note in the manifest.
+func CreateArchive(cfg *config.RocketPoolConfig, settingsPath string, exportSlashingProtection SlashingProtectionExporter) ([]byte, *Manifest, error) {
+
+	client, err := cfg.GetSelectedConsensusClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error getting the selected Consensus client: %w", err)
+	}
+	keystoreDir, isKeyFile, err := getKeystoreFiles(client)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := &Manifest{
+		Version:         shared.RocketPoolVersion,
+		Network:         cfg.Smartnode.Network.Value.(config.Network),
+		ConsensusClient: client,
+		Created:         time.Now().UTC(),
+		Files:           map[string]string{},
+	}
+
+	// Get the files to back up, by their path in the archive
+	files := map[string]string{
+		SettingsFileName: settingsPath,
+		"data/wallet":    cfg.Smartnode.GetWalletPath(),
+	}
+	if cfg.Backup.IncludePassword.Value == true {
+		files["data/password"] = cfg.Smartnode.GetPasswordPath()
+	} else {
+		manifest.Notes = append(manifest.Notes, "The node password isn't included in this backup.")
+	}
+	validatorsPath := filepath.Join(cfg.Smartnode.GetValidatorKeychainPath(), keystoreDir)
+	err = filepath.Walk(validatorsPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(validatorsPath, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if isKeyFile(relativePath) {
+			files[path.Join("data/validators", keystoreDir, relativePath)] = filePath
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("Error reading the validator keystores at %s: %w", validatorsPath, err)
+	}
+	if os.IsNotExist(err) {
+		manifest.Notes = append(manifest.Notes, "There were no validator keystores to back up.")
+	}
+
+	// Read the files
+	contents := map[string][]byte{}
+	for name, filePath := range files {
+		fileContents, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			if os.IsNotExist(err) && name != SettingsFileName {
+				manifest.Notes = append(manifest.Notes, fmt.Sprintf("%s didn't exist, so it isn't included in this backup.", name))
+				continue
+			}
+			return nil, nil, fmt.Errorf("Error reading %s: %w", filePath, err)
+		}
+		contents[name] = fileContents
+	}
+
+	// Export the slashing protection history, since the validator client's database can't be copied safely while it's running
+	if exportSlashingProtection == nil {
+		manifest.Notes = append(manifest.Notes, fmt.Sprintf("The slashing protection history can't be exported automatically in native mode, so it isn't included in this backup. Export it with %s's slashing protection export command and keep it with this backup.", client))
+	} else {
+		interchange, err := exportSlashingProtection(client)
+		if err == nil {
+			var validators int
+			validators, err = checkInterchange(interchange)
+			if err == nil {
+				contents[SlashingProtectionFileName] = interchange
+				manifest.Notes = append(manifest.Notes, fmt.Sprintf("%s has the slashing protection history of %d validators. Import it into %s before your validators start again.", SlashingProtectionFileName, validators, client))
+			}
+		}
+		if err != nil {
+			manifest.Notes = append(manifest.Notes, fmt.Sprintf("WARNING: exporting the slashing protection history from %s failed (%s), so it isn't included in this backup.", client, err.Error()))
+		}
+	}
+
+	return writeArchive(manifest, contents)
+
+}
+
+// Write files to a compressed archive in a consistent order, followed by a manifest with their checksums
+func writeArchive(manifest *Manifest, contents map[string][]byte) ([]byte, *Manifest, error) {
+
+	names := []string{}
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range names {
+		if err := writeTarFile(tarWriter, name, contents[name], manifest.Created); err != nil {
+			return nil, nil, err
+		}
+		checksum := sha256.Sum256(contents[name])
+		manifest.Files[name] = hex.EncodeToString(checksum[:])
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error serializing the backup manifest: %w", err)
+	}
+	if err := writeTarFile(tarWriter, ManifestFileName, manifestBytes, manifest.Created); err != nil {
+		return nil, nil, err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, nil, fmt.Errorf("Error finishing the backup archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, nil, fmt.Errorf("Error compressing the backup archive: %w", err)
+	}
+	return buffer.Bytes(), manifest, nil
+
+}
+
+// Read a backup archive, and verify that its files match the checksums in its manifest
+func ReadArchive(archive []byte) (*Manifest, map[string][]byte, error) {
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error decompressing the backup archive: %w", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	files := map[string][]byte{}
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error reading the backup archive: %w", err)
+		}
+		contents, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error reading %s from the backup archive: %w", header.Name, err)
+		}
+		if header.Name == ManifestFileName {
+			manifest = new(Manifest)
+			if err := json.Unmarshal(contents, manifest); err != nil {
+				return nil, nil, fmt.Errorf("Error deserializing the backup manifest: %w", err)
+			}
+			continue
+		}
+		files[header.Name] = contents
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("The backup archive doesn't have a manifest.")
+	}
+
+	// Verify the files
+	for name, expectedChecksum := range manifest.Files {
+		contents, exists := files[name]
+		if !exists {
+			return nil, nil, fmt.Errorf("%s is listed in the backup manifest but missing from the archive.", name)
+		}
+		checksum := sha256.Sum256(contents)
+		if hex.EncodeToString(checksum[:]) != expectedChecksum {
+			return nil, nil, fmt.Errorf("%s doesn't match its checksum in the backup manifest.", name)
+		}
+	}
+	for name := range files {
+		if _, exists := manifest.Files[name]; !exists {
+			return nil, nil, fmt.Errorf("%s is in the backup archive but not listed in its manifest.", name)
+		}
+	}
+	return manifest, files, nil
+
+}
+
+// Write the files from a verified backup archive into a new staging folder, so they can be checked before they're moved into place
+func StageFiles(files map[string][]byte, stagePath string) error {
+
+	if _, err := os.Stat(stagePath); err == nil {
+		return fmt.Errorf("%s already exists.", stagePath)
+	}
+	for name, contents := range files {
+		filePath := filepath.Join(stagePath, filepath.FromSlash(path.Clean("/"+name)))
+		if err := os.MkdirAll(filepath.Dir(filePath), FolderMode); err != nil {
+			return fmt.Errorf("Error creating the folder for %s: %w", name, err)
+		}
+		if err := ioutil.WriteFile(filePath, contents, FileMode); err != nil {
+			return fmt.Errorf("Error writing %s: %w", filePath, err)
+		}
+	}
+	return nil
+
+}
+
+// Add a file to a tar archive
+func writeTarFile(tarWriter *tar.Writer, name string, contents []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    FileMode,
+		Size:    int64(len(contents)),
+		ModTime: modTime,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("Error adding %s to the backup archive: %w", name, err)
+	}
+	if _, err := tarWriter.Write(contents); err != nil {
+		return fmt.Errorf("Error adding %s to the backup archive: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A valid interchange file with the history of one validator
+const testInterchange = `{
+	"metadata": {
+		"interchange_format_version": "5",
+		"genesis_validators_root": "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	},
+	"data": [{"pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed", "signed_blocks": [], "signed_attestations": []}]
+}`
+
+// Write a file for a test, creating its folder
+func writeTestFile(t *testing.T, filePath string, contents string) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filePath, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Build an archive from raw tar entries, for archives the Smartnode wouldn't write itself
+func buildTestArchive(t *testing.T, entries map[string][]byte) []byte {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, contents := range entries {
+		if err := writeTarFile(tarWriter, name, contents, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestCreateArchive(t *testing.T) {
+
+	// Set up a native mode node with Lighthouse keys and a slashing protection database
+	dataPath := t.TempDir()
+	cfg := config.NewRocketPoolConfig(dataPath, true)
+	cfg.Smartnode.DataPath.Value = dataPath
+	cfg.Native.ConsensusClient.Value = config.ConsensusClient_Lighthouse
+	settingsPath := filepath.Join(dataPath, SettingsFileName)
+	writeTestFile(t, settingsPath, "settings")
+	writeTestFile(t, filepath.Join(dataPath, "wallet"), "wallet")
+	writeTestFile(t, filepath.Join(dataPath, "password"), "password")
+	writeTestFile(t, filepath.Join(dataPath, "validators", "lighthouse", "validators", "0xabcd", "voting-keystore.json"), "keystore")
+	writeTestFile(t, filepath.Join(dataPath, "validators", "lighthouse", "secrets", "0xabcd"), "secret")
+	writeTestFile(t, filepath.Join(dataPath, "validators", "lighthouse", "validators", "slashing_protection.sqlite"), "live database")
+
+	tests := []struct {
+		name            string
+		exporter        SlashingProtectionExporter
+		hasInterchange  bool
+		noteFragment    string
+		includePassword bool
+	}{
+		{
+			name:            "exported",
+			exporter:        func(config.ConsensusClient) ([]byte, error) { return []byte(testInterchange), nil },
+			hasInterchange:  true,
+			noteFragment:    "history of 1 validators",
+			includePassword: true,
+		},
+		{
+			name:         "export failed",
+			exporter:     func(config.ConsensusClient) ([]byte, error) { return nil, errors.New("container not running") },
+			noteFragment: "container not running",
+		},
+		{
+			name:         "invalid export",
+			exporter:     func(config.ConsensusClient) ([]byte, error) { return []byte(`{"metadata": {}}`), nil },
+			noteFragment: "interchange format version",
+		},
+		{
+			name:         "native mode",
+			noteFragment: "native mode",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg.Backup.IncludePassword.Value = test.includePassword
+			archive, manifest, err := CreateArchive(cfg, settingsPath, test.exporter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			readManifest, files, err := ReadArchive(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := map[string]string{
+				SettingsFileName: "settings",
+				"data/wallet":    "wallet",
+				"data/validators/lighthouse/validators/0xabcd/voting-keystore.json": "keystore",
+				"data/validators/lighthouse/secrets/0xabcd":                         "secret",
+			}
+			if test.includePassword {
+				expected["data/password"] = "password"
+			}
+			if test.hasInterchange {
+				expected[SlashingProtectionFileName] = testInterchange
+			}
+			if len(files) != len(expected) {
+				t.Fatalf("archive has %d files, expected %d", len(files), len(expected))
+			}
+			for name, contents := range expected {
+				if string(files[name]) != contents {
+					t.Fatalf("%s has contents [%s], expected [%s]", name, files[name], contents)
+				}
+			}
+			if len(readManifest.Files) != len(manifest.Files) || readManifest.ConsensusClient != config.ConsensusClient_Lighthouse {
+				t.Fatal("the archived manifest doesn't match the returned one")
+			}
+			found := false
+			for _, note := range manifest.Notes {
+				if strings.Contains(note, test.noteFragment) {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("no note mentions [%s]: %v", test.noteFragment, manifest.Notes)
+			}
+		})
+	}
+
+}
+
+func TestReadArchive(t *testing.T) {
+
+	manifestFor := func(files map[string]string) []byte {
+		manifest := Manifest{Files: map[string]string{}}
+		for name, contents := range files {
+			checksum := sha256.Sum256([]byte(contents))
+			manifest.Files[name] = hex.EncodeToString(checksum[:])
+		}
+		bytes, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes
+	}
+
+	tests := []struct {
+		name       string
+		entries    map[string][]byte
+		shouldFail bool
+	}{
+		{
+			name: "valid",
+			entries: map[string][]byte{
+				"data/wallet":    []byte("wallet"),
+				ManifestFileName: manifestFor(map[string]string{"data/wallet": "wallet"}),
+			},
+		},
+		{
+			name: "checksum mismatch",
+			entries: map[string][]byte{
+				"data/wallet":    []byte("tampered"),
+				ManifestFileName: manifestFor(map[string]string{"data/wallet": "wallet"}),
+			},
+			shouldFail: true,
+		},
+		{
+			name: "missing file",
+			entries: map[string][]byte{
+				ManifestFileName: manifestFor(map[string]string{"data/wallet": "wallet"}),
+			},
+			shouldFail: true,
+		},
+		{
+			name: "unlisted file",
+			entries: map[string][]byte{
+				"data/wallet":    []byte("wallet"),
+				"data/extra":     []byte("extra"),
+				ManifestFileName: manifestFor(map[string]string{"data/wallet": "wallet"}),
+			},
+			shouldFail: true,
+		},
+		{
+			name: "no manifest",
+			entries: map[string][]byte{
+				"data/wallet": []byte("wallet"),
+			},
+			shouldFail: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, files, err := ReadArchive(buildTestArchive(t, test.entries))
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if err == nil && string(files["data/wallet"]) != "wallet" {
+				t.Fatal("the wallet wasn't read back")
+			}
+		})
+	}
+
+	// Garbage isn't an archive
+	if _, _, err := ReadArchive([]byte("not an archive")); err == nil {
+		t.Fatal("expected an error reading garbage")
+	}
+
+}
+
+func TestStageFilesStaysInFolder(t *testing.T) {
+	stagePath := filepath.Join(t.TempDir(), "stage")
+	files := map[string][]byte{
+		"data/wallet":         []byte("wallet"),
+		"../../escaped-stage": []byte("escaped"),
+	}
+	if err := StageFiles(files, stagePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(stagePath, "escaped-stage")); err != nil {
+		t.Fatal("a file with a relative path wasn't written inside the stage folder")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(filepath.Dir(stagePath)), "escaped-stage")); err == nil {
+		t.Fatal("a file was written outside the stage folder")
+	}
+	if err := StageFiles(files, stagePath); err == nil {
+		t.Fatal("expected an error staging into an existing folder")
+	}
+}
+
+func TestCheckInterchange(t *testing.T) {
+	tests := []struct {
+		name        string
+		interchange string
+		validators  int
+		shouldFail  bool
+	}{
+		{"valid", testInterchange, 1, false},
+		{"no validators", `{"metadata": {"interchange_format_version": "5", "genesis_validators_root": "0x01"}, "data": []}`, 0, false},
+		{"old version", `{"metadata": {"interchange_format_version": "4", "genesis_validators_root": "0x01"}, "data": []}`, 0, true},
+		{"no genesis root", `{"metadata": {"interchange_format_version": "5"}, "data": []}`, 0, true},
+		{"no pubkey", `{"metadata": {"interchange_format_version": "5", "genesis_validators_root": "0x01"}, "data": [{}]}`, 0, true},
+		{"not json", `slashing_protection.sqlite`, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validators, err := checkInterchange([]byte(test.interchange))
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if validators != test.validators {
+				t.Fatalf("got %d validators, expected %d", validators, test.validators)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	FileNamePrefix       = "rocketpool-backup-"
+	FileNameExtension    = ".tar.gz.gpg"
+	AgeFileNameExtension = ".tar.gz.age"
+	fileNameTimestamp    = "20060102T150405Z"
+)
+
+// Create an encrypted backup of the node and store it in the configured target
+// The Docker client is used to export the validator client's slashing protection history; it can be nil in native mode.
+// Returns the backup's file name, where it was stored and its manifest.
+func Create(cfg *config.RocketPoolConfig, settingsPath string, d *client.Client) (string, string, *Manifest, error) {
+
+	var exporter SlashingProtectionExporter
+	if !cfg.IsNativeMode && d != nil {
+		exporter = NewDockerSlashingProtectionExporter(cfg, d)
+	}
+	archive, manifest, err := CreateArchive(cfg, settingsPath, exporter)
+	if err != nil {
+		return "", "", nil, err
+	}
+	ageRecipient := cfg.Backup.AgeRecipient.Value.(string)
+	encrypted, err := Encrypt(archive, cfg.Backup.Passphrase.Value.(string), cfg.Backup.GetPublicKeyPath(true), ageRecipient)
+	if err != nil {
+		return "", "", nil, err
+	}
+	extension := FileNameExtension
+	if ageRecipient != "" {
+		extension = AgeFileNameExtension
+	}
+	target, err := NewTarget(cfg.Backup, true)
+	if err != nil {
+		return "", "", nil, err
+	}
+	name := fmt.Sprintf("%s%s-%s%s", FileNamePrefix, manifest.Network, manifest.Created.Format(fileNameTimestamp), extension)
+	location, err := target.Upload(name, encrypted)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return name, location, manifest, nil
+
+}
+
+// Delete the oldest backups of a network from a target, keeping the given number of the most recent ones
+// Returns the names of the backups that were deleted.
+func Prune(target Target, network config.Network, keep int) ([]string, error) {
+	names, err := target.List()
+	if err != nil {
+		return nil, err
+	}
+	deleted := []string{}
+	for _, name := range getExpiredBackups(names, network, keep) {
+		if err := target.Delete(name); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, nil
+}
+
+// Get the backups of a network beyond the given number of the most recent ones, oldest first
+// Files that aren't backups made by the Smartnode for the network are left alone.
+func getExpiredBackups(names []string, network config.Network, keep int) []string {
+	prefix := fmt.Sprintf("%s%s-", FileNamePrefix, network)
+	backups := []string{}
+	for _, name := range names {
+		if !IsBackupName(name) || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		timestamp := trimExtension(strings.TrimPrefix(name, prefix))
+		if _, err := time.Parse(fileNameTimestamp, timestamp); err != nil {
+			continue
+		}
+		backups = append(backups, name)
+	}
+	if len(backups) <= keep {
+		return []string{}
+	}
+
+	// The timestamps have a fixed width, so the names sort by age
+	sort.Strings(backups)
+	return backups[:len(backups)-keep]
+}
+
+// Decrypt a backup and verify its contents
+func Open(encrypted []byte, passphrase string, privateKeyPath string) (*Manifest, map[string][]byte, error) {
+	archive, err := Decrypt(encrypted, passphrase, privateKeyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ReadArchive(archive)
+}
+
+// Check if a name looks like a backup made by the Smartnode
+func IsBackupName(name string) bool {
+	return strings.HasPrefix(name, FileNamePrefix) && (strings.HasSuffix(name, FileNameExtension) || strings.HasSuffix(name, AgeFileNameExtension))
+}
+
+// Remove the extension from a backup's name
+func trimExtension(name string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(name, FileNameExtension), AgeFileNameExtension)
+}
+
+// Get the name of the folder a backup is staged in when it's restored
+func GetStageFolderName(now time.Time) string {
+	return fmt.Sprintf("restore-%s", now.Format(fileNameTimestamp))
+}
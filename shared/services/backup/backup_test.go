@@ -0,0 +1,160 @@
+package backup
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestOpen(t *testing.T) {
+
+	// Make an encrypted backup
+	manifest := &Manifest{
+		Network: config.Network_Mainnet,
+		Created: time.Now().UTC(),
+		Files:   map[string]string{},
+	}
+	archive, _, err := writeArchive(manifest, map[string][]byte{"data/wallet": []byte("wallet")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := Encrypt(archive, "correct horse", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make one encrypted to an age identity, and save it and another identity to files
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ageEncrypted, err := Encrypt(archive, "", "", identity.Recipient().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityPath := filepath.Join(t.TempDir(), "identity.txt")
+	if err := ioutil.WriteFile(identityPath, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	otherIdentityPath := filepath.Join(t.TempDir(), "other-identity.txt")
+	if err := ioutil.WriteFile(otherIdentityPath, []byte(otherIdentity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		encrypted      []byte
+		passphrase     string
+		privateKeyPath string
+		shouldFail     bool
+	}{
+		{"correct passphrase", encrypted, "correct horse", "", false},
+		{"wrong passphrase", encrypted, "battery staple", "", true},
+		{"truncated", encrypted[:len(encrypted)/2], "correct horse", "", true},
+		{"age identity", ageEncrypted, "", identityPath, false},
+		{"wrong age identity", ageEncrypted, "", otherIdentityPath, true},
+		{"age without an identity", ageEncrypted, "correct horse", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			openedManifest, files, err := Open(test.encrypted, test.passphrase, test.privateKeyPath)
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if err != nil {
+				return
+			}
+			if string(files["data/wallet"]) != "wallet" || openedManifest.Network != config.Network_Mainnet {
+				t.Fatal("the backup didn't round-trip")
+			}
+		})
+	}
+
+}
+
+func TestGetExpiredBackups(t *testing.T) {
+	names := []string{
+		"rocketpool-backup-mainnet-20240103T000000Z.tar.gz.gpg",
+		"rocketpool-backup-mainnet-20240101T000000Z.tar.gz.gpg",
+		"rocketpool-backup-prater-20231201T000000Z.tar.gz.gpg",
+		"rocketpool-backup-mainnet-20240102T000000Z.tar.gz.age",
+		"rocketpool-backup-mainnet-latest.tar.gz.gpg",
+		"notes.txt",
+	}
+	tests := []struct {
+		name    string
+		network config.Network
+		keep    int
+		expired []string
+	}{
+		{
+			name:    "oldest first",
+			network: config.Network_Mainnet,
+			keep:    1,
+			expired: []string{
+				"rocketpool-backup-mainnet-20240101T000000Z.tar.gz.gpg",
+				"rocketpool-backup-mainnet-20240102T000000Z.tar.gz.age",
+			},
+		},
+		{
+			name:    "under the limit",
+			network: config.Network_Mainnet,
+			keep:    3,
+			expired: []string{},
+		},
+		{
+			name:    "other networks are separate",
+			network: config.Network_Prater,
+			keep:    0,
+			expired: []string{"rocketpool-backup-prater-20231201T000000Z.tar.gz.gpg"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expired := getExpiredBackups(names, test.network, test.keep)
+			if !reflect.DeepEqual(expired, test.expired) {
+				t.Fatalf("expired %v, expected %v", expired, test.expired)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	target := &localTarget{path: t.TempDir()}
+	names := []string{
+		"notes.txt",
+		"rocketpool-backup-mainnet-20240101T000000Z.tar.gz.gpg",
+		"rocketpool-backup-mainnet-20240102T000000Z.tar.gz.gpg",
+		"rocketpool-backup-mainnet-20240103T000000Z.tar.gz.gpg",
+	}
+	for _, name := range names {
+		if _, err := target.Upload(name, []byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := Prune(target, config.Network_Mainnet, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(deleted, names[1:2]) {
+		t.Fatalf("deleted %v", deleted)
+	}
+	remaining, err := target.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(remaining, []string{names[0], names[2], names[3]}) {
+		t.Fatalf("%v remain", remaining)
+	}
+}
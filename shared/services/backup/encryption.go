@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"filippo.io/age"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+	_ "golang.org/x/crypto/ripemd160" // OpenPGP falls back to RIPEMD160 for keys that don't list their preferred hashes
+)
+
+// Settings
+const ageHeader = "age-encryption.org/"
+
+// Encrypt a backup archive with OpenPGP, so it can be decrypted with gpg as well as the Smartnode
+// The archive is encrypted to the public key if one is provided, or with the passphrase otherwise.
+// If an age recipient is provided, it's encrypted to that with age instead.
+func Encrypt(archive []byte, passphrase string, publicKeyPath string, ageRecipient string) ([]byte, error) {
+
+	if ageRecipient != "" {
+		return encryptAge(archive, ageRecipient)
+	}
+
+	var buffer bytes.Buffer
+	hints := &openpgp.FileHints{IsBinary: true}
+	pgpConfig := &packet.Config{DefaultCipher: packet.CipherAES256}
+	var plaintextWriter io.WriteCloser
+	if publicKeyPath != "" {
+		keyFile, err := os.Open(publicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening GPG public key %s: %w", publicKeyPath, err)
+		}
+		defer keyFile.Close()
+		recipients, err := openpgp.ReadArmoredKeyRing(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading GPG public key %s: %w", publicKeyPath, err)
+		}
+		plaintextWriter, err = openpgp.Encrypt(&buffer, recipients, nil, hints, pgpConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+		}
+	} else if passphrase != "" {
+		var err error
+		plaintextWriter, err = openpgp.SymmetricallyEncrypt(&buffer, []byte(passphrase), hints, pgpConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+		}
+	} else {
+		return nil, fmt.Errorf("There is no passphrase or GPG public key to encrypt the backup with.")
+	}
+
+	if _, err := plaintextWriter.Write(archive); err != nil {
+		return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+	}
+	if err := plaintextWriter.Close(); err != nil {
+		return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+	}
+	return buffer.Bytes(), nil
+
+}
+
+// Decrypt a backup archive
+// If a private key is provided, the passphrase unlocks it; otherwise the passphrase decrypts the backup directly.
+// Backups encrypted with age are decrypted with the private key as an age identity file instead.
+func Decrypt(encrypted []byte, passphrase string, privateKeyPath string) ([]byte, error) {
+
+	if IsAgeEncrypted(encrypted) {
+		return decryptAge(encrypted, passphrase, privateKeyPath)
+	}
+
+	keyring := openpgp.EntityList{}
+	if privateKeyPath != "" {
+		keyFile, err := os.Open(privateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening GPG private key %s: %w", privateKeyPath, err)
+		}
+		defer keyFile.Close()
+		keyring, err = openpgp.ReadArmoredKeyRing(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading GPG private key %s: %w", privateKeyPath, err)
+		}
+	}
+
+	// Only try the passphrase once, since openpgp keeps asking for as long as it's given one
+	tried := false
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		if tried {
+			return nil, fmt.Errorf("the passphrase is incorrect")
+		}
+		tried = true
+		if symmetric {
+			return []byte(passphrase), nil
+		}
+		for _, key := range keys {
+			if key.PrivateKey != nil && key.PrivateKey.Encrypted {
+				if err := key.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+					return nil, fmt.Errorf("the passphrase is incorrect")
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	message, err := openpgp.ReadMessage(bytes.NewReader(encrypted), keyring, prompt, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error decrypting the backup: %w", err)
+	}
+	archive, err := ioutil.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		return nil, fmt.Errorf("Error decrypting the backup: %w", err)
+	}
+	return archive, nil
+
+}
+
+// Check if a backup was encrypted with age rather than OpenPGP
+func IsAgeEncrypted(encrypted []byte) bool {
+	return bytes.HasPrefix(encrypted, []byte(ageHeader))
+}
+
+// Encrypt a backup archive to an age recipient, so it can be decrypted with age as well as the Smartnode
+func encryptAge(archive []byte, ageRecipient string) ([]byte, error) {
+	recipient, err := age.ParseX25519Recipient(ageRecipient)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing age recipient %s: %w", ageRecipient, err)
+	}
+	var buffer bytes.Buffer
+	plaintextWriter, err := age.Encrypt(&buffer, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+	}
+	if _, err := plaintextWriter.Write(archive); err != nil {
+		return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+	}
+	if err := plaintextWriter.Close(); err != nil {
+		return nil, fmt.Errorf("Error encrypting the backup: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Decrypt a backup archive encrypted with age
+// The identities come from the identity file if one is provided; otherwise the passphrase decrypts the backup directly, as made with `age --passphrase`.
+func decryptAge(encrypted []byte, passphrase string, identityPath string) ([]byte, error) {
+
+	var identities []age.Identity
+	if identityPath != "" {
+		identityFile, err := os.Open(identityPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening age identity file %s: %w", identityPath, err)
+		}
+		defer identityFile.Close()
+		identities, err = age.ParseIdentities(identityFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading age identity file %s: %w", identityPath, err)
+		}
+	} else {
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("Error decrypting the backup: %w", err)
+		}
+		identities = []age.Identity{identity}
+	}
+
+	plaintextReader, err := age.Decrypt(bytes.NewReader(encrypted), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("Error decrypting the backup: %w", err)
+	}
+	archive, err := ioutil.ReadAll(plaintextReader)
+	if err != nil {
+		return nil, fmt.Errorf("Error decrypting the backup: %w", err)
+	}
+	return archive, nil
+
+}
@@ -0,0 +1,118 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const s3RequestTimeout = 10 * time.Minute
+
+// Stores backups in an S3-compatible bucket
+// Requests use path-style URLs, which S3-compatible services all support.
+type s3Target struct {
+	bucket string
+	prefix string
+	client *minio.Client
+}
+
+// Create an S3 target from the backup settings
+func newS3Target(cfg *config.BackupConfig) (*s3Target, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.S3Endpoint.Value.(string), "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("The S3 endpoint [%s] is not a valid URL.", cfg.S3Endpoint.Value)
+	}
+	if endpoint.Path != "" {
+		return nil, fmt.Errorf("The S3 endpoint [%s] can't have a path; use the bucket and prefix settings instead.", cfg.S3Endpoint.Value)
+	}
+	bucket := cfg.S3Bucket.Value.(string)
+	accessKey := cfg.S3AccessKey.Value.(string)
+	secretKey := cfg.S3SecretKey.Value.(string)
+	if bucket == "" || accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("The S3 bucket, access key and secret key must all be set.")
+	}
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       cfg.S3Region.Value.(string),
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error creating S3 client: %w", err)
+	}
+	return &s3Target{
+		bucket: bucket,
+		prefix: strings.Trim(cfg.S3Prefix.Value.(string), "/"),
+		client: client,
+	}, nil
+}
+
+func (t *s3Target) Upload(name string, data []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+	defer cancel()
+	key := t.getKey(name)
+	_, err := t.client.PutObject(ctx, t.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return "", fmt.Errorf("Error uploading %s to S3: %w", name, err)
+	}
+	return fmt.Sprintf("s3://%s/%s", t.bucket, key), nil
+}
+
+func (t *s3Target) Download(name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+	defer cancel()
+	object, err := t.client.GetObject(ctx, t.bucket, t.getKey(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading %s from S3: %w", name, err)
+	}
+	defer object.Close()
+	data, err := ioutil.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading %s from S3: %w", name, err)
+	}
+	return data, nil
+}
+
+func (t *s3Target) List() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+	defer cancel()
+	prefix := t.getKey("")
+	names := []string{}
+	for object := range t.client.ListObjects(ctx, t.bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("Error listing the backups in S3: %w", object.Err)
+		}
+		name := strings.TrimPrefix(object.Key, prefix)
+		if name != "" && !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (t *s3Target) Delete(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+	defer cancel()
+	if err := t.client.RemoveObject(ctx, t.bucket, t.getKey(name), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("Error deleting %s from S3: %w", name, err)
+	}
+	return nil
+}
+
+// Get the object key for a backup
+func (t *s3Target) getKey(name string) string {
+	if t.prefix == "" {
+		return name
+	}
+	return t.prefix + "/" + name
+}
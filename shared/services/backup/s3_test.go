@@ -0,0 +1,223 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// A minimal in-memory S3 server for a single bucket
+type fakeS3Server struct {
+	bucket  string
+	objects map[string][]byte
+	lock    sync.Mutex
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+s.bucket), "/")
+	switch {
+	case r.Method == http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get("X-Amz-Content-Sha256") == "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+			data = decodeAwsChunked(data)
+		}
+		s.objects[key] = data
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, r.URL.Query())
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, exists := s.objects[key]
+		if !exists {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>")
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// Strip the chunk headers from a body sent with streaming signatures, without checking them
+func decodeAwsChunked(body []byte) []byte {
+	data := []byte{}
+	for len(body) > 0 {
+		header := body[:bytes.Index(body, []byte("\r\n"))]
+		var length int
+		fmt.Sscanf(string(header), "%x;", &length)
+		body = body[len(header)+2:]
+		data = append(data, body[:length]...)
+		body = body[length+2:]
+	}
+	return data
+}
+
+// List the objects under a prefix, one per page so the target has to follow the continuation tokens
+func (s *fakeS3Server) list(w http.ResponseWriter, query url.Values) {
+	type content struct {
+		Key  string
+		Size int
+	}
+	type prefix struct {
+		Prefix string
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Name                  string
+		Prefix                string
+		KeyCount              int
+		IsTruncated           bool
+		NextContinuationToken string    `xml:",omitempty"`
+		Contents              []content `xml:",omitempty"`
+		CommonPrefixes        []prefix  `xml:",omitempty"`
+	}{Name: s.bucket, Prefix: query.Get("prefix")}
+
+	// Folders are listed as common prefixes when there's a delimiter
+	entries := []string{}
+	folders := map[string]bool{}
+	for key := range s.objects {
+		if !strings.HasPrefix(key, result.Prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, result.Prefix)
+		if delimiter := query.Get("delimiter"); delimiter != "" && strings.Contains(rest, delimiter) {
+			folder := result.Prefix + rest[:strings.Index(rest, delimiter)+1]
+			if !folders[folder] {
+				folders[folder] = true
+				entries = append(entries, folder)
+			}
+			continue
+		}
+		entries = append(entries, key)
+	}
+	sort.Strings(entries)
+	start := 0
+	if token := query.Get("continuation-token"); token != "" {
+		fmt.Sscan(token, &start)
+	}
+	if start < len(entries) {
+		if folders[entries[start]] {
+			result.CommonPrefixes = []prefix{{entries[start]}}
+		} else {
+			result.Contents = []content{{entries[start], len(s.objects[entries[start]])}}
+		}
+		result.KeyCount = 1
+	}
+	if start+1 < len(entries) {
+		result.IsTruncated = true
+		result.NextContinuationToken = fmt.Sprint(start + 1)
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
+
+// Create a target backed by a fake S3 server
+func newFakeS3Target(t *testing.T, prefix string, objects map[string][]byte) (*s3Target, *fakeS3Server) {
+	server := &fakeS3Server{bucket: "backups", objects: objects}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	client, err := minio.New(strings.TrimPrefix(httpServer.URL, "http://"), &minio.Options{
+		Creds:        credentials.NewStaticV4("access", "secret", ""),
+		Region:       "us-east-1",
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &s3Target{bucket: "backups", prefix: prefix, client: client}, server
+}
+
+func TestS3Target(t *testing.T) {
+
+	target, server := newFakeS3Target(t, "node", map[string][]byte{
+		"node/old":        []byte("old"),
+		"node/nested/old": []byte("nested"),
+		"other/old":       []byte("other"),
+	})
+
+	// Upload a backup and download it again
+	location, err := target.Upload("new", []byte("backup"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != "s3://backups/node/new" {
+		t.Fatalf("location was %s, expected s3://backups/node/new", location)
+	}
+	if string(server.objects["node/new"]) != "backup" {
+		t.Fatalf("uploaded [%s], expected [backup]", string(server.objects["node/new"]))
+	}
+	data, err := target.Download("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "backup" {
+		t.Fatalf("downloaded [%s], expected [backup]", string(data))
+	}
+
+	// List the backups under the prefix, across several pages and leaving out folders
+	names, err := target.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"new", "old"}) {
+		t.Fatalf("listed %v, expected [new old]", names)
+	}
+
+	// Delete a backup, then fail to download it
+	if err := target.Delete("old"); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := server.objects["node/old"]; exists {
+		t.Fatal("node/old wasn't deleted")
+	}
+	if _, err := target.Download("old"); err == nil {
+		t.Fatal("expected an error downloading a missing backup")
+	}
+
+}
+
+func TestS3GetKey(t *testing.T) {
+	tests := []struct {
+		prefix string
+		name   string
+		key    string
+	}{
+		{"", "backup", "backup"},
+		{"node", "backup", "node/backup"},
+		{"node/mainnet", "", "node/mainnet/"},
+	}
+	for _, test := range tests {
+		target := &s3Target{prefix: test.prefix}
+		if key := target.getKey(test.name); key != test.key {
+			t.Errorf("key for [%s] under [%s] was [%s], expected [%s]", test.name, test.prefix, key, test.key)
+		}
+	}
+}
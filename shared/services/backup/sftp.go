@@ -0,0 +1,192 @@
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const sftpDialTimeout = 30 * time.Second
+
+// Stores backups in a folder on an SFTP server
+type sftpTarget struct {
+	address     string
+	sshConfig   *ssh.ClientConfig
+	remotePath  string
+	fingerprint string
+
+	// Connects to the server and starts an SFTP client, returning it along with a function that disconnects
+	connect func() (*sftp.Client, func(), error)
+}
+
+// Create an SFTP target from the backup settings
+func newSftpTarget(cfg *config.BackupConfig, inDaemon bool) (*sftpTarget, error) {
+
+	host := cfg.SftpHost.Value.(string)
+	user := cfg.SftpUser.Value.(string)
+	fingerprint := cfg.SftpHostKey.Value.(string)
+	if host == "" || user == "" {
+		return nil, fmt.Errorf("The SFTP host and username must both be set.")
+	}
+	if fingerprint == "" {
+		return nil, fmt.Errorf("The SFTP host key fingerprint must be set, so backups are only sent to the right machine.")
+	}
+
+	// Get the login methods
+	authMethods := []ssh.AuthMethod{}
+	keyPath := cfg.GetSftpKeyPath(inDaemon)
+	if keyPath != "" {
+		keyBytes, err := ioutil.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading SFTP private key %s: %w", keyPath, err)
+		}
+		signer, err := ssh.ParsePrivateKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing SFTP private key %s: %w", keyPath, err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if password := cfg.SftpPassword.Value.(string); password != "" {
+		authMethods = append(authMethods, ssh.Password(password))
+	}
+	if len(authMethods) == 0 {
+		return nil, fmt.Errorf("Either the SFTP password or private key must be set.")
+	}
+
+	target := &sftpTarget{
+		address:     net.JoinHostPort(host, strconv.FormatUint(uint64(cfg.SftpPort.Value.(uint16)), 10)),
+		remotePath:  cfg.SftpPath.Value.(string),
+		fingerprint: fingerprint,
+	}
+	target.sshConfig = &ssh.ClientConfig{
+		User:            user,
+		Auth:            authMethods,
+		HostKeyCallback: target.checkHostKey,
+		Timeout:         sftpDialTimeout,
+	}
+	target.connect = target.connectSsh
+	return target, nil
+
+}
+
+func (t *sftpTarget) Upload(name string, data []byte) (string, error) {
+	remoteFile := path.Join(t.remotePath, name)
+	err := t.withClient(func(client *sftp.Client) error {
+		file, err := client.Create(remoteFile)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(data); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error uploading backup to %s:%s: %w", t.address, remoteFile, err)
+	}
+	return fmt.Sprintf("sftp://%s%s", t.address, path.Join("/", remoteFile)), nil
+}
+
+func (t *sftpTarget) Download(name string) ([]byte, error) {
+	remoteFile := path.Join(t.remotePath, name)
+	var data []byte
+	err := t.withClient(func(client *sftp.Client) error {
+		file, err := client.Open(remoteFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		data, err = ioutil.ReadAll(file)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading backup from %s:%s: %w", t.address, remoteFile, err)
+	}
+	return data, nil
+}
+
+func (t *sftpTarget) List() ([]string, error) {
+	names := []string{}
+	err := t.withClient(func(client *sftp.Client) error {
+		entries, err := client.ReadDir(t.getListPath())
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Mode().IsRegular() {
+				names = append(names, entry.Name())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing backups in %s:%s: %w", t.address, t.remotePath, err)
+	}
+	return names, nil
+}
+
+func (t *sftpTarget) Delete(name string) error {
+	remoteFile := path.Join(t.remotePath, name)
+	err := t.withClient(func(client *sftp.Client) error {
+		return client.Remove(remoteFile)
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting backup %s:%s: %w", t.address, remoteFile, err)
+	}
+	return nil
+}
+
+// Get the folder to list, which is the user's login folder if no path is set
+func (t *sftpTarget) getListPath() string {
+	if t.remotePath == "" {
+		return "."
+	}
+	return t.remotePath
+}
+
+// Make sure the server's host key matches the configured fingerprint
+func (t *sftpTarget) checkHostKey(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	fingerprint := ssh.FingerprintSHA256(key)
+	if fingerprint != t.fingerprint {
+		return fmt.Errorf("the host key fingerprint of %s is %s, not the expected %s", hostname, fingerprint, t.fingerprint)
+	}
+	return nil
+}
+
+// Connect to the server and run a function with an SFTP client
+func (t *sftpTarget) withClient(run func(client *sftp.Client) error) error {
+	client, disconnect, err := t.connect()
+	if err != nil {
+		return err
+	}
+	defer disconnect()
+	return run(client)
+}
+
+// Connect to the server over SSH and start an SFTP client
+func (t *sftpTarget) connectSsh() (*sftp.Client, func(), error) {
+	sshClient, err := ssh.Dial("tcp", t.address, t.sshConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting: %w", err)
+	}
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, nil, fmt.Errorf("error starting SFTP: %w", err)
+	}
+	disconnect := func() {
+		client.Close()
+		sshClient.Close()
+	}
+	return client, disconnect, nil
+}
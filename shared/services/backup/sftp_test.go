@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+)
+
+// The server's end of a pair of pipes
+type fakeSftpConn struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// Create a target backed by an in-memory SFTP server
+func newFakeSftpTarget(t *testing.T, remotePath string) (*sftpTarget, *sftp.Client) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	server := sftp.NewRequestServer(fakeSftpConn{serverReader, serverWriter}, sftp.InMemHandler())
+	go server.Serve()
+	client, err := sftp.NewClientPipe(clientReader, clientWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		server.Close()
+		client.Close()
+	})
+	target := &sftpTarget{
+		address:    "localhost:22",
+		remotePath: remotePath,
+		connect: func() (*sftp.Client, func(), error) {
+			return client, func() {}, nil
+		},
+	}
+	return target, client
+}
+
+func TestSftpTarget(t *testing.T) {
+
+	target, client := newFakeSftpTarget(t, "/backups")
+	if err := client.MkdirAll("/backups/staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Upload a backup larger than a single SFTP packet and download it again
+	data := bytes.Repeat([]byte("backup"), 100000)
+	location, err := target.Upload("new", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != "sftp://localhost:22/backups/new" {
+		t.Fatalf("location was %s, expected sftp://localhost:22/backups/new", location)
+	}
+	downloaded, err := target.Download("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(downloaded, data) {
+		t.Fatalf("downloaded %d bytes, expected %d", len(downloaded), len(data))
+	}
+
+	// Overwrite it with a smaller one
+	if _, err := target.Upload("new", []byte("small")); err != nil {
+		t.Fatal(err)
+	}
+	downloaded, err = target.Download("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(downloaded) != "small" {
+		t.Fatalf("downloaded [%s] after overwriting, expected [small]", string(downloaded))
+	}
+
+	// List the folder, leaving out subfolders
+	if _, err := target.Upload("old", []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	names, err := target.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"new", "old"}) {
+		t.Fatalf("listed %v, expected [new old]", names)
+	}
+
+	// Delete a backup, then fail to delete or download it again
+	if err := target.Delete("old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.Delete("old"); err == nil {
+		t.Fatal("expected an error deleting a missing backup")
+	}
+	if _, err := target.Download("old"); err == nil {
+		t.Fatal("expected an error downloading a missing backup")
+	}
+
+}
+
+func TestSftpCheckHostKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		fingerprint string
+		shouldFail  bool
+	}{
+		{"matching", ssh.FingerprintSHA256(key), false},
+		{"different", "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &sftpTarget{fingerprint: test.fingerprint}
+			err := target.checkHostKey("localhost:22", &net.TCPAddr{}, key)
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+		})
+	}
+}
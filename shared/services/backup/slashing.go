@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	SlashingProtectionFileName = "slashing-protection.json"
+	interchangeFormatVersion   = "5"
+	containerValidatorsPath    = "/validators"
+	slashingExportFolder       = "slashing-protection-export"
+	slashingExportTimeout      = 2 * time.Minute
+	validatorContainerSuffix   = "_validator"
+	beaconContainerSuffix      = "_eth2"
+)
+
+// Exports a validator client's slashing protection history in the EIP-3076 interchange format
+type SlashingProtectionExporter func(client config.ConsensusClient) ([]byte, error)
+
+// How a validator client exports its slashing protection history
+// The paths match the way the Smartnode's client containers are launched, with the validator keys mounted at /validators.
+type slashingProtectionExport struct {
+	// The suffix of the container the validator client runs in
+	containerSuffix string
+
+	// The name of the file the export command writes in the export folder
+	fileName string
+
+	// Get the export command, given the network and the export folder inside the container
+	getCommand func(network config.Network, exportFolder string) []string
+}
+
+// The export commands of each validator client
+var slashingProtectionExports = map[config.ConsensusClient]slashingProtectionExport{
+	config.ConsensusClient_Lighthouse: {
+		containerSuffix: validatorContainerSuffix,
+		fileName:        "interchange.json",
+		getCommand: func(network config.Network, exportFolder string) []string {
+			return []string{"lighthouse", "account", "validator", "slashing-protection", "export", path.Join(exportFolder, "interchange.json"),
+				"--datadir", path.Join(containerValidatorsPath, "lighthouse"), "--network", string(network)}
+		},
+	},
+	config.ConsensusClient_Nimbus: {
+		containerSuffix: beaconContainerSuffix,
+		fileName:        "interchange.json",
+		getCommand: func(network config.Network, exportFolder string) []string {
+			return []string{"/home/user/nimbus-eth2/build/nimbus_beacon_node", "slashingdb", "export", path.Join(exportFolder, "interchange.json"),
+				"--data-dir=/ethclient/nimbus", "--validators-dir=" + path.Join(containerValidatorsPath, "nimbus", "validators")}
+		},
+	},
+	config.ConsensusClient_Prysm: {
+		containerSuffix: validatorContainerSuffix,
+		fileName:        "slashing_protection.json",
+		getCommand: func(network config.Network, exportFolder string) []string {
+			return []string{"/app/cmd/validator/validator", "slashing-protection-history", "export", "--accept-terms-of-use",
+				"--datadir=" + path.Join(containerValidatorsPath, "prysm-non-hd", "direct"), "--slashing-protection-export-dir=" + exportFolder}
+		},
+	},
+	config.ConsensusClient_Teku: {
+		containerSuffix: validatorContainerSuffix,
+		fileName:        "interchange.json",
+		getCommand: func(network config.Network, exportFolder string) []string {
+			return []string{"/opt/teku/bin/teku", "slashing-protection", "export",
+				"--data-path=" + path.Join(containerValidatorsPath, "teku"), "--to=" + path.Join(exportFolder, "interchange.json")}
+		},
+	},
+}
+
+// Create an exporter that runs the validator client's own export command in its container
+// The export is written to a temporary folder in the validator keychain, which both the client's container and the daemon can see.
+func NewDockerSlashingProtectionExporter(cfg *config.RocketPoolConfig, d *client.Client) SlashingProtectionExporter {
+	return func(consensusClient config.ConsensusClient) ([]byte, error) {
+
+		export, exists := slashingProtectionExports[consensusClient]
+		if !exists {
+			return nil, fmt.Errorf("%s doesn't have a slashing protection export command", consensusClient)
+		}
+
+		// Make the export folder; the client may not run as root, so anyone can write to it
+		exportFolder := filepath.Join(cfg.Smartnode.GetValidatorKeychainPath(), slashingExportFolder)
+		if err := os.RemoveAll(exportFolder); err != nil {
+			return nil, fmt.Errorf("error clearing %s: %w", exportFolder, err)
+		}
+		if err := os.MkdirAll(exportFolder, FolderMode); err != nil {
+			return nil, fmt.Errorf("error creating %s: %w", exportFolder, err)
+		}
+		defer os.RemoveAll(exportFolder)
+		if err := os.Chmod(exportFolder, 0777); err != nil {
+			return nil, fmt.Errorf("error setting the permissions of %s: %w", exportFolder, err)
+		}
+
+		// Run the export
+		containerName := cfg.Smartnode.ProjectName.Value.(string) + export.containerSuffix
+		command := export.getCommand(cfg.Smartnode.Network.Value.(config.Network), path.Join(containerValidatorsPath, slashingExportFolder))
+		if err := runInContainer(d, containerName, command); err != nil {
+			return nil, err
+		}
+		exportPath := filepath.Join(exportFolder, export.fileName)
+		interchange, err := ioutil.ReadFile(exportPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the export from %s: %w", exportPath, err)
+		}
+		return interchange, nil
+
+	}
+}
+
+// Check that a file is an EIP-3076 interchange file, and get the number of validators it has history for
+func checkInterchange(interchange []byte) (int, error) {
+	var file struct {
+		Metadata struct {
+			InterchangeFormatVersion string `json:"interchange_format_version"`
+			GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+		} `json:"metadata"`
+		Data []struct {
+			Pubkey string `json:"pubkey"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(interchange, &file); err != nil {
+		return 0, fmt.Errorf("the export isn't valid JSON: %w", err)
+	}
+	if file.Metadata.InterchangeFormatVersion != interchangeFormatVersion {
+		return 0, fmt.Errorf("the export has interchange format version [%s], not %s", file.Metadata.InterchangeFormatVersion, interchangeFormatVersion)
+	}
+	if file.Metadata.GenesisValidatorsRoot == "" {
+		return 0, fmt.Errorf("the export doesn't have a genesis validators root")
+	}
+	for _, validator := range file.Data {
+		if validator.Pubkey == "" {
+			return 0, fmt.Errorf("the export has a validator without a pubkey")
+		}
+	}
+	return len(file.Data), nil
+}
+
+// Run a command in a container and wait for it to finish
+func runInContainer(d *client.Client, containerName string, command []string) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), slashingExportTimeout)
+	defer cancel()
+
+	// Start the command
+	exec, err := d.ContainerExecCreate(ctx, containerName, types.ExecConfig{
+		Cmd:          command,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error running the export in %s: %w", containerName, err)
+	}
+	attach, err := d.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return fmt.Errorf("error running the export in %s: %w", containerName, err)
+	}
+	defer attach.Close()
+
+	// Wait for it to finish and check that it succeeded
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, attach.Reader); err != nil {
+		return fmt.Errorf("error reading the output of the export in %s: %w", containerName, err)
+	}
+	inspect, err := d.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return fmt.Errorf("error checking the result of the export in %s: %w", containerName, err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("the export in %s failed with exit code %d: %s", containerName, inspect.ExitCode, strings.TrimSpace(output.String()))
+	}
+	return nil
+
+}
@@ -0,0 +1,93 @@
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Somewhere backups can be stored
+type Target interface {
+	// Store a backup under the given name, and return where it was stored
+	Upload(name string, data []byte) (string, error)
+
+	// Retrieve the backup with the given name
+	Download(name string) ([]byte, error)
+
+	// Get the names of the files stored in the target
+	List() ([]string, error)
+
+	// Delete the backup with the given name
+	Delete(name string) error
+}
+
+// Create the target selected in the backup settings
+// Backups are made by the daemon and restored on the host, so this takes which of them is asking in order to resolve the configured paths.
+func NewTarget(cfg *config.BackupConfig, inDaemon bool) (Target, error) {
+	target := cfg.Target.Value.(config.BackupTarget)
+	switch target {
+	case config.BackupTarget_Local:
+		return &localTarget{
+			path: cfg.GetLocalPath(inDaemon),
+		}, nil
+	case config.BackupTarget_S3:
+		return newS3Target(cfg)
+	case config.BackupTarget_Sftp:
+		return newSftpTarget(cfg, inDaemon)
+	default:
+		return nil, fmt.Errorf("unknown backup target [%v]", target)
+	}
+}
+
+// Stores backups in a local folder
+type localTarget struct {
+	path string
+}
+
+func (t *localTarget) Upload(name string, data []byte) (string, error) {
+	if err := os.MkdirAll(t.path, FolderMode); err != nil {
+		return "", fmt.Errorf("Error creating backup folder %s: %w", t.path, err)
+	}
+	filePath := filepath.Join(t.path, name)
+	if err := ioutil.WriteFile(filePath, data, FileMode); err != nil {
+		return "", fmt.Errorf("Error writing backup to %s: %w", filePath, err)
+	}
+	return filePath, nil
+}
+
+func (t *localTarget) Download(name string) ([]byte, error) {
+	filePath := filepath.Join(t.path, name)
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading backup %s: %w", filePath, err)
+	}
+	return data, nil
+}
+
+func (t *localTarget) List() ([]string, error) {
+	entries, err := ioutil.ReadDir(t.path)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error reading backup folder %s: %w", t.path, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (t *localTarget) Delete(name string) error {
+	filePath := filepath.Join(t.path, name)
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("Error deleting backup %s: %w", filePath, err)
+	}
+	return nil
+}
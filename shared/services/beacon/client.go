@@ -14,12 +14,14 @@ type ValidatorStatusOptions struct {
 type SyncStatus struct {
 	Syncing  bool
 	Progress float64
+	HeadSlot uint64
 }
 type Eth2Config struct {
 	GenesisForkVersion           []byte
 	GenesisValidatorsRoot        []byte
 	GenesisEpoch                 uint64
 	GenesisTime                  uint64
+	SecondsPerSlot               uint64
 	SecondsPerEpoch              uint64
 	EpochsPerSyncCommitteePeriod uint64
 }
@@ -46,6 +48,11 @@ type ValidatorStatus struct {
 	WithdrawableEpoch          uint64
 	Exists                     bool
 }
+type Fork struct {
+	PreviousVersion []byte
+	CurrentVersion  []byte
+	Epoch           uint64
+}
 type Eth1Data struct {
 	DepositRoot  common.Hash
 	DepositCount uint64
@@ -71,6 +78,7 @@ const (
 type Client interface {
 	GetClientType() BeaconClientType
 	GetSyncStatus() (SyncStatus, error)
+	GetPeerCount() (uint64, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetBeaconHead() (BeaconHead, error)
@@ -80,6 +88,7 @@ type Client interface {
 	GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error)
 	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error)
 	GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
+	GetForkSchedule() ([]Fork, error)
 	ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, error)
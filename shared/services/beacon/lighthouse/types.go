@@ -27,6 +27,11 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type PeerCountResponse struct {
+	Data struct {
+		Connected uinteger `json:"connected"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
 		SecondsPerSlot               uinteger `json:"SECONDS_PER_SLOT"`
@@ -67,6 +72,13 @@ type ForkResponse struct {
 		Epoch           uinteger  `json:"epoch"`
 	}
 }
+type ForkScheduleResponse struct {
+	Data []struct {
+		PreviousVersion byteArray `json:"previous_version"`
+		CurrentVersion  byteArray `json:"current_version"`
+		Epoch           uinteger  `json:"epoch"`
+	} `json:"data"`
+}
 type BeaconBlockResponse struct {
 	Data struct {
 		Message struct {
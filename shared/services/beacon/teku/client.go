@@ -27,11 +27,13 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath            = "/eth/v1/node/syncing"
+	RequestPeerCountPath             = "/eth/v1/node/peer_count"
 	RequestEth2ConfigPath            = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath               = "/eth/v1/beacon/genesis"
 	RequestFinalityCheckpointsPath   = "/eth/v1/beacon/states/%s/finality_checkpoints"
 	RequestForkPath                  = "/eth/v1/beacon/states/%s/fork"
+	RequestForkSchedulePath          = "/eth/v1/config/fork_schedule"
 	RequestValidatorsPath            = "/eth/v1/beacon/states/%s/validators"
 	RequestVoluntaryExitPath         = "/eth/v1/beacon/pool/voluntary_exits"
 	RequestBeaconBlockPath           = "/eth/v1/beacon/blocks/%s"
@@ -80,10 +82,20 @@ func (c *Client) GetSyncStatus() (beacon.SyncStatus, error) {
 	return beacon.SyncStatus{
 		Syncing:  isSyncing,
 		Progress: progress,
+		HeadSlot: uint64(syncStatus.Data.HeadSlot),
 	}, nil
 
 }
 
+// Get the number of peers the node is connected to
+func (c *Client) GetPeerCount() (uint64, error) {
+	peerCount, err := c.getPeerCount()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(peerCount.Data.Connected), nil
+}
+
 // Get the eth2 config
 func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -117,6 +129,7 @@ func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 		GenesisValidatorsRoot:        genesis.Data.GenesisValidatorsRoot,
 		GenesisEpoch:                 0,
 		GenesisTime:                  uint64(genesis.Data.GenesisTime),
+		SecondsPerSlot:               uint64(eth2Config.Data.SecondsPerSlot),
 		SecondsPerEpoch:              uint64(eth2Config.Data.SecondsPerSlot * eth2Config.Data.SlotsPerEpoch),
 		EpochsPerSyncCommitteePeriod: uint64(eth2Config.Data.EpochsPerSyncCommitteePeriod),
 	}, nil
@@ -406,6 +419,23 @@ func (c *Client) GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
 
 }
 
+// Get the forks the beacon chain has had and has scheduled, in order
+func (c *Client) GetForkSchedule() ([]beacon.Fork, error) {
+	forkSchedule, err := c.getForkSchedule()
+	if err != nil {
+		return nil, err
+	}
+	forks := make([]beacon.Fork, len(forkSchedule.Data))
+	for i, fork := range forkSchedule.Data {
+		forks[i] = beacon.Fork{
+			PreviousVersion: fork.PreviousVersion,
+			CurrentVersion:  fork.CurrentVersion,
+			Epoch:           uint64(fork.Epoch),
+		}
+	}
+	return forks, nil
+}
+
 // Perform a voluntary exit on a validator
 func (c *Client) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
 	return c.postVoluntaryExit(VoluntaryExitRequest{
@@ -450,6 +480,21 @@ func (c *Client) getSyncStatus() (SyncStatusResponse, error) {
 	return syncStatus, nil
 }
 
+// Get peer count
+func (c *Client) getPeerCount() (PeerCountResponse, error) {
+	responseBody, status, err := c.getRequest(RequestPeerCountPath)
+	if err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: %w", err)
+	} else if status != http.StatusOK {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var peerCount PeerCountResponse
+	if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not decode node peer count: %w", err)
+	}
+	return peerCount, nil
+}
+
 // Get the eth2 config
 func (c *Client) getEth2Config() (Eth2ConfigResponse, error) {
 	responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -510,6 +555,21 @@ func (c *Client) getFinalityCheckpoints(stateId string) (FinalityCheckpointsResp
 	return finalityCheckpoints, nil
 }
 
+// Get fork schedule
+func (c *Client) getForkSchedule() (ForkScheduleResponse, error) {
+	responseBody, status, err := c.getRequest(RequestForkSchedulePath)
+	if err != nil {
+		return ForkScheduleResponse{}, fmt.Errorf("Could not get fork schedule: %w", err)
+	} else if status != http.StatusOK {
+		return ForkScheduleResponse{}, fmt.Errorf("Could not get fork schedule: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var forkSchedule ForkScheduleResponse
+	if err := json.Unmarshal(responseBody, &forkSchedule); err != nil {
+		return ForkScheduleResponse{}, fmt.Errorf("Could not decode fork schedule: %w", err)
+	}
+	return forkSchedule, nil
+}
+
 // Get fork
 func (c *Client) getFork(stateId string) (ForkResponse, error) {
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestForkPath, stateId))
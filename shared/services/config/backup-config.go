@@ -0,0 +1,440 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Defaults
+const (
+	defaultBackupIntervalHours uint64 = 24
+	defaultBackupKeepCount     uint64 = 14
+	defaultBackupS3Region      string = "us-east-1"
+	defaultBackupSftpPort      uint16 = 22
+	containerDataPath          string = "/.rocketpool/data"
+)
+
+// Configuration for backups of the node's settings, wallet and validator keys
+type BackupConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for the daemon's backup schedule
+	EnableScheduledBackups Parameter `yaml:"enableScheduledBackups,omitempty"`
+
+	// How often the daemon makes a backup
+	IntervalHours Parameter `yaml:"intervalHours,omitempty"`
+
+	// How many backups the daemon keeps in the target
+	KeepCount Parameter `yaml:"keepCount,omitempty"`
+
+	// The passphrase the backups are encrypted with
+	Passphrase Parameter `yaml:"passphrase,omitempty"`
+
+	// The GPG public key the backups are encrypted to, instead of a passphrase
+	PublicKeyPath Parameter `yaml:"publicKeyPath,omitempty"`
+
+	// The age recipient the backups are encrypted to, instead of a passphrase or GPG public key
+	AgeRecipient Parameter `yaml:"ageRecipient,omitempty"`
+
+	// Toggle for including the node password
+	IncludePassword Parameter `yaml:"includePassword,omitempty"`
+
+	// Where the backups are stored
+	Target Parameter `yaml:"target,omitempty"`
+
+	// The folder for local backups
+	LocalPath Parameter `yaml:"localPath,omitempty"`
+
+	// S3-compatible storage settings
+	S3Endpoint  Parameter `yaml:"s3Endpoint,omitempty"`
+	S3Region    Parameter `yaml:"s3Region,omitempty"`
+	S3Bucket    Parameter `yaml:"s3Bucket,omitempty"`
+	S3Prefix    Parameter `yaml:"s3Prefix,omitempty"`
+	S3AccessKey Parameter `yaml:"s3AccessKey,omitempty"`
+	S3SecretKey Parameter `yaml:"s3SecretKey,omitempty"`
+
+	// SFTP settings
+	SftpHost     Parameter `yaml:"sftpHost,omitempty"`
+	SftpPort     Parameter `yaml:"sftpPort,omitempty"`
+	SftpUser     Parameter `yaml:"sftpUser,omitempty"`
+	SftpPassword Parameter `yaml:"sftpPassword,omitempty"`
+	SftpKeyPath  Parameter `yaml:"sftpKeyPath,omitempty"`
+	SftpHostKey  Parameter `yaml:"sftpHostKey,omitempty"`
+	SftpPath     Parameter `yaml:"sftpPath,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new backup config
+func NewBackupConfig(config *RocketPoolConfig) *BackupConfig {
+	return &BackupConfig{
+		Title:  "Backup Settings",
+		parent: config,
+
+		EnableScheduledBackups: Parameter{
+			ID:                   "enableScheduledBackups",
+			Name:                 "Enable Scheduled Backups",
+			Description:          "Enable this to have the node daemon regularly make an encrypted backup of your Smartnode settings, node wallet, and the validator keys and slashing protection history of your Consensus client.\n\nYou can also make a backup at any time with `rocketpool service backup`, and restore one with `rocketpool service restore`.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		IntervalHours: Parameter{
+			ID:                   "intervalHours",
+			Name:                 "Backup Interval",
+			Description:          "How often the node daemon makes a backup, in hours.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: defaultBackupIntervalHours},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeepCount: Parameter{
+			ID:                   "keepCount",
+			Name:                 "Backups to Keep",
+			Description:          "How many backups to keep in the backup target. After each scheduled backup, the node daemon deletes the oldest backups of this network beyond this number, including ones made with `rocketpool service backup`.\n\nSet this to 0 to keep every backup.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: defaultBackupKeepCount},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Passphrase: Parameter{
+			ID:                   "passphrase",
+			Name:                 "Encryption Passphrase",
+			Description:          "The passphrase your backups are encrypted with. They can be decrypted with `rocketpool service restore`, or with `gpg --decrypt` if you ever need to get at them without the Smartnode.\n\nStore this passphrase somewhere safe that isn't on this machine - without it, your backups can't be restored.\n\nLeave this blank if you're encrypting your backups to a GPG public key or age recipient instead.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PublicKeyPath: Parameter{
+			ID:                   "publicKeyPath",
+			Name:                 "GPG Public Key Path",
+			Description:          "The path to an ASCII-armored GPG public key (such as one made with `gpg --armor --export`) to encrypt your backups to, instead of a passphrase. Only the matching private key will be able to decrypt them, so it doesn't need to be kept on this machine.\n\nIn Docker mode, this file must be inside your data folder.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AgeRecipient: Parameter{
+			ID:                   "ageRecipient",
+			Name:                 "age Recipient",
+			Description:          "An age public key (starting with `age1`, such as one made with `age-keygen`) to encrypt your backups to with age, instead of a passphrase or GPG public key. Only the matching identity will be able to decrypt them, with `rocketpool service restore --private-key` or `age --decrypt --identity`.\n\nBackups encrypted with age are saved with a `.tar.gz.age` extension.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		IncludePassword: Parameter{
+			ID:                   "includePassword",
+			Name:                 "Include Node Password",
+			Description:          "Enable this to include the password for your node wallet in your backups. Without it, you'll need to enter the password when you restore a backup.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Target: Parameter{
+			ID:                   "target",
+			Name:                 "Backup Target",
+			Description:          "Where your backups are stored.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: BackupTarget_Local},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Local Folder",
+				Description: "Store your backups in a folder on this machine. Remember to copy them somewhere else, or they'll be lost along with the machine.",
+				Value:       BackupTarget_Local,
+			}, {
+				Name:        "S3-Compatible Storage",
+				Description: "Upload your backups to Amazon S3, or any other storage service with an S3-compatible API.",
+				Value:       BackupTarget_S3,
+			}, {
+				Name:        "SFTP",
+				Description: "Upload your backups to another machine with SFTP.",
+				Value:       BackupTarget_Sftp,
+			}},
+		},
+
+		LocalPath: Parameter{
+			ID:                   "localPath",
+			Name:                 "Local Backup Folder",
+			Description:          "The folder to store your backups in. Leave this blank to use the `backups` folder inside your data folder.\n\nIn Docker mode, this folder must be inside your data folder.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Endpoint: Parameter{
+			ID:                   "s3Endpoint",
+			Name:                 "S3 Endpoint",
+			Description:          "The URL of your storage service's S3 API, such as `https://s3.us-east-1.amazonaws.com`. Buckets are accessed with path-style URLs, which all S3-compatible services support.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Region: Parameter{
+			ID:                   "s3Region",
+			Name:                 "S3 Region",
+			Description:          "The region your bucket is in. Services that don't use regions usually expect `us-east-1`.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: defaultBackupS3Region},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Bucket: Parameter{
+			ID:                   "s3Bucket",
+			Name:                 "S3 Bucket",
+			Description:          "The name of the bucket to upload your backups to.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3Prefix: Parameter{
+			ID:                   "s3Prefix",
+			Name:                 "S3 Folder",
+			Description:          "The folder (key prefix) inside the bucket to upload your backups to. Leave this blank to upload them to the top of the bucket.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3AccessKey: Parameter{
+			ID:                   "s3AccessKey",
+			Name:                 "S3 Access Key",
+			Description:          "The access key ID to upload your backups with. We recommend creating a key that can only read and write this bucket.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		S3SecretKey: Parameter{
+			ID:                   "s3SecretKey",
+			Name:                 "S3 Secret Key",
+			Description:          "The secret key that goes with the access key.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpHost: Parameter{
+			ID:                   "sftpHost",
+			Name:                 "SFTP Host",
+			Description:          "The hostname or IP address of the machine to upload your backups to.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpPort: Parameter{
+			ID:                   "sftpPort",
+			Name:                 "SFTP Port",
+			Description:          "The port the machine's SSH server listens on.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultBackupSftpPort},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpUser: Parameter{
+			ID:                   "sftpUser",
+			Name:                 "SFTP Username",
+			Description:          "The user to log in to the machine as.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpPassword: Parameter{
+			ID:                   "sftpPassword",
+			Name:                 "SFTP Password",
+			Description:          "The user's password. Leave this blank if you're logging in with a private key instead.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpKeyPath: Parameter{
+			ID:                   "sftpKeyPath",
+			Name:                 "SFTP Private Key Path",
+			Description:          "The path to an unencrypted SSH private key to log in with. Leave this blank if you're logging in with a password instead.\n\nIn Docker mode, this file must be inside your data folder.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpHostKey: Parameter{
+			ID:                   "sftpHostKey",
+			Name:                 "SFTP Host Key Fingerprint",
+			Description:          "The SHA256 fingerprint of the machine's SSH host key, such as `SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8`. You can get it by running `ssh-keygen -lf /etc/ssh/ssh_host_ed25519_key.pub` on that machine.\n\nThis makes sure your backups are only ever sent to that machine.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SftpPath: Parameter{
+			ID:                   "sftpPath",
+			Name:                 "SFTP Folder",
+			Description:          "The folder on the machine to upload your backups to. It must already exist.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (config *BackupConfig) GetParameters() []*Parameter {
+	return []*Parameter{
+		&config.EnableScheduledBackups,
+		&config.IntervalHours,
+		&config.KeepCount,
+		&config.Passphrase,
+		&config.PublicKeyPath,
+		&config.AgeRecipient,
+		&config.IncludePassword,
+		&config.Target,
+		&config.LocalPath,
+		&config.S3Endpoint,
+		&config.S3Region,
+		&config.S3Bucket,
+		&config.S3Prefix,
+		&config.S3AccessKey,
+		&config.S3SecretKey,
+		&config.SftpHost,
+		&config.SftpPort,
+		&config.SftpUser,
+		&config.SftpPassword,
+		&config.SftpKeyPath,
+		&config.SftpHostKey,
+		&config.SftpPath,
+	}
+}
+
+// Get the parameters that only apply to the given target
+func (config *BackupConfig) GetTargetParameters(target BackupTarget) []*Parameter {
+	switch target {
+	case BackupTarget_Local:
+		return []*Parameter{&config.LocalPath}
+	case BackupTarget_S3:
+		return []*Parameter{&config.S3Endpoint, &config.S3Region, &config.S3Bucket, &config.S3Prefix, &config.S3AccessKey, &config.S3SecretKey}
+	case BackupTarget_Sftp:
+		return []*Parameter{&config.SftpHost, &config.SftpPort, &config.SftpUser, &config.SftpPassword, &config.SftpKeyPath, &config.SftpHostKey, &config.SftpPath}
+	}
+	return []*Parameter{}
+}
+
+// The the title for the config
+func (config *BackupConfig) GetConfigTitle() string {
+	return config.Title
+}
+
+// Get the folder local backups are stored in
+// Restores run on the host while backups are made in the daemon's container, so this takes which of them is asking.
+func (config *BackupConfig) GetLocalPath(inDaemon bool) string {
+	localPath := config.LocalPath.Value.(string)
+	if localPath == "" {
+		return filepath.Join(config.getDataPath(inDaemon), "backups")
+	}
+	return config.getPath(localPath, inDaemon)
+}
+
+// Get the path of the file the daemon records the time of its last scheduled backup in
+func (config *BackupConfig) GetLastBackupPath() string {
+	return filepath.Join(config.getDataPath(true), "last-backup")
+}
+
+// Get the path of the GPG public key the backups are encrypted to
+func (config *BackupConfig) GetPublicKeyPath(inDaemon bool) string {
+	return config.getPath(config.PublicKeyPath.Value.(string), inDaemon)
+}
+
+// Check if there's a passphrase, GPG public key or age recipient to encrypt the backups with
+func (config *BackupConfig) CanEncrypt() bool {
+	return config.Passphrase.Value != "" || config.PublicKeyPath.Value != "" || config.AgeRecipient.Value != ""
+}
+
+// Get the path of the SSH private key used to log in to the SFTP server
+func (config *BackupConfig) GetSftpKeyPath(inDaemon bool) string {
+	return config.getPath(config.SftpKeyPath.Value.(string), inDaemon)
+}
+
+// Get the data folder as the caller sees it
+func (config *BackupConfig) getDataPath(inDaemon bool) string {
+	if inDaemon && !config.parent.IsNativeMode {
+		return containerDataPath
+	}
+	return os.ExpandEnv(config.parent.Smartnode.DataPath.Value.(string))
+}
+
+// Convert a path the user entered to the path the caller sees
+func (config *BackupConfig) getPath(path string, inDaemon bool) string {
+	return config.parent.getDaemonPath(path, inDaemon)
+}
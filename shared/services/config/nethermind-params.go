@@ -13,6 +13,12 @@ const (
 	nethermindTagArm64         string = "rocketpool/nethermind:1.13.0-pi"
 	nethermindEventLogInterval int    = 25000
 	nethermindStopSignal       string = "SIGINT"
+	defaultNethermindAdminPort uint16 = 8555
+
+	// Full pruning only runs when the Smartnode asks for it, and Nethermind refuses to start another one within this many hours of the last,
+	// so asking for the pruning status once a prune has finished reports that it's delayed instead of starting a new one
+	nethermindFullPruningTrigger       string = "Manual"
+	nethermindFullPruningMinDelayHours uint64 = 240
 )
 
 // Configuration for Nethermind
@@ -37,6 +43,9 @@ type NethermindConfig struct {
 	// Nethermind's memory for pruning
 	PruneMemSize Parameter `yaml:"pruneMemSize,omitempty"`
 
+	// The port for the endpoint that serves the admin RPC module
+	AdminPort Parameter `yaml:"adminPort,omitempty"`
+
 	// The Docker Hub tag for Nethermind
 	ContainerTag Parameter `yaml:"containerTag,omitempty"`
 
@@ -107,6 +116,18 @@ func NewNethermindConfig(config *RocketPoolConfig, isFallback bool) *NethermindC
 			OverwriteOnUpgrade:   false,
 		},
 
+		AdminPort: Parameter{
+			ID:                   "adminPort",
+			Name:                 "Admin RPC Port",
+			Description:          "The port Nethermind should use for the separate RPC endpoint that serves its admin module, which the Smartnode uses to start and follow a full prune with `rocketpool service prune-eth1`.\n\nThis endpoint is only available to the Smartnode's containers. It is never exposed outside of Docker, even if you expose the HTTP and Websocket RPC ports.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultNethermindAdminPort},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Eth1},
+			EnvironmentVariables: []string{prefix + "NETHERMIND_ADMIN_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ContainerTag: Parameter{
 			ID:                   "containerTag",
 			Name:                 "Container Tag",
@@ -200,6 +221,7 @@ func (config *NethermindConfig) GetParameters() []*Parameter {
 		&config.CacheSize,
 		&config.MaxPeers,
 		&config.PruneMemSize,
+		&config.AdminPort,
 		&config.ContainerTag,
 		&config.AdditionalFlags,
 	}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/alessio/shellescape"
 	"github.com/rocket-pool/smartnode/shared"
@@ -107,6 +108,9 @@ type RocketPoolConfig struct {
 	Exporter          *ExporterConfig          `yaml:"exporter,omitempty"`
 	BitflyNodeMetrics *BitflyNodeMetricsConfig `yaml:"bitflyNodeMetrics,omitempty"`
 
+	// Backups
+	Backup *BackupConfig `yaml:"backup,omitempty"`
+
 	// Native mode
 	Native *NativeConfig `yaml:"native,omitempty"`
 }
@@ -460,6 +464,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	config.Prometheus = NewPrometheusConfig(config)
 	config.Exporter = NewExporterConfig(config)
 	config.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(config)
+	config.Backup = NewBackupConfig(config)
 	config.Native = NewNativeConfig(config)
 
 	// Apply the default values for mainnet
@@ -556,6 +561,7 @@ func (config *RocketPoolConfig) GetSubconfigs() map[string]Config {
 		"prometheus":                config.Prometheus,
 		"exporter":                  config.Exporter,
 		"bitflyNodeMetrics":         config.BitflyNodeMetrics,
+		"backup":                    config.Backup,
 		"native":                    config.Native,
 	}
 }
@@ -673,6 +679,22 @@ func (config *RocketPoolConfig) GetIncompatibleConsensusClients() ([]ParameterOp
 
 }
 
+// Get the selected consensus client, whether it's local, external or native
+func (config *RocketPoolConfig) GetSelectedConsensusClient() (ConsensusClient, error) {
+	if config.IsNativeMode {
+		return config.Native.ConsensusClient.Value.(ConsensusClient), nil
+	}
+	mode := config.ConsensusClientMode.Value.(Mode)
+	switch mode {
+	case Mode_Local:
+		return config.ConsensusClient.Value.(ConsensusClient), nil
+	case Mode_External:
+		return config.ExternalConsensusClient.Value.(ConsensusClient), nil
+	default:
+		return ConsensusClient_Unknown, fmt.Errorf("unknown consensus client mode [%v]", mode)
+	}
+}
+
 // Get the configuration for the selected client
 func (config *RocketPoolConfig) GetSelectedConsensusClientConfig() (ConsensusConfig, error) {
 	if config.IsNativeMode {
@@ -874,6 +896,8 @@ func (config *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string
 		case ExecutionClient_Nethermind:
 			addParametersToEnvVars(config.Nethermind.GetParameters(), envVars)
 			envVars["EC_STOP_SIGNAL"] = nethermindStopSignal
+			envVars["NETHERMIND_FULL_PRUNING_TRIGGER"] = nethermindFullPruningTrigger
+			envVars["NETHERMIND_FULL_PRUNING_MIN_DELAY_HOURS"] = fmt.Sprint(nethermindFullPruningMinDelayHours)
 		case ExecutionClient_Besu:
 			addParametersToEnvVars(config.Besu.GetParameters(), envVars)
 			envVars["EC_STOP_SIGNAL"] = besuStopSignal
@@ -992,6 +1016,17 @@ func (config *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string
 
 	}
 
+	// Web dashboard
+	// The daemon has to listen on all of the container's interfaces for Docker to forward the port, so it's only forwarded from the host's loopback interface unless it's exposed
+	if config.Smartnode.EnableDashboard.Value == true {
+		envVars["DASHBOARD_ADDRESS"] = "0.0.0.0"
+		if config.Smartnode.DashboardOpenPort.Value == true {
+			envVars["DASHBOARD_OPEN_PORTS"] = fmt.Sprintf("%d:%d/tcp", config.Smartnode.DashboardPort.Value, config.Smartnode.DashboardPort.Value)
+		} else {
+			envVars["DASHBOARD_OPEN_PORTS"] = fmt.Sprintf("127.0.0.1:%d:%d/tcp", config.Smartnode.DashboardPort.Value, config.Smartnode.DashboardPort.Value)
+		}
+	}
+
 	// Bitfly Node Metrics
 	if config.EnableBitflyNodeMetrics.Value == true {
 		addParametersToEnvVars(config.BitflyNodeMetrics.GetParameters(), envVars)
@@ -1083,6 +1118,18 @@ func (config *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Make sure scheduled backups can be encrypted
+	if config.Backup.EnableScheduledBackups.Value == true && !config.Backup.CanEncrypt() {
+		errors = append(errors, "Scheduled backups are enabled, but there is no encryption passphrase, GPG public key or age recipient to encrypt them with.")
+	}
+
+	// Make sure the web dashboard's passwords aren't sent over the network in plain text
+	if config.Smartnode.EnableDashboard.Value == true && config.Smartnode.DashboardOpenPort.Value == true {
+		if certPath, _ := config.Smartnode.GetDashboardTlsPaths(false); certPath == "" {
+			errors = append(errors, "The web dashboard's port is exposed, but it doesn't have a TLS certificate and key to encrypt its connections with.")
+		}
+	}
+
 	// Check for illegal blank strings
 	/* TODO - this needs to be smarter and ignore irrelevant settings
 	for _, param := range config.GetParameters() {
@@ -1103,6 +1150,20 @@ func (config *RocketPoolConfig) Validate() []string {
 	return errors
 }
 
+// Convert a path the user entered to the path the caller sees
+// In Docker mode, paths inside the data folder on the host are mapped to the data folder inside the containers.
+func (config *RocketPoolConfig) getDaemonPath(path string, inDaemon bool) string {
+	path = os.ExpandEnv(path)
+	if path == "" || !inDaemon || config.IsNativeMode {
+		return path
+	}
+	hostDataPath := filepath.Clean(os.ExpandEnv(config.Smartnode.DataPath.Value.(string)))
+	if relativePath, err := filepath.Rel(hostDataPath, filepath.Clean(path)); err == nil && !strings.HasPrefix(relativePath, "..") {
+		return filepath.Join(containerDataPath, relativePath)
+	}
+	return path
+}
+
 // Applies all of the defaults to all of the settings that have them defined
 func (config *RocketPoolConfig) applyAllDefaults() error {
 	for _, param := range config.GetParameters() {
@@ -18,6 +18,7 @@ const (
 
 // Defaults
 const defaultProjectName string = "rocketpool"
+const defaultDashboardPort uint16 = 9106
 
 // Configuration for the Smartnode
 type SmartnodeConfig struct {
@@ -51,6 +52,61 @@ type SmartnodeConfig struct {
 	// Threshold for auto minipool stakes
 	MinipoolStakeGasThreshold Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
+	// Where the node account's key is held
+	NodeAccountBackend Parameter `yaml:"nodeAccountBackend,omitempty"`
+
+	// The derivation path of the node account on a hardware wallet
+	HardwareWalletPath Parameter `yaml:"hardwareWalletPath,omitempty"`
+
+	// The collateral ratio below which the node will stake RPL automatically
+	AutoStakeRplFloor Parameter `yaml:"autoStakeRplFloor,omitempty"`
+
+	// The collateral ratio automatic RPL stakes will top up to
+	AutoStakeRplTarget Parameter `yaml:"autoStakeRplTarget,omitempty"`
+
+	// Whether automatic RPL stakes can swap legacy RPL first
+	AutoStakeSwapLegacyRpl Parameter `yaml:"autoStakeSwapLegacyRpl,omitempty"`
+
+	// The discount below the oracle RPL price at which the node will bid on auction lots automatically
+	AutoBidMinDiscount Parameter `yaml:"autoBidMinDiscount,omitempty"`
+
+	// The most ETH automatic auction bids will spend on a single lot
+	AutoBidLotBudget Parameter `yaml:"autoBidLotBudget,omitempty"`
+
+	// The most ETH automatic auction bids will hold in unclaimed lots
+	AutoBidTotalBudget Parameter `yaml:"autoBidTotalBudget,omitempty"`
+
+	// Threshold for automatic auction bids and claims
+	AutoBidGasThreshold Parameter `yaml:"autoBidGasThreshold,omitempty"`
+
+	// Whether automatic auction bids are only logged rather than submitted
+	AutoBidDryRun Parameter `yaml:"autoBidDryRun,omitempty"`
+
+	// The webhook the watchtower sends oracle DAO proposal notifications to
+	ProposalWebhookUrl Parameter `yaml:"proposalWebhookUrl,omitempty"`
+
+	// How long before voting on an oracle DAO proposal closes the watchtower reminds the node to vote
+	ProposalReminderWindow Parameter `yaml:"proposalReminderWindow,omitempty"`
+
+	// Whether the node daemon serves the web dashboard
+	EnableDashboard Parameter `yaml:"enableDashboard,omitempty"`
+
+	// The port the web dashboard is served on
+	DashboardPort Parameter `yaml:"dashboardPort,omitempty"`
+
+	// Toggle for forwarding the web dashboard port outside of Docker
+	DashboardOpenPort Parameter `yaml:"dashboardOpenPort,omitempty"`
+
+	// The password for the operator's view of the web dashboard
+	DashboardPassword Parameter `yaml:"dashboardPassword,omitempty"`
+
+	// The password for the read-only view of the web dashboard
+	DashboardViewerPassword Parameter `yaml:"dashboardViewerPassword,omitempty"`
+
+	// The TLS certificate and key the web dashboard is served with
+	DashboardTlsCertPath Parameter `yaml:"dashboardTlsCertPath,omitempty"`
+	DashboardTlsKeyPath  Parameter `yaml:"dashboardTlsKeyPath,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -61,6 +117,9 @@ type SmartnodeConfig struct {
 	// The URL to use for staking rETH
 	stakeUrl map[Network]string `yaml:"-"`
 
+	// The URL of the Safe transaction service
+	safeTxServiceUrl map[Network]string `yaml:"-"`
+
 	// The map of networks to execution chain IDs
 	chainID map[Network]uint `yaml:"-"`
 
@@ -73,6 +132,18 @@ type SmartnodeConfig struct {
 	// The path within the daemon Docker container of the validator key folder
 	validatorKeychainPath string `yaml:"-"`
 
+	// The path within the daemon Docker container of the event index folder
+	indexerPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the watchtower submission audit folder
+	auditPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the oracle DAO proposal voting policy
+	proposalPolicyPath string `yaml:"-"`
+
+	// The path within the daemon Docker container of the watchtower's record of the proposals it has notified about
+	proposalWatcherPath string `yaml:"-"`
+
 	// The contract address of RocketStorage
 	storageAddress map[Network]string `yaml:"-"`
 
@@ -82,8 +153,20 @@ type SmartnodeConfig struct {
 	// The contract address of the RPL token
 	rplTokenAddress map[Network]string `yaml:"-"`
 
+	// The contract address of the Uniswap V3 RPL / WETH pool
+	uniswapRplPoolAddress map[Network]string `yaml:"-"`
+
+	// The contract address of the Chainlink RPL / USD price feed
+	chainlinkRplUsdAddress map[Network]string `yaml:"-"`
+
+	// The contract address of the Chainlink ETH / USD price feed
+	chainlinkEthUsdAddress map[Network]string `yaml:"-"`
+
 	// The contract address of the RPL faucet
 	rplFaucetAddress map[Network]string `yaml:"-"`
+
+	// The contract address of Multicall3
+	multicallAddress map[Network]string `yaml:"-"`
 }
 
 // Generates a new Smartnode configuration
@@ -187,6 +270,251 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		NodeAccountBackend: Parameter{
+			ID:   "nodeAccountBackend",
+			Name: "Node Account Backend",
+			Description: "Where the key for your node account is held. By default it is derived from the mnemonic in your node wallet, like your validator keys.\n\n" +
+				"Select a hardware wallet to sign node transactions on a USB Ledger or Trezor instead. Your validator keys will still be derived from your node wallet's mnemonic, but your node account will be the address on the device, so only switch before registering your node (or if the device holds the node account you already registered).\n\n" +
+				"The device must be plugged in, unlocked and (for a Ledger) have the Ethereum app open whenever the Smartnode uses your node account. The node daemon and watchtower won't send automatic transactions (such as claiming rewards, staking minipools or oracle DAO submissions), so these must be done from the CLI. In Docker Mode, the device must also be passed through to the api and node containers.",
+			Type:                 ParameterType_Choice,
+			Default:              map[Network]interface{}{Network_All: NodeAccountBackend_Local},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []ParameterOption{{
+				Name:        "Node Wallet",
+				Description: "Derive the node account from the mnemonic in your node wallet.",
+				Value:       NodeAccountBackend_Local,
+			}, {
+				Name:        "Ledger",
+				Description: "Sign node transactions on a Ledger hardware wallet.",
+				Value:       NodeAccountBackend_Ledger,
+			}, {
+				Name:        "Trezor",
+				Description: "Sign node transactions on a Trezor hardware wallet.",
+				Value:       NodeAccountBackend_Trezor,
+			}},
+		},
+
+		HardwareWalletPath: Parameter{
+			ID:                   "hardwareWalletPath",
+			Name:                 "Hardware Wallet Path",
+			Description:          "The derivation path of your node account on your hardware wallet. The default is the first account used by most wallets; Ledger Live's second account, for example, would be m/44'/60'/1'/0/0.\n\nOnly used when the node account backend is a hardware wallet.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: "m/44'/60'/0'/0/0"},
+			AffectsContainers:    []ContainerID{ContainerID_Api, ContainerID_Node, ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoStakeRplFloor: Parameter{
+			ID:                   "autoStakeRplFloor",
+			Name:                 "Auto-Stake RPL Floor",
+			Description:          "If your node's RPL collateral ratio drops below this percentage (for example, because the RPL price fell), the node will automatically stake RPL from its wallet to bring it back up to the Auto-Stake RPL Target. Use `rocketpool node collateral-plan` to see how your ratio moves with the RPL price.\n\nAutomatic stakes use the RPL Claim Gas Threshold. Set this to 0 to disable automatic staking.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(0)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoStakeRplTarget: Parameter{
+			ID:                   "autoStakeRplTarget",
+			Name:                 "Auto-Stake RPL Target",
+			Description:          "The collateral ratio (as a percentage) that automatic RPL stakes will top your node up to. If the node wallet doesn't hold enough RPL to reach it, the node will stake all of the RPL it has.\n\nOnly used when the Auto-Stake RPL Floor is above 0.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(15)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoStakeSwapLegacyRpl: Parameter{
+			ID:                   "autoStakeSwapLegacyRpl",
+			Name:                 "Auto-Stake Legacy RPL",
+			Description:          "Allow automatic RPL stakes to swap legacy (fixed-supply) RPL in the node wallet for new RPL when the wallet doesn't hold enough new RPL.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoBidMinDiscount: Parameter{
+			ID:                   "autoBidMinDiscount",
+			Name:                 "Auto-Bid Minimum Discount",
+			Description:          "If an RPL auction lot's current price is at least this percentage below the oracle RPL price, the node will bid on it automatically, within the Auto-Bid budgets. Once a lot's auction finishes, the node will also claim its RPL automatically. Use `rocketpool auction bid-plan` to see what the node would bid.\n\nThis must be between 0 and 100; set it to 0 to disable automatic bidding.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(0)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoBidLotBudget: Parameter{
+			ID:                   "autoBidLotBudget",
+			Name:                 "Auto-Bid Lot Budget",
+			Description:          "The most ETH the node will bid on a single auction lot automatically, including any bids you have placed on it yourself.\n\nOnly used when the Auto-Bid Minimum Discount is above 0.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(1)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoBidTotalBudget: Parameter{
+			ID:                   "autoBidTotalBudget",
+			Name:                 "Auto-Bid Total Budget",
+			Description:          "The most ETH the node will hold in bids on auction lots that haven't been claimed yet. Claiming a finished lot releases its bid from this budget, so this limits how much ETH is committed to auctions at once rather than how much is spent in total.\n\nOnly used when the Auto-Bid Minimum Discount is above 0.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(5)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoBidGasThreshold: Parameter{
+			ID:                   "autoBidGasThreshold",
+			Name:                 "Auto-Bid Gas Threshold",
+			Description:          "Automatic auction bids and lot claims will use the `Rapid` suggestion from the gas estimator, based on current network conditions. This threshold is a limit (in gwei) you can put on that suggestion; your node will not bid or claim automatically until the suggestion is below this limit.\n\nSet this to 0 to disable automatic bids and claims.",
+			Type:                 ParameterType_Float,
+			Default:              map[Network]interface{}{Network_All: float64(150)},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AutoBidDryRun: Parameter{
+			ID:                   "autoBidDryRun",
+			Name:                 "Auto-Bid Dry Run",
+			Description:          "Only log the bids and claims the node would make automatically, without submitting them. Use this to check the Auto-Bid settings before enabling them for real.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: true},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ProposalWebhookUrl: Parameter{
+			ID:   "proposalWebhookUrl",
+			Name: "Proposal Notification Webhook",
+			Description: "*Oracle DAO members only.*\n\nThe watchtower logs a notification whenever a new oracle DAO proposal is made, when it votes on one automatically, and when voting on one you haven't voted on is about to close. " +
+				"If you set this to a Discord or Slack incoming webhook URL, the notifications will be posted there too.\n\n" +
+				"The watchtower can also vote on some proposals for you. Describe which in the `odao-vote-policy.yml` file in your data folder; see `rocketpool odao proposals policy` for the format.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ProposalReminderWindow: Parameter{
+			ID:                   "proposalReminderWindow",
+			Name:                 "Proposal Vote Reminder",
+			Description:          "*Oracle DAO members only.*\n\nHow many hours before voting on an oracle DAO proposal closes the watchtower should remind you to vote, if you haven't and the proposal hasn't reached quorum yet. Set this to 0 to disable reminders.",
+			Type:                 ParameterType_Uint,
+			Default:              map[Network]interface{}{Network_All: uint64(24)},
+			AffectsContainers:    []ContainerID{ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableDashboard: Parameter{
+			ID:                   "enableDashboard",
+			Name:                 "Enable Web Dashboard",
+			Description:          "Serve a web dashboard from the node container that shows your node's status, minipools, rewards, client sync progress, recent daemon logs and pending transactions.\n\nThe dashboard needs a password to start; set one in the Dashboard Password setting.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{"ENABLE_DASHBOARD"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DashboardPort: Parameter{
+			ID:                   "dashboardPort",
+			Name:                 "Dashboard Port",
+			Description:          "The port the web dashboard should be served on.",
+			Type:                 ParameterType_Uint16,
+			Default:              map[Network]interface{}{Network_All: defaultDashboardPort},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{"DASHBOARD_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DashboardOpenPort: Parameter{
+			ID:                   "dashboardOpenPort",
+			Name:                 "Expose Dashboard Port",
+			Description:          "Enable this to expose the web dashboard's port to your local network, so other machines can access it too. Otherwise, it can only be reached from this machine.\n\nThe dashboard's passwords are sent with every request, so exposing it requires a TLS certificate and key; set them in the Dashboard TLS Certificate Path and Dashboard TLS Key Path settings.",
+			Type:                 ParameterType_Bool,
+			Default:              map[Network]interface{}{Network_All: false},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DashboardPassword: Parameter{
+			ID:                   "dashboardPassword",
+			Name:                 "Dashboard Password",
+			Description:          "The password for the operator's view of the web dashboard. Log in with the username `operator`.\n\nThe operator's view includes the node daemon's logs and pending transactions.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DashboardViewerPassword: Parameter{
+			ID:                   "dashboardViewerPassword",
+			Name:                 "Dashboard Viewer Password",
+			Description:          "The password for the read-only view of the web dashboard, which you can share with people who aren't operating the node. Log in with the username `viewer`.\n\nThe read-only view shows the node's status, minipools, rewards and client sync progress, but not the daemon's logs or pending transactions. Leave this blank to disable it.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DashboardTlsCertPath: Parameter{
+			ID:                   "dashboardTlsCertPath",
+			Name:                 "Dashboard TLS Certificate Path",
+			Description:          "The path to a PEM-encoded TLS certificate to serve the web dashboard over HTTPS with. This is required to expose the dashboard's port.\n\nIn Docker mode, this file must be inside your data folder.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DashboardTlsKeyPath: Parameter{
+			ID:                   "dashboardTlsKeyPath",
+			Name:                 "Dashboard TLS Key Path",
+			Description:          "The path to the PEM-encoded private key of the web dashboard's TLS certificate.\n\nIn Docker mode, this file must be inside your data folder.",
+			Type:                 ParameterType_String,
+			Default:              map[Network]interface{}{Network_All: ""},
+			AffectsContainers:    []ContainerID{ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		txWatchUrl: map[Network]string{
 			Network_Mainnet: "https://etherscan.io/tx",
 			Network_Prater:  "https://goerli.etherscan.io/tx",
@@ -197,6 +525,11 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			Network_Prater:  "https://testnet.rocketpool.net",
 		},
 
+		safeTxServiceUrl: map[Network]string{
+			Network_Mainnet: "https://safe-transaction-mainnet.safe.global",
+			Network_Prater:  "https://safe-transaction-goerli.safe.global",
+		},
+
 		chainID: map[Network]uint{
 			Network_Mainnet: 1, // Mainnet
 			Network_Prater:  5, // Goerli
@@ -208,6 +541,14 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 
 		validatorKeychainPath: "/.rocketpool/data/validators",
 
+		indexerPath: "/.rocketpool/data/index",
+
+		auditPath: "/.rocketpool/data/audit",
+
+		proposalPolicyPath: "/.rocketpool/data/odao-vote-policy.yml",
+
+		proposalWatcherPath: "/.rocketpool/data/proposal-watcher.json",
+
 		storageAddress: map[Network]string{
 			Network_Mainnet: "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46",
 			Network_Prater:  "0xd8Cd47263414aFEca62d6e2a3917d6600abDceB3",
@@ -223,10 +564,30 @@ func NewSmartnodeConfig(config *RocketPoolConfig) *SmartnodeConfig {
 			Network_Prater:  "0xb4efd85c19999d84251304bda99e90b92300bd93",
 		},
 
+		uniswapRplPoolAddress: map[Network]string{
+			Network_Mainnet: "0xe42318eA3b998e8355a3Da364EB9D48eC725Eb45",
+			Network_Prater:  "",
+		},
+
+		chainlinkRplUsdAddress: map[Network]string{
+			Network_Mainnet: "0x4E155eD98aFE9034b7A5962f6C84c86d869daA9d",
+			Network_Prater:  "",
+		},
+
+		chainlinkEthUsdAddress: map[Network]string{
+			Network_Mainnet: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+			Network_Prater:  "",
+		},
+
 		rplFaucetAddress: map[Network]string{
 			Network_Mainnet: "",
 			Network_Prater:  "0x95D6b8E2106E3B30a72fC87e2B56ce15E37853F9",
 		},
+
+		multicallAddress: map[Network]string{
+			Network_Mainnet: "0xcA11bde05977b3631167028862bE2a173976CA11",
+			Network_Prater:  "0xcA11bde05977b3631167028862bE2a173976CA11",
+		},
 	}
 
 }
@@ -241,6 +602,25 @@ func (config *SmartnodeConfig) GetParameters() []*Parameter {
 		&config.PriorityFee,
 		&config.RplClaimGasThreshold,
 		&config.MinipoolStakeGasThreshold,
+		&config.NodeAccountBackend,
+		&config.HardwareWalletPath,
+		&config.AutoStakeRplFloor,
+		&config.AutoStakeRplTarget,
+		&config.AutoStakeSwapLegacyRpl,
+		&config.AutoBidMinDiscount,
+		&config.AutoBidLotBudget,
+		&config.AutoBidTotalBudget,
+		&config.AutoBidGasThreshold,
+		&config.AutoBidDryRun,
+		&config.ProposalWebhookUrl,
+		&config.ProposalReminderWindow,
+		&config.EnableDashboard,
+		&config.DashboardPort,
+		&config.DashboardOpenPort,
+		&config.DashboardPassword,
+		&config.DashboardViewerPassword,
+		&config.DashboardTlsCertPath,
+		&config.DashboardTlsKeyPath,
 	}
 }
 
@@ -254,6 +634,10 @@ func (config *SmartnodeConfig) GetStakeUrl() string {
 	return config.stakeUrl[config.Network.Value.(Network)]
 }
 
+func (config *SmartnodeConfig) GetSafeTxServiceUrl() string {
+	return config.safeTxServiceUrl[config.Network.Value.(Network)]
+}
+
 func (config *SmartnodeConfig) GetChainID() uint {
 	return config.chainID[config.Network.Value.(Network)]
 }
@@ -282,6 +666,48 @@ func (config *SmartnodeConfig) GetValidatorKeychainPath() string {
 	}
 }
 
+func (config *SmartnodeConfig) GetIndexerPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.DataPath.Value.(string), "index")
+	} else {
+		return config.indexerPath
+	}
+}
+
+func (config *SmartnodeConfig) GetAuditPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.DataPath.Value.(string), "audit")
+	} else {
+		return config.auditPath
+	}
+}
+
+func (config *SmartnodeConfig) GetProposalPolicyPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.DataPath.Value.(string), "odao-vote-policy.yml")
+	} else {
+		return config.proposalPolicyPath
+	}
+}
+
+func (config *SmartnodeConfig) GetProposalWatcherPath() string {
+	if config.parent.IsNativeMode {
+		return filepath.Join(config.DataPath.Value.(string), "proposal-watcher.json")
+	} else {
+		return config.proposalWatcherPath
+	}
+}
+
+// Get the paths of the web dashboard's TLS certificate and key, or blank if it's served without TLS
+func (config *SmartnodeConfig) GetDashboardTlsPaths(inDaemon bool) (string, string) {
+	certPath := config.parent.getDaemonPath(config.DashboardTlsCertPath.Value.(string), inDaemon)
+	keyPath := config.parent.getDaemonPath(config.DashboardTlsKeyPath.Value.(string), inDaemon)
+	if certPath == "" || keyPath == "" {
+		return "", ""
+	}
+	return certPath, keyPath
+}
+
 func (config *SmartnodeConfig) GetStorageAddress() string {
 	return config.storageAddress[config.Network.Value.(Network)]
 }
@@ -294,10 +720,26 @@ func (config *SmartnodeConfig) GetRplTokenAddress() string {
 	return config.rplTokenAddress[config.Network.Value.(Network)]
 }
 
+func (config *SmartnodeConfig) GetUniswapRplPoolAddress() string {
+	return config.uniswapRplPoolAddress[config.Network.Value.(Network)]
+}
+
+func (config *SmartnodeConfig) GetChainlinkRplUsdAddress() string {
+	return config.chainlinkRplUsdAddress[config.Network.Value.(Network)]
+}
+
+func (config *SmartnodeConfig) GetChainlinkEthUsdAddress() string {
+	return config.chainlinkEthUsdAddress[config.Network.Value.(Network)]
+}
+
 func (config *SmartnodeConfig) GetRplFaucetAddress() string {
 	return config.rplFaucetAddress[config.Network.Value.(Network)]
 }
 
+func (config *SmartnodeConfig) GetMulticallAddress() string {
+	return config.multicallAddress[config.Network.Value.(Network)]
+}
+
 func (config *SmartnodeConfig) GetSmartnodeContainerTag() string {
 	return smartnodeTag
 }
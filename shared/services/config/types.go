@@ -6,6 +6,8 @@ type Mode string
 type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
+type NodeAccountBackend string
+type BackupTarget string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -70,6 +72,22 @@ const (
 	ConsensusClient_Teku       ConsensusClient = "teku"
 )
 
+// Enum to describe where the node account's key is held
+const (
+	NodeAccountBackend_Unknown NodeAccountBackend = ""
+	NodeAccountBackend_Local   NodeAccountBackend = "local"
+	NodeAccountBackend_Ledger  NodeAccountBackend = "ledger"
+	NodeAccountBackend_Trezor  NodeAccountBackend = "trezor"
+)
+
+// Enum to describe where backups are stored
+const (
+	BackupTarget_Unknown BackupTarget = ""
+	BackupTarget_Local   BackupTarget = "local"
+	BackupTarget_S3      BackupTarget = "s3"
+	BackupTarget_Sftp    BackupTarget = "sftp"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
@@ -10,8 +10,10 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -24,6 +26,8 @@ type ExecutionClientManager struct {
 	fallbackEcUrl   string
 	primaryEc       *ethclient.Client
 	fallbackEc      *ethclient.Client
+	primaryRpc      *rpc.Client
+	fallbackRpc     *rpc.Client
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
@@ -57,17 +61,20 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	primaryRpc, err := rpc.Dial(primaryEcUrl)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
+	primaryEc := ethclient.NewClient(primaryRpc)
 
+	var fallbackRpc *rpc.Client
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackRpc, err = rpc.Dial(fallbackEcUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
+		fallbackEc = ethclient.NewClient(fallbackRpc)
 	}
 
 	return &ExecutionClientManager{
@@ -75,6 +82,8 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		fallbackEcUrl: fallbackEcUrl,
 		primaryEc:     primaryEc,
 		fallbackEc:    fallbackEc,
+		primaryRpc:    primaryRpc,
+		fallbackRpc:   fallbackRpc,
 		logger:        log.NewColorLogger(color.FgYellow),
 		primaryReady:  true,
 		fallbackReady: fallbackEc != nil,
@@ -110,6 +119,85 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 	return result.([]byte), err
 }
 
+// PendingCallContract executes an Ethereum contract call against the pending state.
+func (p *ExecutionClientManager) PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.PendingCallContract(ctx, call)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), err
+}
+
+// PendingCallWithOverrides executes a contract call against the pending state with a set of state overrides applied first.
+// The overrides are passed to eth_call as-is, so they must marshal to the client's state override set format.
+func (p *ExecutionClientManager) PendingCallWithOverrides(ctx context.Context, call ethereum.CallMsg, overrides interface{}) ([]byte, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		var result hexutil.Bytes
+		err := p.getRpcClient(client).CallContext(ctx, &result, "eth_call", toCallArgs(call), "pending", overrides)
+		return []byte(result), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), err
+}
+
+// TraceCall runs a call against the pending state with the client's built-in call tracer, including the logs it emits.
+// Not every client supports this, so callers should treat an error as the trace being unavailable.
+// If overrides is not nil, the state overrides are applied before the call is traced.
+func (p *ExecutionClientManager) TraceCall(ctx context.Context, call ethereum.CallMsg, overrides interface{}, result interface{}) error {
+	tracerConfig := map[string]interface{}{
+		"tracer": "callTracer",
+		"tracerConfig": map[string]interface{}{
+			"withLog": true,
+		},
+	}
+	if overrides != nil {
+		tracerConfig["stateOverrides"] = overrides
+	}
+	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return nil, p.getRpcClient(client).CallContext(ctx, result, "debug_traceCall", toCallArgs(call), "pending", tracerConfig)
+	})
+	return err
+}
+
+// Get the raw RPC client behind an execution client
+func (p *ExecutionClientManager) getRpcClient(client *ethclient.Client) *rpc.Client {
+	if client == p.fallbackEc {
+		return p.fallbackRpc
+	}
+	return p.primaryRpc
+}
+
+// Convert a call message to the arguments of a raw eth_call or debug_traceCall request
+func toCallArgs(call ethereum.CallMsg) map[string]interface{} {
+	args := map[string]interface{}{
+		"from": call.From,
+		"to":   call.To,
+		"data": hexutil.Bytes(call.Data),
+	}
+	if call.Value != nil {
+		args["value"] = (*hexutil.Big)(call.Value)
+	}
+	if call.Gas != 0 {
+		args["gas"] = hexutil.Uint64(call.Gas)
+	}
+	return args
+}
+
+// PeerCount returns the number of peers the primary client is connected to.
+// This doesn't require the client to be synced, since a lack of peers is a common reason for it not syncing.
+func (p *ExecutionClientManager) PeerCount(ctx context.Context) (uint64, error) {
+	var peerCount hexutil.Uint64
+	err := p.primaryRpc.CallContext(ctx, &peerCount, "net_peerCount")
+	if err != nil {
+		return 0, err
+	}
+	return uint64(peerCount), nil
+}
+
 /// ============================
 /// ContractTransactor Functions
 /// ============================
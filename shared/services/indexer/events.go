@@ -0,0 +1,321 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The data from a minipool's MinipoolPrestaked event
+type minipoolPrestakeEvent struct {
+	Pubkey                []byte   `abi:"validatorPubkey"`
+	Signature             []byte   `abi:"validatorSignature"`
+	DepositDataRoot       [32]byte `abi:"depositDataRoot"`
+	Amount                *big.Int `abi:"amount"`
+	WithdrawalCredentials []byte   `abi:"withdrawalCredentials"`
+	Time                  *big.Int `abi:"time"`
+}
+
+// A minipool status change
+type StatusUpdate struct {
+	Status      rptypes.MinipoolStatus `json:"status"`
+	Time        time.Time              `json:"time"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	TxHash      common.Hash            `json:"txHash"`
+}
+
+// A DAO proposal's execution
+type ProposalExecution struct {
+	ProposalID  uint64      `json:"proposalId"`
+	Time        time.Time   `json:"time"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
+
+// Index the beacon deposit contract's deposit events
+func (idx *Indexer) indexDeposits(batch ethdb.Batch, fromBlock *big.Int, toBlock *big.Int) error {
+
+	addressFilter := []common.Address{*idx.casperDeposit.Address}
+	topicFilter := [][]common.Hash{{idx.casperDeposit.ABI.Events["DepositEvent"].ID}}
+	logs, err := eth.GetLogs(idx.rp, addressFilter, topicFilter, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting deposit events: %w", err)
+	}
+
+	for _, log := range logs {
+		depositEvent := new(utils.BeaconDepositEvent)
+		if err := idx.casperDeposit.Contract.UnpackLog(depositEvent, "DepositEvent", log); err != nil {
+			return fmt.Errorf("Error decoding deposit event in block %d: %w", log.BlockNumber, err)
+		}
+
+		// Convert the deposit amount from little-endian binary to a uint64
+		var amount uint64
+		if err := binary.Read(bytes.NewReader(depositEvent.Amount), binary.LittleEndian, &amount); err != nil {
+			return fmt.Errorf("Error decoding deposit amount in block %d: %w", log.BlockNumber, err)
+		}
+
+		pubkey := rptypes.BytesToValidatorPubkey(depositEvent.Pubkey)
+		deposit := utils.DepositData{
+			Pubkey:                pubkey,
+			WithdrawalCredentials: common.BytesToHash(depositEvent.WithdrawalCredentials),
+			Amount:                amount,
+			Signature:             rptypes.BytesToValidatorSignature(depositEvent.Signature),
+			TxHash:                log.TxHash,
+			BlockNumber:           log.BlockNumber,
+			TxIndex:               log.TxIndex,
+		}
+		if err := putJsonEvent(batch, depositPrefix, pubkey.Bytes(), log, deposit); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Index the RPL rewards claim events
+func (idx *Indexer) indexRplClaims(batch ethdb.Batch, fromBlock *big.Int, toBlock *big.Int) error {
+
+	claimEvent := idx.rocketRewardsPool.ABI.Events["RPLTokensClaimed"]
+	addressFilter := []common.Address{*idx.rocketRewardsPool.Address}
+	topicFilter := [][]common.Hash{{claimEvent.ID}}
+	logs, err := eth.GetLogs(idx.rp, addressFilter, topicFilter, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting RPL claim events: %w", err)
+	}
+
+	for _, log := range logs {
+		if len(log.Topics) < 3 {
+			continue
+		}
+		values := make(map[string]interface{})
+		if err := claimEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return fmt.Errorf("Error decoding RPL claim event in block %d: %w", log.BlockNumber, err)
+		}
+		amount, ok := values["amount"].(*big.Int)
+		if !ok {
+			return fmt.Errorf("RPL claim event in block %d has no amount", log.BlockNumber)
+		}
+
+		// Claims are keyed by the claiming contract and then the claimer
+		claimingContract := common.BytesToAddress(log.Topics[1].Bytes())
+		claimer := common.BytesToAddress(log.Topics[2].Bytes())
+		id := concat(claimingContract.Bytes(), claimer.Bytes())
+		if err := putEvent(batch, eventKey(claimPrefix, id, log.BlockNumber, log.Index), log.BlockNumber, log.Index, amount.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Index minipool creation, prestake and status change events
+func (idx *Indexer) indexMinipoolEvents(batch ethdb.Batch, fromBlock *big.Int, toBlock *big.Int) error {
+
+	// Get every minipool manager deployed so far, so minipools created by earlier versions are recognised
+	addressFilter, err := idx.getMinipoolManagers(batch, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	// Get the minipools created in this range, since minipool events can only be filtered by topic
+	createdEvent := idx.rocketMinipoolManager.ABI.Events["MinipoolCreated"]
+	logs, err := eth.GetLogs(idx.rp, addressFilter, [][]common.Hash{{createdEvent.ID}}, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting minipool creation events: %w", err)
+	}
+	newMinipools := map[common.Address]bool{}
+	for _, log := range logs {
+		if len(log.Topics) < 3 {
+			continue
+		}
+		minipoolAddress := common.BytesToAddress(log.Topics[1].Bytes())
+		nodeAddress := common.BytesToAddress(log.Topics[2].Bytes())
+		if err := putEvent(batch, minipoolKey(minipoolAddress), log.BlockNumber, log.Index, nodeAddress.Bytes()); err != nil {
+			return err
+		}
+		newMinipools[minipoolAddress] = true
+	}
+
+	// Get the prestake and status change events
+	prestakeEvent := idx.minipoolAbi.Events["MinipoolPrestaked"]
+	statusEvent := idx.minipoolAbi.Events["StatusUpdated"]
+	topicFilter := [][]common.Hash{{prestakeEvent.ID, statusEvent.ID}}
+	logs, err = eth.GetLogs(idx.rp, nil, topicFilter, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting minipool events: %w", err)
+	}
+
+	for _, log := range logs {
+
+		// Ignore events from contracts that aren't Rocket Pool minipools
+		if !newMinipools[log.Address] {
+			isMinipool, err := idx.db.Has(minipoolKey(log.Address))
+			if err != nil {
+				return fmt.Errorf("Error reading indexed minipools: %w", err)
+			}
+			if !isMinipool {
+				continue
+			}
+		}
+
+		switch log.Topics[0] {
+		case prestakeEvent.ID:
+			if err := idx.indexPrestake(batch, log); err != nil {
+				return err
+			}
+		case statusEvent.ID:
+			if len(log.Topics) < 2 {
+				continue
+			}
+			values := make(map[string]interface{})
+			if err := statusEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				return fmt.Errorf("Error decoding status update for minipool %s: %w", log.Address.Hex(), err)
+			}
+			update := StatusUpdate{
+				Status:      rptypes.MinipoolStatus(log.Topics[1].Big().Uint64()),
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash,
+			}
+			if statusTime, ok := values["time"].(*big.Int); ok {
+				update.Time = time.Unix(statusTime.Int64(), 0)
+			}
+			if err := putJsonEvent(batch, statusPrefix, log.Address.Bytes(), log, update); err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+
+}
+
+// Index the DAO proposal execution events
+func (idx *Indexer) indexProposalExecutions(batch ethdb.Batch, fromBlock *big.Int, toBlock *big.Int) error {
+
+	executedEvent := idx.rocketDaoProposal.ABI.Events["ProposalExecuted"]
+	addressFilter := []common.Address{*idx.rocketDaoProposal.Address}
+	topicFilter := [][]common.Hash{{executedEvent.ID}}
+	logs, err := eth.GetLogs(idx.rp, addressFilter, topicFilter, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting proposal execution events: %w", err)
+	}
+
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		values := make(map[string]interface{})
+		if err := executedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return fmt.Errorf("Error decoding proposal execution event in block %d: %w", log.BlockNumber, err)
+		}
+		execution := ProposalExecution{
+			ProposalID:  log.Topics[1].Big().Uint64(),
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+		}
+		if executedTime, ok := values["time"].(*big.Int); ok {
+			execution.Time = time.Unix(executedTime.Int64(), 0)
+		}
+		if err := putJsonEvent(batch, proposalPrefix, nil, log, execution); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Get every address the minipool manager has been deployed at up to the end of a range, indexing the upgrades in the range
+func (idx *Indexer) getMinipoolManagers(batch ethdb.Batch, fromBlock *big.Int, toBlock *big.Int) ([]common.Address, error) {
+
+	managers := map[common.Address]bool{
+		*idx.rocketMinipoolManager.Address: true,
+	}
+
+	// Get the managers from earlier ranges
+	iterator := idx.db.NewIterator(managerPrefix, nil)
+	for iterator.Next() {
+		if len(iterator.Key()) == len(managerPrefix)+common.AddressLength {
+			managers[common.BytesToAddress(iterator.Value())] = true
+		}
+	}
+	iterator.Release()
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf("Error reading indexed minipool managers: %w", err)
+	}
+
+	// Get the upgrades in this range; both the old and new addresses are kept
+	upgradeEvent := idx.rocketDaoNodeTrustedUpgrade.ABI.Events["ContractUpgraded"]
+	addressFilter := []common.Address{*idx.rocketDaoNodeTrustedUpgrade.Address}
+	topicFilter := [][]common.Hash{{upgradeEvent.ID}, {crypto.Keccak256Hash([]byte("rocketMinipoolManager"))}}
+	logs, err := eth.GetLogs(idx.rp, addressFilter, topicFilter, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool manager upgrade events: %w", err)
+	}
+	for _, log := range logs {
+		if len(log.Topics) < 3 {
+			continue
+		}
+		for _, topic := range log.Topics[2:] {
+			address := common.BytesToAddress(topic.Bytes())
+			if address == (common.Address{}) {
+				continue
+			}
+			if err := putEvent(batch, concat(managerPrefix, address.Bytes()), log.BlockNumber, log.Index, address.Bytes()); err != nil {
+				return nil, err
+			}
+			managers[address] = true
+		}
+	}
+
+	addresses := make([]common.Address, 0, len(managers))
+	for address := range managers {
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+
+}
+
+// Index a minipool's prestake event
+func (idx *Indexer) indexPrestake(batch ethdb.Batch, log types.Log) error {
+
+	event := new(minipoolPrestakeEvent)
+	if err := idx.minipoolAbi.UnpackIntoInterface(event, "MinipoolPrestaked", log.Data); err != nil {
+		return fmt.Errorf("Error decoding prestake event for minipool %s: %w", log.Address.Hex(), err)
+	}
+	prestake := minipool.PrestakeData{
+		Pubkey:                rptypes.BytesToValidatorPubkey(event.Pubkey),
+		WithdrawalCredentials: common.BytesToHash(event.WithdrawalCredentials),
+		Amount:                event.Amount,
+		Signature:             rptypes.BytesToValidatorSignature(event.Signature),
+		DepositDataRoot:       event.DepositDataRoot,
+		Time:                  time.Unix(event.Time.Int64(), 0),
+	}
+	return putJsonEvent(batch, prestakePrefix, log.Address.Bytes(), log, prestake)
+
+}
+
+// Store an event as JSON under the given prefix and ID
+func putJsonEvent(batch ethdb.Batch, prefix []byte, id []byte, log types.Log, event interface{}) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("Error encoding event in block %d: %w", log.BlockNumber, err)
+	}
+	return putEvent(batch, eventKey(prefix, id, log.BlockNumber, log.Index), log.BlockNumber, log.Index, value)
+}
+
+// Get the key that marks an address as a minipool
+func minipoolKey(address common.Address) []byte {
+	return concat(isMinipoolPrefix, address.Bytes())
+}
@@ -0,0 +1,442 @@
+package indexer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+// Settings
+const (
+	// The number of blocks to index at once if the execution client doesn't limit the event log interval
+	DefaultIndexBatchSize = 10000
+
+	// The number of recent block hashes to keep for detecting reorgs
+	MaxReorgDepth = 64
+
+	// How long to wait for another process to release the index database
+	OpenTimeout = 30 * time.Second
+
+	// LevelDB cache size (MB) and file handles
+	databaseCache   = 16
+	databaseHandles = 16
+
+	// The version of the index layout; indexes written with an older layout are rebuilt from scratch
+	indexVersion uint64 = 3
+)
+
+// Returned when the index database is still held by another process after the open timeout
+var ErrIndexBusy = errors.New("the event index is in use by another process")
+
+// Database key prefixes
+var (
+	headKey          = []byte("meta/head")
+	versionKey       = []byte("meta/version")
+	blockHashPrefix  = []byte("hash/")
+	blockLogPrefix   = []byte("block/")
+	depositPrefix    = []byte("deposit/")
+	claimPrefix      = []byte("claim/")
+	statusPrefix     = []byte("status/")
+	prestakePrefix   = []byte("prestake/")
+	isMinipoolPrefix = []byte("minipool/")
+	managerPrefix    = []byte("manager/")
+	proposalPrefix   = []byte("proposal/")
+)
+
+// Indexes Rocket Pool and beacon deposit contract events into a local database, following the chain head
+type Indexer struct {
+	rp               *rocketpool.RocketPool
+	db               ethdb.KeyValueStore
+	eventLogInterval *big.Int
+	batchSize        uint64
+	lock             sync.Mutex
+
+	// Contracts and events being indexed
+	casperDeposit               *rocketpool.Contract
+	rocketRewardsPool           *rocketpool.Contract
+	rocketMinipoolManager       *rocketpool.Contract
+	rocketDaoNodeTrustedUpgrade *rocketpool.Contract
+	rocketDaoProposal           *rocketpool.Contract
+	minipoolAbi                 *abi.ABI
+	deployBlock                 uint64
+}
+
+// Create a new indexer, storing its database in the given subfolder of the indexer folder
+// Each process that uses an indexer needs its own database
+func NewIndexer(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, name string) (*Indexer, error) {
+	return OpenIndexer(rp, cfg, name, OpenTimeout)
+}
+
+// Create a new indexer, waiting up to the timeout for other processes to release its database
+// ErrIndexBusy is returned if the database is still in use after the timeout.
+func OpenIndexer(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, name string, openTimeout time.Duration) (*Indexer, error) {
+
+	// Get the event log interval
+	eventLogInterval, err := api.GetEventLogInterval(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting event log interval: %w", err)
+	}
+	batchSize := uint64(DefaultIndexBatchSize)
+	if eventLogInterval != nil {
+		batchSize = eventLogInterval.Uint64()
+	}
+
+	// Get the indexed contracts
+	casperDeposit, err := rp.GetContract("casperDeposit")
+	if err != nil {
+		return nil, err
+	}
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool")
+	if err != nil {
+		return nil, err
+	}
+	rocketMinipoolManager, err := rp.GetContract("rocketMinipoolManager")
+	if err != nil {
+		return nil, err
+	}
+	rocketDaoNodeTrustedUpgrade, err := rp.GetContract("rocketDAONodeTrustedUpgrade")
+	if err != nil {
+		return nil, err
+	}
+	rocketDaoProposal, err := rp.GetContract("rocketDAOProposal")
+	if err != nil {
+		return nil, err
+	}
+	minipoolAbi, err := rp.GetABI("rocketMinipool")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the block Rocket Pool was deployed at, which is where indexing starts
+	deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Rocket Pool deployment block: %w", err)
+	}
+
+	// Open the database, waiting for other processes to release it if necessary
+	path := filepath.Join(cfg.Smartnode.GetIndexerPath(), name)
+	var db ethdb.KeyValueStore
+	timeout := time.Now().Add(openTimeout)
+	for {
+		db, err = leveldb.New(path, databaseCache, databaseHandles, "", false)
+		if err == nil {
+			break
+		}
+		if !strings.Contains(err.Error(), "resource temporarily unavailable") {
+			return nil, fmt.Errorf("Error opening event index %s: %w", path, err)
+		}
+		if time.Now().After(timeout) {
+			return nil, fmt.Errorf("Error opening event index %s: %w", path, ErrIndexBusy)
+		}
+		time.Sleep(time.Second)
+	}
+
+	idx := &Indexer{
+		rp:                          rp,
+		db:                          db,
+		eventLogInterval:            eventLogInterval,
+		batchSize:                   batchSize,
+		casperDeposit:               casperDeposit,
+		rocketRewardsPool:           rocketRewardsPool,
+		rocketMinipoolManager:       rocketMinipoolManager,
+		rocketDaoNodeTrustedUpgrade: rocketDaoNodeTrustedUpgrade,
+		rocketDaoProposal:           rocketDaoProposal,
+		minipoolAbi:                 minipoolAbi,
+		deployBlock:                 deployBlock.Uint64(),
+	}
+
+	// Rebuild indexes written with an older layout
+	if err := idx.checkVersion(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return idx, nil
+
+}
+
+// Clear the index if it was written with an older layout, so it's rebuilt from the deployment block
+func (idx *Indexer) checkVersion() error {
+
+	has, err := idx.db.Has(versionKey)
+	if err != nil {
+		return fmt.Errorf("Error reading event index version: %w", err)
+	}
+	if has {
+		value, err := idx.db.Get(versionKey)
+		if err != nil {
+			return fmt.Errorf("Error reading event index version: %w", err)
+		}
+		if binary.BigEndian.Uint64(value) == indexVersion {
+			return nil
+		}
+	}
+
+	batch := idx.db.NewBatch()
+	if err := idx.deleteRange(batch, nil, nil, nil); err != nil {
+		return fmt.Errorf("Error clearing outdated event index: %w", err)
+	}
+	if err := batch.Put(versionKey, uint64ToBytes(indexVersion)); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("Error clearing outdated event index: %w", err)
+	}
+	return nil
+
+}
+
+// Close the index database
+func (idx *Indexer) Close() error {
+	return idx.db.Close()
+}
+
+// Get the latest block that has been indexed
+func (idx *Indexer) GetIndexedBlock() (uint64, error) {
+	has, err := idx.db.Has(headKey)
+	if err != nil {
+		return 0, fmt.Errorf("Error reading indexed block: %w", err)
+	}
+	if !has {
+		return 0, nil
+	}
+	value, err := idx.db.Get(headKey)
+	if err != nil {
+		return 0, fmt.Errorf("Error reading indexed block: %w", err)
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// Index all events up to the given block (or the latest block if nil), rolling back any blocks that were reorged out
+func (idx *Indexer) Sync(toBlock *big.Int) error {
+	_, err := idx.SyncLimited(toBlock, 0)
+	return err
+}
+
+// Index events towards the given block (or the latest block if nil), stopping after at most maxBlocks blocks (or none if 0)
+// Returns the fraction of the blocks since the deployment block that have been indexed, which is 1 once the index has reached the target.
+func (idx *Indexer) SyncLimited(toBlock *big.Int, maxBlocks uint64) (float64, error) {
+
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	// Get the target block
+	if toBlock == nil {
+		latestBlock, err := idx.rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return 0, fmt.Errorf("Error getting latest block: %w", err)
+		}
+		toBlock = big.NewInt(0).SetUint64(latestBlock)
+	}
+	target := toBlock.Uint64()
+
+	// Roll back any reorged blocks
+	head, err := idx.handleReorgs()
+	if err != nil {
+		return 0, err
+	}
+
+	// Index the new blocks in batches
+	from := idx.deployBlock
+	if head >= from {
+		from = head + 1
+	}
+	end := target
+	if maxBlocks > 0 && from+maxBlocks-1 < end {
+		end = from + maxBlocks - 1
+	}
+	for from <= end {
+		to := from + idx.batchSize - 1
+		if to > end {
+			to = end
+		}
+		if err := idx.indexRange(from, to, target); err != nil {
+			return 0, err
+		}
+		from = to + 1
+	}
+
+	// Report the progress
+	if from > target || target <= idx.deployBlock {
+		return 1, nil
+	}
+	return float64(from-idx.deployBlock) / float64(target-idx.deployBlock+1), nil
+
+}
+
+// Index the events in a range of blocks, saving the block hashes that are recent enough to be reorged
+func (idx *Indexer) indexRange(from uint64, to uint64, target uint64) error {
+
+	batch := idx.db.NewBatch()
+	fromBig := big.NewInt(0).SetUint64(from)
+	toBig := big.NewInt(0).SetUint64(to)
+
+	// Index the events
+	if err := idx.indexDeposits(batch, fromBig, toBig); err != nil {
+		return err
+	}
+	if err := idx.indexRplClaims(batch, fromBig, toBig); err != nil {
+		return err
+	}
+	if err := idx.indexMinipoolEvents(batch, fromBig, toBig); err != nil {
+		return err
+	}
+	if err := idx.indexProposalExecutions(batch, fromBig, toBig); err != nil {
+		return err
+	}
+
+	// Save the hashes of the blocks near the target so reorgs can be detected
+	hashStart := from
+	if target >= MaxReorgDepth && target-MaxReorgDepth+1 > hashStart {
+		hashStart = target - MaxReorgDepth + 1
+	}
+	for blockNumber := hashStart; blockNumber <= to; blockNumber++ {
+		header, err := idx.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+		if err != nil {
+			return fmt.Errorf("Error getting header for block %d: %w", blockNumber, err)
+		}
+		if err := batch.Put(blockHashKey(blockNumber), header.Hash().Bytes()); err != nil {
+			return err
+		}
+	}
+
+	// Prune block hashes that are too old to be reorged
+	if to >= MaxReorgDepth {
+		if err := idx.deleteRange(batch, blockHashPrefix, nil, blockHashKey(to-MaxReorgDepth+1)); err != nil {
+			return err
+		}
+	}
+
+	// Update the indexed block and save
+	if err := batch.Put(headKey, uint64ToBytes(to)); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("Error saving indexed events for blocks %d to %d: %w", from, to, err)
+	}
+	return nil
+
+}
+
+// Check the indexed blocks against the chain, rolling back any that were reorged out
+// Returns the latest indexed block that's still canonical
+func (idx *Indexer) handleReorgs() (uint64, error) {
+
+	head, err := idx.GetIndexedBlock()
+	if err != nil || head == 0 {
+		return head, err
+	}
+
+	// Walk back from the head until a block hash matches the chain
+	ancestor := head
+	for {
+		hash, err := idx.db.Get(blockHashKey(ancestor))
+		if err != nil {
+			// Ran out of stored hashes, so assume everything older is final
+			break
+		}
+		header, err := idx.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(ancestor))
+		if err != nil {
+			return 0, fmt.Errorf("Error getting header for block %d: %w", ancestor, err)
+		}
+		if common.BytesToHash(hash) == header.Hash() {
+			break
+		}
+		ancestor--
+	}
+	if ancestor == head {
+		return head, nil
+	}
+
+	// Delete everything indexed after the common ancestor
+	batch := idx.db.NewBatch()
+	iterator := idx.db.NewIterator(blockLogPrefix, uint64ToBytes(ancestor+1))
+	for iterator.Next() {
+		if err := batch.Delete(iterator.Value()); err != nil {
+			iterator.Release()
+			return 0, err
+		}
+		if err := batch.Delete(common.CopyBytes(iterator.Key())); err != nil {
+			iterator.Release()
+			return 0, err
+		}
+	}
+	iterator.Release()
+	if err := iterator.Error(); err != nil {
+		return 0, fmt.Errorf("Error reading indexed events: %w", err)
+	}
+	if err := idx.deleteRange(batch, blockHashPrefix, uint64ToBytes(ancestor+1), nil); err != nil {
+		return 0, err
+	}
+	if err := batch.Put(headKey, uint64ToBytes(ancestor)); err != nil {
+		return 0, err
+	}
+	if err := batch.Write(); err != nil {
+		return 0, fmt.Errorf("Error rolling back reorged blocks: %w", err)
+	}
+	return ancestor, nil
+
+}
+
+// Delete the keys with a prefix in the range [start, end)
+func (idx *Indexer) deleteRange(batch ethdb.Batch, prefix []byte, start []byte, end []byte) error {
+	iterator := idx.db.NewIterator(prefix, start)
+	defer iterator.Release()
+	for iterator.Next() {
+		if end != nil && string(iterator.Key()) >= string(end) {
+			break
+		}
+		if err := batch.Delete(common.CopyBytes(iterator.Key())); err != nil {
+			return err
+		}
+	}
+	return iterator.Error()
+}
+
+// Store an event, recording it against its block so it can be rolled back in a reorg
+func putEvent(batch ethdb.Batch, key []byte, blockNumber uint64, logIndex uint, value []byte) error {
+	if err := batch.Put(key, value); err != nil {
+		return err
+	}
+	return batch.Put(concat(blockLogPrefix, uint64ToBytes(blockNumber), uint32ToBytes(uint32(logIndex)), key), key)
+}
+
+// Key helpers
+func blockHashKey(blockNumber uint64) []byte {
+	return concat(blockHashPrefix, uint64ToBytes(blockNumber))
+}
+func eventKey(prefix []byte, id []byte, blockNumber uint64, logIndex uint) []byte {
+	return concat(prefix, id, uint64ToBytes(blockNumber), uint32ToBytes(uint32(logIndex)))
+}
+func uint64ToBytes(value uint64) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, value)
+	return bytes
+}
+func uint32ToBytes(value uint32) []byte {
+	bytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(bytes, value)
+	return bytes
+}
+func concat(parts ...[]byte) []byte {
+	key := []byte{}
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
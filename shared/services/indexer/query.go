@@ -0,0 +1,157 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+)
+
+// Get the deposit contract's deposit events for the provided pubkeys, starting at the given block
+// Deposits are returned in chain order
+func (idx *Indexer) GetDeposits(pubkeys map[rptypes.ValidatorPubkey]bool, startBlock uint64) (map[rptypes.ValidatorPubkey][]utils.DepositData, error) {
+	depositMap := make(map[rptypes.ValidatorPubkey][]utils.DepositData, len(pubkeys))
+	for pubkey := range pubkeys {
+		err := idx.iterateEvents(depositPrefix, pubkey.Bytes(), startBlock, func(value []byte) error {
+			var deposit utils.DepositData
+			if err := json.Unmarshal(value, &deposit); err != nil {
+				return fmt.Errorf("Error decoding indexed deposit for %s: %w", pubkey.Hex(), err)
+			}
+			depositMap[pubkey] = append(depositMap[pubkey], deposit)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return depositMap, nil
+}
+
+// Get the total amount of RPL claimed by an address through a claiming contract
+func (idx *Indexer) GetRplClaimed(claimingContract common.Address, claimer common.Address, startBlock uint64) (*big.Int, error) {
+	sum := big.NewInt(0)
+	err := idx.iterateEvents(claimPrefix, concat(claimingContract.Bytes(), claimer.Bytes()), startBlock, func(value []byte) error {
+		sum.Add(sum, big.NewInt(0).SetBytes(value))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+// Get the total amount of RPL rewards claimed by a node
+func (idx *Indexer) GetNodeRplClaimed(nodeAddress common.Address) (*big.Int, error) {
+	rocketClaimNode, err := idx.rp.GetAddress("rocketClaimNode")
+	if err != nil {
+		return nil, err
+	}
+	return idx.GetRplClaimed(*rocketClaimNode, nodeAddress, 0)
+}
+
+// Get the total amount of RPL rewards claimed by an oracle DAO member
+func (idx *Indexer) GetTrustedNodeRplClaimed(nodeAddress common.Address) (*big.Int, error) {
+	rocketClaimTrustedNode, err := idx.rp.GetAddress("rocketClaimTrustedNode")
+	if err != nil {
+		return nil, err
+	}
+	return idx.GetRplClaimed(*rocketClaimTrustedNode, nodeAddress, 0)
+}
+
+// Get the data from a minipool's MinipoolPrestaked event
+// Minipools the index doesn't have a prestake event for are looked up on the chain instead.
+func (idx *Indexer) GetPrestakeEvent(minipoolAddress common.Address) (minipool.PrestakeData, error) {
+	var prestake minipool.PrestakeData
+	found := false
+	err := idx.iterateEvents(prestakePrefix, minipoolAddress.Bytes(), 0, func(value []byte) error {
+		if found {
+			return nil
+		}
+		if err := json.Unmarshal(value, &prestake); err != nil {
+			return fmt.Errorf("Error decoding indexed prestake event for minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+		found = true
+		return nil
+	})
+	if err != nil {
+		return minipool.PrestakeData{}, err
+	}
+	if found {
+		return prestake, nil
+	}
+	mp, err := minipool.NewMinipool(idx.rp, minipoolAddress)
+	if err != nil {
+		return minipool.PrestakeData{}, err
+	}
+	return mp.GetPrestakeEvent(idx.eventLogInterval, nil)
+}
+
+// Get a minipool's status changes in chain order
+func (idx *Indexer) GetMinipoolStatusUpdates(minipoolAddress common.Address) ([]StatusUpdate, error) {
+	updates := []StatusUpdate{}
+	err := idx.iterateEvents(statusPrefix, minipoolAddress.Bytes(), 0, func(value []byte) error {
+		var update StatusUpdate
+		if err := json.Unmarshal(value, &update); err != nil {
+			return fmt.Errorf("Error decoding indexed status update for minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+		updates = append(updates, update)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// Get the node that created a minipool, if it has been indexed
+func (idx *Indexer) GetMinipoolNode(minipoolAddress common.Address) (common.Address, bool, error) {
+	has, err := idx.db.Has(minipoolKey(minipoolAddress))
+	if err != nil || !has {
+		return common.Address{}, false, err
+	}
+	value, err := idx.db.Get(minipoolKey(minipoolAddress))
+	if err != nil {
+		return common.Address{}, false, fmt.Errorf("Error reading indexed minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return common.BytesToAddress(value), true, nil
+}
+
+// Get the DAO proposals executed since the given block, in chain order
+func (idx *Indexer) GetProposalExecutions(startBlock uint64) ([]ProposalExecution, error) {
+	executions := []ProposalExecution{}
+	err := idx.iterateEvents(proposalPrefix, nil, startBlock, func(value []byte) error {
+		var execution ProposalExecution
+		if err := json.Unmarshal(value, &execution); err != nil {
+			return fmt.Errorf("Error decoding indexed proposal execution: %w", err)
+		}
+		executions = append(executions, execution)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// Iterate over the stored values of an event for an ID, in chain order, starting at the given block
+func (idx *Indexer) iterateEvents(prefix []byte, id []byte, startBlock uint64, callback func([]byte) error) error {
+	keyPrefix := concat(prefix, id)
+	iterator := idx.db.NewIterator(keyPrefix, uint64ToBytes(startBlock))
+	defer iterator.Release()
+	for iterator.Next() {
+		if len(iterator.Key()) != len(keyPrefix)+12 {
+			continue
+		}
+		if err := callback(common.CopyBytes(iterator.Value())); err != nil {
+			return err
+		}
+	}
+	if err := iterator.Error(); err != nil {
+		return fmt.Errorf("Error reading indexed events: %w", err)
+	}
+	return nil
+}
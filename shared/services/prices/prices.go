@@ -0,0 +1,181 @@
+package prices
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+)
+
+// Price check settings
+// These are the same for every oDAO member, so members that see the same chain state always reach the same decision.
+const (
+	// The largest difference between a source and the median, as a percentage of the median
+	RplPriceTolerance float64 = 5
+
+	// The window of the Uniswap RPL price TWAP, in seconds
+	RplPriceTwapWindow uint32 = 1800
+)
+
+// The price reported by a single source
+type SourcePrice struct {
+	Source string
+	Price  *big.Int
+	Error  error
+}
+
+// The result of checking the RPL price across every available source
+type PriceCheck struct {
+	Block     uint64
+	Prices    []SourcePrice
+	Median    *big.Int
+	Tolerance float64
+
+	// The largest difference between a source and the median, as a percentage of the median
+	MaxDeviation float64
+}
+
+// Gets the RPL price from several independent on-chain sources, so a single manipulated oracle can't move it
+type PriceChecker struct {
+	sources []PriceSource
+}
+
+// Create a new price checker from the sources available on the configured network
+// The 1inch oracle is always used; the Uniswap TWAP and Chainlink feeds are used when they have an address on the network
+func NewPriceChecker(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, oio *contracts.OneInchOracle) (*PriceChecker, error) {
+
+	sources := []PriceSource{
+		&oneInchSource{
+			oracle:     oio,
+			rplAddress: common.HexToAddress(cfg.Smartnode.GetRplTokenAddress()),
+		},
+	}
+
+	// Uniswap V3 TWAP
+	if poolAddress := cfg.Smartnode.GetUniswapRplPoolAddress(); poolAddress != "" {
+		rplAddress, err := rp.GetAddress("rocketTokenRPL")
+		if err != nil {
+			return nil, err
+		}
+		pool, err := contracts.NewUniswapV3Pool(common.HexToAddress(poolAddress), rp.Client)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Uniswap pool binding: %w", err)
+		}
+		sources = append(sources, &uniswapV3TwapSource{
+			pool:        pool,
+			poolAddress: common.HexToAddress(poolAddress),
+			rplAddress:  *rplAddress,
+			window:      RplPriceTwapWindow,
+		})
+	}
+
+	// Chainlink
+	rplUsdAddress := cfg.Smartnode.GetChainlinkRplUsdAddress()
+	ethUsdAddress := cfg.Smartnode.GetChainlinkEthUsdAddress()
+	if rplUsdAddress != "" && ethUsdAddress != "" {
+		rplUsd, err := contracts.NewChainlinkAggregator(common.HexToAddress(rplUsdAddress), rp.Client)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Chainlink feed binding: %w", err)
+		}
+		ethUsd, err := contracts.NewChainlinkAggregator(common.HexToAddress(ethUsdAddress), rp.Client)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Chainlink feed binding: %w", err)
+		}
+		sources = append(sources, &chainlinkSource{
+			client: rp.Client,
+			rplUsd: rplUsd,
+			ethUsd: ethUsd,
+		})
+	}
+
+	return &PriceChecker{
+		sources: sources,
+	}, nil
+
+}
+
+// Get the RPL price from every source at a block, and their median
+// Every source must respond, since members whose medians were taken over different sources would submit different prices.
+// An error is returned if the sources disagree by more than the tolerance; the check is returned alongside it for logging.
+func (p *PriceChecker) GetRplPrice(blockNumber uint64) (*PriceCheck, error) {
+
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(blockNumber),
+	}
+	check := &PriceCheck{
+		Block:     blockNumber,
+		Prices:    make([]SourcePrice, len(p.sources)),
+		Tolerance: RplPriceTolerance,
+	}
+
+	// Query each source
+	prices := []*big.Int{}
+	failures := []string{}
+	for i, source := range p.sources {
+		price, err := source.GetRplPrice(opts)
+		if err == nil && price.Sign() <= 0 {
+			err = fmt.Errorf("price was %s", price.String())
+		}
+		check.Prices[i] = SourcePrice{
+			Source: source.GetName(),
+			Price:  price,
+			Error:  err,
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s)", source.GetName(), err.Error()))
+			continue
+		}
+		prices = append(prices, price)
+	}
+	if len(failures) > 0 {
+		return check, fmt.Errorf("Only %d of %d RPL price sources responded at block %d; failed sources: %s", len(prices), len(p.sources), blockNumber, strings.Join(failures, ", "))
+	}
+
+	// Get the median
+	check.Median = getMedian(prices)
+
+	// Check that every source is within the tolerance of the median
+	for _, price := range prices {
+		deviation := getDeviation(price, check.Median)
+		if deviation > check.MaxDeviation {
+			check.MaxDeviation = deviation
+		}
+	}
+	if check.MaxDeviation > RplPriceTolerance {
+		return check, fmt.Errorf("RPL price sources diverge by %.2f%% at block %d, which is more than the tolerance of %.2f%%", check.MaxDeviation, blockNumber, RplPriceTolerance)
+	}
+
+	return check, nil
+
+}
+
+// Get the median of a set of prices
+func getMedian(prices []*big.Int) *big.Int {
+	sorted := make([]*big.Int, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return big.NewInt(0).Set(sorted[middle])
+	}
+	median := big.NewInt(0).Add(sorted[middle-1], sorted[middle])
+	return median.Quo(median, big.NewInt(2))
+}
+
+// Get the difference between a price and the median, as a percentage of the median
+func getDeviation(price *big.Int, median *big.Int) float64 {
+	difference := new(big.Float).SetInt(big.NewInt(0).Sub(price, median))
+	difference.Abs(difference)
+	difference.Quo(difference, new(big.Float).SetInt(median))
+	deviation, _ := difference.Float64()
+	return deviation * 100
+}
@@ -0,0 +1,149 @@
+package prices
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// A price source with a fixed result
+type fakeSource struct {
+	name  string
+	price *big.Int
+	err   error
+}
+
+func (s *fakeSource) GetName() string {
+	return s.name
+}
+
+func (s *fakeSource) GetRplPrice(opts *bind.CallOpts) (*big.Int, error) {
+	return s.price, s.err
+}
+
+func TestGetMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []int64
+		median int64
+	}{
+		{"single", []int64{7}, 7},
+		{"odd", []int64{30, 10, 20}, 20},
+		{"even", []int64{40, 10, 30, 20}, 25},
+		{"even rounds down", []int64{10, 11}, 10},
+		{"duplicates", []int64{5, 5, 9}, 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prices := []*big.Int{}
+			for _, price := range test.prices {
+				prices = append(prices, big.NewInt(price))
+			}
+			median := getMedian(prices)
+			if median.Cmp(big.NewInt(test.median)) != 0 {
+				t.Fatalf("median was %s, expected %d", median, test.median)
+			}
+			if prices[0].Cmp(big.NewInt(test.prices[0])) != 0 {
+				t.Fatal("prices were reordered")
+			}
+		})
+	}
+}
+
+func TestGetRplPrice(t *testing.T) {
+	tests := []struct {
+		name       string
+		sources    []PriceSource
+		median     int64
+		shouldFail bool
+	}{
+		{
+			name: "all sources agree",
+			sources: []PriceSource{
+				&fakeSource{name: "a", price: big.NewInt(1000)},
+				&fakeSource{name: "b", price: big.NewInt(1010)},
+				&fakeSource{name: "c", price: big.NewInt(990)},
+			},
+			median: 1000,
+		},
+		{
+			name: "one source failed",
+			sources: []PriceSource{
+				&fakeSource{name: "a", price: big.NewInt(1000)},
+				&fakeSource{name: "b", price: big.NewInt(1010)},
+				&fakeSource{name: "c", err: errors.New("unavailable")},
+			},
+			shouldFail: true,
+		},
+		{
+			name: "zero price",
+			sources: []PriceSource{
+				&fakeSource{name: "a", price: big.NewInt(1000)},
+				&fakeSource{name: "b", price: big.NewInt(0)},
+			},
+			shouldFail: true,
+		},
+		{
+			name: "sources diverge",
+			sources: []PriceSource{
+				&fakeSource{name: "a", price: big.NewInt(1000)},
+				&fakeSource{name: "b", price: big.NewInt(1000)},
+				&fakeSource{name: "c", price: big.NewInt(1100)},
+			},
+			median:     1000,
+			shouldFail: true,
+		},
+		{
+			name: "divergence at the tolerance",
+			sources: []PriceSource{
+				&fakeSource{name: "a", price: big.NewInt(1000)},
+				&fakeSource{name: "b", price: big.NewInt(1000)},
+				&fakeSource{name: "c", price: big.NewInt(1050)},
+			},
+			median: 1000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checker := &PriceChecker{sources: test.sources}
+			check, err := checker.GetRplPrice(100)
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if check == nil || len(check.Prices) != len(test.sources) {
+				t.Fatal("every source should be recorded in the check")
+			}
+			if test.median != 0 && (check.Median == nil || check.Median.Cmp(big.NewInt(test.median)) != 0) {
+				t.Fatalf("median was %v, expected %d", check.Median, test.median)
+			}
+		})
+	}
+}
+
+func TestCheckChainlinkRound(t *testing.T) {
+	blockTime := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name       string
+		answer     int64
+		updatedAt  int64
+		shouldFail bool
+	}{
+		{"fresh", 100, blockTime.Unix() - 60, false},
+		{"at the heartbeat", 100, blockTime.Unix() - 3600, false},
+		{"stale", 100, blockTime.Unix() - 3601, true},
+		{"incomplete round", 100, 0, true},
+		{"negative answer", -1, blockTime.Unix(), true},
+		{"zero answer", 0, blockTime.Unix(), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkChainlinkRound(big.NewInt(test.answer), big.NewInt(test.updatedAt), blockTime, time.Hour)
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+)
+
+// The precision of the TWAP calculation, so rounding error stays far below a wei and every node gets the same result
+const twapPrecision = 256
+
+// The heartbeats of the Chainlink feeds; each feed posts a new answer at least this often, so older answers are stale
+const (
+	chainlinkRplUsdHeartbeat time.Duration = 24 * time.Hour
+	chainlinkEthUsdHeartbeat time.Duration = 1 * time.Hour
+)
+
+// A source of the RPL price, in ETH
+type PriceSource interface {
+	// The name of the source, used for logging and records
+	GetName() string
+
+	// The RPL price in wei per RPL at the block in opts
+	GetRplPrice(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// The 1inch offchain oracle's weighted rate across DEXes
+type oneInchSource struct {
+	oracle     *contracts.OneInchOracle
+	rplAddress common.Address
+}
+
+func (s *oneInchSource) GetName() string {
+	return "1inch"
+}
+
+func (s *oneInchSource) GetRplPrice(opts *bind.CallOpts) (*big.Int, error) {
+	return s.oracle.GetRateToEth(opts, s.rplAddress, true)
+}
+
+// A time-weighted average price from a Uniswap V3 RPL / WETH pool
+type uniswapV3TwapSource struct {
+	pool        *contracts.UniswapV3Pool
+	poolAddress common.Address
+	rplAddress  common.Address
+	window      uint32
+}
+
+func (s *uniswapV3TwapSource) GetName() string {
+	return "uniswap-twap"
+}
+
+func (s *uniswapV3TwapSource) GetRplPrice(opts *bind.CallOpts) (*big.Int, error) {
+
+	// Work out which side of the pool RPL is on
+	token0, err := s.pool.Token0(opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Uniswap pool token: %w", err)
+	}
+	token1, err := s.pool.Token1(opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Uniswap pool token: %w", err)
+	}
+	if token0 != s.rplAddress && token1 != s.rplAddress {
+		return nil, fmt.Errorf("Uniswap pool %s does not contain RPL", s.poolAddress.Hex())
+	}
+
+	// Get the mean tick over the window
+	observations, err := s.pool.Observe(opts, []uint32{s.window, 0})
+	if err != nil {
+		return nil, fmt.Errorf("Error observing Uniswap pool: %w", err)
+	}
+	if len(observations.TickCumulatives) != 2 {
+		return nil, fmt.Errorf("Uniswap pool returned %d observations, expected 2", len(observations.TickCumulatives))
+	}
+	tickDelta := big.NewInt(0).Sub(observations.TickCumulatives[1], observations.TickCumulatives[0])
+	meanTick := big.NewInt(0).Quo(tickDelta, big.NewInt(int64(s.window)))
+
+	// Round towards negative infinity, the same way the Uniswap oracle library does
+	if tickDelta.Sign() < 0 && big.NewInt(0).Rem(tickDelta, big.NewInt(int64(s.window))).Sign() != 0 {
+		meanTick.Sub(meanTick, big.NewInt(1))
+	}
+
+	// The tick is the log of the token1 / token0 price; both tokens have 18 decimals
+	price := tickToPrice(meanTick.Int64())
+	if token1 == s.rplAddress {
+		price.Quo(big.NewFloat(1).SetPrec(twapPrecision), price)
+	}
+	price.Mul(price, big.NewFloat(1e18))
+	wei, _ := price.Int(nil)
+	return wei, nil
+
+}
+
+// Get 1.0001^tick, using exponentiation by squaring so the result is deterministic
+func tickToPrice(tick int64) *big.Float {
+	negative := tick < 0
+	if negative {
+		tick = -tick
+	}
+	result := big.NewFloat(1).SetPrec(twapPrecision)
+	base, _ := new(big.Float).SetPrec(twapPrecision).SetString("1.0001")
+	for tick > 0 {
+		if tick&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+		tick >>= 1
+	}
+	if negative {
+		result.Quo(big.NewFloat(1).SetPrec(twapPrecision), result)
+	}
+	return result
+}
+
+// The RPL price derived from Chainlink's RPL / USD and ETH / USD feeds
+type chainlinkSource struct {
+	client rocketpool.ExecutionClient
+	rplUsd *contracts.ChainlinkAggregator
+	ethUsd *contracts.ChainlinkAggregator
+}
+
+func (s *chainlinkSource) GetName() string {
+	return "chainlink"
+}
+
+func (s *chainlinkSource) GetRplPrice(opts *bind.CallOpts) (*big.Int, error) {
+
+	// Answers are checked against the time of the block rather than the local clock, so every node makes the same decision
+	header, err := s.client.HeaderByNumber(context.Background(), opts.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting block header: %w", err)
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+
+	rplUsd, err := getChainlinkAnswer(s.rplUsd, opts, blockTime, chainlinkRplUsdHeartbeat)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Chainlink RPL / USD price: %w", err)
+	}
+	ethUsd, err := getChainlinkAnswer(s.ethUsd, opts, blockTime, chainlinkEthUsdHeartbeat)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Chainlink ETH / USD price: %w", err)
+	}
+
+	// Both answers are scaled to 18 decimals, so the ratio needs one more factor of 1e18 to be in wei
+	price := big.NewInt(0).Mul(rplUsd, big.NewInt(1e18))
+	return price.Quo(price, ethUsd), nil
+
+}
+
+// Get the latest answer of a Chainlink feed, scaled to 18 decimals
+// Answers that are older than the feed's heartbeat at the block time are rejected.
+func getChainlinkAnswer(feed *contracts.ChainlinkAggregator, opts *bind.CallOpts, blockTime time.Time, heartbeat time.Duration) (*big.Int, error) {
+	decimals, err := feed.Decimals(opts)
+	if err != nil {
+		return nil, err
+	}
+	round, err := feed.LatestRoundData(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkChainlinkRound(round.Answer, round.UpdatedAt, blockTime, heartbeat); err != nil {
+		return nil, err
+	}
+	if decimals > 18 {
+		return nil, fmt.Errorf("feed has %d decimals, expected at most 18", decimals)
+	}
+	scale := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(18-decimals)), nil)
+	return big.NewInt(0).Mul(round.Answer, scale), nil
+}
+
+// Check that a Chainlink answer is valid and was updated within the feed's heartbeat
+func checkChainlinkRound(answer *big.Int, updatedAt *big.Int, blockTime time.Time, heartbeat time.Duration) error {
+	if answer.Sign() <= 0 {
+		return fmt.Errorf("feed returned an invalid answer of %s", answer.String())
+	}
+	if updatedAt.Sign() <= 0 {
+		return fmt.Errorf("feed's latest round has not been completed")
+	}
+	updated := time.Unix(updatedAt.Int64(), 0)
+	if age := blockTime.Sub(updated); age > heartbeat {
+		return fmt.Errorf("feed was last updated at %s, %s before the block, which is longer than its heartbeat of %s", updated.UTC().Format(time.RFC3339), age, heartbeat)
+	}
+	return nil
+}
@@ -82,6 +82,22 @@ func (c *Client) AuctionLots() (api.AuctionLotsResponse, error) {
 	return response, nil
 }
 
+// Simulate the bids and claims the node would make automatically with a bidding strategy
+func (c *Client) AuctionBidPlan(minDiscount float64, lotBudget float64, totalBudget float64) (api.AuctionBidPlanResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("auction bid-plan %f %f %f", minDiscount, lotBudget, totalBudget))
+	if err != nil {
+		return api.AuctionBidPlanResponse{}, fmt.Errorf("Could not get auction bid plan: %w", err)
+	}
+	var response api.AuctionBidPlanResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AuctionBidPlanResponse{}, fmt.Errorf("Could not decode auction bid plan response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AuctionBidPlanResponse{}, fmt.Errorf("Could not get auction bid plan: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can create a new lot
 func (c *Client) CanCreateLot() (api.CanCreateLotResponse, error) {
 	responseBytes, err := c.callAPI("auction can-create-lot")
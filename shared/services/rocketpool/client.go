@@ -2,6 +2,7 @@ package rocketpool
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +28,8 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -71,6 +74,7 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbackEc    bool
+	dryRun             bool
 }
 
 // Create new Rocket Pool client from CLI context
@@ -81,11 +85,12 @@ func NewClientFromCtx(c *cli.Context) (*Client, error) {
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
-		c.GlobalBool("debug"))
+		c.GlobalBool("debug"),
+		c.GlobalBool("dry-run"))
 }
 
 // Create new Rocket Pool client
-func NewClient(configPath string, daemonPath string, maxFee float64, maxPrioFee float64, gasLimit uint64, customNonce string, debug bool) (*Client, error) {
+func NewClient(configPath string, daemonPath string, maxFee float64, maxPrioFee float64, gasLimit uint64, customNonce string, debug bool, dryRun bool) (*Client, error) {
 
 	// Initialize SSH client if configured for SSH
 	var sshClient *ssh.Client
@@ -113,6 +118,7 @@ func NewClient(configPath string, daemonPath string, maxFee float64, maxPrioFee
 		debugPrint:         debug,
 		forceFallbackEc:    false,
 		ignoreSyncCheck:    false,
+		dryRun:             dryRun,
 	}
 
 	return client, nil
@@ -126,6 +132,11 @@ func (c *Client) Close() {
 	}
 }
 
+// Check if transactions are being simulated instead of sent
+func (c *Client) IsDryRun() bool {
+	return c.dryRun
+}
+
 // Load the config
 func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
@@ -609,6 +620,24 @@ func (c *Client) StartService(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
+// Pull the images for some of the Rocket Pool service's containers
+func (c *Client) PullServiceImages(composeFiles []string, serviceNames ...string) error {
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("pull %s", strings.Join(quoteServiceNames(serviceNames), " ")))
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
+// Recreate some of the Rocket Pool service's containers with their current settings, without touching the containers they depend on
+func (c *Client) UpdateServiceContainers(composeFiles []string, serviceNames ...string) error {
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("up -d --no-deps %s", strings.Join(quoteServiceNames(serviceNames), " ")))
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "stop")
@@ -638,11 +667,7 @@ func (c *Client) PrintServiceStatus(composeFiles []string) error {
 
 // Print the Rocket Pool service logs
 func (c *Client) PrintServiceLogs(composeFiles []string, tail string, serviceNames ...string) error {
-	sanitizedStrings := make([]string, len(serviceNames))
-	for i, serviceName := range serviceNames {
-		sanitizedStrings[i] = fmt.Sprintf("%s", shellescape.Quote(serviceName))
-	}
-	cmd, err := c.compose(composeFiles, fmt.Sprintf("logs -f --tail %s %s", shellescape.Quote(tail), strings.Join(sanitizedStrings, " ")))
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("logs -f --tail %s %s", shellescape.Quote(tail), strings.Join(quoteServiceNames(serviceNames), " ")))
 	if err != nil {
 		return err
 	}
@@ -721,6 +746,12 @@ func (c *Client) IncrementCustomNonce() {
 	c.customNonce.Add(c.customNonce, big.NewInt(1))
 }
 
+// Sets the custom nonce parameter.
+// This is used for batches of transactions that need to be submitted with sequential nonces.
+func (c *Client) SetCustomNonce(nonce *big.Int) {
+	c.customNonce = nonce
+}
+
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerImage(container string) (string, error) {
 
@@ -865,6 +896,12 @@ func (c *Client) RunPruneProvisioner(container string, volume string, image stri
 
 }
 
+// Runs Besu's offline trie log pruning on its data volume, printing Besu's output
+func (c *Client) RunBesuTrieLogPruner(container string, volume string, image string) error {
+	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient %s --data-path=/ethclient/besu storage trie-log prune", container, volume, image)
+	return c.printOutput(cmd)
+}
+
 // Runs the EC migrator
 func (c *Client) RunEcMigrator(container string, volume string, targetDir string, mode string, image string) error {
 	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient -v %s:/mnt/external -e EC_MIGRATE_MODE='%s' %s", container, volume, targetDir, mode, image)
@@ -1415,6 +1452,10 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 	if c.forceFallbackEc {
 		forceFallbackECFlag = "--force-fallback-ec"
 	}
+	dryRunFlag := ""
+	if c.dryRun {
+		dryRunFlag = "--dry-run"
+	}
 
 	// Run the command
 	var cmd string
@@ -1423,13 +1464,14 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, dryRunFlag, c.getGasOpts(), c.getCustomNonce(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
+			dryRunFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
 			args)
@@ -1458,6 +1500,14 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 	c.maxPrioFee = c.originalMaxPrioFee
 	c.gasLimit = c.originalGasLimit
 
+	// Return the simulation as an error if the command's transaction was simulated instead of sent
+	if c.dryRun && err == nil {
+		var dryRunResponse api.DryRunResponse
+		if json.Unmarshal(output, &dryRunResponse) == nil && dryRunResponse.DryRun != nil {
+			return nil, &simulation.DryRunError{Simulation: *dryRunResponse.DryRun}
+		}
+	}
+
 	return output, err
 }
 
@@ -1589,3 +1639,12 @@ func (c *Client) readOutput(cmdText string) ([]byte, error) {
 	return cmd.Output()
 
 }
+
+// Escape the names of the service's containers so they can be passed to docker-compose
+func quoteServiceNames(serviceNames []string) []string {
+	sanitizedStrings := make([]string, len(serviceNames))
+	for i, serviceName := range serviceNames {
+		sanitizedStrings[i] = shellescape.Quote(serviceName)
+	}
+	return sanitizedStrings
+}
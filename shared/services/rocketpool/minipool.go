@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -56,6 +57,22 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 	return response, nil
 }
 
+// Get a minipool's past events and upcoming lifecycle deadlines
+func (c *Client) MinipoolTimeline(address common.Address) (api.MinipoolTimelineResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool timeline %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolTimelineResponse{}, fmt.Errorf("Could not get minipool timeline: %w", err)
+	}
+	var response api.MinipoolTimelineResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolTimelineResponse{}, fmt.Errorf("Could not decode minipool timeline response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolTimelineResponse{}, fmt.Errorf("Could not get minipool timeline: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
@@ -184,6 +201,54 @@ func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse,
 	return response, nil
 }
 
+// Sign a voluntary exit for a minipool without broadcasting it
+func (c *Client) SignExitMinipool(address common.Address, epoch uint64) (api.SignExitMinipoolResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool sign-exit %s %d", address.Hex(), epoch))
+	if err != nil {
+		return api.SignExitMinipoolResponse{}, fmt.Errorf("Could not sign minipool exit: %w", err)
+	}
+	var response api.SignExitMinipoolResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignExitMinipoolResponse{}, fmt.Errorf("Could not decode sign minipool exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignExitMinipoolResponse{}, fmt.Errorf("Could not sign minipool exit: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a signed voluntary exit can be broadcast
+func (c *Client) CanSubmitExit(epoch uint64) (api.CanSubmitExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-submit-exit %d", epoch))
+	if err != nil {
+		return api.CanSubmitExitResponse{}, fmt.Errorf("Could not get can submit exit status: %w", err)
+	}
+	var response api.CanSubmitExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSubmitExitResponse{}, fmt.Errorf("Could not decode can submit exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSubmitExitResponse{}, fmt.Errorf("Could not get can submit exit status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a signed voluntary exit
+func (c *Client) SubmitExit(validatorIndex uint64, epoch uint64, signature types.ValidatorSignature) (api.SubmitExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool submit-exit %d %d %s", validatorIndex, epoch, signature.Hex()))
+	if err != nil {
+		return api.SubmitExitResponse{}, fmt.Errorf("Could not submit exit: %w", err)
+	}
+	var response api.SubmitExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SubmitExitResponse{}, fmt.Errorf("Could not decode submit exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SubmitExitResponse{}, fmt.Errorf("Could not submit exit: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool can be closed
 func (c *Client) CanCloseMinipool(address common.Address) (api.CanCloseMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-close %s", address.Hex()))
@@ -24,6 +24,22 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
 	return response, nil
 }
 
+// Get the protocol and oracle DAO settings, and optionally the changes made to them by proposals
+func (c *Client) DAOSettings(category string, includeHistory bool) (api.NetworkDAOSettingsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network dao-settings %s %t", category, includeHistory))
+	if err != nil {
+		return api.NetworkDAOSettingsResponse{}, fmt.Errorf("Could not get DAO settings: %w", err)
+	}
+	var response api.NetworkDAOSettingsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkDAOSettingsResponse{}, fmt.Errorf("Could not decode DAO settings response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkDAOSettingsResponse{}, fmt.Errorf("Could not get DAO settings: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get network RPL price
 func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	responseBytes, err := c.callAPI("network rpl-price")
@@ -156,6 +156,54 @@ func (c *Client) ConfirmNodeWithdrawalAddress() (api.SetNodeWithdrawalAddressRes
 	return response, nil
 }
 
+// Check whether an address is a contract or Safe, and whether it can receive ETH
+func (c *Client) CheckWithdrawalAddress(address common.Address) (api.CheckWithdrawalAddressResponse, error) {
+	responseBytes, err := c.callAPI("node check-withdrawal-address", address.Hex())
+	if err != nil {
+		return api.CheckWithdrawalAddressResponse{}, fmt.Errorf("Could not check withdrawal address: %w", err)
+	}
+	var response api.CheckWithdrawalAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckWithdrawalAddressResponse{}, fmt.Errorf("Could not decode check withdrawal address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckWithdrawalAddressResponse{}, fmt.Errorf("Could not check withdrawal address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Create a Safe transaction proposal for the node's current withdrawal address to set a new one
+func (c *Client) SafeSetNodeWithdrawalAddress(withdrawalAddress common.Address, confirm bool) (api.SafeProposalResponse, error) {
+	responseBytes, err := c.callAPI("node safe-set-withdrawal-address", withdrawalAddress.Hex(), strconv.FormatBool(confirm))
+	if err != nil {
+		return api.SafeProposalResponse{}, fmt.Errorf("Could not create Safe set withdrawal address proposal: %w", err)
+	}
+	var response api.SafeProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SafeProposalResponse{}, fmt.Errorf("Could not decode Safe set withdrawal address proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SafeProposalResponse{}, fmt.Errorf("Could not create Safe set withdrawal address proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Create a Safe transaction proposal for the node's pending withdrawal address to confirm itself
+func (c *Client) SafeConfirmNodeWithdrawalAddress() (api.SafeProposalResponse, error) {
+	responseBytes, err := c.callAPI("node safe-confirm-withdrawal-address")
+	if err != nil {
+		return api.SafeProposalResponse{}, fmt.Errorf("Could not create Safe confirm withdrawal address proposal: %w", err)
+	}
+	var response api.SafeProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SafeProposalResponse{}, fmt.Errorf("Could not decode Safe confirm withdrawal address proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SafeProposalResponse{}, fmt.Errorf("Could not create Safe confirm withdrawal address proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Checks if the node's timezone location can be set
 func (c *Client) CanSetNodeTimezone(timezoneLocation string) (api.CanSetNodeTimezoneResponse, error) {
 	responseBytes, err := c.callAPI("node can-set-timezone", timezoneLocation)
@@ -300,6 +348,22 @@ func (c *Client) CanNodeStakeRpl(amountWei *big.Int) (api.CanNodeStakeRplRespons
 	return response, nil
 }
 
+// Get the node's RPL collateral plan for a target collateral ratio
+func (c *Client) NodeCollateralPlan(targetRatio float64) (api.NodeCollateralPlanResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node collateral-plan %f", targetRatio))
+	if err != nil {
+		return api.NodeCollateralPlanResponse{}, fmt.Errorf("Could not get node collateral plan: %w", err)
+	}
+	var response api.NodeCollateralPlanResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeCollateralPlanResponse{}, fmt.Errorf("Could not decode node collateral plan response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeCollateralPlanResponse{}, fmt.Errorf("Could not get node collateral plan: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the gas estimate for approving new RPL interaction
 func (c *Client) NodeStakeRplApprovalGas(amountWei *big.Int) (api.NodeStakeRplApproveGasResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node get-stake-rpl-approval-gas %s", amountWei.String()))
@@ -524,6 +588,22 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the next available nonce for the node account
+func (c *Client) NodeNonce() (api.NodeNonceResponse, error) {
+	responseBytes, err := c.callAPI("node get-nonce")
+	if err != nil {
+		return api.NodeNonceResponse{}, fmt.Errorf("Could not get node nonce: %w", err)
+	}
+	var response api.NodeNonceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeNonceResponse{}, fmt.Errorf("Could not decode node nonce response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeNonceResponse{}, fmt.Errorf("Could not get node nonce: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
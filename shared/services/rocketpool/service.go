@@ -39,3 +39,83 @@ func (c *Client) GetExecutionClientStatus() (api.ExecutionClientStatusResponse,
 	}
 	return response, nil
 }
+
+// Run the daemon's health checks
+func (c *Client) ServiceDoctor() (api.ServiceDoctorResponse, error) {
+	responseBytes, err := c.callAPI("service doctor")
+	if err != nil {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not run health checks: %w", err)
+	}
+	var response api.ServiceDoctorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not decode health check response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not run health checks: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the health of each client
+func (c *Client) GetClientHealth() (api.ClientHealthResponse, error) {
+	responseBytes, err := c.callAPI("service client-health")
+	if err != nil {
+		return api.ClientHealthResponse{}, fmt.Errorf("Could not get client health: %w", err)
+	}
+	var response api.ClientHealthResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ClientHealthResponse{}, fmt.Errorf("Could not decode client health response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ClientHealthResponse{}, fmt.Errorf("Could not get client health: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Creates an encrypted backup of the node and stores it in the configured target
+func (c *Client) CreateBackup() (api.CreateBackupResponse, error) {
+	responseBytes, err := c.callAPI("service backup")
+	if err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %w", err)
+	}
+	var response api.CreateBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not decode create backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Asks the primary Nethermind client to start a full prune of its state database
+func (c *Client) StartNethermindPruning() (api.NethermindPruningResponse, error) {
+	responseBytes, err := c.callAPI("service start-nethermind-pruning")
+	if err != nil {
+		return api.NethermindPruningResponse{}, fmt.Errorf("Could not start Nethermind pruning: %w", err)
+	}
+	var response api.NethermindPruningResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NethermindPruningResponse{}, fmt.Errorf("Could not decode start Nethermind pruning response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NethermindPruningResponse{}, fmt.Errorf("Could not start Nethermind pruning: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the status of the primary Nethermind client's full prune
+func (c *Client) GetNethermindPruningStatus() (api.NethermindPruningResponse, error) {
+	responseBytes, err := c.callAPI("service nethermind-pruning-status")
+	if err != nil {
+		return api.NethermindPruningResponse{}, fmt.Errorf("Could not get Nethermind pruning status: %w", err)
+	}
+	var response api.NethermindPruningResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NethermindPruningResponse{}, fmt.Errorf("Could not decode Nethermind pruning status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NethermindPruningResponse{}, fmt.Errorf("Could not get Nethermind pruning status: %s", response.Error)
+	}
+	return response, nil
+}
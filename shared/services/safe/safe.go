@@ -0,0 +1,131 @@
+package safe
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The origin recorded on proposals, so Safe owners can tell where they came from
+const ProposalOrigin = "Rocket Pool Smartnode"
+
+// Check whether an address is a contract, whether it's a Safe, and whether it can receive ETH
+// The ETH check calls the address with 1 wei from the sender, the same way Rocket Pool sends rewards and refunds.
+func CheckAddress(rp *rocketpool.RocketPool, sender common.Address, address common.Address) (*api.CheckWithdrawalAddressResponse, error) {
+
+	response := api.CheckWithdrawalAddressResponse{}
+
+	// Check for contract code
+	code, err := rp.Client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting code at %s: %w", address.Hex(), err)
+	}
+	if len(code) == 0 {
+		response.CanReceiveEth = true
+		return &response, nil
+	}
+	response.IsContract = true
+
+	// Check that the contract accepts ETH
+	_, err = rp.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From:  sender,
+		To:    &address,
+		Value: big.NewInt(1),
+	}, nil)
+	if err != nil {
+		response.ReceiveEthError = err.Error()
+	} else {
+		response.CanReceiveEth = true
+	}
+
+	// Check if the contract is a Safe
+	safe, err := contracts.NewGnosisSafe(address, rp.Client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Safe binding: %w", err)
+	}
+	threshold, err := safe.GetThreshold(nil)
+	if err != nil {
+		return &response, nil
+	}
+	owners, err := safe.GetOwners(nil)
+	if err != nil {
+		return &response, nil
+	}
+	version, err := safe.VERSION(nil)
+	if err != nil {
+		return &response, nil
+	}
+	response.IsSafe = true
+	response.SafeVersion = version
+	response.SafeThreshold = threshold.Uint64()
+	response.SafeOwners = owners
+	return &response, nil
+
+}
+
+// Create a proposal for a Safe to call a contract
+// The call is simulated from the Safe first, so proposals that would revert aren't handed to its owners.
+func CreateProposal(rp *rocketpool.RocketPool, safeAddress common.Address, to common.Address, data []byte) (*api.SafeProposalResponse, error) {
+
+	// Get the Safe's details
+	safe, err := contracts.NewGnosisSafe(safeAddress, rp.Client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Safe binding: %w", err)
+	}
+	threshold, err := safe.GetThreshold(nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s does not appear to be a Safe: %w", safeAddress.Hex(), err)
+	}
+	nonce, err := safe.Nonce(nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Safe nonce: %w", err)
+	}
+
+	// Simulate the call from the Safe
+	_, err = rp.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From: safeAddress,
+		To:   &to,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("The transaction would fail if the Safe executed it: %w", err)
+	}
+
+	// Build the proposal; gas is paid by the executor, so no refund parameters are set
+	proposal := api.SafeTransactionProposal{
+		To:             to,
+		Value:          big.NewInt(0),
+		Data:           hexutil.Encode(data),
+		Operation:      0,
+		SafeTxGas:      0,
+		BaseGas:        0,
+		GasPrice:       big.NewInt(0),
+		GasToken:       common.Address{},
+		RefundReceiver: common.Address{},
+		Nonce:          nonce.Uint64(),
+		Origin:         ProposalOrigin,
+	}
+
+	// Get the hash the owners need to sign from the Safe itself
+	hash, err := safe.GetTransactionHash(nil, proposal.To, proposal.Value, data, proposal.Operation, big.NewInt(0), big.NewInt(0), proposal.GasPrice, proposal.GasToken, proposal.RefundReceiver, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Safe transaction hash: %w", err)
+	}
+	proposal.ContractTransactionHash = hash
+
+	// Return
+	return &api.SafeProposalResponse{
+		Safe:          safeAddress,
+		SafeThreshold: threshold.Uint64(),
+		Proposal:      proposal,
+	}, nil
+
+}
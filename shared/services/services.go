@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -20,6 +21,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
@@ -41,6 +43,7 @@ var (
 	cfg              *config.RocketPoolConfig
 	passwordManager  *passwords.PasswordManager
 	nodeWallet       *wallet.Wallet
+	simulator        *simulation.Simulator
 	ethClientManager *ExecutionClientManager
 	rocketPool       *rocketpool.RocketPool
 	oneInchOracle    *contracts.OneInchOracle
@@ -84,6 +87,13 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+func GetTransactionSimulator(c *cli.Context) (*simulation.Simulator, error) {
+	if _, err := GetWallet(c); err != nil {
+		return nil, err
+	}
+	return simulator, nil
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -203,10 +213,69 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
 		nodeWallet.AddKeystore("prysm", prysmKeystore)
 		nodeWallet.AddKeystore("teku", tekuKeystore)
+
+		// Hold the node account on a hardware wallet if one is selected
+		backend := cfg.Smartnode.NodeAccountBackend.Value.(config.NodeAccountBackend)
+		if backend == config.NodeAccountBackend_Ledger || backend == config.NodeAccountBackend_Trezor {
+			var hubs []wallet.HardwareWalletHub
+			hubs, err = getHardwareWalletHubs(backend)
+			if err != nil {
+				return
+			}
+			var ec *ExecutionClientManager
+			ec, err = getEthClient(c, cfg)
+			if err != nil {
+				return
+			}
+			err = nodeWallet.SetHardwareWallet(string(backend), cfg.Smartnode.HardwareWalletPath.Value.(string), ec, hubs...)
+			if err != nil {
+				return
+			}
+		}
+
+		// Simulate transactions instead of signing them in dry-run mode
+		if c.GlobalBool("dry-run") {
+			var ec *ExecutionClientManager
+			ec, err = getEthClient(c, cfg)
+			if err != nil {
+				return
+			}
+			var rpClient *rocketpool.RocketPool
+			rpClient, err = getRocketPool(cfg, ec)
+			if err != nil {
+				return
+			}
+			simulator = simulation.NewSimulator(rpClient, ec)
+			nodeWallet.SetTransactionSimulator(simulator.Simulate)
+		}
 	})
 	return nodeWallet, err
 }
 
+func getHardwareWalletHubs(backend config.NodeAccountBackend) ([]wallet.HardwareWalletHub, error) {
+	switch backend {
+	case config.NodeAccountBackend_Ledger:
+		hub, err := usbwallet.NewLedgerHub()
+		if err != nil {
+			return nil, fmt.Errorf("Error connecting to Ledger devices: %w", err)
+		}
+		return []wallet.HardwareWalletHub{hub}, nil
+	case config.NodeAccountBackend_Trezor:
+		// Older Trezors use HID and newer ones use WebUSB
+		hidHub, err := usbwallet.NewTrezorHubWithHID()
+		if err != nil {
+			return nil, fmt.Errorf("Error connecting to Trezor devices: %w", err)
+		}
+		webUsbHub, err := usbwallet.NewTrezorHubWithWebUSB()
+		if err != nil {
+			return nil, fmt.Errorf("Error connecting to Trezor devices: %w", err)
+		}
+		return []wallet.HardwareWalletHub{hidHub, webUsbHub}, nil
+	default:
+		return nil, fmt.Errorf("Unknown hardware wallet type '%s'", backend)
+	}
+}
+
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 	var err error
 	initEthClientProxy.Do(func() {
@@ -0,0 +1,504 @@
+package simulation
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The network contracts whose ABIs are used to decode calls, reverts and events
+var contractNames = []string{
+	"rocketAuctionManager",
+	"rocketClaimNode",
+	"rocketClaimTrustedNode",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAONodeTrustedUpgrade",
+	"rocketDAOProposal",
+	"rocketDepositPool",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketMinipoolStatus",
+	"rocketNetworkBalances",
+	"rocketNetworkFees",
+	"rocketNetworkPrices",
+	"rocketNodeDeposit",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+	"rocketVault",
+}
+
+// Revert data selectors
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// The ERC20 allowance call, and how many storage slots to search for its mapping
+var allowanceSelector = crypto.Keccak256([]byte("allowance(address,address)"))[:4]
+
+const maxAllowanceSlot int64 = 20
+
+// The ERC20 transfer event, used to work out token balance changes
+var transferEventId = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// Returned in place of a transaction error when the transaction was simulated instead of sent
+type DryRunError struct {
+	Simulation api.TransactionSimulation
+}
+
+func (e *DryRunError) Error() string {
+	if e.Simulation.Success {
+		return "dry run: the transaction was simulated successfully and was not sent"
+	}
+	return fmt.Sprintf("dry run: the transaction would revert (%s) and was not sent", e.Simulation.RevertReason)
+}
+
+// The execution client functions needed to simulate transactions
+type ExecutionClient interface {
+	rocketpool.ExecutionClient
+	PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error)
+	PendingCallWithOverrides(ctx context.Context, call ethereum.CallMsg, overrides interface{}) ([]byte, error)
+	TraceCall(ctx context.Context, call ethereum.CallMsg, overrides interface{}, result interface{}) error
+}
+
+// A known contract, used to decode calls and logs
+type knownContract struct {
+	name string
+	abi  *abi.ABI
+}
+
+// The storage overrides for an account, in the execution client's state override set format
+type accountOverride struct {
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// Simulates transactions against the pending block
+type Simulator struct {
+	rp          *rocketpool.RocketPool
+	ec          ExecutionClient
+	contracts   map[common.Address]*knownContract
+	overrides   map[common.Address]*accountOverride
+	assumptions []string
+}
+
+// Create a new simulator
+func NewSimulator(rp *rocketpool.RocketPool, ec ExecutionClient) *Simulator {
+	return &Simulator{
+		rp: rp,
+		ec: ec,
+	}
+}
+
+// Simulate a transaction; the result is always returned as a *DryRunError so the transaction is never sent
+func (s *Simulator) Simulate(from common.Address, tx *types.Transaction) error {
+
+	if tx.To() == nil {
+		return fmt.Errorf("dry run: contract deployments can't be simulated")
+	}
+	simulation := api.TransactionSimulation{
+		From:        from,
+		To:          *tx.To(),
+		Value:       tx.Value(),
+		GasFeeCap:   tx.GasFeeCap(),
+		Events:      []api.SimulatedEvent{},
+		Balances:    []api.SimulatedBalance{},
+		Assumptions: s.assumptions,
+	}
+
+	// Identify the target contract and method
+	s.loadContracts()
+	target := s.getContract(simulation.To)
+	if target != nil {
+		simulation.Contract = target.name
+		if len(tx.Data()) >= 4 {
+			if method, err := target.abi.MethodById(tx.Data()[:4]); err == nil {
+				simulation.Method = method.Sig
+			}
+		}
+	} else if len(tx.Data()) == 0 {
+		simulation.Method = "ETH transfer"
+	}
+
+	// Fees are left out of the call so the account's balance doesn't need to cover the placeholder gas limit
+	call := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	// Transactions that depend on an earlier one, such as a token approval, are run against overridden state instead
+	if len(s.overrides) > 0 {
+		return s.simulateWithOverrides(simulation, call, target)
+	}
+
+	// Run the call against the pending block
+	_, err := s.ec.PendingCallContract(context.Background(), call)
+	if err != nil {
+		simulation.RevertReason = s.decodeRevert(err, target)
+		return &DryRunError{Simulation: simulation}
+	}
+	simulation.Success = true
+
+	// Estimate the gas
+	gasLimit, err := s.ec.EstimateGas(context.Background(), call)
+	if err != nil {
+		simulation.Success = false
+		simulation.RevertReason = s.decodeRevert(err, target)
+		return &DryRunError{Simulation: simulation}
+	}
+	simulation.GasInfo = getGasInfo(gasLimit)
+
+	// Trace the call for its events and balance changes
+	var trace callFrame
+	if err := s.ec.TraceCall(context.Background(), call, nil, &trace); err != nil {
+		simulation.TraceError = fmt.Sprintf("the execution client can't trace calls (%s)", err.Error())
+	} else {
+		s.addStateChanges(&simulation, &trace)
+	}
+
+	return &DryRunError{Simulation: simulation}
+
+}
+
+// Simulate a call against the pending block with the assumed state applied
+// eth_estimateGas doesn't take state overrides, so the gas comes from the trace instead.
+func (s *Simulator) simulateWithOverrides(simulation api.TransactionSimulation, call ethereum.CallMsg, target *knownContract) error {
+
+	// Run the call
+	_, err := s.ec.PendingCallWithOverrides(context.Background(), call, s.overrides)
+	if err != nil {
+		simulation.RevertReason = s.decodeRevert(err, target)
+		return &DryRunError{Simulation: simulation}
+	}
+	simulation.Success = true
+
+	// Trace the call for its gas, events and balance changes
+	var trace callFrame
+	if err := s.ec.TraceCall(context.Background(), call, s.overrides, &trace); err != nil {
+		simulation.TraceError = fmt.Sprintf("the execution client can't trace calls, so the gas can't be estimated with the assumed state (%s)", err.Error())
+		return &DryRunError{Simulation: simulation}
+	}
+	simulation.GasInfo = getGasInfo(uint64(trace.GasUsed))
+	s.addStateChanges(&simulation, &trace)
+	return &DryRunError{Simulation: simulation}
+
+}
+
+// Assume a token allowance is in place for the transactions simulated after this, such as one a dry run skipped approving
+// The allowance's storage slot is found by probing the token's first storage slots for a standard allowances mapping.
+func (s *Simulator) AssumeTokenAllowance(token common.Address, owner common.Address, spender common.Address, amount *big.Int, description string) error {
+
+	// Encode the allowance call
+	data := append(common.CopyBytes(allowanceSelector), common.LeftPadBytes(owner.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(spender.Bytes(), 32)...)
+	call := ethereum.CallMsg{
+		From: owner,
+		To:   &token,
+		Data: data,
+	}
+	value := common.BigToHash(amount)
+
+	// Find the slot that controls the allowance
+	for slot := int64(0); slot < maxAllowanceSlot; slot++ {
+		key := getAllowanceSlot(owner, spender, slot)
+		overrides := map[common.Address]*accountOverride{
+			token: {StateDiff: map[common.Hash]common.Hash{key: value}},
+		}
+		result, err := s.ec.PendingCallWithOverrides(context.Background(), call, overrides)
+		if err != nil {
+			return fmt.Errorf("Error checking the allowance of token %s with state overrides: %w", token.Hex(), err)
+		}
+		if common.BytesToHash(result) != value {
+			continue
+		}
+
+		// Record the override
+		if s.overrides == nil {
+			s.overrides = map[common.Address]*accountOverride{}
+		}
+		if _, exists := s.overrides[token]; !exists {
+			s.overrides[token] = &accountOverride{StateDiff: map[common.Hash]common.Hash{}}
+		}
+		s.overrides[token].StateDiff[key] = value
+		s.assumptions = append(s.assumptions, description)
+		return nil
+	}
+	return fmt.Errorf("Could not find the allowance storage slot of token %s", token.Hex())
+
+}
+
+// Get the storage slot of allowance[owner][spender] for an allowances mapping declared at the given slot
+func getAllowanceSlot(owner common.Address, spender common.Address, slot int64) common.Hash {
+	ownerSlot := crypto.Keccak256(common.LeftPadBytes(owner.Bytes(), 32), common.LeftPadBytes(big.NewInt(slot).Bytes(), 32))
+	return crypto.Keccak256Hash(common.LeftPadBytes(spender.Bytes(), 32), ownerSlot)
+}
+
+// Get the gas limits for an estimated amount of gas
+func getGasInfo(gasLimit uint64) rocketpool.GasInfo {
+	safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
+	if safeGasLimit > rocketpool.MaxGasLimit {
+		safeGasLimit = rocketpool.MaxGasLimit
+	}
+	return rocketpool.GasInfo{
+		EstGasLimit:  gasLimit,
+		SafeGasLimit: safeGasLimit,
+	}
+}
+
+// A call frame from the call tracer
+type callFrame struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Value   *hexutil.Big   `json:"value"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Error   string         `json:"error"`
+	Logs    []callLog      `json:"logs"`
+	Calls   []callFrame    `json:"calls"`
+}
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// Add the events and balance changes from a call trace to a simulation
+func (s *Simulator) addStateChanges(simulation *api.TransactionSimulation, frame *callFrame) {
+
+	type balanceKey struct {
+		account common.Address
+		token   common.Address
+	}
+	deltas := map[balanceKey]*big.Int{}
+	order := []balanceKey{}
+	addDelta := func(account common.Address, token common.Address, amount *big.Int) {
+		key := balanceKey{account, token}
+		delta, exists := deltas[key]
+		if !exists {
+			delta = big.NewInt(0)
+			deltas[key] = delta
+			order = append(order, key)
+		}
+		delta.Add(delta, amount)
+	}
+
+	// Walk the call tree, skipping frames that reverted since their changes are undone
+	var walk func(frame *callFrame)
+	walk = func(frame *callFrame) {
+		if frame.Error != "" {
+			return
+		}
+		if frame.Value != nil && frame.Value.ToInt().Sign() > 0 && frame.Type != "DELEGATECALL" && frame.Type != "STATICCALL" {
+			value := frame.Value.ToInt()
+			addDelta(frame.From, common.Address{}, big.NewInt(0).Neg(value))
+			addDelta(frame.To, common.Address{}, value)
+		}
+		for _, log := range frame.Logs {
+			simulation.Events = append(simulation.Events, s.decodeLog(log))
+			if len(log.Topics) == 3 && log.Topics[0] == transferEventId && len(log.Data) == 32 {
+				value := big.NewInt(0).SetBytes(log.Data)
+				addDelta(common.BytesToAddress(log.Topics[1].Bytes()), log.Address, big.NewInt(0).Neg(value))
+				addDelta(common.BytesToAddress(log.Topics[2].Bytes()), log.Address, value)
+			}
+		}
+		for i := range frame.Calls {
+			walk(&frame.Calls[i])
+		}
+	}
+	walk(frame)
+
+	// Record the non-zero changes
+	for _, key := range order {
+		delta := deltas[key]
+		if delta.Sign() == 0 {
+			continue
+		}
+		balance := api.SimulatedBalance{
+			Account:     key.account,
+			AccountName: s.getName(key.account),
+			Token:       key.token,
+			TokenName:   "ETH",
+			Delta:       delta,
+		}
+		if key.token != (common.Address{}) {
+			balance.TokenName = s.getName(key.token)
+		}
+		if key.account == simulation.From {
+			balance.AccountName = "node"
+		}
+		simulation.Balances = append(simulation.Balances, balance)
+	}
+
+}
+
+// Decode a log with the ABI of the contract that emitted it
+func (s *Simulator) decodeLog(log callLog) api.SimulatedEvent {
+	event := api.SimulatedEvent{
+		Address:  log.Address,
+		Contract: s.getName(log.Address),
+		Values:   map[string]string{},
+	}
+	contract := s.getContract(log.Address)
+	if contract == nil || len(log.Topics) == 0 {
+		event.Name = "unknown"
+		return event
+	}
+	abiEvent, err := contract.abi.EventByID(log.Topics[0])
+	if err != nil {
+		event.Name = log.Topics[0].Hex()
+		return event
+	}
+	event.Name = abiEvent.Name
+
+	values := map[string]interface{}{}
+	indexed := abi.Arguments{}
+	for _, arg := range abiEvent.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abiEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return event
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+		return event
+	}
+	for name, value := range values {
+		event.Values[name] = formatValue(value)
+	}
+	return event
+}
+
+// Decode the reason a call reverted, using the target contract's ABI for custom errors
+func (s *Simulator) decodeRevert(err error, target *knownContract) string {
+
+	// Get the revert data
+	dataError, ok := err.(rpc.DataError)
+	if !ok {
+		return err.Error()
+	}
+	dataString, ok := dataError.ErrorData().(string)
+	if !ok {
+		return err.Error()
+	}
+	data, decodeErr := hexutil.Decode(dataString)
+	if decodeErr != nil || len(data) < 4 {
+		return err.Error()
+	}
+
+	// Standard errors
+	selector := data[:4]
+	if string(selector) == string(errorSelector) {
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			return reason
+		}
+	}
+	if string(selector) == string(panicSelector) && len(data) >= 36 {
+		return fmt.Sprintf("panic code 0x%x", big.NewInt(0).SetBytes(data[4:36]))
+	}
+
+	// Custom errors
+	if target != nil {
+		for _, customError := range target.abi.Errors {
+			if string(customError.ID.Bytes()[:4]) != string(selector) {
+				continue
+			}
+			values, err := customError.Inputs.Unpack(data[4:])
+			if err != nil {
+				break
+			}
+			args := make([]string, len(values))
+			for i, value := range values {
+				args[i] = formatValue(value)
+			}
+			return fmt.Sprintf("%s(%s)", customError.Name, strings.Join(args, ", "))
+		}
+	}
+	return fmt.Sprintf("%s (data 0x%s)", err.Error(), hex.EncodeToString(data))
+
+}
+
+// Load the addresses and ABIs of the network contracts
+func (s *Simulator) loadContracts() {
+	if s.contracts != nil {
+		return
+	}
+	contracts := map[common.Address]*knownContract{}
+	for _, name := range contractNames {
+		contract, err := s.rp.GetContract(name)
+		if err != nil {
+			// Not every contract exists on every deployment
+			continue
+		}
+		contracts[*contract.Address] = &knownContract{
+			name: name,
+			abi:  contract.ABI,
+		}
+	}
+	s.contracts = contracts
+}
+
+// Get a known contract by address, including minipools
+func (s *Simulator) getContract(address common.Address) *knownContract {
+	if contract, exists := s.contracts[address]; exists {
+		return contract
+	}
+	exists, err := minipool.GetMinipoolExists(s.rp, address, nil)
+	if err != nil || !exists {
+		s.contracts[address] = nil
+		return nil
+	}
+	minipoolAbi, err := s.rp.GetABI("rocketMinipool")
+	if err != nil {
+		return nil
+	}
+	contract := &knownContract{
+		name: "minipool",
+		abi:  minipoolAbi,
+	}
+	s.contracts[address] = contract
+	return contract
+}
+
+// Get the name of an address if it's a known contract, or the address itself
+func (s *Simulator) getName(address common.Address) string {
+	if contract := s.getContract(address); contract != nil {
+		return contract.name
+	}
+	return address.Hex()
+}
+
+// Format a decoded ABI value for display
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
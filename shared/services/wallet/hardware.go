@@ -0,0 +1,233 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// A source of hardware wallets, such as go-ethereum's USB hubs
+// Anything that can list wallets can be used, so the USB transport can be replaced with a mock
+type HardwareWalletHub interface {
+	Wallets() []accounts.Wallet
+}
+
+// A source of block headers, used to price the legacy transactions hardware wallets sign
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// A node account held on a hardware wallet
+type hardwareAccount struct {
+	name    string
+	hubs    []HardwareWalletHub
+	path    accounts.DerivationPath
+	headers HeaderReader
+
+	// Cache of the device & account, once found
+	lock    sync.Mutex
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// Hold the node account on a hardware wallet instead of deriving it from the mnemonic
+// Validator keys are still derived from the mnemonic.
+func (w *Wallet) SetHardwareWallet(name string, derivationPath string, headers HeaderReader, hubs ...HardwareWalletHub) error {
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return fmt.Errorf("Invalid hardware wallet derivation path '%s': %w", derivationPath, err)
+	}
+	w.hardware = &hardwareAccount{
+		name:    name,
+		hubs:    hubs,
+		path:    path,
+		headers: headers,
+	}
+	return nil
+}
+
+// Get the type of hardware wallet that holds the node account, or an empty string if it's derived from the seed
+func (w *Wallet) GetHardwareWalletName() string {
+	if w.hardware == nil {
+		return ""
+	}
+	return w.hardware.name
+}
+
+// Get the node account from the hardware wallet
+func (w *Wallet) getHardwareAccount() (accounts.Wallet, accounts.Account, error) {
+
+	hw := w.hardware
+	hw.lock.Lock()
+	defer hw.lock.Unlock()
+
+	// Check for cached account, and look for the device again if it's been disconnected
+	if hw.wallet != nil {
+		if _, err := hw.wallet.Status(); err == nil {
+			return hw.wallet, hw.account, nil
+		}
+		hw.wallet.Close()
+		hw.wallet = nil
+	}
+
+	// Find a connected device that can derive the account
+	errs := []string{}
+	for _, hub := range hw.hubs {
+		for _, device := range hub.Wallets() {
+			account, err := openHardwareAccount(device, hw.path)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", device.URL().String(), err.Error()))
+				continue
+			}
+			hw.wallet = device
+			hw.account = account
+			return device, account, nil
+		}
+	}
+
+	// No device could be used
+	if len(errs) == 0 {
+		return nil, accounts.Account{}, fmt.Errorf("No %s hardware wallet was found; please make sure it is plugged in and unlocked", hw.name)
+	}
+	return nil, accounts.Account{}, fmt.Errorf("Could not get the node account from the %s hardware wallet (%v)", hw.name, errs)
+
+}
+
+// Forget a device that failed, so the next use of the node account looks for it again
+func (w *Wallet) forgetHardwareAccount(device accounts.Wallet) {
+	hw := w.hardware
+	hw.lock.Lock()
+	defer hw.lock.Unlock()
+	if hw.wallet == device {
+		hw.wallet.Close()
+		hw.wallet = nil
+	}
+}
+
+// Open a hardware wallet and derive the account at the path
+func openHardwareAccount(device accounts.Wallet, path accounts.DerivationPath) (accounts.Account, error) {
+
+	// Open the device; PINs can't be entered from the Smartnode, so the device must already be unlocked
+	if err := device.Open(""); err != nil && !errors.Is(err, accounts.ErrWalletAlreadyOpen) {
+		return accounts.Account{}, fmt.Errorf("Could not open device (make sure it is unlocked and, for a Ledger, the Ethereum app is open): %w", err)
+	}
+
+	// Derive & pin the account so the device will sign for it
+	account, err := device.Derive(path, true)
+	if err != nil {
+		device.Close()
+		return accounts.Account{}, fmt.Errorf("Could not derive account at path %s: %w", path.String(), err)
+	}
+	return account, nil
+
+}
+
+// Get a transactor that signs transactions on the hardware wallet
+// go-ethereum's Ledger and Trezor drivers can only sign legacy transactions, so the fee caps set on the transactor (here or by the caller)
+// are turned into a legacy gas price when the transaction is signed.
+func (w *Wallet) getHardwareTransactor() (*bind.TransactOpts, error) {
+
+	// Get the account
+	_, account, err := w.getHardwareAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create & return transactor
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			legacyTx, err := w.getLegacyTransaction(tx)
+			if err != nil {
+				return nil, err
+			}
+			signedTx, err := w.signHardwareTransaction(account, legacyTx)
+			if err != nil {
+				return nil, fmt.Errorf("Could not sign transaction on the %s hardware wallet: %w", w.hardware.name, err)
+			}
+			return signedTx, nil
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+	}, nil
+
+}
+
+// Sign a transaction on the device holding the account
+// If signing fails and the device has since been reconnected, the transaction is signed again on the reconnected device.
+func (w *Wallet) signHardwareTransaction(account accounts.Account, tx *types.Transaction) (*types.Transaction, error) {
+
+	// Sign on the current device
+	device, _, err := w.getHardwareAccount()
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := device.SignTx(account, tx, w.chainID)
+	if err == nil {
+		return signedTx, nil
+	}
+
+	// Look for the device again, and retry if it's been replaced
+	w.forgetHardwareAccount(device)
+	newDevice, newAccount, findErr := w.getHardwareAccount()
+	if findErr != nil || newDevice == device || newAccount.Address != account.Address {
+		return nil, err
+	}
+	return newDevice.SignTx(newAccount, tx, w.chainID)
+
+}
+
+// Convert a dynamic fee transaction into a legacy one that hardware wallets can sign
+// Legacy transactions pay their whole gas price, so it's set to the pending block's base fee plus the tip, capped at the fee cap.
+func (w *Wallet) getLegacyTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	if tx.Type() == types.LegacyTxType {
+		return tx, nil
+	}
+	gasPrice := tx.GasFeeCap()
+	if w.hardware.headers != nil {
+		header, err := w.hardware.headers.HeaderByNumber(context.Background(), big.NewInt(int64(rpc.PendingBlockNumber)))
+		if err != nil {
+			return nil, fmt.Errorf("Could not get the pending block's base fee: %w", err)
+		}
+		gasPrice = getLegacyGasPrice(header.BaseFee, tx.GasTipCap(), tx.GasFeeCap())
+	}
+	return toLegacyTransaction(tx, gasPrice), nil
+}
+
+// Get the gas price for a legacy transaction that pays the tip on top of the base fee, without going over the fee cap
+// Chains without a base fee pay the fee cap.
+func getLegacyGasPrice(baseFee *big.Int, gasTipCap *big.Int, gasFeeCap *big.Int) *big.Int {
+	if baseFee == nil {
+		return gasFeeCap
+	}
+	gasPrice := new(big.Int).Add(baseFee, gasTipCap)
+	if gasPrice.Cmp(gasFeeCap) > 0 {
+		return gasFeeCap
+	}
+	return gasPrice
+}
+
+// Convert a dynamic fee transaction into a legacy one with the given gas price
+func toLegacyTransaction(tx *types.Transaction, gasPrice *big.Int) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    tx.Nonce(),
+		GasPrice: gasPrice,
+		Gas:      tx.Gas(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	})
+}
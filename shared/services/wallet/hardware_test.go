@@ -0,0 +1,271 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// A hub with a single fake device
+type fakeHub struct {
+	device *fakeDevice
+}
+
+func (h *fakeHub) Wallets() []accounts.Wallet {
+	return []accounts.Wallet{h.device}
+}
+
+// A header source with a fixed base fee for the pending block
+type fakeHeaders struct {
+	baseFee *big.Int
+}
+
+func (h *fakeHeaders) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: number, BaseFee: h.baseFee}, nil
+}
+
+// A fake hardware wallet that signs like go-ethereum's Ledger and Trezor drivers, which only support legacy transactions
+type fakeDevice struct {
+	key          *ecdsa.PrivateKey
+	opened       bool
+	disconnected bool
+	derived      accounts.DerivationPath
+}
+
+func (d *fakeDevice) account() accounts.Account {
+	return accounts.Account{Address: crypto.PubkeyToAddress(d.key.PublicKey)}
+}
+
+func (d *fakeDevice) URL() accounts.URL                { return accounts.URL{Scheme: "fake", Path: "device"} }
+func (d *fakeDevice) Status() (string, error)          { return "ok", nil }
+func (d *fakeDevice) Open(passphrase string) error     { d.opened = true; return nil }
+func (d *fakeDevice) Close() error                     { d.opened = false; return nil }
+func (d *fakeDevice) Accounts() []accounts.Account     { return []accounts.Account{d.account()} }
+func (d *fakeDevice) Contains(a accounts.Account) bool { return a.Address == d.account().Address }
+func (d *fakeDevice) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+func (d *fakeDevice) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	d.derived = path
+	return d.account(), nil
+}
+func (d *fakeDevice) SignData(a accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+func (d *fakeDevice) SignDataWithPassphrase(a accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+func (d *fakeDevice) SignText(a accounts.Account, text []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+func (d *fakeDevice) SignTextWithPassphrase(a accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+func (d *fakeDevice) SignTx(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if d.disconnected {
+		return nil, errors.New("device disconnected")
+	}
+	if !d.opened {
+		return nil, accounts.ErrWalletClosed
+	}
+	if tx.Type() != types.LegacyTxType {
+		return nil, types.ErrTxTypeNotSupported
+	}
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), d.key)
+}
+func (d *fakeDevice) SignTxWithPassphrase(a accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return d.SignTx(a, tx, chainID)
+}
+
+func TestHardwareTransactorSignsLegacyTransactions(t *testing.T) {
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &fakeDevice{key: key}
+	chainID := big.NewInt(5)
+	maxFee := big.NewInt(50e9)
+	w := &Wallet{
+		chainID:        chainID,
+		maxFee:         maxFee,
+		maxPriorityFee: big.NewInt(2e9),
+	}
+	headers := &fakeHeaders{}
+	if err := w.SetHardwareWallet("Ledger", "m/44'/60'/0'/0/3", headers, &fakeHub{device: device}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := w.getHardwareTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.From != device.account().Address {
+		t.Fatalf("transactor is for %s, expected %s", opts.From.Hex(), device.account().Address.Hex())
+	}
+	if device.derived.String() != "m/44'/60'/0'/0/3" {
+		t.Fatalf("account was derived at %s", device.derived.String())
+	}
+
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	dynamicFeeTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: w.maxPriorityFee,
+		GasFeeCap: opts.GasFeeCap,
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		baseFee  *big.Int
+		gasPrice *big.Int
+	}{
+		{
+			name:     "base fee plus tip",
+			tx:       dynamicFeeTx,
+			baseFee:  big.NewInt(20e9),
+			gasPrice: big.NewInt(22e9),
+		},
+		{
+			name:     "capped at the max fee",
+			tx:       dynamicFeeTx,
+			baseFee:  big.NewInt(49e9),
+			gasPrice: maxFee,
+		},
+		{
+			name:     "no base fee",
+			tx:       dynamicFeeTx,
+			baseFee:  nil,
+			gasPrice: maxFee,
+		},
+		{
+			name: "legacy",
+			tx: types.NewTx(&types.LegacyTx{
+				Nonce:    7,
+				GasPrice: big.NewInt(30e9),
+				Gas:      21000,
+				To:       &to,
+				Value:    big.NewInt(1),
+			}),
+			baseFee:  big.NewInt(20e9),
+			gasPrice: big.NewInt(30e9),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers.baseFee = test.baseFee
+			signedTx, err := opts.Signer(opts.From, test.tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signedTx.Type() != types.LegacyTxType {
+				t.Fatalf("signed transaction has type %d, expected a legacy transaction", signedTx.Type())
+			}
+			if signedTx.GasPrice().Cmp(test.gasPrice) != 0 {
+				t.Fatalf("signed transaction has gas price %s, expected %s", signedTx.GasPrice(), test.gasPrice)
+			}
+			if signedTx.Nonce() != test.tx.Nonce() || *signedTx.To() != to || signedTx.Value().Cmp(test.tx.Value()) != 0 || signedTx.Gas() != test.tx.Gas() {
+				t.Fatal("signed transaction doesn't match the original")
+			}
+			sender, err := types.Sender(types.NewEIP155Signer(chainID), signedTx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sender != opts.From {
+				t.Fatalf("transaction was signed by %s, expected %s", sender.Hex(), opts.From.Hex())
+			}
+		})
+	}
+
+	// Transactions for other accounts aren't signed
+	if _, err := opts.Signer(to, dynamicFeeTx); !errors.Is(err, bind.ErrNotAuthorized) {
+		t.Fatalf("expected ErrNotAuthorized, got %v", err)
+	}
+
+}
+
+func TestHardwareTransactorFindsReconnectedDevice(t *testing.T) {
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub := &fakeHub{device: &fakeDevice{key: key}}
+	w := &Wallet{
+		chainID:        big.NewInt(5),
+		maxFee:         big.NewInt(50e9),
+		maxPriorityFee: big.NewInt(2e9),
+	}
+	if err := w.SetHardwareWallet("Trezor", "m/44'/60'/0'/0/0", &fakeHeaders{baseFee: big.NewInt(20e9)}, hub); err != nil {
+		t.Fatal(err)
+	}
+	opts, err := w.getHardwareTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unplug the device and plug it back in, which the hub reports as a new device
+	hub.device.disconnected = true
+	hub.device = &fakeDevice{key: key}
+
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(30e9), Gas: 21000, To: &to, Value: big.NewInt(1)})
+	if _, err := opts.Signer(opts.From, tx); err != nil {
+		t.Fatalf("transaction wasn't signed on the reconnected device: %v", err)
+	}
+	if !hub.device.opened {
+		t.Fatal("the reconnected device wasn't opened")
+	}
+
+	// A device that's still connected isn't asked to sign again after it fails
+	hub.device.disconnected = true
+	if _, err := opts.Signer(opts.From, tx); err == nil {
+		t.Fatal("expected the transaction to fail")
+	}
+
+}
+
+func TestUnattendedHardwareTransactor(t *testing.T) {
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &Wallet{
+		ws:      &walletStore{},
+		seed:    []byte{1},
+		mk:      new(hdkeychain.ExtendedKey),
+		chainID: big.NewInt(5),
+	}
+	if err := w.SetHardwareWallet("Ledger", "m/44'/60'/0'/0/0", &fakeHeaders{}, &fakeHub{device: &fakeDevice{key: key}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Daemons get an error instead of a transactor that waits on the device
+	w.SetUnattended()
+	if _, err := w.GetNodeAccountTransactor(); !errors.Is(err, ErrUnattendedHardwareWallet) {
+		t.Fatalf("expected ErrUnattendedHardwareWallet, got %v", err)
+	}
+
+	// The node account can still be read
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("node account is %s", account.Address.Hex())
+	}
+
+}
@@ -115,3 +115,19 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// Check if a validator key and its secret are stored
+func (ks *Keystore) HasValidatorKey(pubkey rptypes.ValidatorPubkey) (bool, error) {
+	for _, path := range []string{
+		filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName),
+		filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())),
+	} {
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("Could not check validator key file %s: %w", path, err)
+		}
+	}
+	return true, nil
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 
 	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	rpkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -156,6 +157,30 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 
 }
 
+// Check if a validator key is stored in the account store
+func (ks *Keystore) HasValidatorKey(pubkey rptypes.ValidatorPubkey) (bool, error) {
+
+	// Don't create the account store if it doesn't exist yet
+	_, err := os.Stat(filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("Could not check validator keystore: %w", err)
+	}
+
+	// Search the account store
+	if err := ks.initialize(); err != nil {
+		return false, err
+	}
+	for _, publicKey := range ks.as.PublicKeys {
+		if bytes.Equal(publicKey, pubkey.Bytes()) {
+			return true, nil
+		}
+	}
+	return false, nil
+
+}
+
 // Initialize the account store
 func (ks *Keystore) initialize() error {
 
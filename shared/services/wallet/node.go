@@ -10,9 +10,15 @@ import (
 	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 )
 
+// Returned when a daemon asks for a transactor and the node account is held on a hardware wallet
+var ErrUnattendedHardwareWallet = errors.New("Automatic transactions are disabled")
+
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
@@ -21,6 +27,12 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
+	// Get the account from the hardware wallet if it holds the node account
+	if w.hardware != nil {
+		_, account, err := w.getHardwareAccount()
+		return account, err
+	}
+
 	// Get private key
 	privateKey, path, err := w.getNodePrivateKey()
 	if err != nil {
@@ -53,6 +65,20 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Create a transactor that simulates instead of signing if requested
+	if w.simulate != nil {
+		return w.getSimulationTransactor()
+	}
+
+	// Create a transactor that signs on the hardware wallet if it holds the node account
+	// Daemons can't wait for someone to confirm the transaction on the device, so they get an error instead.
+	if w.hardware != nil {
+		if w.unattended {
+			return nil, fmt.Errorf("%w: the node account is held on a %s hardware wallet, so transactions must be sent from the CLI and confirmed on the device", ErrUnattendedHardwareWallet, w.hardware.name)
+		}
+		return w.getHardwareTransactor()
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -69,6 +95,36 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 }
 
+// Get a transactor that passes transactions to the simulator without signing or sending them
+func (w *Wallet) getSimulationTransactor() (*bind.TransactOpts, error) {
+
+	// Get the node address
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// The gas limit is set so the transaction isn't estimated (and rejected) before it's simulated
+	gasLimit := w.gasLimit
+	if gasLimit == 0 {
+		gasLimit = rocketpool.MaxGasLimit
+	}
+
+	// Create & return transactor
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return nil, w.simulate(address, tx)
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  gasLimit,
+		Context:   context.Background(),
+		NoSend:    true,
+	}, nil
+
+}
+
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
@@ -77,6 +133,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Check the key isn't held on a hardware wallet
+	if w.hardware != nil {
+		return nil, fmt.Errorf("The node account is held on a %s hardware wallet, so its private key can't be exported", w.hardware.name)
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
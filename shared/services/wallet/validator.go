@@ -218,6 +218,15 @@ func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, s
 
 }
 
+// Check if a validator key is stored in the named keystore
+func (w *Wallet) HasValidatorKey(keystoreName string, pubkey rptypes.ValidatorPubkey) (bool, error) {
+	ks, exists := w.keystores[keystoreName]
+	if !exists {
+		return false, fmt.Errorf("Unknown validator keystore '%s'", keystoreName)
+	}
+	return ks.HasValidatorKey(pubkey)
+}
+
 // Initialize BLS support
 var initBLS sync.Once
 
@@ -10,6 +10,8 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -59,8 +61,20 @@ type Wallet struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// If set, node transactions are passed to this instead of being signed
+	simulate TransactionSimulator
+
+	// If set, the node account is held on this hardware wallet instead of being derived from the seed
+	hardware *hardwareAccount
+
+	// If set, nobody is around to confirm transactions on a hardware wallet, so they're refused instead of waiting on the device
+	unattended bool
 }
 
+// Simulates an unsigned transaction, returning the result as an error so the transaction is never sent
+type TransactionSimulator func(from common.Address, tx *types.Transaction) error
+
 // Encrypted wallet store
 type walletStore struct {
 	Crypto         map[string]interface{} `json:"crypto"`
@@ -98,6 +112,16 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 
 }
 
+// Simulate node transactions instead of signing them
+func (w *Wallet) SetTransactionSimulator(simulate TransactionSimulator) {
+	w.simulate = simulate
+}
+
+// Refuse to sign node transactions on a hardware wallet, for daemons that send transactions without anyone to confirm them on the device
+func (w *Wallet) SetUnattended() {
+	w.unattended = true
+}
+
 // Gets the wallet's chain ID
 func (w *Wallet) GetChainID() *big.Int {
 	copy := big.NewInt(0).Set(w.chainID)
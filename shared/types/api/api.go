@@ -4,3 +4,10 @@ type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// The response printed instead of a command's usual response when its transaction was simulated in dry-run mode
+type DryRunResponse struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error"`
+	DryRun *TransactionSimulation `json:"dryRun"`
+}
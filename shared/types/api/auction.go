@@ -23,6 +23,30 @@ type AuctionStatusResponse struct {
 	} `json:"lotCounts"`
 }
 
+type AuctionBidPlanResponse struct {
+	Status         string          `json:"status"`
+	Error          string          `json:"error"`
+	MinDiscount    float64         `json:"minDiscount"`
+	LotBudget      *big.Int        `json:"lotBudget"`
+	TotalBudget    *big.Int        `json:"totalBudget"`
+	BiddingEnabled bool            `json:"biddingEnabled"`
+	OraclePrice    *big.Int        `json:"oraclePrice"`
+	OpenBids       *big.Int        `json:"openBids"`
+	Bids           []AuctionLotBid `json:"bids"`
+	SkippedLots    []AuctionLotBid `json:"skippedLots"`
+	ClaimableLots  []uint64        `json:"claimableLots"`
+}
+type AuctionLotBid struct {
+	LotIndex     uint64   `json:"lotIndex"`
+	CurrentPrice *big.Int `json:"currentPrice"`
+	Discount     float64  `json:"discount"`
+	RemainingRpl *big.Int `json:"remainingRpl"`
+	ExistingBid  *big.Int `json:"existingBid"`
+	BidAmount    *big.Int `json:"bidAmount"`
+	ExpectedRpl  *big.Int `json:"expectedRpl"`
+	RplPerEth    float64  `json:"rplPerEth"`
+}
+
 type AuctionLotsResponse struct {
 	Status string       `json:"status"`
 	Error  string       `json:"error"`
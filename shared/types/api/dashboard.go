@@ -0,0 +1,53 @@
+package api
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The roles the web dashboard can be logged into with
+type DashboardRole string
+
+const (
+	DashboardRole_Operator DashboardRole = "operator"
+	DashboardRole_Viewer   DashboardRole = "viewer"
+)
+
+type DashboardSessionResponse struct {
+	Status     string        `json:"status"`
+	Error      string        `json:"error"`
+	Role       DashboardRole `json:"role"`
+	ReadOnly   bool          `json:"readOnly"`
+	Network    string        `json:"network"`
+	TxWatchUrl string        `json:"txWatchUrl"`
+}
+
+type DashboardLogsResponse struct {
+	Status string             `json:"status"`
+	Error  string             `json:"error"`
+	Lines  []DashboardLogLine `json:"lines"`
+}
+type DashboardLogLine struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// The transactions the node account has waiting to be mined
+// Pending is the gap between the account's mined and pending nonces; Transactions lists the ones the node daemon submitted itself.
+type DashboardTransactionsResponse struct {
+	Status         string                 `json:"status"`
+	Error          string                 `json:"error"`
+	AccountAddress common.Address         `json:"accountAddress"`
+	MinedNonce     uint64                 `json:"minedNonce"`
+	PendingNonce   uint64                 `json:"pendingNonce"`
+	Pending        uint64                 `json:"pending"`
+	Transactions   []DashboardTransaction `json:"transactions"`
+}
+type DashboardTransaction struct {
+	Hash        common.Hash `json:"hash"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	Mined       bool        `json:"mined"`
+	Succeeded   bool        `json:"succeeded"`
+	BlockNumber uint64      `json:"blockNumber"`
+}
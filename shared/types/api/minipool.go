@@ -13,10 +13,11 @@ import (
 )
 
 type MinipoolStatusResponse struct {
-	Status         string            `json:"status"`
-	Error          string            `json:"error"`
-	Minipools      []MinipoolDetails `json:"minipools"`
-	LatestDelegate common.Address    `json:"latestDelegate"`
+	Status            string            `json:"status"`
+	Error             string            `json:"error"`
+	Minipools         []MinipoolDetails `json:"minipools"`
+	LatestDelegate    common.Address    `json:"latestDelegate"`
+	DepositInflowRate *big.Int          `json:"depositInflowRate"`
 }
 type MinipoolDetails struct {
 	Address             common.Address         `json:"address"`
@@ -37,6 +38,8 @@ type MinipoolDetails struct {
 	PreviousDelegate    common.Address         `json:"previousDelegate"`
 	EffectiveDelegate   common.Address         `json:"effectiveDelegate"`
 	TimeUntilDissolve   time.Duration          `json:"timeUntilDissolve"`
+	Lifecycle           MinipoolLifecycle      `json:"lifecycle"`
+	Queue               MinipoolQueuePosition  `json:"queue"`
 }
 type ValidatorDetails struct {
 	Exists      bool     `json:"exists"`
@@ -46,6 +49,19 @@ type ValidatorDetails struct {
 	NodeBalance *big.Int `json:"nodeBalance"`
 }
 
+// A minipool's position in the minipool queues
+// Position counts the minipools ahead of it across all of the queues, in the order user ETH is assigned to them.
+type MinipoolQueuePosition struct {
+	InQueue     bool                  `json:"inQueue"`
+	DepositType types.MinipoolDeposit `json:"depositType"`
+	QueueIndex  uint64                `json:"queueIndex"`
+	Position    uint64                `json:"position"`
+	EthAhead    *big.Int              `json:"ethAhead"`
+	EthRequired *big.Int              `json:"ethRequired"`
+	EtaKnown    bool                  `json:"etaKnown"`
+	Eta         time.Duration         `json:"eta"`
+}
+
 type CanRefundMinipoolResponse struct {
 	Status                    string             `json:"status"`
 	Error                     string             `json:"error"`
@@ -83,6 +99,28 @@ type ExitMinipoolResponse struct {
 	Error  string `json:"error"`
 }
 
+type SignExitMinipoolResponse struct {
+	Status         string                   `json:"status"`
+	Error          string                   `json:"error"`
+	Pubkey         types.ValidatorPubkey    `json:"pubkey"`
+	ValidatorIndex uint64                   `json:"validatorIndex"`
+	Epoch          uint64                   `json:"epoch"`
+	Signature      types.ValidatorSignature `json:"signature"`
+	ForkVersion    []byte                   `json:"forkVersion"`
+}
+type CanSubmitExitResponse struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	CanSubmit    bool   `json:"canSubmit"`
+	EpochTooHigh bool   `json:"epochTooHigh"`
+	CurrentEpoch uint64 `json:"currentEpoch"`
+	ForkVersion  []byte `json:"forkVersion"`
+}
+type SubmitExitResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type CanProcessWithdrawalResponse struct {
 	Status        string             `json:"status"`
 	Error         string             `json:"error"`
@@ -212,3 +250,56 @@ type GetVanityArtifactsResponse struct {
 	MinipoolManagerAddress common.Address `json:"minipoolManagerAddress"`
 	InitHash               common.Hash    `json:"initHash"`
 }
+
+// An action in a minipool's lifecycle
+type MinipoolLifecycleAction string
+
+const (
+	MinipoolAction_WaitForDeposit   MinipoolLifecycleAction = "waitForDeposit"
+	MinipoolAction_ScrubCheck       MinipoolLifecycleAction = "scrubCheck"
+	MinipoolAction_Stake            MinipoolLifecycleAction = "stake"
+	MinipoolAction_Dissolve         MinipoolLifecycleAction = "dissolve"
+	MinipoolAction_Exit             MinipoolLifecycleAction = "exit"
+	MinipoolAction_MarkWithdrawable MinipoolLifecycleAction = "markWithdrawable"
+	MinipoolAction_WithdrawFinalise MinipoolLifecycleAction = "withdrawFinalise"
+	MinipoolAction_Close            MinipoolLifecycleAction = "close"
+	MinipoolAction_Refund           MinipoolLifecycleAction = "refund"
+)
+
+// The party responsible for taking a lifecycle action
+type MinipoolLifecycleActor string
+
+const (
+	MinipoolActor_Node      MinipoolLifecycleActor = "node"
+	MinipoolActor_OracleDao MinipoolLifecycleActor = "oracleDao"
+	MinipoolActor_Protocol  MinipoolLifecycleActor = "protocol"
+)
+
+// A step in a minipool's lifecycle
+// A zero AvailableTime means the step is available now, and a zero Deadline means it has no deadline
+type MinipoolLifecycleStep struct {
+	Action        MinipoolLifecycleAction `json:"action"`
+	Actor         MinipoolLifecycleActor  `json:"actor"`
+	AvailableTime time.Time               `json:"availableTime"`
+	Deadline      time.Time               `json:"deadline"`
+}
+
+// The remaining steps in a minipool's lifecycle, starting with the next one
+type MinipoolLifecycle struct {
+	Status types.MinipoolStatus    `json:"status"`
+	Steps  []MinipoolLifecycleStep `json:"steps"`
+}
+
+type MinipoolTimelineResponse struct {
+	Status    string                  `json:"status"`
+	Error     string                  `json:"error"`
+	Events    []MinipoolTimelineEvent `json:"events"`
+	Lifecycle MinipoolLifecycle       `json:"lifecycle"`
+}
+type MinipoolTimelineEvent struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Time        time.Time   `json:"time"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
@@ -2,6 +2,9 @@ package api
 
 import (
 	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type NodeFeeResponse struct {
@@ -50,3 +53,29 @@ type NetworkTimezonesResponse struct {
 	TimezoneTotal  uint64            `json:"timezoneTotal"`
 	NodeTotal      uint64            `json:"nodeTotal"`
 }
+
+type NetworkDAOSetting struct {
+	DAO          string   `json:"dao"`
+	Category     string   `json:"category"`
+	ContractName string   `json:"contractName"`
+	Path         string   `json:"path"`
+	Value        *big.Int `json:"value"`
+}
+type NetworkDAOSettingChange struct {
+	ProposalID   uint64      `json:"proposalId"`
+	ContractName string      `json:"contractName"`
+	Path         string      `json:"path"`
+	Value        *big.Int    `json:"value"`
+	Time         time.Time   `json:"time"`
+	BlockNumber  uint64      `json:"blockNumber"`
+	TxHash       common.Hash `json:"txHash"`
+}
+type NetworkDAOSettingsResponse struct {
+	Status                   string                    `json:"status"`
+	Error                    string                    `json:"error"`
+	Settings                 []NetworkDAOSetting       `json:"settings"`
+	History                  []NetworkDAOSettingChange `json:"history"`
+	HistoryIndexing          bool                      `json:"historyIndexing"`
+	HistoryIndexProgress     float64                   `json:"historyIndexProgress"`
+	ProtocolDAOBootstrapMode bool                      `json:"protocolDaoBootstrapMode"`
+}
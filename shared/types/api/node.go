@@ -92,6 +92,44 @@ type GetNodePendingWithdrawalAddressResponse struct {
 	Address common.Address `json:"address"`
 }
 
+type CheckWithdrawalAddressResponse struct {
+	Status          string           `json:"status"`
+	Error           string           `json:"error"`
+	IsContract      bool             `json:"isContract"`
+	CanReceiveEth   bool             `json:"canReceiveEth"`
+	ReceiveEthError string           `json:"receiveEthError"`
+	IsSafe          bool             `json:"isSafe"`
+	SafeVersion     string           `json:"safeVersion"`
+	SafeThreshold   uint64           `json:"safeThreshold"`
+	SafeOwners      []common.Address `json:"safeOwners"`
+}
+
+// A transaction for a Safe to execute, in the Safe transaction service's format
+// An owner must add their address as the sender and their signature of the contract transaction hash before it's submitted.
+type SafeTransactionProposal struct {
+	To                      common.Address `json:"to"`
+	Value                   *big.Int       `json:"value"`
+	Data                    string         `json:"data"`
+	Operation               uint8          `json:"operation"`
+	SafeTxGas               uint64         `json:"safeTxGas"`
+	BaseGas                 uint64         `json:"baseGas"`
+	GasPrice                *big.Int       `json:"gasPrice"`
+	GasToken                common.Address `json:"gasToken"`
+	RefundReceiver          common.Address `json:"refundReceiver"`
+	Nonce                   uint64         `json:"nonce"`
+	ContractTransactionHash common.Hash    `json:"contractTransactionHash"`
+	Sender                  string         `json:"sender,omitempty"`
+	Signature               string         `json:"signature,omitempty"`
+	Origin                  string         `json:"origin"`
+}
+type SafeProposalResponse struct {
+	Status        string                  `json:"status"`
+	Error         string                  `json:"error"`
+	Safe          common.Address          `json:"safe"`
+	SafeThreshold uint64                  `json:"safeThreshold"`
+	Proposal      SafeTransactionProposal `json:"proposal"`
+}
+
 type CanSetNodeTimezoneResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -155,6 +193,28 @@ type NodeStakeRplStakeResponse struct {
 	Error       string      `json:"error"`
 	StakeTxHash common.Hash `json:"stakeTxHash"`
 }
+type NodeCollateralPlanResponse struct {
+	Status                string                 `json:"status"`
+	Error                 string                 `json:"error"`
+	RplStake              *big.Int               `json:"rplStake"`
+	RplPrice              *big.Int               `json:"rplPrice"`
+	RplBalance            *big.Int               `json:"rplBalance"`
+	FixedSupplyRplBalance *big.Int               `json:"fixedSupplyRplBalance"`
+	ActiveMinipools       uint64                 `json:"activeMinipools"`
+	CollateralRatio       float64                `json:"collateralRatio"`
+	MinimumRatio          float64                `json:"minimumRatio"`
+	MaximumRatio          float64                `json:"maximumRatio"`
+	MinimumRatioRplPrice  *big.Int               `json:"minimumRatioRplPrice"`
+	MaximumRatioRplPrice  *big.Int               `json:"maximumRatioRplPrice"`
+	TargetRatio           float64                `json:"targetRatio"`
+	RplToStake            *big.Int               `json:"rplToStake"`
+	Projections           []CollateralProjection `json:"projections"`
+}
+type CollateralProjection struct {
+	PriceChange     float64  `json:"priceChange"`
+	RplPrice        *big.Int `json:"rplPrice"`
+	CollateralRatio float64  `json:"collateralRatio"`
+}
 type NodeStakeRplAllowanceResponse struct {
 	Status    string   `json:"status"`
 	Error     string   `json:"error"`
@@ -226,6 +286,12 @@ type NodeBurnResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type NodeNonceResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Nonce  uint64 `json:"nonce"`
+}
+
 type NodeSyncProgressResponse struct {
 	Status       string                       `json:"status"`
 	Error        string                       `json:"error"`
@@ -262,10 +328,13 @@ type NodeRewardsResponse struct {
 	CumulativeRewards           float64       `json:"cumulativeRewards"`
 	EstimatedTrustedRewards     float64       `json:"estimatedTrustedRewards"`
 	CumulativeTrustedRewards    float64       `json:"cumulativeTrustedRewards"`
-	UnclaimedRewards            float64       `json:"unclaimedRewards"`
-	UnclaimedTrustedRewards     float64       `json:"unclaimedTrustedRewards"`
-	BeaconRewards               float64       `json:"beaconRewards"`
-	TxHash                      common.Hash   `json:"txHash"`
+	// Set while the event index the cumulative rewards come from is still catching up
+	CumulativeRewardsIndexing      bool        `json:"cumulativeRewardsIndexing"`
+	CumulativeRewardsIndexProgress float64     `json:"cumulativeRewardsIndexProgress"`
+	UnclaimedRewards               float64     `json:"unclaimedRewards"`
+	UnclaimedTrustedRewards        float64     `json:"unclaimedTrustedRewards"`
+	BeaconRewards                  float64     `json:"beaconRewards"`
+	TxHash                         common.Hash `json:"txHash"`
 }
 
 type DepositContractInfoResponse struct {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
@@ -207,55 +208,7 @@ type CanProposeTNDAOSettingResponse struct {
 	ProposalCooldownActive bool               `json:"proposalCooldownActive"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
-type ProposeTNDAOSettingMembersQuorumResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingMembersRplBondResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingMinipoolUnbondedMaxResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingProposalCooldownResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingProposalVoteTimespanResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingProposalVoteDelayTimespanResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingProposalExecuteTimespanResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingProposalActionTimespanResponse struct {
-	Status     string      `json:"status"`
-	Error      string      `json:"error"`
-	ProposalId uint64      `json:"proposalId"`
-	TxHash     common.Hash `json:"txHash"`
-}
-type ProposeTNDAOSettingScrubPeriodResponse struct {
+type ProposeTNDAOSettingResponse struct {
 	Status     string      `json:"status"`
 	Error      string      `json:"error"`
 	ProposalId uint64      `json:"proposalId"`
@@ -286,3 +239,44 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	Error       string `json:"error"`
 	ScrubPeriod uint64 `json:"scrubPeriod"`
 }
+
+// The kinds of transaction the watchtower submits on behalf of an oracle DAO member
+type WatchtowerSubmissionType string
+
+const (
+	Submission_NetworkBalances      WatchtowerSubmissionType = "balances"
+	Submission_RplPrice             WatchtowerSubmissionType = "prices"
+	Submission_WithdrawableMinipool WatchtowerSubmissionType = "withdrawable"
+	Submission_ScrubMinipool        WatchtowerSubmissionType = "scrub"
+	Submission_ChallengeResponse    WatchtowerSubmissionType = "challenge"
+	Submission_ProposalVote         WatchtowerSubmissionType = "vote"
+)
+
+// A record of a watchtower submission, including the inputs it was calculated from
+// Amounts are stored as decimal wei strings so records can be compared field by field
+// The result is only recorded when the submission succeeded; failed submissions have an error instead
+type WatchtowerSubmission struct {
+	Type      WatchtowerSubmissionType     `json:"type"`
+	Time      time.Time                    `json:"time"`
+	Block     uint64                       `json:"block"`
+	Inputs    map[string]string            `json:"inputs,omitempty"`
+	Result    map[string]string            `json:"result"`
+	Minipools []WatchtowerSubmissionDetail `json:"minipools,omitempty"`
+	TxHash    common.Hash                  `json:"txHash"`
+	Error     string                       `json:"error,omitempty"`
+}
+type WatchtowerSubmissionDetail struct {
+	Address common.Address    `json:"address"`
+	Values  map[string]string `json:"values"`
+}
+
+type TNDAOSubmissionsResponse struct {
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error"`
+	Submissions []TNDAOSubmissionAudit `json:"submissions"`
+}
+type TNDAOSubmissionAudit struct {
+	Submission      WatchtowerSubmission `json:"submission"`
+	ConsensusFound  bool                 `json:"consensusFound"`
+	ConsensusResult map[string]string    `json:"consensusResult"`
+}
@@ -26,3 +26,64 @@ type ExecutionClientStatusResponse struct {
 	Error         string                       `json:"error"`
 	ManagerStatus ExecutionClientManagerStatus `json:"managerStatus"`
 }
+
+// The result of a single health check
+type HealthCheckStatus string
+
+const (
+	HealthCheckStatus_Pass HealthCheckStatus = "pass"
+	HealthCheckStatus_Warn HealthCheckStatus = "warn"
+	HealthCheckStatus_Fail HealthCheckStatus = "fail"
+	HealthCheckStatus_Skip HealthCheckStatus = "skip"
+)
+
+// A single health check run by the doctor, with advice on fixing it if it didn't pass
+type HealthCheck struct {
+	Name        string            `json:"name"`
+	Status      HealthCheckStatus `json:"status"`
+	Message     string            `json:"message"`
+	Remediation string            `json:"remediation,omitempty"`
+}
+
+type ServiceDoctorResponse struct {
+	Status string        `json:"status"`
+	Error  string        `json:"error"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// The health of each client, used to decide if a restarted client has recovered
+type ClientHealthResponse struct {
+	Status         string                       `json:"status"`
+	Error          string                       `json:"error"`
+	EcStatus       ExecutionClientManagerStatus `json:"ecStatus"`
+	EcPeers        uint64                       `json:"ecPeers"`
+	EcPeersError   string                       `json:"ecPeersError"`
+	CcSynced       bool                         `json:"ccSynced"`
+	CcSyncProgress float64                      `json:"ccSyncProgress"`
+	CcError        string                       `json:"ccError"`
+	CcPeers        uint64                       `json:"ccPeers"`
+	CcPeersError   string                       `json:"ccPeersError"`
+
+	// Validators that were rewarded for attesting between the previous epoch and this one
+	// They're only checked once the clients are ready, which ValidatorsChecked reports.
+	ValidatorsChecked   bool   `json:"validatorsChecked"`
+	Epoch               uint64 `json:"epoch"`
+	ActiveValidators    uint64 `json:"activeValidators"`
+	AttestingValidators uint64 `json:"attestingValidators"`
+	ValidatorsError     string `json:"validatorsError"`
+}
+
+type CreateBackupResponse struct {
+	Status   string   `json:"status"`
+	Error    string   `json:"error"`
+	Name     string   `json:"name"`
+	Location string   `json:"location"`
+	Files    int      `json:"files"`
+	Notes    []string `json:"notes"`
+}
+
+type NethermindPruningResponse struct {
+	Status        string `json:"status"`
+	Error         string `json:"error"`
+	PruningStatus string `json:"pruningStatus"`
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The result of simulating a transaction against the pending block without signing or sending it
+type TransactionSimulation struct {
+	From         common.Address     `json:"from"`
+	To           common.Address     `json:"to"`
+	Contract     string             `json:"contract"`
+	Method       string             `json:"method"`
+	Value        *big.Int           `json:"value"`
+	Success      bool               `json:"success"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
+	GasFeeCap    *big.Int           `json:"gasFeeCap"`
+	TraceError   string             `json:"traceError"`
+	Events       []SimulatedEvent   `json:"events"`
+	Balances     []SimulatedBalance `json:"balances"`
+	Assumptions  []string           `json:"assumptions"`
+}
+
+// An event the simulated transaction would emit
+type SimulatedEvent struct {
+	Address  common.Address    `json:"address"`
+	Contract string            `json:"contract"`
+	Name     string            `json:"name"`
+	Values   map[string]string `json:"values"`
+}
+
+// A change to an account's ETH or token balance that the simulated transaction would make
+// Token is the zero address for ETH; gas costs are not included
+type SimulatedBalance struct {
+	Account     common.Address `json:"account"`
+	AccountName string         `json:"accountName"`
+	Token       common.Address `json:"token"`
+	TokenName   string         `json:"tokenName"`
+	Delta       *big.Int       `json:"delta"`
+}
@@ -11,6 +11,7 @@ type WalletStatusResponse struct {
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
 	AccountAddress    common.Address `json:"accountAddress"`
+	HardwareWallet    string         `json:"hardwareWallet"`
 }
 
 type SetPasswordResponse struct {
@@ -6,18 +6,33 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
+	fmt.Println(string(EncodeResponse(response, responseError)))
+}
+
+// Encode an API response, setting its status and error fields
+// response must be a pointer to a struct type with Error and Status string fields
+func EncodeResponse(response interface{}, responseError error) []byte {
+
+	// Encode the simulation instead if the command's transaction was simulated in dry-run mode
+	var dryRun *simulation.DryRunError
+	if errors.As(responseError, &dryRun) {
+		response = &api.DryRunResponse{
+			DryRun: &dryRun.Simulation,
+		}
+		responseError = nil
+	}
 
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
-		PrintErrorResponse(errors.New("Invalid API response"))
-		return
+		return EncodeResponse(&api.APIResponse{}, errors.New("Invalid API response"))
 	}
 
 	// Create zero response value if nil
@@ -30,8 +45,7 @@ func PrintResponse(response interface{}, responseError error) {
 	sf := r.Elem().FieldByName("Status")
 	ef := r.Elem().FieldByName("Error")
 	if !(sf.IsValid() && sf.CanSet() && sf.Kind() == reflect.String && ef.IsValid() && ef.CanSet() && ef.Kind() == reflect.String) {
-		PrintErrorResponse(errors.New("Invalid API response"))
-		return
+		return EncodeResponse(&api.APIResponse{}, errors.New("Invalid API response"))
 	}
 
 	// Populate error
@@ -49,12 +63,9 @@ func PrintResponse(response interface{}, responseError error) {
 	// Encode
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
-		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
-		return
+		return EncodeResponse(&api.APIResponse{}, fmt.Errorf("Could not encode API response: %w", err))
 	}
-
-	// Print
-	fmt.Println(string(responseBytes))
+	return responseBytes
 
 }
 
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Print the result of a dry-run transaction simulation
+func PrintTransactionSimulation(simulation api.TransactionSimulation) {
+
+	fmt.Printf("%sDry run: this transaction was simulated against the pending block. It was not signed or sent.%s\n\n", colorYellow, colorReset)
+
+	// Transaction
+	target := simulation.To.Hex()
+	if simulation.Contract != "" {
+		target = fmt.Sprintf("%s (%s)", simulation.Contract, simulation.To.Hex())
+	}
+	fmt.Printf("From:    %s\n", simulation.From.Hex())
+	fmt.Printf("To:      %s\n", target)
+	if simulation.Method != "" {
+		fmt.Printf("Method:  %s\n", simulation.Method)
+	}
+	if simulation.Value != nil && simulation.Value.Sign() > 0 {
+		fmt.Printf("Value:   %.6f ETH\n", math.RoundDown(eth.WeiToEth(simulation.Value), 6))
+	}
+	fmt.Println()
+	if len(simulation.Assumptions) > 0 {
+		fmt.Println("The simulation assumes:")
+		for _, assumption := range simulation.Assumptions {
+			fmt.Printf("\t%s\n", assumption)
+		}
+		fmt.Println()
+	}
+
+	// Result
+	if !simulation.Success {
+		fmt.Printf("%sThe transaction would revert: %s%s\n", colorRed, simulation.RevertReason, colorReset)
+		return
+	}
+	fmt.Printf("%sThe transaction would succeed.%s\n", colorGreen, colorReset)
+	if simulation.GasInfo.EstGasLimit == 0 {
+		fmt.Println("Estimated gas: unavailable")
+	} else {
+		fmt.Printf("Estimated gas: %d (safe limit %d)\n", simulation.GasInfo.EstGasLimit, simulation.GasInfo.SafeGasLimit)
+	}
+	if simulation.GasFeeCap != nil && simulation.GasFeeCap.Sign() > 0 && simulation.GasInfo.SafeGasLimit > 0 {
+		maxCost := big.NewInt(0).Mul(simulation.GasFeeCap, big.NewInt(0).SetUint64(simulation.GasInfo.SafeGasLimit))
+		fmt.Printf("Maximum gas cost: %.6f ETH at a max fee of %.2f gwei\n", math.RoundDown(eth.WeiToEth(maxCost), 6), eth.WeiToGwei(simulation.GasFeeCap))
+	}
+	fmt.Println()
+
+	// State changes
+	if simulation.TraceError != "" {
+		fmt.Printf("%sEvents and balance changes are unavailable: %s%s\n", colorYellow, simulation.TraceError, colorReset)
+		return
+	}
+	if len(simulation.Balances) > 0 {
+		fmt.Println("Balance changes (excluding gas):")
+		for _, balance := range simulation.Balances {
+			sign := "+"
+			if balance.Delta.Sign() < 0 {
+				sign = "-"
+			}
+			amount := big.NewInt(0).Abs(balance.Delta)
+			fmt.Printf("\t%-45s %s%.6f %s\n", balance.AccountName, sign, math.RoundDown(eth.WeiToEth(amount), 6), balance.TokenName)
+		}
+		fmt.Println()
+	}
+	if len(simulation.Events) > 0 {
+		fmt.Println("Events:")
+		for _, event := range simulation.Events {
+			fmt.Printf("\t%s.%s\n", event.Contract, event.Name)
+			names := make([]string, 0, len(event.Values))
+			for name := range event.Values {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf("\t\t%s: %s\n", name, event.Values[name])
+			}
+		}
+	}
+
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 )
 
 const colorReset string = "\033[0m"
@@ -42,6 +44,23 @@ func PrintMultiTransactionNonceWarning() {
 
 }
 
+// Print a reminder to confirm a transaction on the hardware wallet, if the node account is held on one
+func PrintHardwareWalletPrompt(rp *rocketpool.Client) {
+
+	if rp.IsDryRun() {
+		return
+	}
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil || isNew {
+		return
+	}
+	backend := cfg.Smartnode.NodeAccountBackend.Value.(config.NodeAccountBackend)
+	if backend == config.NodeAccountBackend_Ledger || backend == config.NodeAccountBackend_Trezor {
+		fmt.Printf("%sPlease check the transaction details on your %s and confirm it on the device.%s\n", colorYellow, backend, colorReset)
+	}
+
+}
+
 // Implementation of PrintTransactionHash and PrintTransactionHashNoCancel
 func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMessage string) {
 
@@ -96,6 +115,13 @@ var errorMap = map[string]string{
 // Prints an error in a prettier format, removing the "stack trace" if it represents
 // a contract revert message
 func PrettyPrintError(err error) {
+	// Dry runs end with the simulation in place of the transaction
+	var dryRun *simulation.DryRunError
+	if errors.As(err, &dryRun) {
+		PrintTransactionSimulation(dryRun.Simulation)
+		return
+	}
+
 	errorMessage := err.Error()
 	prettyErr := errorMessage
 	if strings.Contains(errorMessage, "execution reverted:") {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/urfave/cli"
 
@@ -107,6 +108,16 @@ func ValidatePercentage(name, value string) (float64, error) {
 	return val, nil
 }
 
+// Validate an RPL collateral ratio
+// Ratios are fractions of the user ETH in a node's minipools, so they can be above 1.
+func ValidateCollateralRatio(name, value string) (float64, error) {
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil || val <= 0 || val > 10 {
+		return 0, fmt.Errorf("Invalid %s '%s' - must be a number greater than 0 and at most 10", name, value)
+	}
+	return val, nil
+}
+
 // Validate a token type
 func ValidateTokenType(name, value string) (string, error) {
 	val := strings.ToLower(value)
@@ -125,6 +136,25 @@ func ValidateProposalType(name, value string) (string, error) {
 	return val, nil
 }
 
+// Validate a watchtower submission type
+func ValidateSubmissionType(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "balances" || val == "prices" || val == "withdrawable" || val == "scrub" || val == "challenge" || val == "vote" || val == "all") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid types are 'balances', 'prices', 'withdrawable', 'scrub', 'challenge', 'vote', and 'all'", name, value)
+	}
+	return val, nil
+}
+
+// Validate a DAO setting category
+func ValidateDAOSettingCategory(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	switch val {
+	case "all", "pdao", "odao", "auction", "deposit", "inflation", "minipool", "network", "node", "rewards", "members", "proposals":
+		return val, nil
+	}
+	return "", fmt.Errorf("Invalid %s '%s' - valid categories are 'all', 'pdao', 'odao', 'auction', 'deposit', 'inflation', 'minipool', 'network', 'node', 'rewards', 'members', and 'proposals'", name, value)
+}
+
 //
 // Command specific types
 //
@@ -266,3 +296,12 @@ func ValidateTxHash(name, value string) (common.Hash, error) {
 	return hash, nil
 
 }
+
+// Validate a validator signature
+func ValidateValidatorSignature(name, value string) (types.ValidatorSignature, error) {
+	signature, err := types.HexToValidatorSignature(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	return signature, nil
+}
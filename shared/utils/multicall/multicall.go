@@ -0,0 +1,159 @@
+package multicall
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The parts of the Multicall3 ABI used here
+const multicall3Abi = `[
+	{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// A call in a Multicall3 aggregate3 batch
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// The result of a call in a Multicall3 aggregate3 batch
+type result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// A call queued on a MultiCaller, which unpacks its return value into the output once the batch has run
+type queuedCall struct {
+	abi    *abi.ABI
+	method string
+	output interface{}
+	name   string
+}
+
+// Runs a batch of read-only contract calls in a single eth_call through the Multicall3 contract
+// Every call is allowed to fail on its own, so one reverting call doesn't prevent the rest from being read.
+// A MultiCaller isn't safe for concurrent use, since the calls are queued on it until the batch is run.
+type MultiCaller struct {
+	client  bind.ContractCaller
+	address common.Address
+	abi     abi.ABI
+	calls   []*queuedCall
+	batch   []call3
+}
+
+// Create a new MultiCaller for the Multicall3 contract at the given address
+func NewMultiCaller(client bind.ContractCaller, address common.Address) (*MultiCaller, error) {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the Multicall3 ABI: %w", err)
+	}
+	return &MultiCaller{
+		client:  client,
+		address: address,
+		abi:     multicallAbi,
+	}, nil
+}
+
+// Queue a contract call; output must be a pointer to a variable of the method's return type
+// The name is used to identify the call in errors.
+func (mc *MultiCaller) AddCall(contract *rocketpool.Contract, output interface{}, name string, method string, args ...interface{}) error {
+	callData, err := contract.ABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("Error encoding the %s call: %w", name, err)
+	}
+	mc.calls = append(mc.calls, &queuedCall{
+		abi:    contract.ABI,
+		method: method,
+		output: output,
+		name:   name,
+	})
+	mc.batch = append(mc.batch, call3{
+		Target:       *contract.Address,
+		AllowFailure: true,
+		CallData:     callData,
+	})
+	return nil
+}
+
+// Queue a call for the ETH balance of an address; output must be a **big.Int
+func (mc *MultiCaller) AddEthBalance(address common.Address, output **big.Int, name string) error {
+	callData, err := mc.abi.Pack("getEthBalance", address)
+	if err != nil {
+		return fmt.Errorf("Error encoding the %s call: %w", name, err)
+	}
+	mc.calls = append(mc.calls, &queuedCall{
+		abi:    &mc.abi,
+		method: "getEthBalance",
+		output: output,
+		name:   name,
+	})
+	mc.batch = append(mc.batch, call3{
+		Target:       mc.address,
+		AllowFailure: true,
+		CallData:     callData,
+	})
+	return nil
+}
+
+// Run the queued calls and unpack their results, then clear the queue
+// The returned map has an error for each call that failed, keyed by name; the error is only returned if the batch itself couldn't be run.
+func (mc *MultiCaller) Execute(opts *bind.CallOpts) (map[string]error, error) {
+
+	calls := mc.calls
+	batch := mc.batch
+	mc.calls = nil
+	mc.batch = nil
+	if len(batch) == 0 {
+		return map[string]error{}, nil
+	}
+
+	// Run the batch
+	callData, err := mc.abi.Pack("aggregate3", batch)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding the multicall batch: %w", err)
+	}
+	var blockNumber *big.Int
+	ctx := context.Background()
+	if opts != nil {
+		blockNumber = opts.BlockNumber
+		if opts.Context != nil {
+			ctx = opts.Context
+		}
+	}
+	response, err := mc.client.CallContract(ctx, ethereum.CallMsg{To: &mc.address, Data: callData}, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Error running the multicall batch: %w", err)
+	}
+	unpacked, err := mc.abi.Unpack("aggregate3", response)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding the multicall batch: %w", err)
+	}
+	results := *abi.ConvertType(unpacked[0], new([]result)).(*[]result)
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("The multicall batch returned %d results for %d calls", len(results), len(calls))
+	}
+
+	// Unpack the results
+	errs := map[string]error{}
+	for i, call := range calls {
+		if !results[i].Success {
+			errs[call.name] = fmt.Errorf("Error getting %s: the call reverted", call.name)
+			continue
+		}
+		if err := call.abi.UnpackIntoInterface(call.output, call.method, results[i].ReturnData); err != nil {
+			errs[call.name] = fmt.Errorf("Error decoding %s: %w", call.name, err)
+		}
+	}
+	return errs, nil
+
+}
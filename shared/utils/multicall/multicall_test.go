@@ -0,0 +1,171 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// A token-like contract to call through the fake Multicall3
+const testAbi = `[
+	{"inputs":[],"name":"totalSupply","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"paused","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"}
+]`
+
+var (
+	multicallAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	tokenAddress     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	holderAddress    = common.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+// A fake Multicall3 that runs each call against canned responses
+type fakeCaller struct {
+	t           *testing.T
+	multicall   abi.ABI
+	token       abi.ABI
+	blockNumber *big.Int
+	fail        bool
+}
+
+func (f *fakeCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if f.fail {
+		return nil, errors.New("connection refused")
+	}
+	f.blockNumber = blockNumber
+	if *call.To != multicallAddress {
+		f.t.Fatalf("called %s instead of the multicall contract", call.To.Hex())
+	}
+	args, err := f.multicall.Methods["aggregate3"].Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	calls := *abi.ConvertType(args[0], new([]call3)).(*[]call3)
+	results := []result{}
+	for _, c := range calls {
+		if !c.AllowFailure {
+			f.t.Fatal("a call doesn't allow failure")
+		}
+		results = append(results, f.run(c))
+	}
+	return f.multicall.Methods["aggregate3"].Outputs.Pack(results)
+}
+
+func (f *fakeCaller) run(c call3) result {
+	pack := func(abi abi.ABI, method string, value interface{}) result {
+		data, err := abi.Methods[method].Outputs.Pack(value)
+		if err != nil {
+			f.t.Fatal(err)
+		}
+		return result{Success: true, ReturnData: data}
+	}
+	if c.Target == multicallAddress {
+		return pack(f.multicall, "getEthBalance", big.NewInt(5))
+	}
+	method, err := f.token.MethodById(c.CallData[:4])
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	switch method.Name {
+	case "totalSupply":
+		return pack(f.token, method.Name, big.NewInt(1000))
+	case "balanceOf":
+		return pack(f.token, method.Name, big.NewInt(7))
+	case "paused":
+		// A call that returns nothing, like one to an address without code
+		return result{Success: true}
+	}
+	return result{Success: false}
+}
+
+func TestMultiCaller(t *testing.T) {
+
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenAbi, err := abi.JSON(strings.NewReader(testAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &rocketpool.Contract{Address: &tokenAddress, ABI: &tokenAbi}
+
+	tests := []struct {
+		name      string
+		method    string
+		args      []interface{}
+		expected  *big.Int
+		shouldErr bool
+	}{
+		{name: "no arguments", method: "totalSupply", expected: big.NewInt(1000)},
+		{name: "with arguments", method: "balanceOf", args: []interface{}{holderAddress}, expected: big.NewInt(7)},
+		{name: "empty return data", method: "paused", shouldErr: true},
+	}
+
+	caller := &fakeCaller{t: t, multicall: multicallAbi, token: tokenAbi}
+	mc, err := NewMultiCaller(caller, multicallAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := make([]*big.Int, len(tests))
+	var paused bool
+	for i, test := range tests {
+		var output interface{} = &outputs[i]
+		if test.method == "paused" {
+			output = &paused
+		}
+		if err := mc.AddCall(token, output, test.name, test.method, test.args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var ethBalance *big.Int
+	if err := mc.AddEthBalance(holderAddress, &ethBalance, "ETH balance"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.AddCall(token, new(*big.Int), "bad arguments", "balanceOf"); err == nil {
+		t.Fatal("expected an error queueing a call with missing arguments")
+	}
+
+	errs, err := mc.Execute(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, test := range tests {
+		if test.shouldErr != (errs[test.name] != nil) {
+			t.Fatalf("%s: expected failure: %t, got error: %v", test.name, test.shouldErr, errs[test.name])
+		}
+		if test.expected != nil && outputs[i].Cmp(test.expected) != 0 {
+			t.Fatalf("%s: got %s, expected %s", test.name, outputs[i], test.expected)
+		}
+	}
+	if ethBalance.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("got ETH balance %s", ethBalance)
+	}
+
+	// The queue is cleared after running
+	errs, err = mc.Execute(nil)
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("expected an empty batch, got %v, %v", errs, err)
+	}
+
+	// A failed batch is an error for the whole batch
+	caller.fail = true
+	if err := mc.AddCall(token, &outputs[0], "total supply", "totalSupply"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Execute(nil); err == nil {
+		t.Fatal("expected an error running the batch")
+	}
+
+}
@@ -0,0 +1,171 @@
+package rp
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/auction"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The limits automatic auction bids are placed within
+type AuctionBidStrategy struct {
+	MinDiscount float64  // The fraction below the oracle RPL price a lot's price must be to bid on it
+	LotBudget   *big.Int // The most ETH to bid on a single lot
+	TotalBudget *big.Int // The most ETH to hold in bids on lots that haven't been claimed yet
+}
+
+// The bids and claims the strategy would make
+type AuctionBidPlan struct {
+	BiddingEnabled bool
+	OraclePrice    *big.Int
+	OpenBids       *big.Int
+	Bids           []api.AuctionLotBid
+	SkippedLots    []api.AuctionLotBid
+	ClaimableLots  []uint64
+}
+
+// Plan the bids a node should place on open lots, and the finished lots it should claim
+// Lots are bid on in order of their discount, so the budget goes to the cheapest RPL first.
+// Bids are sized at the lot's current price; a lot's final price can only be lower, so the RPL received is at least the expected amount.
+func PlanAuctionBids(rp *rocketpool.RocketPool, bidder common.Address, strategy AuctionBidStrategy, opts *bind.CallOpts) (AuctionBidPlan, error) {
+
+	// Data
+	var wg errgroup.Group
+	var lots []auction.LotDetails
+	var currentBlock uint64
+	var oraclePrice *big.Int
+	var biddingEnabled bool
+
+	// Get data
+	wg.Go(func() error {
+		var err error
+		lots, err = auction.GetLotsWithBids(rp, bidder, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		currentBlock, err = rp.Client.BlockNumber(context.Background())
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		oraclePrice, err = network.GetRPLPrice(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		biddingEnabled, err = protocol.GetBidOnLotEnabled(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return AuctionBidPlan{}, err
+	}
+
+	// Return
+	return planAuctionBids(lots, currentBlock, oraclePrice, biddingEnabled, strategy), nil
+
+}
+
+// Plan the bids and claims for a bidder's view of the lots
+func planAuctionBids(lots []auction.LotDetails, currentBlock uint64, oraclePrice *big.Int, biddingEnabled bool, strategy AuctionBidStrategy) AuctionBidPlan {
+
+	plan := AuctionBidPlan{
+		BiddingEnabled: biddingEnabled,
+		OraclePrice:    oraclePrice,
+		OpenBids:       big.NewInt(0),
+		Bids:           []api.AuctionLotBid{},
+		SkippedLots:    []api.AuctionLotBid{},
+		ClaimableLots:  []uint64{},
+	}
+
+	// Find the lots that are open for bidding and the ones that can be claimed
+	candidates := []api.AuctionLotBid{}
+	for _, lot := range lots {
+		hasBid := lot.AddressBidAmount.Sign() > 0
+		if hasBid {
+			plan.OpenBids.Add(plan.OpenBids, lot.AddressBidAmount)
+		}
+		if lot.Cleared {
+			if hasBid {
+				plan.ClaimableLots = append(plan.ClaimableLots, lot.Index)
+			}
+			continue
+		}
+		if currentBlock >= lot.EndBlock || lot.RemainingRPLAmount.Sign() == 0 || lot.CurrentPrice.Sign() == 0 {
+			continue
+		}
+		candidates = append(candidates, api.AuctionLotBid{
+			LotIndex:     lot.Index,
+			CurrentPrice: lot.CurrentPrice,
+			Discount:     GetAuctionDiscount(lot.CurrentPrice, plan.OraclePrice),
+			RemainingRpl: lot.RemainingRPLAmount,
+			ExistingBid:  lot.AddressBidAmount,
+			BidAmount:    big.NewInt(0),
+			ExpectedRpl:  big.NewInt(0),
+			RplPerEth:    1 / eth.WeiToEth(lot.CurrentPrice),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Discount > candidates[j].Discount
+	})
+
+	// Size the bids within the budgets
+	totalRemaining := new(big.Int).Sub(strategy.TotalBudget, plan.OpenBids)
+	for _, bid := range candidates {
+		if !plan.BiddingEnabled || bid.Discount < strategy.MinDiscount {
+			plan.SkippedLots = append(plan.SkippedLots, bid)
+			continue
+		}
+
+		// Bid the smallest of the lot budget, the total budget and the value of the RPL left in the lot
+		amount := new(big.Int).Sub(strategy.LotBudget, bid.ExistingBid)
+		if totalRemaining.Cmp(amount) < 0 {
+			amount.Set(totalRemaining)
+		}
+		lotValue := new(big.Int).Mul(bid.RemainingRpl, bid.CurrentPrice)
+		lotValue.Div(lotValue, eth.EthToWei(1))
+		if lotValue.Cmp(amount) < 0 {
+			amount.Set(lotValue)
+		}
+		if amount.Sign() <= 0 {
+			plan.SkippedLots = append(plan.SkippedLots, bid)
+			continue
+		}
+
+		bid.BidAmount = amount
+		bid.ExpectedRpl = GetAuctionBidRpl(amount, bid.CurrentPrice)
+		totalRemaining.Sub(totalRemaining, amount)
+		plan.Bids = append(plan.Bids, bid)
+	}
+	return plan
+
+}
+
+// Get the fraction a lot's price is below the oracle RPL price; negative if it's above it
+func GetAuctionDiscount(lotPrice *big.Int, oraclePrice *big.Int) float64 {
+	if oraclePrice.Sign() == 0 {
+		return 0
+	}
+	return 1 - eth.WeiToEth(lotPrice)/eth.WeiToEth(oraclePrice)
+}
+
+// Get the RPL a bid buys at a lot price
+func GetAuctionBidRpl(bidAmount *big.Int, lotPrice *big.Int) *big.Int {
+	if lotPrice.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	rpl := new(big.Int).Mul(bidAmount, eth.EthToWei(1))
+	return rpl.Div(rpl, lotPrice)
+}
@@ -0,0 +1,155 @@
+package rp
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/auction"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Make an open lot at a price in ETH per RPL, with the RPL left in it and the bidder's existing bid in ETH
+func openLot(index uint64, price float64, remainingRpl float64, existingBid float64) auction.LotDetails {
+	return auction.LotDetails{
+		Index:              index,
+		EndBlock:           200,
+		CurrentPrice:       eth.EthToWei(price),
+		RemainingRPLAmount: eth.EthToWei(remainingRpl),
+		AddressBidAmount:   eth.EthToWei(existingBid),
+	}
+}
+
+func TestPlanAuctionBids(t *testing.T) {
+
+	strategy := AuctionBidStrategy{
+		MinDiscount: 0.05,
+		LotBudget:   eth.EthToWei(2),
+		TotalBudget: eth.EthToWei(5),
+	}
+	cleared := openLot(9, 0.009, 0, 1)
+	cleared.Cleared = true
+	ended := openLot(8, 0.005, 1000, 0)
+	ended.EndBlock = 100
+
+	tests := []struct {
+		name           string
+		lots           []auction.LotDetails
+		biddingEnabled bool
+		bids           map[uint64]float64
+		skipped        []uint64
+		claimable      []uint64
+	}{
+		{
+			name:           "bids the cheapest lots first",
+			lots:           []auction.LotDetails{openLot(1, 0.009, 1000, 0), openLot(2, 0.008, 1000, 0)},
+			biddingEnabled: true,
+			bids:           map[uint64]float64{2: 2, 1: 2},
+			skipped:        []uint64{},
+		},
+		{
+			name:           "skips lots without enough discount",
+			lots:           []auction.LotDetails{openLot(1, 0.0096, 1000, 0), openLot(2, 0.0095, 1000, 0)},
+			biddingEnabled: true,
+			bids:           map[uint64]float64{2: 2},
+			skipped:        []uint64{1},
+		},
+		{
+			name:           "skips everything when bidding is disabled",
+			lots:           []auction.LotDetails{openLot(1, 0.008, 1000, 0)},
+			biddingEnabled: false,
+			bids:           map[uint64]float64{},
+			skipped:        []uint64{1},
+		},
+		{
+			name:           "tops up an existing bid to the lot budget",
+			lots:           []auction.LotDetails{openLot(1, 0.008, 1000, 1.5)},
+			biddingEnabled: true,
+			bids:           map[uint64]float64{1: 0.5},
+			skipped:        []uint64{},
+		},
+		{
+			name:           "open bids count against the total budget",
+			lots:           []auction.LotDetails{openLot(1, 0.008, 1000, 2), openLot(2, 0.007, 1000, 2), openLot(3, 0.006, 1000, 0)},
+			biddingEnabled: true,
+			bids:           map[uint64]float64{3: 1},
+			skipped:        []uint64{2, 1},
+		},
+		{
+			name:           "bids no more than the RPL left is worth",
+			lots:           []auction.LotDetails{openLot(1, 0.008, 50, 0)},
+			biddingEnabled: true,
+			bids:           map[uint64]float64{1: 0.4},
+			skipped:        []uint64{},
+		},
+		{
+			name:           "claims cleared lots and ignores ended ones",
+			lots:           []auction.LotDetails{cleared, ended},
+			biddingEnabled: true,
+			bids:           map[uint64]float64{},
+			skipped:        []uint64{},
+			claimable:      []uint64{9},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := planAuctionBids(test.lots, 150, eth.EthToWei(0.01), test.biddingEnabled, strategy)
+
+			// Check the bids are in discount order and sized correctly
+			if len(plan.Bids) != len(test.bids) {
+				t.Fatalf("placed %d bids, expected %d", len(plan.Bids), len(test.bids))
+			}
+			for i, bid := range plan.Bids {
+				expected, exists := test.bids[bid.LotIndex]
+				if !exists {
+					t.Fatalf("bid on lot %d", bid.LotIndex)
+				}
+				if !closeToEth(bid.BidAmount, expected) {
+					t.Fatalf("bid %f ETH on lot %d, expected %f", eth.WeiToEth(bid.BidAmount), bid.LotIndex, expected)
+				}
+				if i > 0 && bid.Discount > plan.Bids[i-1].Discount {
+					t.Fatal("bids aren't ordered by discount")
+				}
+			}
+
+			// Check the skipped and claimable lots
+			skipped := []uint64{}
+			for _, lot := range plan.SkippedLots {
+				skipped = append(skipped, lot.LotIndex)
+			}
+			if !reflect.DeepEqual(skipped, test.skipped) {
+				t.Fatalf("skipped lots %v, expected %v", skipped, test.skipped)
+			}
+			claimable := test.claimable
+			if claimable == nil {
+				claimable = []uint64{}
+			}
+			if !reflect.DeepEqual(plan.ClaimableLots, claimable) {
+				t.Fatalf("claimable lots %v, expected %v", plan.ClaimableLots, claimable)
+			}
+		})
+	}
+
+}
+
+func TestGetAuctionBidRpl(t *testing.T) {
+	tests := []struct {
+		name     string
+		bid      *big.Int
+		lotPrice *big.Int
+		rpl      *big.Int
+	}{
+		{"whole tokens", eth.EthToWei(1), eth.EthToWei(0.01), eth.EthToWei(100)},
+		{"rounds down", big.NewInt(10), big.NewInt(3), big.NewInt(3333333333333333333)},
+		{"no price", eth.EthToWei(1), big.NewInt(0), big.NewInt(0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rpl := GetAuctionBidRpl(test.bid, test.lotPrice)
+			if rpl.Cmp(test.rpl) != 0 {
+				t.Fatalf("bid buys %s, expected %s", rpl, test.rpl)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package rp
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+)
+
+// The amount of user ETH in each minipool that RPL collateral is measured against
+const MinipoolUserDepositEth float64 = 16
+
+// A node's RPL collateral and the network settings that bound it
+type NodeCollateral struct {
+	RplStake        *big.Int
+	RplPrice        *big.Int
+	ActiveMinipools uint64
+	MinimumRatio    float64
+	MaximumRatio    float64
+}
+
+// Get a node's RPL collateral
+func GetNodeCollateral(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (NodeCollateral, error) {
+
+	// Data
+	var wg errgroup.Group
+	collateral := NodeCollateral{}
+
+	// Get the node's stake
+	wg.Go(func() error {
+		var err error
+		collateral.RplStake, err = node.GetNodeRPLStake(rp, nodeAddress, opts)
+		return err
+	})
+
+	// Get the RPL price
+	wg.Go(func() error {
+		var err error
+		collateral.RplPrice, err = network.GetRPLPrice(rp, opts)
+		return err
+	})
+
+	// Get the node's active minipools
+	wg.Go(func() error {
+		var err error
+		collateral.ActiveMinipools, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAddress, opts)
+		return err
+	})
+
+	// Get the collateral bounds
+	wg.Go(func() error {
+		var err error
+		collateral.MinimumRatio, err = protocol.GetMinimumPerMinipoolStake(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		collateral.MaximumRatio, err = protocol.GetMaximumPerMinipoolStake(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return NodeCollateral{}, err
+	}
+	return collateral, nil
+
+}
+
+// Get the ratio of the value of a node's staked RPL to the user ETH in its minipools, at the given RPL price
+// Returns -1 if the node has no active minipools.
+func GetCollateralRatio(rplStake *big.Int, rplPrice *big.Int, activeMinipools uint64) float64 {
+	if activeMinipools == 0 {
+		return -1
+	}
+	return eth.WeiToEth(rplPrice) * eth.WeiToEth(rplStake) / (float64(activeMinipools) * MinipoolUserDepositEth)
+}
+
+// Get the total RPL stake that gives a node the target collateral ratio at the given RPL price
+func GetRplStakeForCollateralRatio(ratio float64, rplPrice *big.Int, activeMinipools uint64) *big.Int {
+	if rplPrice.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	collateralValue := eth.EthToWei(ratio * float64(activeMinipools) * MinipoolUserDepositEth)
+	stake := new(big.Int).Mul(collateralValue, eth.EthToWei(1))
+	return stake.Div(stake, rplPrice)
+}
+
+// Get the RPL price at which a node's stake gives it the target collateral ratio
+func GetRplPriceForCollateralRatio(ratio float64, rplStake *big.Int, activeMinipools uint64) *big.Int {
+	if rplStake.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	collateralValue := eth.EthToWei(ratio * float64(activeMinipools) * MinipoolUserDepositEth)
+	price := new(big.Int).Mul(collateralValue, eth.EthToWei(1))
+	return price.Div(price, rplStake)
+}
+
+// Get the additional RPL a node needs to stake to reach the target collateral ratio, or 0 if it's already there
+func GetRplToStakeForCollateralRatio(collateral NodeCollateral, ratio float64) *big.Int {
+	required := GetRplStakeForCollateralRatio(ratio, collateral.RplPrice, collateral.ActiveMinipools)
+	if required.Cmp(collateral.RplStake) <= 0 {
+		return big.NewInt(0)
+	}
+	return required.Sub(required, collateral.RplStake)
+}
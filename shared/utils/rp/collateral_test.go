@@ -0,0 +1,97 @@
+package rp
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Check that a wei amount is within a gwei of an amount in whole tokens, since the ratios are calculated with floats
+func closeToEth(wei *big.Int, amount float64) bool {
+	return math.Abs(eth.WeiToEth(wei)-amount) < 1e-9
+}
+
+func TestGetCollateralRatio(t *testing.T) {
+	tests := []struct {
+		name            string
+		rplStake        float64
+		rplPrice        float64
+		activeMinipools uint64
+		ratio           float64
+	}{
+		{"no minipools", 1000, 0.01, 0, -1},
+		{"minimum", 160, 0.01, 1, 0.1},
+		{"maximum", 4800, 0.01, 1, 3},
+		{"several minipools", 640, 0.01, 4, 0.1},
+		{"no stake", 0, 0.01, 2, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ratio := GetCollateralRatio(eth.EthToWei(test.rplStake), eth.EthToWei(test.rplPrice), test.activeMinipools)
+			if math.Abs(ratio-test.ratio) > 1e-9 {
+				t.Fatalf("ratio was %f, expected %f", ratio, test.ratio)
+			}
+		})
+	}
+}
+
+func TestCollateralRatioTargets(t *testing.T) {
+	tests := []struct {
+		name            string
+		ratio           float64
+		rplPrice        float64
+		rplStake        float64
+		activeMinipools uint64
+		requiredStake   float64
+		targetPrice     float64
+	}{
+		{"minimum ratio", 0.1, 0.01, 160, 1, 160, 0.01},
+		{"maximum ratio", 1.5, 0.02, 1200, 2, 2400, 0.04},
+		{"no minipools", 0.1, 0.01, 100, 0, 0, 0},
+		{"no price", 0.1, 0, 100, 1, 0, 0.016},
+		{"no stake", 0.1, 0.01, 0, 1, 160, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stake := GetRplStakeForCollateralRatio(test.ratio, eth.EthToWei(test.rplPrice), test.activeMinipools)
+			if !closeToEth(stake, test.requiredStake) {
+				t.Fatalf("required stake was %f RPL, expected %f", eth.WeiToEth(stake), test.requiredStake)
+			}
+			price := GetRplPriceForCollateralRatio(test.ratio, eth.EthToWei(test.rplStake), test.activeMinipools)
+			if !closeToEth(price, test.targetPrice) {
+				t.Fatalf("target price was %f ETH, expected %f", eth.WeiToEth(price), test.targetPrice)
+			}
+		})
+	}
+}
+
+func TestGetRplToStakeForCollateralRatio(t *testing.T) {
+	tests := []struct {
+		name            string
+		rplStake        float64
+		activeMinipools uint64
+		ratio           float64
+		toStake         float64
+	}{
+		{"below target", 100, 1, 0.1, 60},
+		{"at target", 160, 1, 0.1, 0},
+		{"above target", 500, 1, 0.1, 0},
+		{"top up several minipools", 320, 3, 0.15, 400},
+		{"no minipools", 0, 0, 0.1, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collateral := NodeCollateral{
+				RplStake:        eth.EthToWei(test.rplStake),
+				RplPrice:        eth.EthToWei(0.01),
+				ActiveMinipools: test.activeMinipools,
+			}
+			amount := GetRplToStakeForCollateralRatio(collateral, test.ratio)
+			if !closeToEth(amount, test.toStake) {
+				t.Fatalf("would stake %f RPL, expected %f", eth.WeiToEth(amount), test.toStake)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package rp
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// A DAO setting update made by an executed proposal
+// Bool values are 1 for true and 0 for false.
+type DAOSettingChange struct {
+	ProposalID   uint64      `json:"proposalId"`
+	DAO          string      `json:"dao"`
+	ContractName string      `json:"contractName"`
+	Path         string      `json:"path"`
+	Value        *big.Int    `json:"value"`
+	Time         time.Time   `json:"time"`
+	BlockNumber  uint64      `json:"blockNumber"`
+	TxHash       common.Hash `json:"txHash"`
+}
+
+// Decode a proposal's payload, returning the setting update it makes if it's a setting proposal
+// The execution time, block and transaction aren't set, since they come from the proposal's ProposalExecuted event.
+// Settings changed by the guardian while the protocol DAO is in bootstrap mode aren't made by proposals, so they have no setting change to find.
+func GetProposalSettingChange(rp *rocketpool.RocketPool, proposalId uint64) (DAOSettingChange, bool, error) {
+
+	// Get the proposal's DAO and payload
+	daoName, err := dao.GetProposalDAO(rp, proposalId, nil)
+	if err != nil {
+		return DAOSettingChange{}, false, fmt.Errorf("Error getting the DAO for proposal %d: %w", proposalId, err)
+	}
+	payload, err := dao.GetProposalPayload(rp, proposalId, nil)
+	if err != nil {
+		return DAOSettingChange{}, false, fmt.Errorf("Error getting the payload for proposal %d: %w", proposalId, err)
+	}
+
+	// Decode the payload
+	decoded, err := DecodeProposalPayload(rp, daoName, payload)
+	if err != nil {
+		return DAOSettingChange{}, false, fmt.Errorf("Error decoding the payload for proposal %d: %w", proposalId, err)
+	}
+	if decoded.Action != ProposalAction_Setting {
+		return DAOSettingChange{}, false, nil
+	}
+	contractName, path, value, err := decoded.GetSettingChange()
+	if err != nil {
+		return DAOSettingChange{}, false, fmt.Errorf("Error decoding the setting change in proposal %d: %w", proposalId, err)
+	}
+
+	return DAOSettingChange{
+		ProposalID:   proposalId,
+		DAO:          daoName,
+		ContractName: contractName,
+		Path:         path,
+		Value:        value,
+	}, true, nil
+
+}
+
+// Check whether the protocol DAO is still in bootstrap mode, where the guardian changes its settings directly instead of by proposal
+func GetProtocolDAOBootstrapMode(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	rocketDAOProtocol, err := rp.GetContract("rocketDAOProtocol")
+	if err != nil {
+		return false, err
+	}
+	disabled := new(bool)
+	if err := rocketDAOProtocol.Call(opts, disabled, "getBootstrapModeDisabled"); err != nil {
+		return false, fmt.Errorf("Could not get protocol DAO bootstrap mode status: %w", err)
+	}
+	return !*disabled, nil
+}
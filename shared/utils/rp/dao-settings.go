@@ -0,0 +1,757 @@
+package rp
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The DAOs that own Rocket Pool's settings
+const (
+	DAOProtocol    string = "pdao"
+	DAOTrustedNode string = "odao"
+)
+
+// The unit a DAO setting is expressed in
+type DAOSettingUnit string
+
+const (
+	// A percentage, stored on-chain as a fraction scaled by 1e18
+	DAOSettingUnitPercent DAOSettingUnit = "percent"
+	// An amount of RPL, stored on-chain in wei
+	DAOSettingUnitRpl DAOSettingUnit = "RPL"
+	// An amount of ETH, stored on-chain in wei
+	DAOSettingUnitEth DAOSettingUnit = "ETH"
+	// A plain number
+	DAOSettingUnitCount DAOSettingUnit = "count"
+	// A length of time, stored on-chain in seconds
+	DAOSettingUnitDuration DAOSettingUnit = "duration"
+	// A true / false flag
+	DAOSettingUnitBool DAOSettingUnit = "bool"
+	// A number of blocks
+	DAOSettingUnitBlocks DAOSettingUnit = "blocks"
+	// A unix timestamp
+	DAOSettingUnitTimestamp DAOSettingUnit = "timestamp"
+	// A decimal ratio, stored on-chain scaled by 1e18
+	DAOSettingUnitRatio DAOSettingUnit = "ratio"
+)
+
+// A Rocket Pool DAO setting
+type DAOSetting struct {
+	// The name used to refer to the setting on the command line
+	Key string
+	// Alternative names for the setting on the command line
+	Aliases []string
+	// The DAO that owns the setting
+	DAO string
+	// The group of related settings the setting belongs to
+	Category string
+	// The settings contract that holds the setting
+	ContractName string
+	// The setting's path within its contract
+	Path string
+	// The contract method used to read the setting and its arguments, if it isn't read by path
+	Getter     string
+	GetterArgs []interface{}
+	// The unit of the setting's value
+	Unit DAOSettingUnit
+	// The lowest allowed on-chain value, or nil if unbounded
+	Min *big.Int
+	// The highest allowed on-chain value, or nil if unbounded
+	Max *big.Int
+	// A description of what the setting controls
+	Description string
+}
+
+// All of the oracle DAO settings, which can be changed by proposal
+// These cover the members, minipool and proposals settings contracts, which are all of the oracle DAO settings rocketpool-go binds; it has no oracle DAO rewards settings contract
+var TNDAOSettings = []DAOSetting{
+
+	// Members
+	{
+		Key:          "members-quorum",
+		Aliases:      []string{"q"},
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.QuorumSettingPath,
+		Unit:         DAOSettingUnitPercent,
+		Min:          eth.EthToWei(0.51),
+		Max:          eth.EthToWei(0.75),
+		Description:  "The percentage of members that must vote for a proposal for it to pass.",
+	},
+	{
+		Key:          "members-rplbond",
+		Aliases:      []string{"b"},
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.RPLBondSettingPath,
+		Unit:         DAOSettingUnitRpl,
+		Description:  "The amount of RPL a new member must bond to join the oracle DAO.",
+	},
+	{
+		Key:          "members-minipool-unbonded-max",
+		Aliases:      []string{"u"},
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.MinipoolUnbondedMaxSettingPath,
+		Unit:         DAOSettingUnitCount,
+		Description:  "The maximum number of unbonded minipools a member can run.",
+	},
+	{
+		Key:          "members-minipool-unbonded-min-fee",
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.MinipoolUnbondedMinFeeSettingPath,
+		Unit:         DAOSettingUnitPercent,
+		Min:          big.NewInt(0),
+		Max:          eth.EthToWei(1),
+		Description:  "The minimum commission rate a member's unbonded minipools must be created at.",
+	},
+	{
+		Key:          "members-challenge-cooldown",
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.ChallengeCooldownSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Description:  "How long a member must wait between making challenges.",
+	},
+	{
+		Key:          "members-challenge-window",
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.ChallengeWindowSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Min:          big.NewInt(1),
+		Description:  "How long a challenged member has to respond before they can be removed.",
+	},
+	{
+		Key:          "members-challenge-cost",
+		DAO:          DAOTrustedNode,
+		Category:     "members",
+		ContractName: trustednode.MembersSettingsContractName,
+		Path:         trustednode.ChallengeCostSettingPath,
+		Unit:         DAOSettingUnitEth,
+		Description:  "The amount of ETH a non-member must pay to challenge a member.",
+	},
+
+	// Minipools
+	{
+		Key:          "scrub-period",
+		Aliases:      []string{"s"},
+		DAO:          DAOTrustedNode,
+		Category:     "minipool",
+		ContractName: trustednode.MinipoolSettingsContractName,
+		Path:         trustednode.ScrubPeriodPath,
+		Unit:         DAOSettingUnitDuration,
+		Description:  "How long new minipools wait in prelaunch for the scrub check before they can be staked. Must be at least an hour shorter than the protocol's minipool launch timeout.",
+	},
+	{
+		Key:          "scrub-penalty-enabled",
+		DAO:          DAOTrustedNode,
+		Category:     "minipool",
+		ContractName: trustednode.MinipoolSettingsContractName,
+		Path:         trustednode.ScrubPenaltyEnabledPath,
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether scrubbed minipools have part of their node operator's RPL stake slashed.",
+	},
+
+	// Proposals
+	{
+		Key:          "proposal-cooldown",
+		Aliases:      []string{"c"},
+		DAO:          DAOTrustedNode,
+		Category:     "proposals",
+		ContractName: trustednode.ProposalsSettingsContractName,
+		Path:         trustednode.CooldownTimeSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Description:  "How long a member must wait between making proposals.",
+	},
+	{
+		Key:          "proposal-vote-timespan",
+		Aliases:      []string{"v"},
+		DAO:          DAOTrustedNode,
+		Category:     "proposals",
+		ContractName: trustednode.ProposalsSettingsContractName,
+		Path:         trustednode.VoteTimeSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Min:          big.NewInt(1),
+		Description:  "How long a proposal can be voted on.",
+	},
+	{
+		Key:          "proposal-vote-delay-timespan",
+		Aliases:      []string{"d"},
+		DAO:          DAOTrustedNode,
+		Category:     "proposals",
+		ContractName: trustednode.ProposalsSettingsContractName,
+		Path:         trustednode.VoteDelayTimeSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Description:  "How long after a proposal is made before voting on it opens.",
+	},
+	{
+		Key:          "proposal-execute-timespan",
+		Aliases:      []string{"x"},
+		DAO:          DAOTrustedNode,
+		Category:     "proposals",
+		ContractName: trustednode.ProposalsSettingsContractName,
+		Path:         trustednode.ExecuteTimeSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Min:          big.NewInt(1),
+		Description:  "How long a passed proposal can be executed for before it expires.",
+	},
+	{
+		Key:          "proposal-action-timespan",
+		Aliases:      []string{"a"},
+		DAO:          DAOTrustedNode,
+		Category:     "proposals",
+		ContractName: trustednode.ProposalsSettingsContractName,
+		Path:         trustednode.ActionTimeSettingPath,
+		Unit:         DAOSettingUnitDuration,
+		Min:          big.NewInt(1),
+		Description:  "How long an executed invite or leave proposal can be acted on before it expires.",
+	},
+}
+
+// All of the protocol DAO settings
+var PDAOSettings = []DAOSetting{
+
+	// Auctions
+	{
+		Key:          "auction-lot-create-enabled",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.lot.create.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether new RPL auction lots can be created.",
+	},
+	{
+		Key:          "auction-lot-bidding-enabled",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.lot.bidding.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether bids can be placed on RPL auction lots.",
+	},
+	{
+		Key:          "auction-lot-value-minimum",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.lot.value.minimum",
+		Unit:         DAOSettingUnitEth,
+		Description:  "The minimum value of the RPL in a new auction lot.",
+	},
+	{
+		Key:          "auction-lot-value-maximum",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.lot.value.maximum",
+		Unit:         DAOSettingUnitEth,
+		Description:  "The maximum value of the RPL in a new auction lot.",
+	},
+	{
+		Key:          "auction-lot-duration",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.lot.duration",
+		Unit:         DAOSettingUnitBlocks,
+		Description:  "How long an auction lot stays open for bidding.",
+	},
+	{
+		Key:          "auction-price-start",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.price.start",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The starting price of an auction lot, relative to the RPL price.",
+	},
+	{
+		Key:          "auction-price-reserve",
+		DAO:          DAOProtocol,
+		Category:     "auction",
+		ContractName: protocol.AuctionSettingsContractName,
+		Path:         "auction.price.reserve",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The reserve price of an auction lot, relative to the RPL price.",
+	},
+
+	// Deposits
+	{
+		Key:          "deposit-enabled",
+		DAO:          DAOProtocol,
+		Category:     "deposit",
+		ContractName: protocol.DepositSettingsContractName,
+		Path:         "deposit.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether users can deposit ETH into the deposit pool.",
+	},
+	{
+		Key:          "deposit-assign-enabled",
+		DAO:          DAOProtocol,
+		Category:     "deposit",
+		ContractName: protocol.DepositSettingsContractName,
+		Path:         "deposit.assign.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether deposited ETH is assigned to minipools in the queue.",
+	},
+	{
+		Key:          "deposit-minimum",
+		DAO:          DAOProtocol,
+		Category:     "deposit",
+		ContractName: protocol.DepositSettingsContractName,
+		Path:         "deposit.minimum",
+		Unit:         DAOSettingUnitEth,
+		Description:  "The smallest ETH deposit a user can make.",
+	},
+	{
+		Key:          "deposit-pool-maximum",
+		DAO:          DAOProtocol,
+		Category:     "deposit",
+		ContractName: protocol.DepositSettingsContractName,
+		Path:         "deposit.pool.maximum",
+		Unit:         DAOSettingUnitEth,
+		Description:  "The maximum amount of ETH the deposit pool can hold.",
+	},
+	{
+		Key:          "deposit-assign-maximum",
+		DAO:          DAOProtocol,
+		Category:     "deposit",
+		ContractName: protocol.DepositSettingsContractName,
+		Path:         "deposit.assign.maximum",
+		Unit:         DAOSettingUnitCount,
+		Description:  "The maximum number of minipools a single deposit can assign ETH to.",
+	},
+
+	// Inflation
+	{
+		Key:          "inflation-interval-rate",
+		DAO:          DAOProtocol,
+		Category:     "inflation",
+		ContractName: protocol.InflationSettingsContractName,
+		Path:         "rpl.inflation.interval.rate",
+		Unit:         DAOSettingUnitRatio,
+		Description:  "The factor the RPL supply is multiplied by each inflation interval.",
+	},
+	{
+		Key:          "inflation-interval-start",
+		DAO:          DAOProtocol,
+		Category:     "inflation",
+		ContractName: protocol.InflationSettingsContractName,
+		Path:         "rpl.inflation.interval.start",
+		Unit:         DAOSettingUnitTimestamp,
+		Description:  "When RPL inflation started.",
+	},
+
+	// Minipools
+	{
+		Key:          "minipool-submit-withdrawable-enabled",
+		DAO:          DAOProtocol,
+		Category:     "minipool",
+		ContractName: protocol.MinipoolSettingsContractName,
+		Path:         "minipool.submit.withdrawable.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether the oracle DAO can mark minipools as withdrawable.",
+	},
+	{
+		Key:          "minipool-launch-timeout",
+		DAO:          DAOProtocol,
+		Category:     "minipool",
+		ContractName: protocol.MinipoolSettingsContractName,
+		Path:         "minipool.launch.timeout",
+		Unit:         DAOSettingUnitDuration,
+		Description:  "How long a prelaunch minipool can wait to be staked before it can be dissolved.",
+	},
+
+	// Network
+	{
+		Key:          "network-consensus-threshold",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.consensus.threshold",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The percentage of oracle DAO members that must submit the same network update for it to be accepted.",
+	},
+	{
+		Key:          "network-submit-balances-enabled",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.submit.balances.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether the oracle DAO submits network balances.",
+	},
+	{
+		Key:          "network-submit-balances-frequency",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.submit.balances.frequency",
+		Unit:         DAOSettingUnitBlocks,
+		Description:  "How often the oracle DAO submits network balances.",
+	},
+	{
+		Key:          "network-submit-prices-enabled",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.submit.prices.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether the oracle DAO submits the RPL price.",
+	},
+	{
+		Key:          "network-submit-prices-frequency",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.submit.prices.frequency",
+		Unit:         DAOSettingUnitBlocks,
+		Description:  "How often the oracle DAO submits the RPL price.",
+	},
+	{
+		Key:          "network-node-fee-minimum",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.node.fee.minimum",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The lowest commission rate new minipools can be created with.",
+	},
+	{
+		Key:          "network-node-fee-target",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.node.fee.target",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The commission rate new minipools are created with when deposit demand is balanced.",
+	},
+	{
+		Key:          "network-node-fee-maximum",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.node.fee.maximum",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The highest commission rate new minipools can be created with.",
+	},
+	{
+		Key:          "network-node-fee-demand-range",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.node.fee.demand.range",
+		Unit:         DAOSettingUnitEth,
+		Description:  "The deposit pool surplus or shortfall at which the commission rate reaches its minimum or maximum.",
+	},
+	{
+		Key:          "network-reth-collateral-target",
+		DAO:          DAOProtocol,
+		Category:     "network",
+		ContractName: protocol.NetworkSettingsContractName,
+		Path:         "network.reth.collateral.target",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The share of the rETH supply the protocol aims to keep as ETH collateral.",
+	},
+
+	// Nodes
+	{
+		Key:          "node-registration-enabled",
+		DAO:          DAOProtocol,
+		Category:     "node",
+		ContractName: protocol.NodeSettingsContractName,
+		Path:         "node.registration.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether new nodes can register.",
+	},
+	{
+		Key:          "node-deposit-enabled",
+		DAO:          DAOProtocol,
+		Category:     "node",
+		ContractName: protocol.NodeSettingsContractName,
+		Path:         "node.deposit.enabled",
+		Unit:         DAOSettingUnitBool,
+		Description:  "Whether nodes can make deposits to create minipools.",
+	},
+	{
+		Key:          "node-per-minipool-stake-minimum",
+		DAO:          DAOProtocol,
+		Category:     "node",
+		ContractName: protocol.NodeSettingsContractName,
+		Path:         "node.per.minipool.stake.minimum",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The minimum RPL collateral per minipool, relative to its user ETH.",
+	},
+	{
+		Key:          "node-per-minipool-stake-maximum",
+		DAO:          DAOProtocol,
+		Category:     "node",
+		ContractName: protocol.NodeSettingsContractName,
+		Path:         "node.per.minipool.stake.maximum",
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The maximum RPL collateral per minipool that earns rewards, relative to its user ETH.",
+	},
+
+	// Rewards
+	{
+		Key:          "rewards-claim-period",
+		DAO:          DAOProtocol,
+		Category:     "rewards",
+		ContractName: protocol.RewardsSettingsContractName,
+		Path:         "rpl.rewards.claim.period.time",
+		Unit:         DAOSettingUnitDuration,
+		Description:  "How long each RPL rewards interval lasts.",
+	},
+	{
+		Key:          "rewards-claimer-dao",
+		DAO:          DAOProtocol,
+		Category:     "rewards",
+		ContractName: protocol.RewardsSettingsContractName,
+		Path:         "rewards.claims.group.amount.rocketClaimDAO",
+		Getter:       "getRewardsClaimerPerc",
+		GetterArgs:   []interface{}{"rocketClaimDAO"},
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The share of RPL inflation given to the protocol DAO treasury.",
+	},
+	{
+		Key:          "rewards-claimer-node",
+		DAO:          DAOProtocol,
+		Category:     "rewards",
+		ContractName: protocol.RewardsSettingsContractName,
+		Path:         "rewards.claims.group.amount.rocketClaimNode",
+		Getter:       "getRewardsClaimerPerc",
+		GetterArgs:   []interface{}{"rocketClaimNode"},
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The share of RPL inflation given to node operators.",
+	},
+	{
+		Key:          "rewards-claimer-trusted-node",
+		DAO:          DAOProtocol,
+		Category:     "rewards",
+		ContractName: protocol.RewardsSettingsContractName,
+		Path:         "rewards.claims.group.amount.rocketClaimTrustedNode",
+		Getter:       "getRewardsClaimerPerc",
+		GetterArgs:   []interface{}{"rocketClaimTrustedNode"},
+		Unit:         DAOSettingUnitPercent,
+		Description:  "The share of RPL inflation given to oracle DAO members.",
+	},
+}
+
+// Get the settings owned by a DAO, or every DAO setting if dao is empty
+func GetDAOSettings(dao string) []DAOSetting {
+	switch dao {
+	case DAOProtocol:
+		return PDAOSettings
+	case DAOTrustedNode:
+		return TNDAOSettings
+	}
+	return append(append([]DAOSetting{}, PDAOSettings...), TNDAOSettings...)
+}
+
+// Get a DAO's setting by its key, one of its aliases, or its path
+func GetDAOSetting(dao string, key string) (DAOSetting, error) {
+	for _, setting := range GetDAOSettings(dao) {
+		if setting.Key == key || setting.Path == key {
+			return setting, nil
+		}
+		for _, alias := range setting.Aliases {
+			if alias == key {
+				return setting, nil
+			}
+		}
+	}
+	switch dao {
+	case DAOProtocol:
+		return DAOSetting{}, fmt.Errorf("Unknown protocol DAO setting '%s'", key)
+	case DAOTrustedNode:
+		return DAOSetting{}, fmt.Errorf("Unknown oracle DAO setting '%s'", key)
+	}
+	return DAOSetting{}, fmt.Errorf("Unknown DAO setting '%s'", key)
+}
+
+// Get a DAO setting by the contract that holds it and its path
+func FindDAOSetting(contractName string, path string) (DAOSetting, bool) {
+	for _, setting := range GetDAOSettings("") {
+		if setting.ContractName == contractName && setting.Path == path {
+			return setting, true
+		}
+	}
+	return DAOSetting{}, false
+}
+
+// Get the current value of a DAO setting
+// Bool settings are returned as 1 for true and 0 for false.
+func GetDAOSettingValue(rp *rocketpool.RocketPool, setting DAOSetting, opts *bind.CallOpts) (*big.Int, error) {
+
+	// Get the settings contract
+	contract, err := rp.GetContract(setting.ContractName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the setting by its path unless it has its own getter
+	getter := setting.Getter
+	args := setting.GetterArgs
+	if getter == "" {
+		getter = "getSettingUint"
+		if setting.Unit == DAOSettingUnitBool {
+			getter = "getSettingBool"
+		}
+		args = []interface{}{setting.Path}
+	}
+
+	if setting.Unit == DAOSettingUnitBool {
+		value := new(bool)
+		if err := contract.Call(opts, value, getter, args...); err != nil {
+			return nil, fmt.Errorf("Could not get setting %s: %w", setting.Path, err)
+		}
+		if *value {
+			return big.NewInt(1), nil
+		}
+		return big.NewInt(0), nil
+	}
+	value := new(*big.Int)
+	if err := contract.Call(opts, value, getter, args...); err != nil {
+		return nil, fmt.Errorf("Could not get setting %s: %w", setting.Path, err)
+	}
+	return *value, nil
+
+}
+
+// Convert a value as entered by the user into its on-chain encoding
+// Percentages are given from 0 to 100, token amounts in whole tokens, and durations in the format 1h30m45s.
+func (s DAOSetting) ParseValue(value string) (string, error) {
+	var encoded *big.Int
+	switch s.Unit {
+	case DAOSettingUnitPercent:
+		percent, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("Invalid %s value '%s' - must be a percentage", s.Path, value)
+		}
+		encoded = eth.EthToWei(percent / 100)
+	case DAOSettingUnitRpl, DAOSettingUnitEth:
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("Invalid %s value '%s' - must be an amount of %s", s.Path, value, s.Unit)
+		}
+		encoded = eth.EthToWei(amount)
+	case DAOSettingUnitRatio:
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("Invalid %s value '%s' - must be a decimal number", s.Path, value)
+		}
+		encoded = eth.EthToWei(ratio)
+	case DAOSettingUnitCount, DAOSettingUnitBlocks, DAOSettingUnitTimestamp:
+		count, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("Invalid %s value '%s' - must be a whole number", s.Path, value)
+		}
+		encoded = new(big.Int).SetUint64(count)
+	case DAOSettingUnitDuration:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return "", fmt.Errorf("Invalid %s value '%s' - must be a duration such as 1h30m45s", s.Path, value)
+		}
+		encoded = big.NewInt(int64(duration.Seconds()))
+	case DAOSettingUnitBool:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("Invalid %s value '%s' - must be true or false", s.Path, value)
+		}
+		return strconv.FormatBool(enabled), nil
+	default:
+		return "", fmt.Errorf("Setting %s has unknown unit '%s'", s.Path, s.Unit)
+	}
+
+	// Check the range
+	if _, err := s.DecodeValue(encoded.String()); err != nil {
+		return "", err
+	}
+	return encoded.String(), nil
+}
+
+// Decode and range-check the on-chain encoding of a value
+// Bool settings are returned as 1 for true and 0 for false.
+func (s DAOSetting) DecodeValue(encoded string) (*big.Int, error) {
+	if s.Unit == DAOSettingUnitBool {
+		enabled, err := strconv.ParseBool(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s value '%s' - must be true or false", s.Path, encoded)
+		}
+		if enabled {
+			return big.NewInt(1), nil
+		}
+		return big.NewInt(0), nil
+	}
+
+	value, success := new(big.Int).SetString(encoded, 10)
+	if !success || value.Sign() < 0 {
+		return nil, fmt.Errorf("Invalid %s value '%s' - must be a non-negative integer", s.Path, encoded)
+	}
+	if (s.Min != nil && value.Cmp(s.Min) < 0) || (s.Max != nil && value.Cmp(s.Max) > 0) {
+		return nil, fmt.Errorf("Invalid %s value %s - must be %s", s.Path, s.FormatValue(value), s.FormatRange())
+	}
+	return value, nil
+}
+
+// Format an on-chain value for display
+func (s DAOSetting) FormatValue(value *big.Int) string {
+	switch s.Unit {
+	case DAOSettingUnitPercent:
+		return fmt.Sprintf("%s%%", strconv.FormatFloat(eth.WeiToEth(value)*100, 'f', -1, 64))
+	case DAOSettingUnitRpl, DAOSettingUnitEth:
+		return fmt.Sprintf("%s %s", strconv.FormatFloat(eth.WeiToEth(value), 'f', -1, 64), s.Unit)
+	case DAOSettingUnitRatio:
+		return strconv.FormatFloat(eth.WeiToEth(value), 'f', -1, 64)
+	case DAOSettingUnitDuration:
+		return (time.Duration(value.Int64()) * time.Second).String()
+	case DAOSettingUnitBlocks:
+		return fmt.Sprintf("%s blocks", value.String())
+	case DAOSettingUnitTimestamp:
+		return time.Unix(value.Int64(), 0).UTC().Format(time.RFC1123)
+	case DAOSettingUnitBool:
+		return strconv.FormatBool(value.Sign() != 0)
+	default:
+		return value.String()
+	}
+}
+
+// Describe the range of values the setting accepts
+func (s DAOSetting) FormatRange() string {
+	switch {
+	case s.Unit == DAOSettingUnitBool:
+		return "true or false"
+	case s.Min != nil && s.Max != nil:
+		return fmt.Sprintf("between %s and %s", s.FormatValue(s.Min), s.FormatValue(s.Max))
+	case s.Min != nil:
+		return fmt.Sprintf("at least %s", s.FormatValue(s.Min))
+	case s.Max != nil:
+		return fmt.Sprintf("at most %s", s.FormatValue(s.Max))
+	default:
+		return "any value"
+	}
+}
+
+// Get the names the setting can be referred to by on the command line
+func (s DAOSetting) Names() string {
+	names := append([]string{s.Key}, s.Aliases...)
+	return strings.Join(names, ", ")
+}
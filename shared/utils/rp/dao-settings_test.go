@@ -0,0 +1,148 @@
+package rp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Make a payload for a proposal to change an oracle DAO setting
+func settingPayload(path string, value *big.Int) ProposalPayload {
+	return ProposalPayload{
+		Action: ProposalAction_Setting,
+		Method: "proposalSettingUint",
+		Args:   []interface{}{trustednode.ProposalsSettingsContractName, path, value},
+	}
+}
+
+func TestGetDAOSetting(t *testing.T) {
+	tests := []struct {
+		name       string
+		dao        string
+		key        string
+		settingKey string
+		shouldFail bool
+	}{
+		{"by key", DAOTrustedNode, "members-quorum", "members-quorum", false},
+		{"by alias", DAOTrustedNode, "q", "members-quorum", false},
+		{"by path", DAOTrustedNode, trustednode.CooldownTimeSettingPath, "proposal-cooldown", false},
+		{"any DAO", "", "auction-lot-create-enabled", "auction-lot-create-enabled", false},
+		{"wrong DAO", DAOProtocol, "members-quorum", "", true},
+		{"unknown", DAOTrustedNode, "members-quorum-max", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setting, err := GetDAOSetting(test.dao, test.key)
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if setting.Key != test.settingKey {
+				t.Fatalf("setting was '%s', expected '%s'", setting.Key, test.settingKey)
+			}
+		})
+	}
+}
+
+func TestDAOSettingValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		value      string
+		encoded    string
+		formatted  string
+		shouldFail bool
+	}{
+		{"percent", "members-quorum", "60", "600000000000000000", "60%", false},
+		{"percent below minimum", "members-quorum", "50", "", "", true},
+		{"percent above maximum", "members-quorum", "76", "", "", true},
+		{"RPL", "members-rplbond", "1750.5", "1750500000000000000000", "1750.5 RPL", false},
+		{"count", "members-minipool-unbonded-max", "30", "30", "30", false},
+		{"fractional count", "members-minipool-unbonded-max", "1.5", "", "", true},
+		{"duration", "proposal-cooldown", "1h30m", "5400", "1h30m0s", false},
+		{"invalid duration", "proposal-cooldown", "90", "", "", true},
+		{"bool", "scrub-penalty-enabled", "true", "true", "true", false},
+		{"invalid bool", "scrub-penalty-enabled", "yes", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setting, err := GetDAOSetting(DAOTrustedNode, test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := setting.ParseValue(test.value)
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if err != nil {
+				return
+			}
+			if encoded != test.encoded {
+				t.Fatalf("encoded value was %s, expected %s", encoded, test.encoded)
+			}
+			value, err := setting.DecodeValue(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if formatted := setting.FormatValue(value); formatted != test.formatted {
+				t.Fatalf("formatted value was %s, expected %s", formatted, test.formatted)
+			}
+		})
+	}
+}
+
+func TestProposalPayloadSettingChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    ProposalPayload
+		path       string
+		value      *big.Int
+		shouldFail bool
+	}{
+		{"uint", settingPayload(trustednode.CooldownTimeSettingPath, big.NewInt(3600)), trustednode.CooldownTimeSettingPath, big.NewInt(3600), false},
+		{"bool true", ProposalPayload{Action: ProposalAction_Setting, Method: "proposalSettingBool", Args: []interface{}{trustednode.MinipoolSettingsContractName, trustednode.ScrubPenaltyEnabledPath, true}}, trustednode.ScrubPenaltyEnabledPath, big.NewInt(1), false},
+		{"bool false", ProposalPayload{Action: ProposalAction_Setting, Method: "proposalSettingBool", Args: []interface{}{trustednode.MinipoolSettingsContractName, trustednode.ScrubPenaltyEnabledPath, false}}, trustednode.ScrubPenaltyEnabledPath, big.NewInt(0), false},
+		{"not a setting", ProposalPayload{Action: ProposalAction_Kick, Method: "proposalKick"}, "", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, path, value, err := test.payload.GetSettingChange()
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+			if err != nil {
+				return
+			}
+			if path != test.path {
+				t.Fatalf("path was %s, expected %s", path, test.path)
+			}
+			if value.Cmp(test.value) != 0 {
+				t.Fatalf("value was %s, expected %s", value, test.value)
+			}
+		})
+	}
+}
+
+func TestProposalPayloadDescribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     ProposalPayload
+		current     *big.Int
+		description string
+	}{
+		{"setting change", settingPayload(trustednode.CooldownTimeSettingPath, big.NewInt(7200)), big.NewInt(3600), "set proposal.cooldown.time from 1h0m0s to 2h0m0s"},
+		{"unknown current value", settingPayload(trustednode.CooldownTimeSettingPath, big.NewInt(7200)), nil, "set proposal.cooldown.time to 2h0m0s"},
+		{"unknown setting", settingPayload("proposal.unknown", big.NewInt(5)), nil, "set proposal.unknown on rocketDAONodeTrustedSettingsProposals to 5"},
+		{"kick", ProposalPayload{Action: ProposalAction_Kick, Method: "proposalKick", Args: []interface{}{"0x01", eth.EthToWei(100)}}, nil, "kick 0x01 from the oracle DAO with a fine of 100.000000 RPL"},
+		{"unrecognised", ProposalPayload{Action: ProposalAction_Unknown}, nil, "unrecognised payload"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			description := test.payload.Describe(test.current)
+			if description != test.description {
+				t.Fatalf("description was '%s', expected '%s'", description, test.description)
+			}
+		})
+	}
+}
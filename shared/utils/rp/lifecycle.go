@@ -0,0 +1,154 @@
+package rp
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The network settings that determine minipool lifecycle deadlines
+type MinipoolLifecycleSettings struct {
+	ScrubPeriod   time.Duration
+	LaunchTimeout time.Duration
+}
+
+// Get the network settings that determine minipool lifecycle deadlines
+func GetMinipoolLifecycleSettings(rp *rocketpool.RocketPool, opts *bind.CallOpts) (MinipoolLifecycleSettings, error) {
+
+	// Data
+	var wg errgroup.Group
+	settings := MinipoolLifecycleSettings{}
+
+	// Get the scrub period
+	wg.Go(func() error {
+		scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, opts)
+		if err == nil {
+			settings.ScrubPeriod = time.Duration(scrubPeriodSeconds) * time.Second
+		}
+		return err
+	})
+
+	// Get the launch timeout
+	wg.Go(func() error {
+		var err error
+		settings.LaunchTimeout, err = protocol.GetMinipoolLaunchTimeout(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return MinipoolLifecycleSettings{}, err
+	}
+	return settings, nil
+
+}
+
+// Get the time at which a prelaunch minipool's scrub check ends and it can be staked
+func GetScrubCheckEnd(status minipool.StatusDetails, settings MinipoolLifecycleSettings) time.Time {
+	return status.StatusTime.Add(settings.ScrubPeriod)
+}
+
+// Get the time at which a prelaunch minipool times out and can be dissolved
+func GetDissolveTime(status minipool.StatusDetails, settings MinipoolLifecycleSettings) time.Time {
+	return status.StatusTime.Add(settings.LaunchTimeout)
+}
+
+// Check whether a minipool has passed the scrub check and can be staked
+func IsMinipoolStakeable(status minipool.StatusDetails, settings MinipoolLifecycleSettings, now time.Time) bool {
+	return status.Status == types.Prelaunch && now.After(GetScrubCheckEnd(status, settings))
+}
+
+// Check whether a prelaunch minipool has timed out and can be dissolved
+func IsMinipoolTimedOut(status minipool.StatusDetails, settings MinipoolLifecycleSettings, now time.Time) bool {
+	return status.Status == types.Prelaunch && !now.Before(GetDissolveTime(status, settings))
+}
+
+// Get the remaining steps in a minipool's lifecycle as of the given time (usually the latest block time)
+func GetMinipoolLifecycle(status minipool.StatusDetails, finalised bool, refundAvailable bool, settings MinipoolLifecycleSettings, now time.Time) api.MinipoolLifecycle {
+
+	steps := []api.MinipoolLifecycleStep{}
+
+	// Refunds can be claimed at any time and don't block the rest of the lifecycle
+	if refundAvailable {
+		steps = append(steps, api.MinipoolLifecycleStep{
+			Action: api.MinipoolAction_Refund,
+			Actor:  api.MinipoolActor_Node,
+		})
+	}
+
+	// Finalised minipools have nothing left to do
+	if finalised {
+		return api.MinipoolLifecycle{Status: status.Status, Steps: steps}
+	}
+
+	switch status.Status {
+
+	// Waiting in the queue for user ETH to be assigned
+	case types.Initialized:
+		steps = append(steps, api.MinipoolLifecycleStep{
+			Action: api.MinipoolAction_WaitForDeposit,
+			Actor:  api.MinipoolActor_Protocol,
+		})
+
+	// Waiting for the scrub check, then for the node to stake before the launch timeout
+	case types.Prelaunch:
+		scrubCheckEnd := GetScrubCheckEnd(status, settings)
+		dissolveTime := GetDissolveTime(status, settings)
+		if now.Before(scrubCheckEnd) {
+			steps = append(steps, api.MinipoolLifecycleStep{
+				Action:   api.MinipoolAction_ScrubCheck,
+				Actor:    api.MinipoolActor_OracleDao,
+				Deadline: scrubCheckEnd,
+			})
+		}
+		if now.Before(dissolveTime) {
+			steps = append(steps, api.MinipoolLifecycleStep{
+				Action:        api.MinipoolAction_Stake,
+				Actor:         api.MinipoolActor_Node,
+				AvailableTime: scrubCheckEnd,
+				Deadline:      dissolveTime,
+			})
+		}
+		steps = append(steps, api.MinipoolLifecycleStep{
+			Action:        api.MinipoolAction_Dissolve,
+			Actor:         api.MinipoolActor_OracleDao,
+			AvailableTime: dissolveTime,
+		})
+
+	// Validating until the node exits, after which the oDAO marks it as withdrawable
+	case types.Staking:
+		steps = append(steps, api.MinipoolLifecycleStep{
+			Action: api.MinipoolAction_Exit,
+			Actor:  api.MinipoolActor_Node,
+		}, api.MinipoolLifecycleStep{
+			Action: api.MinipoolAction_MarkWithdrawable,
+			Actor:  api.MinipoolActor_OracleDao,
+		})
+
+	// Waiting for the node to withdraw its share and finalise
+	case types.Withdrawable:
+		steps = append(steps, api.MinipoolLifecycleStep{
+			Action: api.MinipoolAction_WithdrawFinalise,
+			Actor:  api.MinipoolActor_Node,
+		})
+
+	// Waiting for the node to close the minipool and recover its deposit
+	case types.Dissolved:
+		steps = append(steps, api.MinipoolLifecycleStep{
+			Action: api.MinipoolAction_Close,
+			Actor:  api.MinipoolActor_Node,
+		})
+
+	}
+
+	return api.MinipoolLifecycle{Status: status.Status, Steps: steps}
+
+}
@@ -0,0 +1,92 @@
+package rp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The network contract events for oracle DAO balance and price updates
+const (
+	BalancesSubmittedEvent = "BalancesSubmitted"
+	BalancesUpdatedEvent   = "BalancesUpdated"
+	PricesSubmittedEvent   = "PricesSubmitted"
+	PricesUpdatedEvent     = "PricesUpdated"
+)
+
+// A network balances or prices submission by an oracle DAO member, or the update made once they reached consensus
+// Submitter is only set for member submissions
+type NetworkUpdate struct {
+	Block       uint64              `json:"block"`
+	Submitter   common.Address      `json:"submitter"`
+	Values      map[string]*big.Int `json:"values"`
+	BlockNumber uint64              `json:"blockNumber"`
+	TxHash      common.Hash         `json:"txHash"`
+}
+
+// Get the balances or prices updates emitted by a network contract between two blocks
+// If forBlock is not nil, only the updates for that block are returned
+func GetNetworkUpdates(rp *rocketpool.RocketPool, contractName string, eventName string, forBlock *big.Int, fromBlock *big.Int, toBlock *big.Int, intervalSize *big.Int) ([]NetworkUpdate, error) {
+
+	// Get the event
+	contract, err := rp.GetContract(contractName)
+	if err != nil {
+		return nil, err
+	}
+	event, exists := contract.ABI.Events[eventName]
+	if !exists {
+		return nil, fmt.Errorf("Event %s not found on %s", eventName, contractName)
+	}
+
+	// Get the event logs
+	// The blocks and interval are copied, since the log scan modifies them and callers may pass the same block for both
+	if forBlock != nil {
+		forBlock = big.NewInt(0).Set(forBlock)
+	}
+	if fromBlock != nil {
+		fromBlock = big.NewInt(0).Set(fromBlock)
+	}
+	if intervalSize != nil {
+		intervalSize = big.NewInt(0).Set(intervalSize)
+	}
+	logs, err := eth.GetLogs(rp, []common.Address{*contract.Address}, [][]common.Hash{{event.ID}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting %s events: %w", eventName, err)
+	}
+
+	// Decode the updates
+	updates := []NetworkUpdate{}
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Error decoding %s event: %w", eventName, err)
+		}
+		block, ok := values["block"].(*big.Int)
+		if !ok || (forBlock != nil && block.Cmp(forBlock) != 0) {
+			continue
+		}
+		update := NetworkUpdate{
+			Block:       block.Uint64(),
+			Values:      map[string]*big.Int{},
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+		}
+		if len(log.Topics) > 1 {
+			update.Submitter = common.BytesToAddress(log.Topics[1].Bytes())
+		}
+		for name, value := range values {
+			if name == "block" || name == "time" {
+				continue
+			}
+			if amount, ok := value.(*big.Int); ok {
+				update.Values[name] = amount
+			}
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+
+}
@@ -0,0 +1,156 @@
+package rp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kinds of action a DAO proposal can take
+type ProposalAction string
+
+const (
+	ProposalAction_Invite  ProposalAction = "invite"
+	ProposalAction_Leave   ProposalAction = "leave"
+	ProposalAction_Replace ProposalAction = "replace"
+	ProposalAction_Kick    ProposalAction = "kick"
+	ProposalAction_Setting ProposalAction = "setting"
+	ProposalAction_Upgrade ProposalAction = "upgrade"
+	ProposalAction_Unknown ProposalAction = "unknown"
+)
+
+// The proposal payload methods that update a setting
+const (
+	proposalSettingUintMethod = "proposalSettingUint"
+	proposalSettingBoolMethod = "proposalSettingBool"
+)
+
+// The action taken by each proposal payload method
+var proposalActions = map[string]ProposalAction{
+	"proposalInvite":          ProposalAction_Invite,
+	"proposalLeave":           ProposalAction_Leave,
+	"proposalReplace":         ProposalAction_Replace,
+	"proposalKick":            ProposalAction_Kick,
+	proposalSettingUintMethod: ProposalAction_Setting,
+	proposalSettingBoolMethod: ProposalAction_Setting,
+	"proposalUpgrade":         ProposalAction_Upgrade,
+}
+
+// A decoded DAO proposal payload
+type ProposalPayload struct {
+	Action ProposalAction
+	Method string
+	Args   []interface{}
+}
+
+// Decode a proposal payload with the ABI of the DAO that made it
+func DecodeProposalPayload(rp *rocketpool.RocketPool, daoName string, payload []byte) (ProposalPayload, error) {
+	if len(payload) < 4 {
+		return ProposalPayload{Action: ProposalAction_Unknown}, nil
+	}
+	daoAbi, err := rp.GetABI(daoName)
+	if err != nil {
+		return ProposalPayload{}, fmt.Errorf("Error getting the %s ABI: %w", daoName, err)
+	}
+	method, err := daoAbi.MethodById(payload)
+	if err != nil {
+		return ProposalPayload{Action: ProposalAction_Unknown}, nil
+	}
+	args, err := method.Inputs.UnpackValues(payload[4:])
+	if err != nil {
+		return ProposalPayload{}, fmt.Errorf("Error decoding %s payload: %w", method.RawName, err)
+	}
+	action, known := proposalActions[method.RawName]
+	if !known {
+		action = ProposalAction_Unknown
+	}
+	return ProposalPayload{
+		Action: action,
+		Method: method.RawName,
+		Args:   args,
+	}, nil
+}
+
+// Get the settings contract, path and new value of a setting proposal
+// Bool values are returned as 1 for true and 0 for false.
+func (p ProposalPayload) GetSettingChange() (string, string, *big.Int, error) {
+	if p.Action != ProposalAction_Setting || len(p.Args) != 3 {
+		return "", "", nil, fmt.Errorf("%s is not a setting proposal", p.Method)
+	}
+	contractName, ok := p.Args[0].(string)
+	if !ok {
+		return "", "", nil, fmt.Errorf("Proposal has an invalid settings contract name")
+	}
+	path, ok := p.Args[1].(string)
+	if !ok {
+		return "", "", nil, fmt.Errorf("Proposal has an invalid setting path")
+	}
+	switch value := p.Args[2].(type) {
+	case *big.Int:
+		return contractName, path, value, nil
+	case bool:
+		if value {
+			return contractName, path, big.NewInt(1), nil
+		}
+		return contractName, path, big.NewInt(0), nil
+	}
+	return "", "", nil, fmt.Errorf("Proposal has an invalid setting value")
+}
+
+// Describe what a proposal will do; current is the setting's current value, and is only used for setting proposals
+func (p ProposalPayload) Describe(current *big.Int) string {
+	switch p.Action {
+	case ProposalAction_Invite:
+		if len(p.Args) == 3 {
+			return fmt.Sprintf("invite %v (%s, %v) to join the oracle DAO", p.Args[0], addressArg(p.Args[2]), p.Args[1])
+		}
+	case ProposalAction_Leave:
+		if len(p.Args) == 1 {
+			return fmt.Sprintf("allow %s to leave the oracle DAO", addressArg(p.Args[0]))
+		}
+	case ProposalAction_Replace:
+		if len(p.Args) == 4 {
+			return fmt.Sprintf("replace %s with %v (%s, %v)", addressArg(p.Args[0]), p.Args[1], addressArg(p.Args[3]), p.Args[2])
+		}
+	case ProposalAction_Kick:
+		if len(p.Args) == 2 {
+			fine, _ := p.Args[1].(*big.Int)
+			if fine == nil {
+				fine = big.NewInt(0)
+			}
+			return fmt.Sprintf("kick %s from the oracle DAO with a fine of %.6f RPL", addressArg(p.Args[0]), eth.WeiToEth(fine))
+		}
+	case ProposalAction_Setting:
+		contractName, path, value, err := p.GetSettingChange()
+		if err != nil {
+			break
+		}
+		setting, known := FindDAOSetting(contractName, path)
+		if !known {
+			return fmt.Sprintf("set %s on %s to %s", path, contractName, value.String())
+		}
+		if current == nil {
+			return fmt.Sprintf("set %s to %s", path, setting.FormatValue(value))
+		}
+		return fmt.Sprintf("set %s from %s to %s", path, setting.FormatValue(current), setting.FormatValue(value))
+	case ProposalAction_Upgrade:
+		if len(p.Args) == 4 {
+			return fmt.Sprintf("%v contract %v at %s", p.Args[0], p.Args[1], addressArg(p.Args[3]))
+		}
+	}
+	if p.Method != "" {
+		return fmt.Sprintf("call %s%v", p.Method, p.Args)
+	}
+	return "unrecognised payload"
+}
+
+// Format an address argument
+func addressArg(arg interface{}) string {
+	if address, ok := arg.(common.Address); ok {
+		return address.Hex()
+	}
+	return fmt.Sprintf("%v", arg)
+}
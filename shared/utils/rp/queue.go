@@ -0,0 +1,237 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The period the deposit inflow rate is averaged over
+const DepositInflowWindow = 7 * 24 * time.Hour
+
+// The average time between execution layer blocks, used to find the start of the inflow window
+const averageBlockTime = 12 * time.Second
+
+// The longest queue wait that's estimated; slower deposit rates are treated as too slow to estimate from
+const MaxMinipoolQueueEta = 5 * 365 * 24 * time.Hour
+
+// The minipool queues, in the order user ETH is assigned from them
+var minipoolQueues = []struct {
+	depositType types.MinipoolDeposit
+	key         string
+}{
+	{types.Half, "minipools.available.half"},
+	{types.Full, "minipools.available.full"},
+	{types.Empty, "minipools.available.empty"},
+}
+
+// The state of the minipool queues and the deposit pool
+type MinipoolQueueState struct {
+	Lengths            map[types.MinipoolDeposit]uint64
+	UserAmounts        map[types.MinipoolDeposit]*big.Int
+	DepositPoolBalance *big.Int
+}
+
+// Get the state of the minipool queues and the deposit pool
+func GetMinipoolQueueState(rp *rocketpool.RocketPool, opts *bind.CallOpts) (MinipoolQueueState, error) {
+
+	// Data
+	var wg errgroup.Group
+	var lengths minipool.QueueLengths
+	state := MinipoolQueueState{
+		UserAmounts: map[types.MinipoolDeposit]*big.Int{},
+	}
+	var halfAmount, fullAmount, emptyAmount *big.Int
+
+	// Get data
+	wg.Go(func() error {
+		var err error
+		lengths, err = minipool.GetQueueLengths(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		halfAmount, err = protocol.GetMinipoolHalfDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		fullAmount, err = protocol.GetMinipoolFullDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		emptyAmount, err = protocol.GetMinipoolEmptyDepositUserAmount(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.DepositPoolBalance, err = deposit.GetBalance(rp, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return MinipoolQueueState{}, err
+	}
+
+	// Return
+	state.Lengths = map[types.MinipoolDeposit]uint64{
+		types.Half:  lengths.HalfDeposit,
+		types.Full:  lengths.FullDeposit,
+		types.Empty: lengths.EmptyDeposit,
+	}
+	state.UserAmounts[types.Half] = halfAmount
+	state.UserAmounts[types.Full] = fullAmount
+	state.UserAmounts[types.Empty] = emptyAmount
+	return state, nil
+
+}
+
+// Get a minipool's position in the queues and the user ETH that has to be deposited before it's assigned
+// Returns a position with InQueue set to false if the minipool isn't in the queue.
+func GetMinipoolQueuePosition(rp *rocketpool.RocketPool, state MinipoolQueueState, minipoolAddress common.Address, depositType types.MinipoolDeposit, opts *bind.CallOpts) (api.MinipoolQueuePosition, error) {
+
+	// Get the minipool's index in its queue
+	queueStorage, err := rp.GetContract("addressQueueStorage")
+	if err != nil {
+		return api.MinipoolQueuePosition{}, err
+	}
+	for _, queue := range minipoolQueues {
+		if queue.depositType != depositType {
+			continue
+		}
+		index := new(*big.Int)
+		if err := queueStorage.Call(opts, index, "getIndexOf", crypto.Keccak256Hash([]byte(queue.key)), minipoolAddress); err != nil {
+			return api.MinipoolQueuePosition{}, fmt.Errorf("Could not get the queue index of minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+		return getMinipoolQueuePosition(state, depositType, *index), nil
+	}
+	return getMinipoolQueuePosition(state, depositType, big.NewInt(-1)), nil
+
+}
+
+// Get the position of the minipool at an index in the queue for its deposit type, where a negative index means it isn't queued
+func getMinipoolQueuePosition(state MinipoolQueueState, depositType types.MinipoolDeposit, index *big.Int) api.MinipoolQueuePosition {
+
+	position := api.MinipoolQueuePosition{
+		DepositType: depositType,
+		EthAhead:    big.NewInt(0),
+		EthRequired: big.NewInt(0),
+	}
+	if index.Sign() < 0 {
+		return position
+	}
+
+	// Count the minipools and user ETH ahead of it
+	var ahead uint64
+	for _, queue := range minipoolQueues {
+		if queue.depositType != depositType {
+			ahead += state.Lengths[queue.depositType]
+			position.EthAhead.Add(position.EthAhead, new(big.Int).Mul(state.UserAmounts[queue.depositType], big.NewInt(int64(state.Lengths[queue.depositType]))))
+			continue
+		}
+		position.InQueue = true
+		position.QueueIndex = index.Uint64()
+		position.Position = ahead + position.QueueIndex
+		position.EthAhead.Add(position.EthAhead, new(big.Int).Mul(state.UserAmounts[depositType], new(big.Int).SetUint64(position.QueueIndex)))
+		break
+	}
+
+	// The deposit pool balance is assigned to the queue before any new deposits
+	position.EthRequired.Add(position.EthAhead, state.UserAmounts[depositType])
+	position.EthRequired.Sub(position.EthRequired, state.DepositPoolBalance)
+	if position.EthRequired.Sign() < 0 {
+		position.EthRequired.SetUint64(0)
+	}
+	return position
+
+}
+
+// Get the average rate of user deposits into the deposit pool over the inflow window ending at the opts block (or the latest block if unset), in wei per second
+func GetDepositInflowRate(rp *rocketpool.RocketPool, intervalSize *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+
+	// Get the event
+	contract, err := rp.GetContract("rocketDepositPool")
+	if err != nil {
+		return nil, err
+	}
+	event, exists := contract.ABI.Events["DepositReceived"]
+	if !exists {
+		return nil, fmt.Errorf("Event DepositReceived not found on rocketDepositPool")
+	}
+
+	// Get the blocks in the window
+	var toBlock uint64
+	if opts != nil && opts.BlockNumber != nil {
+		toBlock = opts.BlockNumber.Uint64()
+	} else {
+		toBlock, err = rp.Client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, err
+		}
+	}
+	windowBlocks := uint64(DepositInflowWindow / averageBlockTime)
+	fromBlock := uint64(0)
+	if toBlock > windowBlocks {
+		fromBlock = toBlock - windowBlocks
+	}
+
+	// Get the event logs from every deposit pool contract, so deposits made before an upgrade are counted
+	// The interval is copied, since the log scan modifies it
+	if intervalSize != nil {
+		intervalSize = big.NewInt(0).Set(intervalSize)
+	}
+	logs, err := eth.FilterContractLogs(rp, "rocketDepositPool", eth.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Topics:    [][]common.Hash{{event.ID}},
+	}, intervalSize)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting DepositReceived events: %w", err)
+	}
+
+	// Sum the deposits
+	total := big.NewInt(0)
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := contract.ABI.UnpackIntoMap(values, "DepositReceived", log.Data); err != nil {
+			return nil, fmt.Errorf("Error decoding DepositReceived event: %w", err)
+		}
+		if amount, ok := values["amount"].(*big.Int); ok {
+			total.Add(total, amount)
+		}
+	}
+	return total.Div(total, big.NewInt(int64(DepositInflowWindow/time.Second))), nil
+
+}
+
+// Get the estimated time until a queued minipool is assigned at the given deposit inflow rate
+// Returns false if the deposit rate is too low to estimate it from, including when there have been no deposits.
+func GetMinipoolQueueEta(position api.MinipoolQueuePosition, inflowRate *big.Int) (time.Duration, bool) {
+	if position.EthRequired.Sign() == 0 {
+		return 0, true
+	}
+	if inflowRate == nil || inflowRate.Sign() == 0 {
+		return 0, false
+	}
+	seconds := new(big.Int).Div(position.EthRequired, inflowRate)
+	if seconds.Cmp(big.NewInt(int64(MaxMinipoolQueueEta/time.Second))) > 0 {
+		return 0, false
+	}
+	return time.Duration(seconds.Int64()) * time.Second, true
+}
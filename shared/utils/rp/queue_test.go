@@ -0,0 +1,90 @@
+package rp
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func TestGetMinipoolQueuePosition(t *testing.T) {
+
+	state := MinipoolQueueState{
+		Lengths: map[types.MinipoolDeposit]uint64{
+			types.Half:  2,
+			types.Full:  3,
+			types.Empty: 1,
+		},
+		UserAmounts: map[types.MinipoolDeposit]*big.Int{
+			types.Half:  eth.EthToWei(16),
+			types.Full:  eth.EthToWei(16),
+			types.Empty: eth.EthToWei(32),
+		},
+		DepositPoolBalance: eth.EthToWei(20),
+	}
+
+	tests := []struct {
+		name        string
+		depositType types.MinipoolDeposit
+		index       int64
+		inQueue     bool
+		position    uint64
+		ethAhead    float64
+		ethRequired float64
+	}{
+		{"not queued", types.Half, -1, false, 0, 0, 0},
+		{"front of the queue", types.Half, 0, true, 0, 0, 0},
+		{"behind a half minipool", types.Half, 1, true, 1, 16, 12},
+		{"behind the half queue", types.Full, 1, true, 3, 48, 44},
+		{"behind every other queue", types.Empty, 0, true, 5, 80, 92},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			position := getMinipoolQueuePosition(state, test.depositType, big.NewInt(test.index))
+			if position.InQueue != test.inQueue {
+				t.Fatalf("in queue was %t, expected %t", position.InQueue, test.inQueue)
+			}
+			if position.Position != test.position {
+				t.Fatalf("position was %d, expected %d", position.Position, test.position)
+			}
+			if !closeToEth(position.EthAhead, test.ethAhead) {
+				t.Fatalf("ETH ahead was %f, expected %f", eth.WeiToEth(position.EthAhead), test.ethAhead)
+			}
+			if !closeToEth(position.EthRequired, test.ethRequired) {
+				t.Fatalf("ETH required was %f, expected %f", eth.WeiToEth(position.EthRequired), test.ethRequired)
+			}
+		})
+	}
+
+}
+
+func TestGetMinipoolQueueEta(t *testing.T) {
+	tests := []struct {
+		name        string
+		ethRequired *big.Int
+		inflowRate  *big.Int
+		eta         time.Duration
+		known       bool
+	}{
+		{"nothing required", big.NewInt(0), nil, 0, true},
+		{"no deposits", eth.EthToWei(32), nil, 0, false},
+		{"zero rate", eth.EthToWei(32), big.NewInt(0), 0, false},
+		{"one hour", eth.EthToWei(36), eth.EthToWei(0.01), time.Hour, true},
+		{"too slow", eth.EthToWei(32), big.NewInt(1), 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eta, known := GetMinipoolQueueEta(api.MinipoolQueuePosition{EthRequired: test.ethRequired}, test.inflowRate)
+			if known != test.known {
+				t.Fatalf("known was %t, expected %t", known, test.known)
+			}
+			if eta != test.eta {
+				t.Fatalf("ETA was %s, expected %s", eta, test.eta)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package rp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The proposer value that matches proposals made by the node itself
+const VoteRuleProposerSelf string = "self"
+
+// An example vote policy, describing the file format
+const ExampleVotePolicy string = `# Rules are checked in order, and the first one that matches a proposal decides the vote.
+# Proposals that don't match any rule are left for you to vote on manually.
+# Every rule must set a proposer, an action, or both; a rule only matches when all of its conditions do.
+# Rules that vote in favour must also set a proposer, or list the settings they apply to, so they can't pass every proposal of a type.
+rules:
+
+  # Always vote in favour of the proposals this node makes
+  - name: own-proposals
+    proposer: self
+    support: true
+
+  # Vote in favour of another member's proposals to shorten the proposal cooldown
+  # proposer: 'self' or a member's address
+  # action: invite, leave, replace, kick, setting or upgrade
+  # settings: only for setting proposals, the setting paths the rule applies to
+  - name: trusted-cooldown-changes
+    proposer: "0x0000000000000000000000000000000000000000"
+    action: setting
+    settings:
+      - proposal.cooldown.time
+    support: true
+`
+
+// A rule for voting on oracle DAO proposals automatically
+type VoteRule struct {
+	Name     string         `yaml:"name"`
+	Proposer string         `yaml:"proposer,omitempty"`
+	Action   ProposalAction `yaml:"action,omitempty"`
+	Settings []string       `yaml:"settings,omitempty"`
+	Support  *bool          `yaml:"support"`
+}
+
+// The rules the watchtower uses to vote on oracle DAO proposals automatically
+type VotePolicy struct {
+	Rules []VoteRule `yaml:"rules"`
+}
+
+// Load the vote policy from a file
+// Returns nil if the file doesn't exist, in which case nothing should be voted on automatically.
+func LoadVotePolicy(path string) (*VotePolicy, error) {
+
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error reading vote policy %s: %w", path, err)
+	}
+
+	policy := new(VotePolicy)
+	if err := yaml.UnmarshalStrict(bytes, policy); err != nil {
+		return nil, fmt.Errorf("Error parsing vote policy %s: %w", path, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("Invalid vote policy %s: %w", path, err)
+	}
+	return policy, nil
+
+}
+
+// Check that every rule is narrow enough to be applied safely
+func (p *VotePolicy) validate() error {
+	for i, rule := range p.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if rule.Proposer == "" && rule.Action == "" {
+			return fmt.Errorf("rule %s must set a proposer, an action, or both", name)
+		}
+		if rule.Proposer != "" && rule.Proposer != VoteRuleProposerSelf && !common.IsHexAddress(rule.Proposer) {
+			return fmt.Errorf("rule %s has an invalid proposer '%s' - must be 'self' or an address", name, rule.Proposer)
+		}
+		if rule.Action != "" {
+			if _, known := proposalActionNames()[rule.Action]; !known {
+				return fmt.Errorf("rule %s has an invalid action '%s'", name, rule.Action)
+			}
+		}
+		if len(rule.Settings) > 0 && rule.Action != ProposalAction_Setting {
+			return fmt.Errorf("rule %s lists settings, so its action must be 'setting'", name)
+		}
+		if rule.Support == nil {
+			return fmt.Errorf("rule %s must set support to true or false", name)
+		}
+		if *rule.Support && rule.Proposer == "" && len(rule.Settings) == 0 {
+			return fmt.Errorf("rule %s would vote in favour of every '%s' proposal - it must also set a proposer, or list the settings it applies to", name, rule.Action)
+		}
+	}
+	return nil
+}
+
+// Get the first rule that matches a proposal, or nil if none do
+func (p *VotePolicy) Match(proposer common.Address, nodeAddress common.Address, payload ProposalPayload) *VoteRule {
+	if p == nil {
+		return nil
+	}
+	for i, rule := range p.Rules {
+		if rule.Proposer == VoteRuleProposerSelf && proposer != nodeAddress {
+			continue
+		}
+		if rule.Proposer != "" && rule.Proposer != VoteRuleProposerSelf && proposer != common.HexToAddress(rule.Proposer) {
+			continue
+		}
+		if rule.Action != "" && rule.Action != payload.Action {
+			continue
+		}
+		if len(rule.Settings) > 0 {
+			_, path, _, err := payload.GetSettingChange()
+			if err != nil || !containsString(rule.Settings, path) {
+				continue
+			}
+		}
+		return &p.Rules[i]
+	}
+	return nil
+}
+
+// Get the name of a rule for logging
+func (r *VoteRule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	conditions := []string{}
+	if r.Proposer != "" {
+		conditions = append(conditions, "proposer="+r.Proposer)
+	}
+	if r.Action != "" {
+		conditions = append(conditions, "action="+string(r.Action))
+	}
+	return strings.Join(conditions, ",")
+}
+
+// Get the actions a vote rule can match
+func proposalActionNames() map[ProposalAction]bool {
+	actions := map[ProposalAction]bool{}
+	for _, action := range proposalActions {
+		actions[action] = true
+	}
+	return actions
+}
+
+// Check whether a string slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package rp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+)
+
+func TestVotePolicyMatch(t *testing.T) {
+
+	yes := true
+	no := false
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	member := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	stranger := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	policy := &VotePolicy{Rules: []VoteRule{
+		{Name: "own-proposals", Proposer: VoteRuleProposerSelf, Support: &yes},
+		{Name: "member-cooldown", Proposer: member.Hex(), Action: ProposalAction_Setting, Settings: []string{trustednode.CooldownTimeSettingPath}, Support: &yes},
+		{Name: "no-kicks", Action: ProposalAction_Kick, Support: &no},
+		{Name: "member-kicks", Proposer: member.Hex(), Action: ProposalAction_Kick, Support: &yes},
+	}}
+	kick := ProposalPayload{Action: ProposalAction_Kick, Method: "proposalKick"}
+
+	tests := []struct {
+		name     string
+		policy   *VotePolicy
+		proposer common.Address
+		payload  ProposalPayload
+		rule     string
+	}{
+		{"own proposal", policy, node, kick, "own-proposals"},
+		{"listed setting", policy, member, settingPayload(trustednode.CooldownTimeSettingPath, big.NewInt(3600)), "member-cooldown"},
+		{"unlisted setting", policy, member, settingPayload(trustednode.VoteTimeSettingPath, big.NewInt(3600)), ""},
+		{"other proposer", policy, stranger, settingPayload(trustednode.CooldownTimeSettingPath, big.NewInt(3600)), ""},
+		{"first match wins", policy, member, kick, "no-kicks"},
+		{"other action", policy, member, ProposalPayload{Action: ProposalAction_Invite, Method: "proposalInvite"}, ""},
+		{"no policy", nil, node, kick, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := test.policy.Match(test.proposer, node, test.payload)
+			name := ""
+			if rule != nil {
+				name = rule.Name
+			}
+			if name != test.rule {
+				t.Fatalf("matched rule '%s', expected '%s'", name, test.rule)
+			}
+		})
+	}
+
+}
+
+func TestVotePolicyValidate(t *testing.T) {
+	yes := true
+	no := false
+	member := "0x2222222222222222222222222222222222222222"
+	tests := []struct {
+		name       string
+		rule       VoteRule
+		shouldFail bool
+	}{
+		{"self proposer", VoteRule{Proposer: VoteRuleProposerSelf, Support: &yes}, false},
+		{"address and action", VoteRule{Proposer: member, Action: ProposalAction_Kick, Support: &yes}, false},
+		{"listed settings", VoteRule{Action: ProposalAction_Setting, Settings: []string{trustednode.CooldownTimeSettingPath}, Support: &yes}, false},
+		{"against a whole action", VoteRule{Action: ProposalAction_Upgrade, Support: &no}, false},
+		{"for a whole action", VoteRule{Action: ProposalAction_Upgrade, Support: &yes}, true},
+		{"no conditions", VoteRule{Support: &no}, true},
+		{"invalid proposer", VoteRule{Proposer: "someone", Support: &yes}, true},
+		{"invalid action", VoteRule{Proposer: member, Action: "dissolve", Support: &yes}, true},
+		{"settings without setting action", VoteRule{Proposer: member, Action: ProposalAction_Kick, Settings: []string{trustednode.CooldownTimeSettingPath}, Support: &yes}, true},
+		{"no support", VoteRule{Proposer: member}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &VotePolicy{Rules: []VoteRule{test.rule}}
+			err := policy.validate()
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("expected failure: %t, got error: %v", test.shouldFail, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// A signed voluntary exit in the standard beacon node API format, which can be submitted to any beacon node
+// The fork version it was signed for is kept alongside so it can be checked before broadcasting
+type SignedExit struct {
+	Message     SignedExitMessage `json:"message"`
+	Signature   string            `json:"signature"`
+	ForkVersion string            `json:"fork_version,omitempty"`
+}
+type SignedExitMessage struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// A signed voluntary exit encrypted with a password, in the same layout as an EIP-2335 keystore
+type EncryptedExit struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Name    string                 `json:"name"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Pubkey  types.ValidatorPubkey  `json:"pubkey"`
+	Epoch   uint64                 `json:"epoch"`
+}
+
+// Create a signed voluntary exit
+func NewSignedExit(validatorIndex uint64, epoch uint64, signature types.ValidatorSignature, forkVersion []byte) SignedExit {
+	return SignedExit{
+		Message: SignedExitMessage{
+			Epoch:          strconv.FormatUint(epoch, 10),
+			ValidatorIndex: strconv.FormatUint(validatorIndex, 10),
+		},
+		Signature:   "0x" + signature.Hex(),
+		ForkVersion: hexutil.Encode(forkVersion),
+	}
+}
+
+// Parse the validator index, epoch and signature of a signed voluntary exit
+func (e SignedExit) Parse() (uint64, uint64, types.ValidatorSignature, error) {
+	validatorIndex, err := strconv.ParseUint(e.Message.ValidatorIndex, 10, 64)
+	if err != nil {
+		return 0, 0, types.ValidatorSignature{}, fmt.Errorf("Invalid validator index '%s': %w", e.Message.ValidatorIndex, err)
+	}
+	epoch, err := strconv.ParseUint(e.Message.Epoch, 10, 64)
+	if err != nil {
+		return 0, 0, types.ValidatorSignature{}, fmt.Errorf("Invalid epoch '%s': %w", e.Message.Epoch, err)
+	}
+	signature, err := types.HexToValidatorSignature(strings.TrimPrefix(e.Signature, "0x"))
+	if err != nil {
+		return 0, 0, types.ValidatorSignature{}, fmt.Errorf("Invalid signature '%s': %w", e.Signature, err)
+	}
+	return validatorIndex, epoch, signature, nil
+}
+
+// Encrypt a signed voluntary exit with a password
+func EncryptExit(exit SignedExit, pubkey types.ValidatorPubkey, epoch uint64, password string) (EncryptedExit, error) {
+
+	// Serialize the exit
+	exitBytes, err := json.Marshal(exit)
+	if err != nil {
+		return EncryptedExit{}, fmt.Errorf("Could not serialize signed exit: %w", err)
+	}
+
+	// Encrypt it
+	encryptor := eth2ks.New()
+	crypto, err := encryptor.Encrypt(exitBytes, password)
+	if err != nil {
+		return EncryptedExit{}, fmt.Errorf("Could not encrypt signed exit: %w", err)
+	}
+
+	// Return
+	return EncryptedExit{
+		Crypto:  crypto,
+		Name:    encryptor.Name(),
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Pubkey:  pubkey,
+		Epoch:   epoch,
+	}, nil
+
+}
+
+// Decrypt an encrypted signed voluntary exit
+func DecryptExit(encryptedExit EncryptedExit, password string) (SignedExit, error) {
+
+	// Decrypt the exit
+	encryptor := eth2ks.New()
+	exitBytes, err := encryptor.Decrypt(encryptedExit.Crypto, password)
+	if err != nil {
+		return SignedExit{}, fmt.Errorf("Could not decrypt signed exit: %w", err)
+	}
+
+	// Deserialize it
+	var exit SignedExit
+	if err := json.Unmarshal(exitBytes, &exit); err != nil {
+		return SignedExit{}, fmt.Errorf("Could not parse signed exit: %w", err)
+	}
+	return exit, nil
+
+}
@@ -1,11 +1,34 @@
 package validator
 
 import (
+	"fmt"
+
 	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
+// Get the fork version the beacon chain at the given head epoch verifies voluntary exits for an epoch with
+// Epochs at or after a scheduled fork are refused, since the chain will verify them with a fork version that hasn't been released yet
+func GetExitForkVersion(forks []beacon.Fork, headEpoch uint64, epoch uint64) ([]byte, error) {
+	var headFork *beacon.Fork
+	for fi, fork := range forks {
+		if fork.Epoch <= headEpoch {
+			headFork = &forks[fi]
+		} else if fork.Epoch <= epoch {
+			return nil, fmt.Errorf("Epoch %d is at or after the fork scheduled for epoch %d, so an exit signed for it now would never be accepted; please choose an epoch before the fork", epoch, fork.Epoch)
+		}
+	}
+	if headFork == nil {
+		return nil, fmt.Errorf("The fork schedule has no fork active at epoch %d", headEpoch)
+	}
+	if epoch < headFork.Epoch {
+		return headFork.PreviousVersion, nil
+	}
+	return headFork.CurrentVersion, nil
+}
+
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex uint64, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
 
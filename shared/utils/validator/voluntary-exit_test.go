@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+func TestGetExitForkVersion(t *testing.T) {
+
+	phase0 := []byte{0, 0, 0, 0}
+	altair := []byte{1, 0, 0, 0}
+	bellatrix := []byte{2, 0, 0, 0}
+	forks := []beacon.Fork{
+		{PreviousVersion: phase0, CurrentVersion: phase0, Epoch: 0},
+		{PreviousVersion: phase0, CurrentVersion: altair, Epoch: 100},
+		{PreviousVersion: altair, CurrentVersion: bellatrix, Epoch: 200},
+	}
+
+	tests := []struct {
+		name        string
+		headEpoch   uint64
+		epoch       uint64
+		forkVersion []byte
+		expectError bool
+	}{
+		{"current epoch", 150, 150, altair, false},
+		{"future epoch before the scheduled fork", 150, 199, altair, false},
+		{"epoch at the scheduled fork", 150, 200, nil, true},
+		{"epoch after the scheduled fork", 150, 1000, nil, true},
+		{"epoch before the head fork", 150, 50, phase0, false},
+		{"epoch after the last fork", 250, 300, bellatrix, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forkVersion, err := GetExitForkVersion(forks, test.headEpoch, test.epoch)
+			if (err != nil) != test.expectError {
+				t.Fatalf("expected failure: %t, got error: %v", test.expectError, err)
+			}
+			if !bytes.Equal(forkVersion, test.forkVersion) {
+				t.Fatalf("fork version was %x, expected %x", forkVersion, test.forkVersion)
+			}
+		})
+	}
+
+}